@@ -0,0 +1,170 @@
+// Package cells renders cink tokens as tcell cells, for tcell and tview
+// applications (e.g. a config viewer widget) that draw highlighted text
+// directly instead of parsing ANSI escapes back into styles.
+package cells
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+	"github.com/mattn/go-runewidth"
+)
+
+// TabWidth is the number of columns between tab stops.
+const TabWidth = lexer.TabWidth
+
+// Cell is a single screen cell: a rune, any combining runes that follow it
+// and the style to draw them with.
+type Cell struct {
+	Rune      rune
+	Combining []rune
+	Style     tcell.Style
+}
+
+// Renderer converts tokens to lines of cells using a theme. The style for
+// each token type is converted once and cached, so a Renderer is not safe for
+// concurrent use.
+type Renderer struct {
+	theme  *highlighter.Theme
+	base   tcell.Style
+	styles map[lexer.TokenType]tcell.Style
+}
+
+// NewRenderer creates a Renderer for theme. Theme styles are applied on top
+// of base, so its background and attributes show through where the theme
+// does not set them.
+func NewRenderer(theme *highlighter.Theme, base tcell.Style) *Renderer {
+	return &Renderer{
+		theme:  theme,
+		base:   base,
+		styles: make(map[lexer.TokenType]tcell.Style),
+	}
+}
+
+// Highlight tokenizes input with h and returns its lines as cells in h's
+// theme on the default style.
+func Highlight(h *highlighter.Highlighter, input string) [][]Cell {
+	return NewRenderer(h.Theme(), tcell.StyleDefault).Lines(h.Tokens(input))
+}
+
+// StyleFor returns the style tokens of type t are drawn with.
+func (r *Renderer) StyleFor(t lexer.TokenType) tcell.Style {
+	style, ok := r.styles[t]
+	if !ok {
+		style = r.base
+		if r.theme != nil {
+			style = Style(r.base, r.theme.GetColor(t))
+		}
+		r.styles[t] = style
+	}
+	return style
+}
+
+// Lines converts tokens to cells, one slice per line. Tabs are expanded to
+// spaces at TabWidth stops and carriage returns are dropped.
+func (r *Renderer) Lines(tokens []lexer.Token) [][]Cell {
+	var lines [][]Cell
+	var line []Cell
+	col := 0
+	for _, token := range tokens {
+		style := r.StyleFor(token.Type)
+		for _, c := range token.Value {
+			switch {
+			case c == '\n':
+				lines = append(lines, line)
+				line, col = nil, 0
+			case c == '\r':
+			case c == '\t':
+				for n := TabWidth - col%TabWidth; n > 0; n-- {
+					line = append(line, Cell{Rune: ' ', Style: style})
+					col++
+				}
+			case runewidth.RuneWidth(c) == 0 && len(line) > 0:
+				last := &line[len(line)-1]
+				last.Combining = append(last.Combining, c)
+			default:
+				line = append(line, Cell{Rune: c, Style: style})
+				col += max(runewidth.RuneWidth(c), 1)
+			}
+		}
+	}
+	if line != nil {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Draw draws lines onto s in the width by height area at x, y, clipping
+// lines that are too long or too many. Wide runes take two columns; one that
+// does not fit is not drawn. Cells past the end of each line are left as
+// they are, so clear the area first when redrawing.
+func Draw(s tcell.Screen, x, y, width, height int, lines [][]Cell) {
+	for row := 0; row < height && row < len(lines); row++ {
+		col := 0
+		for _, c := range lines[row] {
+			w := max(runewidth.RuneWidth(c.Rune), 1)
+			if col+w > width {
+				break
+			}
+			s.SetContent(x+col, y+row, c.Rune, c.Combining, c.Style)
+			col += w
+		}
+	}
+}
+
+// Style applies an ANSI SGR style (as used in themes) to base. Bold, dim,
+// italic and underline are kept, along with foreground and background colors
+// in 16-color, 256-color and true color form. A reset returns to base.
+func Style(base tcell.Style, ansi string) tcell.Style {
+	style := base
+	for _, seq := range strings.Split(ansi, "\033[") {
+		params := strings.Split(strings.TrimSuffix(seq, "m"), ";")
+		for i := 0; i < len(params); i++ {
+			n, err := strconv.Atoi(params[i])
+			if err != nil {
+				continue
+			}
+			switch {
+			case n == 0:
+				style = base
+			case n == 1:
+				style = style.Bold(true)
+			case n == 2:
+				style = style.Dim(true)
+			case n == 3:
+				style = style.Italic(true)
+			case n == 4:
+				style = style.Underline(true)
+			case (n == 38 || n == 48) && i+2 < len(params) && params[i+1] == "5":
+				c, _ := strconv.Atoi(params[i+2])
+				style = setColor(style, n == 48, tcell.PaletteColor(c))
+				i += 2
+			case (n == 38 || n == 48) && i+4 < len(params) && params[i+1] == "2":
+				r, _ := strconv.Atoi(params[i+2])
+				g, _ := strconv.Atoi(params[i+3])
+				b, _ := strconv.Atoi(params[i+4])
+				style = setColor(style, n == 48, tcell.NewRGBColor(int32(r), int32(g), int32(b)))
+				i += 4
+			case n >= 30 && n <= 37:
+				style = style.Foreground(tcell.PaletteColor(n - 30))
+			case n >= 90 && n <= 97:
+				style = style.Foreground(tcell.PaletteColor(n - 90 + 8))
+			case n >= 40 && n <= 47:
+				style = style.Background(tcell.PaletteColor(n - 40))
+			case n >= 100 && n <= 107:
+				style = style.Background(tcell.PaletteColor(n - 100 + 8))
+			}
+		}
+	}
+	return style
+}
+
+func setColor(style tcell.Style, background bool, c tcell.Color) tcell.Style {
+	if background {
+		return style.Background(c)
+	}
+	return style.Foreground(c)
+}
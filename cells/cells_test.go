@@ -0,0 +1,102 @@
+package cells
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestStyle(t *testing.T) {
+	base := tcell.StyleDefault.Background(tcell.ColorBlack)
+	tests := []struct {
+		name     string
+		ansi     string
+		expected tcell.Style
+	}{
+		{"empty", "", base},
+		{"basic red", highlighter.Red, base.Foreground(tcell.PaletteColor(1))},
+		{"bright cyan", highlighter.BrightCyan, base.Foreground(tcell.PaletteColor(14))},
+		{"bold blue", highlighter.Bold + highlighter.Blue, base.Bold(true).Foreground(tcell.PaletteColor(4))},
+		{"dim italic", highlighter.Dim + highlighter.Italic, base.Dim(true).Italic(true)},
+		{"underline", highlighter.Underline, base.Underline(true)},
+		{"256", highlighter.Color256(244), base.Foreground(tcell.PaletteColor(244))},
+		{"truecolor", highlighter.RGB(255, 158, 100), base.Foreground(tcell.NewRGBColor(255, 158, 100))},
+		{"background", highlighter.BgRGB(1, 2, 3) + highlighter.BgColor256(17), base.Background(tcell.PaletteColor(17))},
+		{"reset", highlighter.Bold + highlighter.Reset + highlighter.Red, base.Foreground(tcell.PaletteColor(1))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Style(base, tt.ansi); got != tt.expected {
+				t.Errorf("Style(%q) = %v, want %v", tt.ansi, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRendererLines(t *testing.T) {
+	theme := highlighter.VibrantTheme()
+	r := NewRenderer(theme, tcell.StyleDefault)
+	tokens := []lexer.Token{
+		{Type: lexer.TokenCommand, Value: "interface"},
+		{Type: lexer.TokenText, Value: "\t"},
+		{Type: lexer.TokenInterface, Value: "Gi0/1"},
+		{Type: lexer.TokenText, Value: "\r\n"},
+		{Type: lexer.TokenCommand, Value: "é"},
+	}
+
+	lines := r.Lines(tokens)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+
+	var text string
+	for _, c := range lines[0] {
+		text += string(c.Rune)
+	}
+	if want := "interface       Gi0/1"; text != want {
+		t.Errorf("line 1 = %q, want %q", text, want)
+	}
+	if got, want := lines[0][0].Style, Style(tcell.StyleDefault, theme.GetColor(lexer.TokenCommand)); got != want {
+		t.Errorf("command style = %v, want %v", got, want)
+	}
+	if got, want := lines[0][16].Style, r.StyleFor(lexer.TokenInterface); got != want {
+		t.Errorf("interface style = %v, want %v", got, want)
+	}
+
+	if len(lines[1]) != 1 || lines[1][0].Rune != 'e' || len(lines[1][0].Combining) != 1 {
+		t.Errorf("line 2 = %+v, want one cell with a combining rune", lines[1])
+	}
+}
+
+func TestDraw(t *testing.T) {
+	s := tcell.NewSimulationScreen("")
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Fini()
+	s.SetSize(20, 5)
+
+	h := highlighter.New(highlighter.WithTheme(highlighter.VibrantTheme()))
+	lines := Highlight(h, "interface GigabitEthernet0/1\n shutdown\n")
+	Draw(s, 1, 1, 12, 1, lines)
+
+	var text string
+	for x := 0; x < 20; x++ {
+		c, _, _, _ := s.GetContent(x, 1)
+		text += string(c)
+	}
+	if want := " interface Gi       "; text[:len(want)] != want {
+		t.Errorf("row = %q, want %q", text, want)
+	}
+	if c, _, _, _ := s.GetContent(1, 2); c == 's' {
+		t.Error("Draw wrote past the height limit")
+	}
+
+	_, _, style, _ := s.GetContent(1, 1)
+	if want := Style(tcell.StyleDefault, h.Theme().GetColor(lexer.TokenCommand)); style != want {
+		t.Errorf("style = %v, want %v", style, want)
+	}
+}
@@ -0,0 +1,21 @@
+module github.com/lasseh/cink/cells
+
+go 1.22
+
+require (
+	github.com/gdamore/tcell/v2 v2.7.4
+	github.com/lasseh/cink v0.0.0
+	github.com/mattn/go-runewidth v0.0.15
+)
+
+require (
+	github.com/gdamore/encoding v1.0.0 // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/rivo/uniseg v0.4.3 // indirect
+	golang.org/x/sys v0.26.0 // indirect
+	golang.org/x/term v0.25.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+// Built from this repository
+replace github.com/lasseh/cink => ../
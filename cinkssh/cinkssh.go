@@ -0,0 +1,178 @@
+// Package cinkssh highlights interactive sessions opened with
+// golang.org/x/crypto/ssh:
+//
+//	session, err := client.NewSession()
+//	...
+//	restore, err := cinkssh.Wrap(session, highlighter.NordTheme())
+//	if err != nil {
+//		return err
+//	}
+//	defer restore()
+//	if err := session.Shell(); err != nil {
+//		return err
+//	}
+//	return session.Wait()
+//
+// It is a separate module so the main cink module does not depend on
+// x/crypto.
+package cinkssh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"sync"
+	"syscall"
+
+	"github.com/lasseh/cink/highlighter"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
+)
+
+// Wrap highlights session output with theme, the default theme if nil. Call
+// it before Shell, Start or Run, and don't request a pty yourself.
+//
+// Stdout and Stderr are wrapped in a Writer; unset ones default to
+// os.Stdout and os.Stderr. When Stdin is unset and standard input is a
+// terminal, Wrap also connects it to the session: it requests a pty of the
+// terminal's size, puts the terminal in raw mode so keys reach the device as
+// typed, and passes window size changes on so the device re-wraps its output.
+// The returned function restores the terminal and must be called once the
+// session is over.
+func Wrap(session *ssh.Session, theme *highlighter.Theme) (restore func(), err error) {
+	if theme == nil {
+		theme = highlighter.DefaultTheme()
+	}
+	// Stdout and stderr share a highlighter, so the hostname learned from a
+	// prompt on one is known on the other
+	hl := highlighter.New(
+		highlighter.WithTheme(theme),
+		highlighter.WithColorDepth(highlighter.DetectTerminal()),
+	)
+	if session.Stdout == nil {
+		session.Stdout = os.Stdout
+	}
+	if session.Stderr == nil {
+		session.Stderr = os.Stderr
+	}
+	session.Stdout = NewWriter(session.Stdout, hl)
+	session.Stderr = NewWriter(session.Stderr, hl)
+
+	fd := int(os.Stdin.Fd())
+	if session.Stdin != nil || !term.IsTerminal(fd) {
+		return func() {}, nil
+	}
+	session.Stdin = os.Stdin
+
+	width, height, err := term.GetSize(fd)
+	if err != nil {
+		return nil, fmt.Errorf("getting terminal size: %w", err)
+	}
+	termType := os.Getenv("TERM")
+	if termType == "" {
+		termType = "xterm-256color"
+	}
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          1,
+		ssh.TTY_OP_ISPEED: 14400,
+		ssh.TTY_OP_OSPEED: 14400,
+	}
+	if err := session.RequestPty(termType, height, width, modes); err != nil {
+		return nil, fmt.Errorf("requesting pty: %w", err)
+	}
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("setting raw mode: %w", err)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGWINCH)
+	sigDone := make(chan struct{})
+	go func() {
+		defer close(sigDone)
+		for range sigCh {
+			if width, height, err := term.GetSize(fd); err == nil {
+				// The session may already be closing; nothing to resize then
+				_ = session.WindowChange(height, width)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(sigCh)
+			close(sigCh)
+			<-sigDone
+			if err := term.Restore(fd, oldState); err != nil {
+				fmt.Fprintf(os.Stderr, "cink: error restoring terminal: %v\n", err)
+			}
+		})
+	}, nil
+}
+
+// screenSwitch matches the sequences that switch to and from the alternate
+// screen, which full-screen programs (vi, less, top) draw on.
+var screenSwitch = regexp.MustCompile(`\x1b\[\?(1049|1047|47)[hl]`)
+
+// Writer highlights a session's output as it is written. Like cink's own
+// terminal wrapper it highlights each line, and the partial line a write
+// ends with so prompts are colored as they appear. While a full-screen
+// program has the alternate screen, output is passed through untouched.
+type Writer struct {
+	mu  sync.Mutex
+	w   io.Writer
+	hl  *highlighter.Highlighter
+	raw bool // a full-screen program is running
+}
+
+// NewWriter creates a Writer that writes output highlighted by hl to w.
+func NewWriter(w io.Writer, hl *highlighter.Highlighter) *Writer {
+	return &Writer{w: w, hl: hl}
+}
+
+// Write highlights p and writes it to the underlying writer.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var out bytes.Buffer
+	rest := p
+	for len(rest) > 0 {
+		loc := screenSwitch.FindIndex(rest)
+		if loc == nil {
+			w.render(&out, rest)
+			break
+		}
+		w.render(&out, rest[:loc[0]])
+		out.Write(rest[loc[0]:loc[1]])
+		w.raw = rest[loc[1]-1] == 'h'
+		rest = rest[loc[1]:]
+	}
+
+	if _, err := w.w.Write(out.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// render writes data to out, highlighted line by line unless a full-screen
+// program is running.
+func (w *Writer) render(out *bytes.Buffer, data []byte) {
+	if w.raw {
+		out.Write(data)
+		return
+	}
+	for len(data) > 0 {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line = data[:i+1]
+		}
+		out.WriteString(w.hl.HighlightForced(string(line)))
+		data = data[len(line):]
+	}
+}
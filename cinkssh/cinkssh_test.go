@@ -0,0 +1,92 @@
+package cinkssh
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/highlighter"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestWriter(t *testing.T) {
+	hl := highlighter.New(highlighter.WithColorDepth(highlighter.TrueColor))
+
+	tests := []struct {
+		name   string
+		writes []string
+		want   string // exact output, "" to expect the input highlighted
+	}{
+		{
+			name:   "lines and prompt",
+			writes: []string{"R1#show ip int brief\r\n", "Gi0/1  10.0.0.1  YES manual up  up\r\nR1#"},
+		},
+		{
+			name:   "full-screen program passed through",
+			writes: []string{"\x1b[?1049h\x1b[Hinterface Gi0/1\r\n", "  shutdown", "\x1b[?1049l"},
+			want:   "\x1b[?1049h\x1b[Hinterface Gi0/1\r\n  shutdown\x1b[?1049l",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w := NewWriter(&out, hl)
+			for _, s := range tt.writes {
+				n, err := w.Write([]byte(s))
+				if err != nil || n != len(s) {
+					t.Fatalf("Write(%q) = %d, %v", s, n, err)
+				}
+			}
+			input := strings.Join(tt.writes, "")
+			if tt.want != "" {
+				if got := out.String(); got != tt.want {
+					t.Errorf("got %q, want %q", got, tt.want)
+				}
+				return
+			}
+			if got := highlighter.StripANSI(out.String()); got != input {
+				t.Errorf("text changed: got %q, want %q", got, input)
+			}
+			if out.String() == input {
+				t.Error("output should be highlighted")
+			}
+		})
+	}
+}
+
+func TestWriterAfterFullScreen(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out, highlighter.New())
+	w.Write([]byte("\x1b[?1049hvi screen\x1b[?1049linterface Gi0/1\r\n"))
+
+	got := out.String()
+	if !strings.HasPrefix(got, "\x1b[?1049hvi screen\x1b[?1049l") {
+		t.Errorf("full-screen output should pass through, got %q", got)
+	}
+	if strings.HasSuffix(got, "\x1b[?1049linterface Gi0/1\r\n") {
+		t.Errorf("output after leaving the full-screen program should be highlighted, got %q", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	var stdout bytes.Buffer
+	session := &ssh.Session{Stdout: &stdout, Stdin: strings.NewReader("")}
+	restore, err := Wrap(session, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restore()
+
+	if _, ok := session.Stdout.(*Writer); !ok {
+		t.Errorf("Stdout should be a *Writer, got %T", session.Stdout)
+	}
+	if _, ok := session.Stderr.(*Writer); !ok {
+		t.Errorf("Stderr should default to a wrapped os.Stderr, got %T", session.Stderr)
+	}
+
+	session.Stdout.Write([]byte("interface Gi0/1\n"))
+	if got := highlighter.StripANSI(stdout.String()); got != "interface Gi0/1\n" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/lasseh/cink/cinkssh
+
+go 1.22
+
+require (
+	github.com/lasseh/cink v0.0.0
+	golang.org/x/crypto v0.24.0
+	golang.org/x/term v0.25.0
+)
+
+require (
+	github.com/mattn/go-runewidth v0.0.15 // indirect
+	github.com/rivo/uniseg v0.4.3 // indirect
+	golang.org/x/sys v0.26.0 // indirect
+)
+
+// Built from this repository
+replace github.com/lasseh/cink => ../
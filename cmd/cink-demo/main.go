@@ -156,6 +156,24 @@ Vlan    Mac Address       Type        Ports
  All    0100.0ccc.cccc    STATIC      CPU
 `
 
+const sampleSpanningTree = `VLAN0100
+  Spanning tree enabled protocol rstp
+  Root ID    Priority    24676
+             Address     0011.2233.4455
+             Cost        4
+             Port        1 (GigabitEthernet1/0/1)
+
+  Bridge ID  Priority    32868  (priority 32768 sys-id-ext 100)
+             Address     aabb.ccdd.eeff
+
+Interface           Role Sts Cost      Prio.Nbr Type
+------------------- ---- --- --------- -------- --------------------------------
+Gi1/0/1             Root FWD 4         128.1    P2p
+Gi1/0/2             Desg FWD 4         128.2    P2p
+Gi1/0/3             Altn BLK 4         128.3    P2p
+Gi1/0/4             Desg LRN 4         128.4    P2p Edge
+`
+
 func main() {
 	var (
 		themeName  string
@@ -203,6 +221,8 @@ func showAllThemes() {
 		{"dracula", highlighter.DraculaTheme()},
 		{"gruvbox", highlighter.GruvboxDarkTheme()},
 		{"onedark", highlighter.OneDarkTheme()},
+		{"colorblind", highlighter.ColorblindTheme()},
+		{"high-contrast", highlighter.HighContrastTheme()},
 	}
 
 	sample := `!
@@ -249,4 +269,7 @@ func showShowOutputDemo(themeName string) {
 
 	fmt.Println("\n--- show mac address-table ---")
 	fmt.Println(hl.HighlightShowOutput(sampleMACTable))
+
+	fmt.Println("\n--- show spanning-tree ---")
+	fmt.Println(hl.HighlightShowOutput(sampleSpanningTree))
 }
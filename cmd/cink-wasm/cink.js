@@ -0,0 +1,55 @@
+// cink.js - browser wrapper for cink.wasm (build with "make wasm").
+//
+// Load Go's wasm_exec.js first (copied to build/ by "make wasm"), then:
+//
+//   import { loadCink } from "./cink.js";
+//
+//   const cink = await loadCink("cink.wasm");
+//   pre.innerHTML = cink.highlightHTML(config, { theme: "nord" });
+//   const tokens = cink.tokenize(config, { mode: "config" });
+//
+// Options: theme, dialect and mode take the same names as the CLI flags;
+// force highlights input that does not look like Cisco output.
+
+export async function loadCink(url = "cink.wasm") {
+  if (typeof Go === "undefined") {
+    throw new Error("cink: wasm_exec.js must be loaded before cink.js");
+  }
+
+  const go = new Go();
+  const response = fetch(url);
+  const { instance } = WebAssembly.instantiateStreaming
+    ? await WebAssembly.instantiateStreaming(response, go.importObject)
+    : await WebAssembly.instantiate(await (await response).arrayBuffer(), go.importObject);
+
+  // The Go program registers globalThis.cink and then blocks forever
+  go.run(instance);
+  const api = globalThis.cink;
+
+  return {
+    version: api.version,
+
+    // highlightHTML returns escaped HTML with one <span class="cink-<type>">
+    // per token; wrap it in <pre> to keep the layout.
+    highlightHTML(input, options = {}) {
+      return api.highlightHTML(String(input), options);
+    },
+
+    // tokenize returns an array of {type, value, line, column} objects.
+    tokenize(input, options = {}) {
+      const result = api.tokenize(String(input), options);
+      if (result instanceof Error) {
+        throw result;
+      }
+      return JSON.parse(result);
+    },
+
+    themes() {
+      return Array.from(api.themes());
+    },
+
+    dialects() {
+      return Array.from(api.dialects());
+    },
+  };
+}
@@ -0,0 +1,125 @@
+//go:build js && wasm
+
+// cink-wasm exposes the highlighter to JavaScript when compiled to
+// WebAssembly (make wasm). It sets a global "cink" object:
+//
+//	cink.highlightHTML(input, options) string  // HTML spans, see HighlightHTML
+//	cink.tokenize(input, options) string       // JSON array of tokens
+//	cink.themes() string[]
+//	cink.dialects() string[]
+//	cink.version string
+//
+// options is an optional object with "theme", "dialect" and "mode" names (as
+// accepted by the CLI flags) and a "force" boolean. cink.js wraps this in a
+// promise-based loader.
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"syscall/js"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+)
+
+// version is set via ldflags at build time (see Makefile)
+var version = "dev"
+
+// options mirrors the CLI flags that make sense in a browser.
+type options struct {
+	theme   string
+	dialect string
+	mode    string
+	force   bool
+}
+
+func parseOptions(args []js.Value) options {
+	var opts options
+	if len(args) < 2 || args[1].Type() != js.TypeObject {
+		return opts
+	}
+	get := func(name string) js.Value { return args[1].Get(name) }
+	if v := get("theme"); v.Type() == js.TypeString {
+		opts.theme = v.String()
+	}
+	if v := get("dialect"); v.Type() == js.TypeString {
+		opts.dialect = v.String()
+	}
+	if v := get("mode"); v.Type() == js.TypeString {
+		opts.mode = v.String()
+	}
+	opts.force = get("force").Truthy()
+	return opts
+}
+
+func (o options) dialectValue() lexer.Dialect {
+	if o.dialect == "" {
+		return lexer.DialectAuto
+	}
+	return lexer.DialectByName(strings.ToLower(o.dialect))
+}
+
+func (o options) modeValue() lexer.ParseMode {
+	return lexer.ParseModeByName(strings.ToLower(o.mode))
+}
+
+func highlightHTML(_ js.Value, args []js.Value) any {
+	if len(args) == 0 {
+		return ""
+	}
+	opts := parseOptions(args)
+
+	hl := highlighter.New(
+		highlighter.WithTheme(highlighter.ThemeByName(strings.ToLower(opts.theme))),
+		highlighter.WithDialect(opts.dialectValue()),
+		highlighter.WithMode(opts.modeValue()),
+		highlighter.WithDetection(!opts.force),
+	)
+	return hl.HighlightHTML(args[0].String())
+}
+
+func tokenize(_ js.Value, args []js.Value) any {
+	if len(args) == 0 {
+		return "[]"
+	}
+	opts := parseOptions(args)
+
+	lex := lexer.New(highlighter.StripANSI(args[0].String()))
+	lex.SetDialect(opts.dialectValue())
+	lex.SetParseMode(opts.modeValue())
+	tokens := lex.Tokenize()
+	if tokens == nil {
+		tokens = []lexer.Token{}
+	}
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return js.Global().Get("Error").New(err.Error())
+	}
+	return string(data)
+}
+
+func stringArray(names []string) any {
+	values := make([]any, len(names))
+	for i, name := range names {
+		values[i] = name
+	}
+	return values
+}
+
+func main() {
+	js.Global().Set("cink", js.ValueOf(map[string]any{
+		"highlightHTML": js.FuncOf(highlightHTML),
+		"tokenize":      js.FuncOf(tokenize),
+		"themes": js.FuncOf(func(js.Value, []js.Value) any {
+			return stringArray(highlighter.ThemeNames())
+		}),
+		"dialects": js.FuncOf(func(js.Value, []js.Value) any {
+			return stringArray(lexer.DialectNames())
+		}),
+		"version": version,
+	}))
+
+	// Keep the Go runtime alive so the callbacks stay valid
+	select {}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+)
+
+// isFileList reports whether args name files to highlight (cink *.cfg)
+// rather than a command to run (cink ssh router): the first must be a
+// regular file that is not executable, since commands are looked up on $PATH.
+func isFileList(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	info, err := os.Stat(args[0])
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0o111 == 0
+}
+
+// fileReport is what highlighting one file of a batch found.
+type fileReport struct {
+	path    string
+	dialect lexer.Dialect
+	mode    lexer.ParseMode
+	err     error // the file could not be read
+
+	bad     int // bad states in show output: down, err-disabled, Idle, ...
+	warning int // warning states in show output
+	type7   int // reversible type 7 passwords
+
+	failOn *tokenWatch // --fail-on watch the counts are passed on to
+}
+
+// hook is a highlighter token hook that counts the type 7 passwords of the
+// file, then passes the token on to the --fail-on watch.
+func (r *fileReport) hook(tok lexer.Token) lexer.Token {
+	if tok.Type == lexer.TokenSecret {
+		r.type7++
+	}
+	if r.failOn != nil {
+		return r.failOn.hook(tok)
+	}
+	return tok
+}
+
+// highlightFiles writes each file in paths to out under a header naming it
+// and the dialect and mode detected for it, then a summary of the states
+// found in each. Files that cannot be read are reported in the summary and
+// the returned error, and the rest are still highlighted.
+func highlightFiles(out io.Writer, paths []string, opts options) error {
+	theme := opts.theme.WithColorDepth(opts.depth)
+	if opts.disabled {
+		theme = nil
+	}
+
+	// One anonymizer for the batch keeps placeholders consistent across files
+	var anon *highlighter.Anonymizer
+	if opts.anonymize {
+		anon = highlighter.NewAnonymizer()
+	}
+
+	reports := make([]*fileReport, len(paths))
+	var failed []string
+	for i, path := range paths {
+		r := &fileReport{path: path, failOn: opts.failOn}
+		reports[i] = r
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			r.err = err
+			failed = append(failed, path)
+			continue
+		}
+		input := string(data)
+		if opts.stripPager {
+			input = highlighter.StripPagination(input)
+		}
+		if anon != nil {
+			input = anon.AnonymizeAligned(input)
+		}
+
+		// Each file gets its own highlighter, so detection starts over
+		r.dialect, r.mode = opts.dialect, opts.mode
+		if r.dialect == lexer.DialectAuto {
+			r.dialect = lexer.DetectDialect(input)
+		}
+		if r.mode == lexer.ParseModeAuto {
+			r.mode = lexer.DetectParseMode(input)
+		}
+		hl := newHighlighter(opts)
+		hl.SetTokenHook(r.hook)
+		highlighted := hl.HighlightForced(input) + hl.FlushSuppressed()
+		if opts.disabled {
+			highlighted = input
+		}
+		// States are counted as the footer lists them, once per finding
+		summary := highlighter.Summarize(input)
+		for _, f := range summary.Findings {
+			if f.Severity == lexer.TokenStateBad {
+				r.bad++
+			} else {
+				r.warning++
+			}
+		}
+
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprint(out, fileHeader(r, theme))
+		fmt.Fprint(out, highlighted)
+		if !strings.HasSuffix(highlighted, "\n") && highlighted != "" {
+			fmt.Fprintln(out)
+		}
+		if opts.footer {
+			fmt.Fprint(out, "\n"+summary.Footer(theme))
+		}
+	}
+
+	fmt.Fprintln(out)
+	if _, err := fmt.Fprint(out, batchSummary(reports, theme)); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return errors.New("could not read " + strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// fileHeader formats the line that introduces a file of a batch:
+//
+//	==> r1.cfg (ios config) <==
+//
+// A nil theme renders plain text.
+func fileHeader(r *fileReport, theme *highlighter.Theme) string {
+	header := fmt.Sprintf("==> %s (%s %s) <==", r.path, r.dialect.Name(), strings.ToLower(r.mode.String()))
+	if theme == nil {
+		return header + "\n"
+	}
+	return theme.GetColor(lexer.TokenSection) + header + highlighter.Reset + "\n"
+}
+
+// batchSummary formats the files of a batch and what was found in each,
+// colored with the theme's state colors:
+//
+//	Files: 3 processed, 1 with bad states, 1 with warnings
+//	  r1.cfg   2 bad states, 1 warning
+//	  r2.cfg   clean
+//	  r3.cfg   1 type 7 password
+//
+// A nil theme renders plain text.
+func batchSummary(reports []*fileReport, theme *highlighter.Theme) string {
+	color := func(t lexer.TokenType, text string) string {
+		if theme == nil {
+			return text
+		}
+		if c := theme.GetColor(t); c != "" {
+			return c + text + highlighter.Reset
+		}
+		return text
+	}
+	count := func(n int, t lexer.TokenType, what string) string {
+		if n != 1 {
+			what += "s"
+		}
+		return color(t, strconv.Itoa(n)) + " " + what
+	}
+
+	width := 0
+	for _, r := range reports {
+		width = max(width, highlighter.DisplayWidth(r.path))
+	}
+
+	var withBad, withWarning, unreadable int
+	var rows strings.Builder
+	for _, r := range reports {
+		var parts []string
+		switch {
+		case r.err != nil:
+			unreadable++
+			parts = append(parts, color(lexer.TokenStateBad, "error: "+r.err.Error()))
+		case r.bad+r.warning+r.type7 == 0:
+			parts = append(parts, color(lexer.TokenStateGood, "clean"))
+		}
+		if r.bad > 0 {
+			withBad++
+			parts = append(parts, count(r.bad, lexer.TokenStateBad, "bad state"))
+		}
+		if r.warning > 0 {
+			withWarning++
+			parts = append(parts, count(r.warning, lexer.TokenStateWarning, "warning"))
+		}
+		if r.type7 > 0 {
+			parts = append(parts, count(r.type7, lexer.TokenSecret, "type 7 password"))
+		}
+		fmt.Fprintf(&rows, "  %s   %s\n", highlighter.PadRight(r.path, width), strings.Join(parts, ", "))
+	}
+
+	totals := []string{fmt.Sprintf("%d processed", len(reports)-unreadable)}
+	if unreadable > 0 {
+		totals = append(totals, color(lexer.TokenStateBad, strconv.Itoa(unreadable))+" unreadable")
+	}
+	if withBad > 0 {
+		totals = append(totals, color(lexer.TokenStateBad, strconv.Itoa(withBad))+" with bad states")
+	}
+	if withWarning > 0 {
+		totals = append(totals, color(lexer.TokenStateWarning, strconv.Itoa(withWarning))+" with warnings")
+	}
+	label := "Files:"
+	if theme != nil {
+		label = highlighter.Bold + label + highlighter.Reset
+	}
+	return label + " " + strings.Join(totals, ", ") + "\n" + rows.String()
+}
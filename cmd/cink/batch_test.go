@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestIsFileList(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "r1.cfg")
+	script := filepath.Join(dir, "collect.sh")
+	if err := os.WriteFile(config, []byte("hostname r1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{config}, true},
+		{[]string{config, filepath.Join(dir, "missing.cfg")}, true},
+		{[]string{script, config}, false}, // a command to run
+		{[]string{dir}, false},
+		{[]string{"ssh", "router"}, false},
+	}
+	for _, tt := range tests {
+		if got := isFileList(tt.args); got != tt.want {
+			t.Errorf("isFileList(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestHighlightFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"r1.cfg":  "hostname r1\nusername admin password 7 0822455D0A16\n",
+		"sw1.txt": "Interface              IP-Address      OK? Method Status                Protocol\nGigabitEthernet0/1     10.0.0.1        YES NVRAM  up                    down\n",
+		"r2.cfg":  "hostname r2\ninterface Loopback0\n ip address 10.0.0.2 255.255.255.255",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	paths := []string{"r1.cfg", "sw1.txt", "r2.cfg", "missing.cfg"}
+	for i, name := range paths {
+		paths[i] = filepath.Join(dir, name)
+	}
+
+	var out bytes.Buffer
+	opts := options{theme: highlighter.DefaultTheme(), dialect: lexer.DialectAuto, disabled: true}
+	err := highlightFiles(&out, paths, opts)
+	if err == nil || !strings.Contains(err.Error(), "missing.cfg") {
+		t.Errorf("expected an error naming the missing file, got %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"==> " + paths[0] + " (ios config) <==\nhostname r1\n",
+		"==> " + paths[1] + " (ios show) <==\n",
+		"255.255.255.255\n\nFiles: 3 processed, 1 unreadable, 1 with bad states\n",
+		paths[0] + "        1 type 7 password\n", // padded to missing.cfg
+		paths[1] + "       1 bad state\n",
+		paths[2] + "        clean\n",
+		paths[3] + "   error: ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "\x1b[") {
+		t.Error("disabled highlighting should write plain text")
+	}
+}
+
+func TestHighlightFilesFailOn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "r1.cfg")
+	if err := os.WriteFile(path, []byte("username admin password 7 0822455D0A16\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w, _ := parseFailOn("type7")
+	opts := options{theme: highlighter.DefaultTheme(), dialect: lexer.DialectAuto, failOn: w}
+	if err := highlightFiles(&bytes.Buffer{}, []string{path}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.failure(); got != "1 type7" {
+		t.Errorf("failure() = %q, want %q", got, "1 type7")
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// settings holds the defaults that flags override. They come from, in
+// increasing priority: built-in defaults, the config file, and CINK_*
+// environment variables.
+type settings struct {
+	Theme     string // color theme name
+	Dialect   string // auto, ios, frr, aruba
+	Mode      string // auto, config, show
+	Color     string // auto, always, never
+	Pager     string // pager command for stdin mode, "" for $PAGER or less -R
+	Anonymize bool   // scrub identifying values by default
+}
+
+func defaultSettings() settings {
+	return settings{
+		Theme:   "default",
+		Dialect: "auto",
+		Mode:    "auto",
+		Color:   "auto",
+	}
+}
+
+// configPath returns the config file location: $CINK_CONFIG, else
+// $XDG_CONFIG_HOME/cink/config.toml, else ~/.config/cink/config.toml.
+func configPath() string {
+	if p := os.Getenv("CINK_CONFIG"); p != "" {
+		return p
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "cink", "config.toml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "cink", "config.toml")
+}
+
+// loadSettings builds settings from the config file and environment.
+// A missing config file is not an error. The environment still applies when
+// the config file has one, so the settings returned with it can be used.
+func loadSettings() (settings, error) {
+	s := defaultSettings()
+
+	var err error
+	if path := configPath(); path != "" {
+		var f *os.File
+		f, err = os.Open(path)
+		switch {
+		case err == nil:
+			err = s.parseConfig(f, path)
+			f.Close()
+		case errors.Is(err, fs.ErrNotExist):
+			err = nil
+		default:
+			err = fmt.Errorf("reading config: %w", err)
+		}
+	}
+
+	s.applyEnv()
+	return s, err
+}
+
+// applyEnv overrides settings from CINK_THEME, CINK_DIALECT, CINK_MODE,
+// CINK_COLOR, CINK_PAGER and CINK_ANONYMIZE.
+func (s *settings) applyEnv() {
+	if v := os.Getenv("CINK_THEME"); v != "" {
+		s.Theme = v
+	}
+	if v := os.Getenv("CINK_DIALECT"); v != "" {
+		s.Dialect = v
+	}
+	if v := os.Getenv("CINK_MODE"); v != "" {
+		s.Mode = v
+	}
+	if v := os.Getenv("CINK_COLOR"); v != "" {
+		s.Color = v
+	}
+	if v := os.Getenv("CINK_PAGER"); v != "" {
+		s.Pager = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("CINK_ANONYMIZE")); err == nil {
+		s.Anonymize = v
+	}
+}
+
+// parseConfig reads a flat TOML file of "key = value" pairs. Strings may be
+// double- or single-quoted, booleans are true/false, and # starts a comment.
+func (s *settings) parseConfig(r io.Reader, name string) error {
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(stripTOMLComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("%s:%d: expected key = value", name, lineNum)
+		}
+		key = strings.TrimSpace(key)
+		raw = strings.TrimSpace(raw)
+
+		var err error
+		switch key {
+		case "theme":
+			s.Theme, err = parseTOMLString(raw)
+		case "dialect":
+			s.Dialect, err = parseTOMLString(raw)
+		case "mode":
+			s.Mode, err = parseTOMLString(raw)
+		case "color":
+			s.Color, err = parseTOMLString(raw)
+		case "pager":
+			s.Pager, err = parseTOMLString(raw)
+		case "anonymize":
+			s.Anonymize, err = strconv.ParseBool(raw)
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", name, lineNum, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseTOMLString parses a basic ("...") or literal ('...') TOML string.
+func parseTOMLString(raw string) (string, error) {
+	if len(raw) >= 2 && raw[0] == '\'' && raw[len(raw)-1] == '\'' {
+		return raw[1 : len(raw)-1], nil
+	}
+	if len(raw) >= 2 && raw[0] == '"' {
+		return strconv.Unquote(raw)
+	}
+	return "", fmt.Errorf("expected quoted string, got %s", raw)
+}
+
+// stripTOMLComment removes a trailing # comment that is not inside quotes.
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && quote == '"' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	input := `# cink defaults
+theme = "nord"
+dialect = 'frr'   # vtysh boxes
+mode = "show"
+color = "always"
+pager = "less -R # not a comment"
+anonymize = true
+`
+	s := defaultSettings()
+	if err := s.parseConfig(strings.NewReader(input), "config.toml"); err != nil {
+		t.Fatalf("parseConfig: %v", err)
+	}
+
+	want := settings{
+		Theme:     "nord",
+		Dialect:   "frr",
+		Mode:      "show",
+		Color:     "always",
+		Pager:     "less -R # not a comment",
+		Anonymize: true,
+	}
+	if s != want {
+		t.Errorf("got %+v, want %+v", s, want)
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"unknown key", "colour = \"auto\"\n", "config.toml:1: unknown key"},
+		{"missing equals", "\ntheme nord\n", "config.toml:2: expected key = value"},
+		{"unquoted string", "theme = nord\n", "config.toml:1: expected quoted string"},
+		{"bad bool", "anonymize = yes please\n", "config.toml:1:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := defaultSettings()
+			err := s.parseConfig(strings.NewReader(tt.input), "config.toml")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadSettingsPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte("theme = \"nord\"\nmode = \"config\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("CINK_CONFIG", path)
+	t.Setenv("CINK_THEME", "dracula")
+	t.Setenv("CINK_ANONYMIZE", "1")
+
+	s, err := loadSettings()
+	if err != nil {
+		t.Fatalf("loadSettings: %v", err)
+	}
+	if s.Theme != "dracula" {
+		t.Errorf("environment should override config file, got theme %q", s.Theme)
+	}
+	if s.Mode != "config" {
+		t.Errorf("config file should override defaults, got mode %q", s.Mode)
+	}
+	if s.Dialect != "auto" {
+		t.Errorf("unset keys should keep defaults, got dialect %q", s.Dialect)
+	}
+	if !s.Anonymize {
+		t.Error("CINK_ANONYMIZE=1 should enable anonymization")
+	}
+}
+
+func TestLoadSettingsConfigError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("theme = nord\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("CINK_CONFIG", path)
+	t.Setenv("CINK_THEME", "dracula")
+
+	s, err := loadSettings()
+	if err == nil {
+		t.Error("expected an error for the unquoted theme")
+	}
+	if s.Theme != "dracula" {
+		t.Errorf("environment should apply despite the config error, got theme %q", s.Theme)
+	}
+}
+
+func TestLoadSettingsMissingFile(t *testing.T) {
+	t.Setenv("CINK_CONFIG", filepath.Join(t.TempDir(), "missing.toml"))
+
+	s, err := loadSettings()
+	if err != nil {
+		t.Fatalf("missing config file should not be an error: %v", err)
+	}
+	if s != defaultSettings() {
+		t.Errorf("expected defaults, got %+v", s)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lasseh/cink/parser"
+)
+
+// patternList is a flag that may be given more than once, collecting each
+// value in order.
+type patternList []string
+
+func (p *patternList) String() string {
+	return strings.Join(*p, ", ")
+}
+
+func (p *patternList) Set(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	*p = append(*p, value)
+	return nil
+}
+
+// extractSections returns the config sections of input whose first line
+// matches any of patterns, for --extract. It is an error for nothing to
+// match, so a typo in a script does not pass silently.
+func extractSections(input string, patterns []string) (string, error) {
+	sections := parser.ParseConfigTree(input).Extract(patterns...)
+	if sections == "" {
+		return "", fmt.Errorf("no section matches %q", strings.Join(patterns, `", "`))
+	}
+	return sections, nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func TestPatternListFlag(t *testing.T) {
+	var patterns patternList
+	fs := flag.NewFlagSet("cink", flag.ContinueOnError)
+	fs.Var(&patterns, "extract", "")
+	if err := fs.Parse([]string{"--extract", "interface Gi0/0/1", "--extract=router bgp*"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := (patternList{"interface Gi0/0/1", "router bgp*"}); !reflect.DeepEqual(patterns, want) {
+		t.Errorf("patterns = %q, want %q", patterns, want)
+	}
+	if err := patterns.Set(" "); err == nil {
+		t.Error("expected an error for an empty pattern")
+	}
+}
+
+func TestExtractSections(t *testing.T) {
+	config := "hostname R1\n!\ninterface GigabitEthernet0/0/1\n shutdown\n!\nline vty 0 4\n login\n"
+
+	got, err := extractSections(config, []string{"interface Gi0/0/1", "line vty*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "interface GigabitEthernet0/0/1\n shutdown\n!\nline vty 0 4\n login\n"; got != want {
+		t.Errorf("extractSections = %q, want %q", got, want)
+	}
+
+	if _, err := extractSections(config, []string{"router bgp"}); err == nil {
+		t.Error("expected an error when no section matches")
+	}
+}
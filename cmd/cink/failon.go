@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+)
+
+// exitFailOn is the exit status when a --fail-on condition was met.
+const exitFailOn = 3
+
+// failOnConditions maps --fail-on condition names to the token type that
+// triggers them.
+var failOnConditions = map[string]lexer.TokenType{
+	"bad-state": lexer.TokenStateBad,     // down, err-disabled, Idle, BLK, ...
+	"warning":   lexer.TokenStateWarning, // Active, LRN, ...
+	"type7":     lexer.TokenSecret,       // reversible type 7 passwords
+}
+
+// failOnNames returns the condition names accepted by --fail-on.
+func failOnNames() []string {
+	names := make([]string, 0, len(failOnConditions))
+	for name := range failOnConditions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tokenWatch records which --fail-on conditions appeared in highlighted output.
+type tokenWatch struct {
+	conditions map[lexer.TokenType]string
+	mu         sync.Mutex
+	hits       map[string]int
+}
+
+// parseFailOn parses a comma-separated list of --fail-on conditions. It
+// returns nil for an empty list.
+func parseFailOn(list string) (*tokenWatch, error) {
+	w := &tokenWatch{
+		conditions: make(map[lexer.TokenType]string),
+		hits:       make(map[string]int),
+	}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		t, ok := failOnConditions[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown --fail-on condition %q (valid: %s)", name, strings.Join(failOnNames(), ", "))
+		}
+		w.conditions[t] = name
+	}
+	if len(w.conditions) == 0 {
+		return nil, nil
+	}
+	return w, nil
+}
+
+// hook is a highlighter token hook that counts watched tokens.
+func (w *tokenWatch) hook(tok lexer.Token) lexer.Token {
+	if name, ok := w.conditions[tok.Type]; ok {
+		w.mu.Lock()
+		w.hits[name]++
+		w.mu.Unlock()
+	}
+	return tok
+}
+
+// watch tokenizes input with hl, whose token hook is w's, for output that is
+// not made from the highlighted tokens: --stats, and formats with
+// highlighting turned off.
+func (w *tokenWatch) watch(hl *highlighter.Highlighter, input string) {
+	if w != nil {
+		hl.Tokens(input)
+	}
+}
+
+// failure describes the conditions that were met, or returns "" if none were.
+func (w *tokenWatch) failure() string {
+	if w == nil {
+		return ""
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var parts []string
+	for _, name := range failOnNames() {
+		if n := w.hits[name]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, name))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
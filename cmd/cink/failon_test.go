@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestParseFailOn(t *testing.T) {
+	if w, err := parseFailOn(""); w != nil || err != nil {
+		t.Errorf("empty list should disable checks, got %v, %v", w, err)
+	}
+	if _, err := parseFailOn("bad-state,nope"); err == nil {
+		t.Error("unknown conditions should be rejected")
+	}
+
+	w, err := parseFailOn(" Bad-State , type7")
+	if err != nil {
+		t.Fatalf("parseFailOn: %v", err)
+	}
+	if w.conditions[lexer.TokenStateBad] != "bad-state" || w.conditions[lexer.TokenSecret] != "type7" {
+		t.Errorf("unexpected conditions: %v", w.conditions)
+	}
+	if _, ok := w.conditions[lexer.TokenStateWarning]; ok {
+		t.Error("warning was not requested")
+	}
+}
+
+func TestFailOnHook(t *testing.T) {
+	var nilWatch *tokenWatch
+	if nilWatch.failure() != "" {
+		t.Error("a nil watch never fails")
+	}
+
+	w, _ := parseFailOn("bad-state,type7")
+	hl := newHighlighter(options{theme: highlighter.DefaultTheme(), dialect: lexer.DialectAuto, failOn: w})
+
+	hl.HighlightForced("username admin password 7 0822455D0A16\n")
+	if got := w.failure(); got != "1 type7" {
+		t.Errorf("failure() = %q, want %q", got, "1 type7")
+	}
+
+	hl.HighlightShowOutput("GigabitEthernet0/1 is down, line protocol is down\n")
+	if got := w.failure(); got != "1 bad-state, 1 type7" {
+		t.Errorf("failure() = %q, want bad-state and type7", got)
+	}
+}
+
+func TestFailOnWatch(t *testing.T) {
+	var nilWatch *tokenWatch
+	nilWatch.watch(highlighter.New(), "")
+
+	w, _ := parseFailOn("bad-state")
+	hl := newHighlighter(options{theme: highlighter.DefaultTheme(), dialect: lexer.DialectAuto, failOn: w})
+	hl.Disable()
+
+	w.watch(hl, "Interface           IP-Address  OK? Method Status  Protocol\n"+
+		"GigabitEthernet0/1  10.0.0.1    YES NVRAM  down    down\n")
+	if got := w.failure(); got != "2 bad-state" {
+		t.Errorf("failure() = %q, want %q", got, "2 bad-state")
+	}
+}
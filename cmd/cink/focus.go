@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// parseFocus parses a comma-separated list of --focus categories. It returns
+// nil for an empty list.
+func parseFocus(list string) ([]lexer.Category, error) {
+	var categories []lexer.Category
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		c, ok := lexer.CategoryByName(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown --focus category %q (valid: %s)", name, focusNames())
+		}
+		categories = append(categories, c)
+	}
+	return categories, nil
+}
+
+// focusNames returns the category names accepted by --focus.
+func focusNames() string {
+	var names []string
+	for _, c := range lexer.AllCategories() {
+		names = append(names, strings.ToLower(c.String()))
+	}
+	return strings.Join(names, ", ")
+}
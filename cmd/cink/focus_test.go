@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestParseFocus(t *testing.T) {
+	if c, err := parseFocus(""); c != nil || err != nil {
+		t.Errorf("empty list should disable focus, got %v, %v", c, err)
+	}
+	if _, err := parseFocus("address,ips"); err == nil {
+		t.Error("unknown categories should be rejected")
+	}
+
+	c, err := parseFocus(" Address , name")
+	if err != nil {
+		t.Fatalf("parseFocus: %v", err)
+	}
+	if len(c) != 2 || c[0] != lexer.CategoryAddress || c[1] != lexer.CategoryName {
+		t.Errorf("parseFocus = %v, want [Address Name]", c)
+	}
+}
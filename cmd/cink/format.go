@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+)
+
+// formatNames lists the output forms of --format; ansi, the default, is
+// handled by the usual highlighting.
+var formatNames = []string{"ansi", "html", "json", "svg", "irc", "md"}
+
+// parseFormat checks a --format name. It returns "" for ansi.
+func parseFormat(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "", "ansi":
+		return "", nil
+	case "markdown":
+		return "md", nil
+	}
+	for _, f := range formatNames {
+		if name == f {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("unknown --format %q (valid: %s)", name, strings.Join(formatNames, ", "))
+}
+
+// renderFormat renders the whole of input in format, one of formatNames
+// other than ansi.
+func renderFormat(hl *highlighter.Highlighter, input, format string) (string, error) {
+	switch format {
+	case "html":
+		return `<pre class="cink">` + hl.HighlightHTML(strings.TrimSuffix(input, "\n")) + "</pre>\n", nil
+	case "json":
+		tokens := hl.Tokens(input)
+		if tokens == nil {
+			tokens = []lexer.Token{}
+		}
+		data, err := json.MarshalIndent(tokens, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case "svg":
+		return hl.HighlightSVG(input), nil
+	case "irc":
+		return hl.HighlightIRC(input), nil
+	case "md":
+		return hl.HighlightMarkdown(input), nil
+	}
+	return "", fmt.Errorf("unknown format %q", format)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{"", "", false},
+		{"ansi", "", false},
+		{"HTML", "html", false},
+		{"markdown", "md", false},
+		{"svg", "svg", false},
+		{"pdf", "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseFormat(tt.in)
+		if got != tt.want || (err != nil) != tt.err {
+			t.Errorf("parseFormat(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestRenderFormat(t *testing.T) {
+	hl := highlighter.New()
+	hl.SetAlwaysHighlight(true)
+	input := "interface Gi0/1\n shutdown\n"
+
+	out, err := renderFormat(hl, input, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tokens []lexer.Token
+	if err := json.Unmarshal([]byte(out), &tokens); err != nil {
+		t.Fatalf("json output does not parse: %v", err)
+	}
+	if len(tokens) == 0 || tokens[0].Value != "interface" || tokens[0].Type != lexer.TokenCommand {
+		t.Errorf("json tokens start with %+v", tokens)
+	}
+
+	for format, prefix := range map[string]string{
+		"html": `<pre class="cink"><span class="cink-command"`,
+		"svg":  `<svg xmlns="http://www.w3.org/2000/svg"`,
+		"md":   `<pre><span`,
+		"irc":  highlighter.IRCBold + highlighter.IRCColor,
+	} {
+		out, err := renderFormat(hl, input, format)
+		if err != nil || !strings.HasPrefix(out, prefix) {
+			t.Errorf("%s output starts %.60q, want %q (err %v)", format, out, prefix, err)
+		}
+	}
+}
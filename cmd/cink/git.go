@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lasseh/cink/highlighter"
+)
+
+// gitTextconv writes the highlighted contents of path to stdout, for use as
+// a git textconv filter:
+//
+//	# .gitattributes
+//	*.cfg diff=cink
+//
+//	# .gitconfig
+//	[diff "cink"]
+//	    textconv = cink git-textconv
+//
+// Output is in full colors and skips detection, streaming and the pager so
+// the same file always produces the same bytes, whether or not stdout is a
+// terminal and whatever TERM and NO_COLOR say.
+func gitTextconv(path string, opts options) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	input := string(data)
+	if opts.anonymize {
+		input = highlighter.NewAnonymizer().AnonymizeAligned(input)
+	}
+	if !opts.disabled {
+		input = newHighlighter(opts).HighlightForced(input)
+	}
+	_, err = fmt.Fprint(os.Stdout, input)
+	return err
+}
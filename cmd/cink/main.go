@@ -8,8 +8,11 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+	"github.com/lasseh/cink/parser"
 	"github.com/lasseh/cink/terminal"
 )
 
@@ -21,15 +24,68 @@ const usage = `cink - Cisco INK syntax highlighter
 USAGE:
     cink ssh user@router          # Interactive SSH with highlighting
     cat config.conf | cink        # Highlight a config file
+    cink backups/*.cfg            # Highlight files, with a header and summary
     cink -t monokai ssh router    # Use a different theme
+    cink git-textconv r1.cfg      # Highlight a file for git diff (textconv)
+    cink themes --preview         # Show a sample of every theme
+    git diff | cink --diff-highlight
 
 OPTIONS:
     -f, --force           Always highlight (skip auto-detection)
     -t, --theme <name>    Color theme (see THEMES below)
+    -D, --dialect <name>  Syntax dialect: auto (default), ios, frr, aruba
+    -m, --mode <name>     Parse mode: auto (default), config, show, transcript
     -n, --no-highlight    Disable highlighting (pass-through mode)
+    -a, --anonymize       Scrub IPs, hostnames, usernames and SNMP locations
+    -s, --strip-pager     Remove --More-- prompts and backspace artifacts
+        --heatmap         Color interface counters by value (0 dim, >0 yellow, >=1000 red)
+        --flaps <dur>     Show BGP/OSPF neighbor uptimes below dur (e.g. 1h) as warnings
+        --lsa-aging       Highlight fresh LSAs and dim old ones in OSPF database output
+        --pool-usage      Color DHCP pools 80% or more leased as warnings, 95% as critical
+        --percent <w,c>   Color percentages from w as warnings, from c as critical
+                          (default 70,90; "off" colors them all as good)
+        --routes <list>   Flag route summary counts outside source=min-max, e.g.
+                          "bgp=800000-,ospf=1-" (comma-separated, either bound optional)
+        --indent-guides   Draw guides showing the nesting depth of config sections
+        --line-numbers    Prefix each line with its line number
+        --learn-names     Color route-map, ACL, VRF and class-map names like their
+                          definitions wherever they are used
+        --severity <lvl>  Hide syslog messages less severe than lvl (0-7 or a name
+                          such as errors), showing a dimmed count in their place
+        --compact         Merge escape codes of adjacent same-colored words
+        --focus <list>    Dim everything but these token categories: structure,
+                          name, address, literal, state, secret (comma-separated)
+        --diff-highlight  Highlight unified diff input (git pager / diffFilter)
+        --stats           Print a summary of interface, BGP, OSPF and ACL counts
+        --footer          Follow the output with the interfaces down, neighbors not up
+                          and other bad or warning states found, with their lines
+        --explain         Follow each line with the type of each token and the rule
+                          that chose it, for reporting misclassifications
+        --fold            Collapse config sections to their first line
+        --extract <glob>  Print only the config sections whose first line matches,
+                          e.g. "interface Gi0/0/*" (repeatable)
+        --expand-groups   Show the members of object-groups below the ACL lines
+                          that use them
+        --fail-on <list>  Exit with status 3 if the input contained any of:
+                          bad-state, warning, type7 (comma-separated)
+        --format <name>   Output form: ansi (default), html, json (tokens), svg,
+                          irc (mIRC color codes) or md (Markdown)
+        --no-pager        Don't page output taller than the terminal
     -v, --version         Show version
     -h, --help            Show this help
 
+CONFIGURATION:
+    Defaults are read from ~/.config/cink/config.toml (or $CINK_CONFIG),
+    then overridden by environment variables, then by flags:
+
+        theme = "nord"          # CINK_THEME
+        dialect = "auto"        # CINK_DIALECT
+        mode = "auto"           # CINK_MODE
+        color = "auto"          # CINK_COLOR: auto, always, never
+        pager = "less -R"       # CINK_PAGER, else $PAGER (stdin mode, when output
+                                # is taller than the terminal)
+        anonymize = false       # CINK_ANONYMIZE
+
 THEMES:
     default     - Tokyo Night color scheme (default)
     tokyonight  - Tokyo Night color scheme
@@ -41,25 +97,123 @@ THEMES:
     dracula     - Dracula color scheme
     gruvbox     - Gruvbox Dark color scheme
     onedark     - Atom One Dark color scheme
+    colorblind  - Okabe-Ito palette, safe for red-green color blindness
+    high-contrast - Bright colors and underlined failures for low vision
 
 `
 
+// options holds the resolved command line configuration.
+type options struct {
+	theme      *highlighter.Theme
+	depth      highlighter.ColorDepth
+	dialect    lexer.Dialect
+	mode       lexer.ParseMode
+	disabled   bool
+	force      bool
+	anonymize  bool
+	stripPager bool
+	heatmap    bool
+	flaps      time.Duration // 0 when off
+	lsaAging   bool
+	poolUsage  bool
+	percent    [2]float64 // warning and critical percentage, 0 when off
+	guides     bool
+	lineNums   bool
+	learnNames bool
+	routes     map[string]highlighter.RouteExpectation // nil unless --routes is set
+	compact    bool
+	focus      []lexer.Category // nil unless --focus is set
+	hideLogs   bool             // hide syslog messages less severe than severity
+	severity   int              // least severe syslog message shown
+	diff       bool
+	stats      bool
+	footer     bool
+	explain    bool
+	fold       bool
+	extract    []string    // section patterns, nil unless --extract is set
+	expand     bool        // inline object-group members
+	failOn     *tokenWatch // nil unless --fail-on is set
+	pager      string      // "" when paging is off
+	format     string      // --format other than ansi, "" for ANSI escapes
+}
+
 func main() {
+	cfg, err := loadSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cink: %v\n", err)
+	}
+
 	var (
 		themeName   string
+		dialectName string
+		modeName    string
 		noHighlight bool
 		forceHL     bool
+		anonymize   bool
+		stripPager  bool
+		heatmap     bool
+		flaps       time.Duration
+		lsaAging    bool
+		poolUsage   bool
+		percent     string
+		routes      string
+		guides      bool
+		lineNums    bool
+		learnNames  bool
+		severity    string
+		compact     bool
+		focus       string
+		diffMode    bool
+		stats       bool
+		footer      bool
+		explain     bool
+		fold        bool
+		extract     patternList
+		expand      bool
+		failOn      string
+		format      string
+		noPager     bool
 		showVersion bool
 		showHelp    bool
 		debug       bool
 	)
 
-	flag.StringVar(&themeName, "theme", "default", "Color theme")
-	flag.StringVar(&themeName, "t", "default", "Color theme (shorthand)")
-	flag.BoolVar(&noHighlight, "no-highlight", false, "Disable highlighting")
-	flag.BoolVar(&noHighlight, "n", false, "Disable highlighting (shorthand)")
-	flag.BoolVar(&forceHL, "force", false, "Force highlighting (skip detection)")
-	flag.BoolVar(&forceHL, "f", false, "Force highlighting (shorthand)")
+	flag.StringVar(&themeName, "theme", cfg.Theme, "Color theme")
+	flag.StringVar(&themeName, "t", cfg.Theme, "Color theme (shorthand)")
+	flag.StringVar(&dialectName, "dialect", cfg.Dialect, "Syntax dialect")
+	flag.StringVar(&dialectName, "D", cfg.Dialect, "Syntax dialect (shorthand)")
+	flag.StringVar(&modeName, "mode", cfg.Mode, "Parse mode")
+	flag.StringVar(&modeName, "m", cfg.Mode, "Parse mode (shorthand)")
+	flag.BoolVar(&noHighlight, "no-highlight", cfg.Color == "never", "Disable highlighting")
+	flag.BoolVar(&noHighlight, "n", cfg.Color == "never", "Disable highlighting (shorthand)")
+	flag.BoolVar(&forceHL, "force", cfg.Color == "always", "Force highlighting (skip detection)")
+	flag.BoolVar(&forceHL, "f", cfg.Color == "always", "Force highlighting (shorthand)")
+	flag.BoolVar(&anonymize, "anonymize", cfg.Anonymize, "Scrub identifying values")
+	flag.BoolVar(&anonymize, "a", cfg.Anonymize, "Scrub identifying values (shorthand)")
+	flag.BoolVar(&stripPager, "strip-pager", false, "Remove pagination artifacts")
+	flag.BoolVar(&stripPager, "s", false, "Remove pagination artifacts (shorthand)")
+	flag.BoolVar(&heatmap, "heatmap", false, "Color interface counters by value")
+	flag.DurationVar(&flaps, "flaps", 0, "Highlight neighbor uptimes below this duration")
+	flag.BoolVar(&lsaAging, "lsa-aging", false, "Emphasize fresh and old OSPF LSAs")
+	flag.BoolVar(&poolUsage, "pool-usage", false, "Color DHCP pools by utilization")
+	flag.StringVar(&percent, "percent", "", "Percentage thresholds (warning,critical)")
+	flag.StringVar(&routes, "routes", "", "Expected route counts by source (source=min-max,...)")
+	flag.BoolVar(&guides, "indent-guides", false, "Draw indent guides")
+	flag.BoolVar(&lineNums, "line-numbers", false, "Prefix lines with their line number")
+	flag.BoolVar(&learnNames, "learn-names", false, "Color references to names defined in the input")
+	flag.StringVar(&severity, "severity", "", "Hide syslog messages less severe than this level")
+	flag.BoolVar(&compact, "compact", false, "Coalesce escape codes of same-style tokens")
+	flag.StringVar(&focus, "focus", "", "Dim all but these token categories")
+	flag.BoolVar(&diffMode, "diff-highlight", false, "Highlight unified diff input")
+	flag.BoolVar(&stats, "stats", false, "Print a summary of the input")
+	flag.BoolVar(&footer, "footer", false, "Append a summary of the problems found")
+	flag.BoolVar(&explain, "explain", false, "Explain how each token was classified")
+	flag.BoolVar(&fold, "fold", false, "Collapse config sections")
+	flag.Var(&extract, "extract", "Print only config sections matching this pattern")
+	flag.BoolVar(&expand, "expand-groups", false, "Inline object-group members")
+	flag.StringVar(&failOn, "fail-on", "", "Exit non-zero if the input contains these conditions")
+	flag.StringVar(&format, "format", "ansi", "Output form: ansi, html, json, svg, irc, md")
+	flag.BoolVar(&noPager, "no-pager", false, "Don't page long output")
 	flag.BoolVar(&showVersion, "version", false, "Show version")
 	flag.BoolVar(&showVersion, "v", false, "Show version (shorthand)")
 	flag.BoolVar(&showHelp, "help", false, "Show help")
@@ -83,25 +237,140 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Select theme
-	theme := highlighter.ThemeByName(strings.ToLower(themeName))
+	watch, err := parseFailOn(failOn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cink: %v\n", err)
+		os.Exit(2)
+	}
+	categories, err := parseFocus(focus)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cink: %v\n", err)
+		os.Exit(2)
+	}
+	warning, critical, err := parsePercent(percent)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cink: %v\n", err)
+		os.Exit(2)
+	}
+
+	expect, err := parseRoutes(routes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cink: %v\n", err)
+		os.Exit(2)
+	}
+	outputFormat, err := parseFormat(format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cink: %v\n", err)
+		os.Exit(2)
+	}
+	level, err := parseSeverity(severity)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cink: %v\n", err)
+		os.Exit(2)
+	}
+
+	opts := options{
+		theme:      highlighter.ThemeByName(strings.ToLower(themeName)),
+		depth:      highlighter.DetectTerminal(),
+		dialect:    lexer.DialectByName(strings.ToLower(dialectName)),
+		mode:       lexer.ParseModeByName(strings.ToLower(modeName)),
+		disabled:   noHighlight,
+		force:      forceHL,
+		anonymize:  anonymize,
+		stripPager: stripPager,
+		heatmap:    heatmap,
+		flaps:      flaps,
+		lsaAging:   lsaAging,
+		poolUsage:  poolUsage,
+		percent:    [2]float64{warning, critical},
+		routes:     expect,
+		guides:     guides,
+		lineNums:   lineNums,
+		learnNames: learnNames,
+		hideLogs:   level >= 0,
+		severity:   level,
+		compact:    compact,
+		focus:      categories,
+		diff:       diffMode,
+		stats:      stats,
+		footer:     footer,
+		explain:    explain,
+		fold:       fold,
+		extract:    extract,
+		expand:     expand,
+		failOn:     watch,
+		format:     outputFormat,
+	}
+	if !noPager {
+		opts.pager = pagerCommand(cfg.Pager)
+	}
+	// Other formats are not for the terminal, so they get the theme's full colors
+	if opts.format != "" {
+		opts.depth = highlighter.TrueColor
+	}
+	// NO_COLOR and TERM=dumb turn highlighting off unless it is forced
+	if opts.depth == highlighter.NoColor {
+		if opts.force {
+			opts.depth = highlighter.TrueColor
+		} else {
+			opts.disabled = true
+		}
+	}
 
 	args := flag.Args()
 
 	// Enable debug mode
 	terminal.SetDebug(debug)
 
+	// git textconv filter: cink git-textconv FILE
+	if len(args) == 2 && args[0] == "git-textconv" {
+		// The same file gives the same bytes whatever the terminal, as
+		// for --format
+		opts.depth, opts.disabled = highlighter.TrueColor, noHighlight
+		if err := gitTextconv(args[1], opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		exitOnFailure(opts)
+		return
+	}
+
+	// Theme list: cink themes [--preview]
+	if len(args) > 0 && args[0] == "themes" {
+		if err := listThemes(os.Stdout, args[1:], opts); err != nil {
+			fmt.Fprintf(os.Stderr, "cink: %v\n", err)
+			os.Exit(2)
+		}
+		return
+	}
+
 	// If no command provided, read from stdin and highlight
 	if len(args) == 0 {
-		if err := highlightStdin(theme, noHighlight, forceHL); err != nil {
+		if err := highlightStdin(opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		exitOnFailure(opts)
+		return
+	}
+
+	// Files to highlight: cink *.cfg
+	if isFileList(args) {
+		out, closeOut, err := openOutput(opts.pager)
+		if err == nil {
+			err = highlightFiles(out, args, opts)
+			closeOut()
+		}
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		exitOnFailure(opts)
 		return
 	}
 
 	// Run command with PTY terminal
-	if err := runWithTerminal(args, theme, noHighlight); err != nil {
+	if err := runWithTerminal(args, opts); err != nil {
 		var exitErr *terminal.ExitError
 		if errors.As(err, &exitErr) {
 			os.Exit(exitErr.Code)
@@ -111,7 +380,7 @@ func main() {
 	}
 }
 
-func highlightStdin(theme *highlighter.Theme, disabled bool, force bool) error {
+func highlightStdin(opts options) error {
 	// Check if stdin is a terminal (no pipe)
 	stat, err := os.Stdin.Stat()
 	if err != nil {
@@ -122,25 +391,212 @@ func highlightStdin(theme *highlighter.Theme, disabled bool, force bool) error {
 		return nil
 	}
 
-	hl := highlighter.NewWithTheme(theme)
+	out, closeOut, err := openOutput(opts.pager)
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+
+	hl := newHighlighter(opts)
+
+	// Diffs are highlighted hunk by hunk, so read the whole input
+	if opts.diff {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		input := string(data)
+		if opts.disabled {
+			opts.failOn.watch(hl, input)
+		} else {
+			input = hl.HighlightDiff(input)
+		}
+		_, err = fmt.Fprint(out, input)
+		return err
+	}
+
+	// The summary counts across the whole input
+	if opts.stats {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		opts.failOn.watch(hl, string(data))
+		summary := highlighter.Summarize(highlighter.StripPagination(string(data)))
+		theme := opts.theme
+		if opts.disabled {
+			theme = nil
+		}
+		_, err = fmt.Fprint(out, summary.Render(theme))
+		return err
+	}
+
+	// The legend explains each line of the whole input
+	if opts.explain {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		input := string(data)
+		if opts.stripPager {
+			input = highlighter.StripPagination(input)
+		}
+		if opts.anonymize {
+			input = highlighter.NewAnonymizer().AnonymizeAligned(input)
+		}
+		explained := hl.Explain(input)
+		if opts.disabled {
+			explained = highlighter.StripANSI(explained)
+		}
+		_, err = fmt.Fprint(out, explained)
+		return err
+	}
+
+	// Sections and object-groups span lines, so read the whole input
+	if len(opts.extract) > 0 || opts.expand {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		input := string(data)
+		if opts.stripPager {
+			input = highlighter.StripPagination(input)
+		}
+		// Index the groups before extracting, so groups defined outside the
+		// extracted sections are still expanded
+		var groups *parser.ObjectGroupIndex
+		if opts.expand {
+			groups = parser.NewObjectGroupIndex(input)
+		}
+		if len(opts.extract) > 0 {
+			input, err = extractSections(input, opts.extract)
+			if err != nil {
+				return err
+			}
+		}
+		if opts.expand {
+			input = groups.Inline(input)
+		}
+		if opts.anonymize {
+			input = highlighter.NewAnonymizer().AnonymizeAligned(input)
+		}
+		if opts.disabled {
+			opts.failOn.watch(hl, input)
+		} else {
+			input = hl.HighlightForced(input)
+		}
+		_, err = fmt.Fprint(out, input)
+		return err
+	}
+
+	if opts.fold {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		input := string(data)
+		if opts.stripPager {
+			input = highlighter.StripPagination(input)
+		}
+		if opts.anonymize {
+			input = highlighter.NewAnonymizer().AnonymizeAligned(input)
+		}
+		if opts.disabled {
+			hl.Disable()
+		}
+		_, err = fmt.Fprint(out, hl.Fold(input).Render())
+		return err
+	}
+
+	// Other formats render the whole input at once
+	if opts.format != "" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		input := string(data)
+		if opts.stripPager {
+			input = highlighter.StripPagination(input)
+		}
+		if opts.anonymize {
+			input = highlighter.NewAnonymizer().AnonymizeAligned(input)
+		}
+		if opts.disabled {
+			hl.Disable()
+			// JSON is made from the tokens either way
+			if opts.format != "json" {
+				opts.failOn.watch(hl, input)
+			}
+		}
+		hl.SetAlwaysHighlight(opts.force)
+		rendered, err := renderFormat(hl, input, opts.format)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(out, rendered)
+		return err
+	}
+
+	// Transcripts need the whole capture to pair each command with its output,
+	// and --fail-on checks need it to tell show output from configuration
+	if (opts.mode == lexer.ParseModeTranscript && !opts.disabled) || opts.failOn != nil {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		input := string(data)
+		if opts.stripPager {
+			input = highlighter.StripPagination(input)
+		}
+		if opts.anonymize {
+			input = highlighter.NewAnonymizer().AnonymizeAligned(input)
+		}
+		highlighted := input
+		if opts.force || opts.mode == lexer.ParseModeTranscript {
+			highlighted = hl.HighlightForced(input)
+		} else {
+			highlighted = hl.Highlight(input)
+		}
+		if opts.disabled {
+			highlighted = input
+		} else {
+			highlighted += hl.FlushSuppressed()
+		}
+		if _, err = fmt.Fprint(out, highlighted); err != nil || !opts.footer {
+			return err
+		}
+		return writeFooter(out, input, opts)
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 
-	// Track if we've detected Cisco content (sticky detection)
-	detectedCisco := force
+	// The footer needs the whole input, kept as it streams past
+	var seen strings.Builder
+
+	// One anonymizer for the whole stream keeps placeholders consistent across lines
+	var anon *highlighter.Anonymizer
+	if opts.anonymize {
+		anon = highlighter.NewAnonymizer()
+	}
 
 	for {
 		line, err := reader.ReadString('\n')
+		if len(line) > 0 && opts.stripPager {
+			line = highlighter.StripPagination(line)
+		}
 		if len(line) > 0 {
-			if disabled {
-				fmt.Print(line)
-			} else if detectedCisco || force {
-				fmt.Print(hl.HighlightForced(line))
+			if anon != nil {
+				line = anon.AnonymizeAligned(line)
+			}
+			if opts.footer {
+				seen.WriteString(line)
+			}
+			if opts.disabled {
+				fmt.Fprint(out, line)
+			} else if opts.force {
+				fmt.Fprint(out, hl.HighlightForced(line))
 			} else {
-				highlighted := hl.Highlight(line)
-				if highlighted != line {
-					detectedCisco = true
-				}
-				fmt.Print(highlighted)
+				fmt.Fprint(out, hl.Highlight(line))
 			}
 		}
 		if err != nil {
@@ -150,14 +606,94 @@ func highlightStdin(theme *highlighter.Theme, disabled bool, force bool) error {
 			return err
 		}
 	}
+	fmt.Fprint(out, hl.FlushSuppressed())
 
+	if opts.footer {
+		return writeFooter(out, seen.String(), opts)
+	}
 	return nil
 }
 
-func runWithTerminal(args []string, theme *highlighter.Theme, disabled bool) error {
+// writeFooter writes the bad and warning states found in input below the
+// output, separated from it by a blank line.
+func writeFooter(out io.Writer, input string, opts options) error {
+	theme := opts.theme
+	if opts.disabled {
+		theme = nil
+	}
+	_, err := fmt.Fprint(out, "\n"+highlighter.Summarize(input).Footer(theme))
+	return err
+}
+
+// newHighlighter creates a highlighter configured from opts.
+func newHighlighter(opts options) *highlighter.Highlighter {
+	hlOpts := []highlighter.Option{
+		highlighter.WithTheme(opts.theme),
+		highlighter.WithColorDepth(opts.depth),
+		highlighter.WithDialect(opts.dialect),
+		highlighter.WithMode(opts.mode),
+		// Input is one session: once it looks like Cisco, keep highlighting
+		highlighter.WithPinnedDetection(),
+	}
+	if opts.heatmap {
+		hlOpts = append(hlOpts, highlighter.WithHeatmap(highlighter.DefaultHeatmap()))
+	}
+	if opts.flaps > 0 {
+		hlOpts = append(hlOpts, highlighter.WithFlapThreshold(opts.flaps))
+	}
+	if opts.lsaAging {
+		hlOpts = append(hlOpts, highlighter.WithLSAAging(highlighter.DefaultLSAFresh, highlighter.DefaultLSAOld))
+	}
+	if opts.poolUsage {
+		hlOpts = append(hlOpts, highlighter.WithPoolUsage(highlighter.DefaultPoolWarning, highlighter.DefaultPoolCritical))
+	}
+	hlOpts = append(hlOpts, highlighter.WithPercentThresholds(opts.percent[0], opts.percent[1]))
+	if opts.routes != nil {
+		hlOpts = append(hlOpts, highlighter.WithRouteExpectations(opts.routes))
+	}
+	if opts.guides {
+		hlOpts = append(hlOpts, highlighter.WithIndentGuides())
+	}
+	if opts.lineNums {
+		hlOpts = append(hlOpts, highlighter.WithLineNumbers())
+	}
+	if opts.learnNames {
+		hlOpts = append(hlOpts, highlighter.WithLearnedNames())
+	}
+	if opts.hideLogs {
+		hlOpts = append(hlOpts, highlighter.WithLogSeverity(opts.severity))
+	}
+	if opts.compact {
+		hlOpts = append(hlOpts, highlighter.WithCompactOutput())
+	}
+	if opts.focus != nil {
+		hlOpts = append(hlOpts, highlighter.WithFocus(opts.focus...))
+	}
+	if opts.failOn != nil {
+		hlOpts = append(hlOpts, highlighter.WithTokenHook(opts.failOn.hook))
+	}
+	return highlighter.New(hlOpts...)
+}
+
+// exitOnFailure exits with exitFailOn if a --fail-on condition was met.
+func exitOnFailure(opts options) {
+	if failure := opts.failOn.failure(); failure != "" {
+		fmt.Fprintf(os.Stderr, "cink: input contained %s\n", failure)
+		os.Exit(exitFailOn)
+	}
+}
+
+func runWithTerminal(args []string, opts options) error {
 	t := terminal.New(args[0], args[1:]...)
-	t.SetTheme(theme)
-	t.SetEnabled(!disabled)
+	t.SetTheme(opts.theme.WithColorDepth(opts.depth))
+	t.SetDialect(opts.dialect)
+	t.SetEnabled(!opts.disabled)
+	if opts.heatmap {
+		t.SetHeatmap(highlighter.DefaultHeatmap())
+	}
+	if opts.anonymize {
+		t.SetRedaction(highlighter.NewAnonymizer())
+	}
 
 	return t.Run()
 }
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// defaultPager is used when neither the pager setting nor $PAGER is set.
+const defaultPager = "less -R"
+
+// pagerIdleFlush is how long output is held back with no more arriving
+// before it is written out unpaged, so streams such as tail -f show up.
+const pagerIdleFlush = 500 * time.Millisecond
+
+// pagerCommand returns the pager to use: the configured one (config file or
+// CINK_PAGER), else $PAGER, else less -R.
+func pagerCommand(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	if p := os.Getenv("PAGER"); p != "" {
+		return p
+	}
+	return defaultPager
+}
+
+// openOutput returns the writer for highlighted output. When a pager is
+// given and stdout is a terminal, output taller than the terminal is piped
+// through the pager; the returned close function flushes shorter output and
+// waits for the pager to exit.
+func openOutput(pager string) (io.Writer, func(), error) {
+	fd := int(os.Stdout.Fd())
+	if pager == "" || !term.IsTerminal(fd) {
+		return os.Stdout, func() {}, nil
+	}
+	_, height, err := term.GetSize(fd)
+	if err != nil || height <= 0 {
+		return os.Stdout, func() {}, nil
+	}
+
+	p := &pagedOutput{pager: pager, height: height, out: os.Stdout, idle: pagerIdleFlush}
+	return p, func() { _ = p.Close() }, nil
+}
+
+// pagedOutput holds output back until it no longer fits on the screen, then
+// starts the pager and passes everything through it. Output that fits is
+// written to out when the writer is closed, so short results stay on screen
+// without a pager to quit. Output that stops arriving for idle before then
+// is written out and paging is off for the rest, since input that pauses,
+// such as a log being followed, may never end.
+type pagedOutput struct {
+	pager  string        // shell command, e.g. "less -R"
+	height int           // terminal rows
+	out    io.Writer     // the terminal
+	idle   time.Duration // 0 to hold output back until Close
+
+	mu    sync.Mutex   // Write and the idle timer
+	buf   bytes.Buffer // output held back so far
+	lines int          // newlines in buf
+	timer *time.Timer  // fires after idle without a Write
+
+	cmd  *exec.Cmd
+	pipe io.WriteCloser // the pager's stdin, once started
+	done bool           // the pager has exited or could not be started
+}
+
+func (p *pagedOutput) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch {
+	case p.done && p.pipe == nil:
+		return p.out.Write(b)
+	case p.done:
+		return len(b), nil
+	case p.pipe != nil:
+		return p.writePager(b)
+	}
+
+	p.buf.Write(b)
+	p.lines += bytes.Count(b, []byte{'\n'})
+	// Leave a row for the shell prompt that follows the output
+	if p.lines < p.height {
+		p.waitIdle()
+		return len(b), nil
+	}
+	p.stopIdle()
+	if err := p.start(); err != nil {
+		// Without a pager, behave as if paging were off
+		p.done = true
+		_, err := p.buf.WriteTo(p.out)
+		return len(b), err
+	}
+	_, err := p.writePager(p.buf.Bytes())
+	p.buf.Reset()
+	return len(b), err
+}
+
+// waitIdle (re)starts the timer that writes the output held back once no
+// more arrives.
+func (p *pagedOutput) waitIdle() {
+	switch {
+	case p.idle <= 0:
+	case p.timer == nil:
+		p.timer = time.AfterFunc(p.idle, p.flushIdle)
+	default:
+		p.timer.Reset(p.idle)
+	}
+}
+
+// stopIdle stops the idle timer, if one is running.
+func (p *pagedOutput) stopIdle() {
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+}
+
+// flushIdle writes the output held back and turns paging off.
+func (p *pagedOutput) flushIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done || p.pipe != nil {
+		return
+	}
+	p.done = true
+	_, _ = p.buf.WriteTo(p.out)
+}
+
+// writePager writes to the pager. Once the user quits the pager the rest of
+// the output is dropped rather than reported as an error.
+func (p *pagedOutput) writePager(b []byte) (int, error) {
+	n, err := p.pipe.Write(b)
+	if errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed) {
+		p.done = true
+		return len(b), nil
+	}
+	return n, err
+}
+
+func (p *pagedOutput) start() error {
+	p.cmd = exec.Command("sh", "-c", p.pager)
+	p.cmd.Stdout = p.out
+	p.cmd.Stderr = os.Stderr
+	// less shows escape sequences literally unless told otherwise, so give
+	// a bare $PAGER=less the -R it needs
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		p.cmd.Env = append(os.Environ(), "LESS=R")
+	}
+	w, err := p.cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("starting pager: %w", err)
+	}
+	if err := p.cmd.Start(); err != nil {
+		return fmt.Errorf("starting pager: %w", err)
+	}
+	p.pipe = w
+	return nil
+}
+
+// Close writes output that fit on the screen, or closes the pager's input
+// and waits for the user to quit it.
+func (p *pagedOutput) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopIdle()
+	if p.pipe == nil {
+		_, err := p.buf.WriteTo(p.out)
+		return err
+	}
+	p.pipe.Close()
+	return p.cmd.Wait()
+}
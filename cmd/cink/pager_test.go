@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPagerCommand(t *testing.T) {
+	tests := []struct {
+		configured string
+		env        string
+		want       string
+	}{
+		{"", "", defaultPager},
+		{"", "more", "more"},
+		{"most", "more", "most"},
+	}
+	for _, tt := range tests {
+		t.Setenv("PAGER", tt.env)
+		if got := pagerCommand(tt.configured); got != tt.want {
+			t.Errorf("pagerCommand(%q) with PAGER=%q = %q, want %q", tt.configured, tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestPagedOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		lines  int
+		height int
+		paged  bool
+	}{
+		{"fits", 3, 10, false},
+		{"leaves a row for the prompt", 9, 10, false},
+		{"taller than the terminal", 10, 10, true},
+		{"much taller", 100, 10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "paged")
+			var out bytes.Buffer
+			p := &pagedOutput{pager: "cat > " + file, height: tt.height, out: &out}
+
+			want := strings.Repeat("interface Gi0/1\n", tt.lines)
+			for _, line := range strings.SplitAfter(want, "\n") {
+				if _, err := p.Write([]byte(line)); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+			if err := p.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			paged, _ := os.ReadFile(file)
+			got, other := out.String(), string(paged)
+			if tt.paged {
+				got, other = other, got
+			}
+			if got != want {
+				t.Errorf("paged=%v: got %q, want %q", tt.paged, got, want)
+			}
+			if other != "" {
+				t.Errorf("paged=%v: unexpected output %q", tt.paged, other)
+			}
+		})
+	}
+}
+
+func TestPagedOutputQuit(t *testing.T) {
+	// A pager that exits at once, like the user quitting less
+	p := &pagedOutput{pager: "exit 0", height: 2, out: &bytes.Buffer{}}
+	for i := 0; i < 1000; i++ {
+		if _, err := p.Write([]byte(strings.Repeat("x", 1024) + "\n")); err != nil {
+			t.Fatalf("writing after the pager quit: %v", err)
+		}
+	}
+	_ = p.Close()
+}
+
+func TestPagedOutputIdle(t *testing.T) {
+	// Output that pauses before filling the screen, like tail -f, is
+	// written out instead of waiting for Close
+	file := filepath.Join(t.TempDir(), "paged")
+	var out bytes.Buffer
+	p := &pagedOutput{pager: "cat > " + file, height: 10, out: &out, idle: 10 * time.Millisecond}
+
+	p.Write([]byte("line 1\n"))
+	time.Sleep(50 * time.Millisecond)
+	p.mu.Lock()
+	got := out.String()
+	p.mu.Unlock()
+	if got != "line 1\n" {
+		t.Errorf("after a pause: got %q, want the line written out", got)
+	}
+
+	// The rest is not paged, however tall
+	p.Write([]byte(strings.Repeat("line\n", 20)))
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if paged, _ := os.ReadFile(file); len(paged) != 0 {
+		t.Errorf("unexpected paged output %q", paged)
+	}
+	if want := "line 1\n" + strings.Repeat("line\n", 20); out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
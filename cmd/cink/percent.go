@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/highlighter"
+)
+
+// parsePercent parses the "warning,critical" thresholds of --percent. It
+// returns the defaults for an empty value and 0, 0 for "off".
+func parsePercent(value string) (warning, critical float64, err error) {
+	switch value = strings.TrimSpace(value); value {
+	case "":
+		return highlighter.DefaultPercentWarning, highlighter.DefaultPercentCritical, nil
+	case "off":
+		return 0, 0, nil
+	}
+	w, c, ok := strings.Cut(value, ",")
+	if ok {
+		warning, err = strconv.ParseFloat(strings.TrimSpace(w), 64)
+	}
+	if ok && err == nil {
+		critical, err = strconv.ParseFloat(strings.TrimSpace(c), 64)
+	}
+	if !ok || err != nil || warning < 0 || critical < warning {
+		return 0, 0, fmt.Errorf("invalid --percent %q (want warning,critical such as 70,90, or off)", value)
+	}
+	return warning, critical, nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		value             string
+		warning, critical float64
+		ok                bool
+	}{
+		{"", 70, 90, true},
+		{"off", 0, 0, true},
+		{"80,95", 80, 95, true},
+		{" 50.5 , 75 ", 50.5, 75, true},
+		{"90,80", 0, 0, false},
+		{"80", 0, 0, false},
+		{"high,95", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		warning, critical, err := parsePercent(tt.value)
+		if (err == nil) != tt.ok || warning != tt.warning || critical != tt.critical {
+			t.Errorf("parsePercent(%q) = %v, %v, %v", tt.value, warning, critical, err)
+		}
+	}
+}
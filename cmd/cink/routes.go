@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/highlighter"
+)
+
+// parseRoutes parses the "source=min-max,..." route count expectations of
+// --routes, such as "bgp=800000-1200000,ospf=1-,connected=1-". Either bound
+// may be left out. It returns nil for an empty value.
+func parseRoutes(value string) (map[string]highlighter.RouteExpectation, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	expect := make(map[string]highlighter.RouteExpectation)
+	for _, item := range strings.Split(value, ",") {
+		source, bounds, ok := strings.Cut(item, "=")
+		source = strings.TrimSpace(source)
+		low, high, ranged := strings.Cut(bounds, "-")
+		if !ok || source == "" || !ranged {
+			return nil, fmt.Errorf("invalid --routes %q (want source=min-max such as bgp=1000-, comma-separated)", item)
+		}
+		var e highlighter.RouteExpectation
+		var err error
+		if low = strings.TrimSpace(low); low != "" {
+			e.Min, err = strconv.Atoi(low)
+		}
+		if high = strings.TrimSpace(high); high != "" && err == nil {
+			e.Max, err = strconv.Atoi(high)
+		}
+		if err != nil || e.Min < 0 || e.Max < 0 || e.Max > 0 && e.Max < e.Min || low == "" && high == "" {
+			return nil, fmt.Errorf("invalid --routes %q (want source=min-max such as bgp=1000-, comma-separated)", item)
+		}
+		expect[source] = e
+	}
+	return expect, nil
+}
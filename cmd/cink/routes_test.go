@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lasseh/cink/highlighter"
+)
+
+func TestParseRoutes(t *testing.T) {
+	tests := []struct {
+		value string
+		want  map[string]highlighter.RouteExpectation
+		ok    bool
+	}{
+		{"", nil, true},
+		{"bgp=800000-1200000", map[string]highlighter.RouteExpectation{"bgp": {Min: 800000, Max: 1200000}}, true},
+		{"ospf 1=1-, connected=1-", map[string]highlighter.RouteExpectation{
+			"ospf 1": {Min: 1}, "connected": {Min: 1},
+		}, true},
+		{"static=-10", map[string]highlighter.RouteExpectation{"static": {Max: 10}}, true},
+		{"bgp", nil, false},
+		{"bgp=100", nil, false},
+		{"bgp=-", nil, false},
+		{"bgp=10-5", nil, false},
+		{"=1-", nil, false},
+		{"bgp=many-", nil, false},
+	}
+
+	for _, tt := range tests {
+		got, err := parseRoutes(tt.value)
+		if (err == nil) != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseRoutes(%q) = %v, %v", tt.value, got, err)
+		}
+	}
+}
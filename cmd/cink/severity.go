@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lasseh/cink/highlighter"
+)
+
+// parseSeverity parses the --severity level, a number from 0 to 7 or a
+// severity name such as "errors". It returns -1, which shows every message,
+// for an empty value.
+func parseSeverity(value string) (int, error) {
+	if strings.TrimSpace(value) == "" {
+		return -1, nil
+	}
+	level, ok := highlighter.LogSeverityByName(value)
+	if !ok {
+		return 0, fmt.Errorf("invalid --severity %q (want 0-7 or %s)", value, strings.Join(highlighter.LogSeverityNames[:], ", "))
+	}
+	return level, nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+		ok    bool
+	}{
+		{"", -1, true},
+		{"3", 3, true},
+		{"errors", 3, true},
+		{"Debugging", 7, true},
+		{"9", 0, false},
+		{"error", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := parseSeverity(tt.value)
+		if (err == nil) != tt.ok || err == nil && got != tt.want {
+			t.Errorf("parseSeverity(%q) = %d, %v, want %d", tt.value, got, err, tt.want)
+		}
+	}
+}
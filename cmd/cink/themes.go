@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/lasseh/cink/highlighter"
+)
+
+// listThemes writes the name of every theme to out, for cink themes, or with
+// --preview the name of each followed by its ThemePreview.
+func listThemes(out io.Writer, args []string, opts options) error {
+	preview := false
+	for _, arg := range args {
+		switch arg {
+		case "--preview", "-preview", "-p":
+			preview = true
+		default:
+			return fmt.Errorf("themes: unknown argument %q", arg)
+		}
+	}
+
+	for i, name := range highlighter.ThemeNames() {
+		if !preview {
+			fmt.Fprintln(out, name)
+			continue
+		}
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		sample := highlighter.ThemePreview(highlighter.ThemeByName(name).WithColorDepth(opts.depth))
+		if opts.disabled {
+			sample = highlighter.StripANSI(sample)
+			fmt.Fprintln(out, name)
+		} else {
+			fmt.Fprintln(out, highlighter.Bold+name+highlighter.Reset)
+		}
+		if _, err := fmt.Fprint(out, sample); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/highlighter"
+)
+
+func TestListThemes(t *testing.T) {
+	var buf strings.Builder
+	if err := listThemes(&buf, nil, options{}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), strings.Join(highlighter.ThemeNames(), "\n")+"\n"; got != want {
+		t.Errorf("listThemes = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := listThemes(&buf, []string{"--preview"}, options{depth: highlighter.TrueColor}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, name := range highlighter.ThemeNames() {
+		if !strings.Contains(out, highlighter.Bold+name+highlighter.Reset+"\n") {
+			t.Errorf("preview has no heading for %s", name)
+		}
+	}
+	if !strings.Contains(out, highlighter.ThemePreview(highlighter.NordTheme())) {
+		t.Error("preview should include each theme's ThemePreview")
+	}
+
+	buf.Reset()
+	if err := listThemes(&buf, []string{"--preview"}, options{disabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "\033[") {
+		t.Error("preview without color should have no escapes")
+	}
+
+	if err := listThemes(&buf, []string{"--bogus"}, options{}); err == nil {
+		t.Error("unknown arguments should be rejected")
+	}
+}
@@ -4,7 +4,11 @@ go 1.22
 
 require (
 	github.com/creack/pty v1.1.21
+	github.com/mattn/go-runewidth v0.0.15
 	golang.org/x/term v0.25.0
 )
 
-require golang.org/x/sys v0.26.0 // indirect
+require (
+	github.com/rivo/uniseg v0.4.3 // indirect
+	golang.org/x/sys v0.26.0 // indirect
+)
@@ -0,0 +1,134 @@
+package highlighter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// tableRowPattern matches a line with at least two runs of two or more spaces
+// between fields, the lines AlignColumns keeps aligned.
+var tableRowPattern = regexp.MustCompile(`\S {2,}\S.*\S {2,}\S`)
+
+// DisplayWidth returns the number of columns s takes in a terminal: escape
+// sequences take none, tabs advance to the next lexer.TabWidth stop, wide
+// runes take two columns and combining marks none. For text of several lines
+// it is the width of the widest.
+func DisplayWidth(s string) int {
+	width := 0
+	for _, line := range strings.Split(StripANSI(s), "\n") {
+		width = max(width, lexer.AdvanceColumn(1, strings.TrimSuffix(line, "\r"))-1)
+	}
+	return width
+}
+
+// PadRight returns s followed by enough spaces to make it width columns wide,
+// as DisplayWidth counts them. Highlighted s keeps its escapes, so cells of a
+// table can be colored before they are padded. s is returned unchanged if it
+// is already as wide.
+func PadRight(s string, width int) string {
+	if n := width - DisplayWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// PadLeft is PadRight for right-aligned columns: the spaces go before s.
+func PadLeft(s string, width int) string {
+	if n := width - DisplayWidth(s); n > 0 {
+		return strings.Repeat(" ", n) + s
+	}
+	return s
+}
+
+// SetColumnAlignment keeps the columns of tables in show output aligned when
+// values change width before rendering: redaction placeholders and values
+// rewritten by the token hook get the spaces after them widened or narrowed
+// (see AlignColumns and Anonymizer.AnonymizeAligned). Highlighting alone
+// never moves a column, so it has no effect without either.
+func (h *Highlighter) SetColumnAlignment(enabled bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.alignColumns = enabled
+}
+
+// ColumnAlignment returns whether table columns are realigned.
+func (h *Highlighter) ColumnAlignment() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.alignColumns
+}
+
+// applyColumnAlignment realigns table rows after the token hook.
+func (h *Highlighter) applyColumnAlignment(tokens []lexer.Token) []lexer.Token {
+	h.mu.RLock()
+	enabled, hook := h.alignColumns, h.tokenHook
+	h.mu.RUnlock()
+	if !enabled || hook == nil {
+		return tokens
+	}
+	return AlignColumns(tokens)
+}
+
+// AlignColumns restores the column alignment of table rows in tokens whose
+// values have changed width since they were lexed, as when redaction replaces
+// an address with a shorter placeholder or a token hook rewrites a value.
+// Each token's Column is taken as where it belongs; on lines with fields
+// separated by runs of two or more spaces, the spaces after a changed value
+// are widened or narrowed (to no less than one space) so later fields start
+// there again. Other lines,
+// such as config lines, are left as they are. Columns of the returned tokens
+// are where they now start.
+func AlignColumns(tokens []lexer.Token) []lexer.Token {
+	out := make([]lexer.Token, 0, len(tokens))
+	for _, line := range splitTokenLines(tokens) {
+		var text strings.Builder
+		for _, token := range line {
+			text.WriteString(token.Value)
+		}
+		align := tableRowPattern.MatchString(text.String())
+
+		col, shift := 1, 0
+		for _, token := range line {
+			if token.Column > 0 {
+				shift = col - token.Column
+			}
+			if align && shift != 0 && token.Type == lexer.TokenText {
+				token.Value, shift = alignGaps(token.Value, shift)
+			}
+			token.Column = col
+			col = lexer.AdvanceColumn(col, token.Value)
+			out = append(out, token)
+		}
+	}
+	return out
+}
+
+// alignGaps widens or narrows the runs of spaces in value to take up shift,
+// the columns value starts right of where it belongs, and returns it with the
+// shift that is left. A value that starts left of its place pads the first
+// run; one that starts right of it narrows runs of two or more spaces down to
+// one.
+func alignGaps(value string, shift int) (string, int) {
+	var buf strings.Builder
+	for i := 0; i < len(value); {
+		if value[i] != ' ' {
+			buf.WriteByte(value[i])
+			i++
+			continue
+		}
+		n := 1
+		for i+n < len(value) && value[i+n] == ' ' {
+			n++
+		}
+		i += n
+		if shift < 0 || n >= 2 && shift > 0 {
+			adjust := min(shift, n-1)
+			n -= adjust
+			shift -= adjust
+		}
+		buf.WriteString(strings.Repeat(" ", n))
+	}
+	return buf.String(), shift
+}
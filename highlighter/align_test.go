@@ -0,0 +1,135 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lasseh/cink/internal/benchdata"
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{"up", 2},
+		{"\033[32mup\033[0m", 2},
+		{"a\tb", 9},
+		{"日本", 4},
+		{"é", 1},
+		{"short\nlonger line\r\n", 11},
+	}
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.input); got != tt.want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	colored := "\033[32mup\033[0m"
+	if got := PadRight(colored, 5); got != colored+"   " {
+		t.Errorf("PadRight = %q", got)
+	}
+	if got := PadLeft(colored, 5); got != "   "+colored {
+		t.Errorf("PadLeft = %q", got)
+	}
+	if got := PadRight("administratively", 4); got != "administratively" {
+		t.Errorf("PadRight should not truncate, got %q", got)
+	}
+}
+
+func TestAlignColumns(t *testing.T) {
+	row := "Gi0/0     192.0.2.1       YES NVRAM  up      up\n"
+	tests := []struct {
+		name    string
+		input   string
+		replace map[string]string
+		want    string
+	}{
+		{"narrower", row, map[string]string{"192.0.2.1": "10.0.0.1"},
+			"Gi0/0     10.0.0.1        YES NVRAM  up      up\n"},
+		{"wider", row, map[string]string{"Gi0/0": "GigabitEthernet0/0"},
+			"GigabitEthernet0/0 192.0.2.1 YES NVRAM up    up\n"},
+		{"wider than the gaps", row, map[string]string{"192.0.2.1": "2001:db8:ffff:ffff::1"},
+			"Gi0/0     2001:db8:ffff:ffff::1 YES NVRAM up up\n"},
+		{"config line", " ip address 192.0.2.1 255.255.255.0\n", map[string]string{"192.0.2.1": "10.0.0.1"},
+			" ip address 10.0.0.1 255.255.255.0\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := lexer.New(tt.input).Tokenize()
+			for i, tok := range tokens {
+				if v, ok := tt.replace[tok.Value]; ok {
+					tokens[i].Value = v
+				}
+			}
+			aligned := AlignColumns(tokens)
+			var got strings.Builder
+			for _, tok := range aligned {
+				if col := lexer.AdvanceColumn(1, got.String()[strings.LastIndexByte(got.String(), '\n')+1:]); tok.Column != col {
+					t.Errorf("token %q has column %d, starts at %d", tok.Value, tok.Column, col)
+				}
+				got.WriteString(tok.Value)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnAlignmentTokenHook(t *testing.T) {
+	input := "Interface              IP-Address      OK? Method Status                Protocol\n" +
+		"GigabitEthernet0/0     192.0.2.1       YES NVRAM  up                    up\n"
+	shorten := func(tok lexer.Token) lexer.Token {
+		tok.Value = strings.Replace(tok.Value, "GigabitEthernet", "Gi", 1)
+		return tok
+	}
+	h := New(WithColumnAlignment(), WithTokenHook(shorten))
+	want := "Interface              IP-Address      OK? Method Status                Protocol\n" +
+		"Gi0/0                  192.0.2.1       YES NVRAM  up                    up\n"
+	if got := StripANSI(h.HighlightForced(input)); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	h.SetColumnAlignment(false)
+	if got := StripANSI(h.HighlightForced(input)); !strings.Contains(got, "Gi0/0     192.0.2.1") {
+		t.Errorf("without alignment the hook's values should be rendered as is, got:\n%s", got)
+	}
+}
+
+// TestHighlightKeepsColumns checks that highlighting only ever inserts escape
+// sequences, so every line of output is as wide as its input line, whatever
+// the theme and render options.
+func TestHighlightKeepsColumns(t *testing.T) {
+	inputs := []string{benchdata.ShowTech(1 << 13), benchdata.Transcript(5), benchdata.Config(50)}
+	options := map[string][]Option{
+		"default":    nil,
+		"compact":    {WithCompactOutput()},
+		"focus":      {WithFocus(lexer.CategoryAddress)},
+		"thresholds": {WithFlapThreshold(time.Hour), WithLSAAging(time.Minute, time.Hour), WithPoolUsage(50, 80)},
+		"heatmap":    {WithHeatmap(DefaultHeatmap())},
+		"guides":     {WithIndentGuides()},
+	}
+	for name, opts := range options {
+		for _, theme := range ThemeNames() {
+			h := New(append(opts, WithTheme(ThemeByName(theme)))...)
+			for _, input := range inputs {
+				want := strings.Split(input, "\n")
+				got := strings.Split(h.HighlightForced(input), "\n")
+				if len(got) != len(want) {
+					t.Fatalf("%s/%s: %d lines, want %d", name, theme, len(got), len(want))
+				}
+				for i := range want {
+					if DisplayWidth(got[i]) != DisplayWidth(want[i]) {
+						t.Fatalf("%s/%s: line %d is %q, input %q", name, theme, i+1, StripANSI(got[i]), want[i])
+					}
+				}
+			}
+		}
+	}
+}
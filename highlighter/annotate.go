@@ -0,0 +1,295 @@
+package highlighter
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// eraseToEOL extends the active background color to the end of the line.
+const eraseToEOL = "\033[K"
+
+// AnnotationStyle describes how an annotated line is emphasized.
+// Background is an ANSI background escape (see BgRGB and BgColor256) laid
+// under the token colors; Marker is a short gutter string such as "+" or "~"
+// printed in MarkerColor before the line. Either may be empty.
+type AnnotationStyle struct {
+	Background  string
+	Marker      string
+	MarkerColor string
+}
+
+// Predefined annotation styles for change-review tooling.
+var (
+	AnnotationAdded   = AnnotationStyle{Background: BgRGB(32, 48, 32), Marker: "+", MarkerColor: Bold + Green}
+	AnnotationRemoved = AnnotationStyle{Background: BgRGB(56, 28, 32), Marker: "-", MarkerColor: Bold + Red}
+	AnnotationChanged = AnnotationStyle{Background: BgRGB(52, 46, 24), Marker: "~", MarkerColor: Bold + Yellow}
+	AnnotationFocus   = AnnotationStyle{Background: BgRGB(40, 44, 64), Marker: ">", MarkerColor: Bold + Cyan}
+)
+
+// HighlightAnnotated highlights input and overlays the given styles on the
+// annotated lines. Keys are 1-based line numbers. Lines keep their token
+// highlighting; the background is re-applied after every token so it is not
+// cleared by the per-token reset. When any annotation has a marker, every line
+// gets a gutter of the same width so columns stay aligned.
+func (h *Highlighter) HighlightAnnotated(input string, annotations map[int]AnnotationStyle) string {
+	if !h.IsEnabled() || input == "" {
+		return input
+	}
+
+	h.mu.RLock()
+	theme := h.theme
+	h.mu.RUnlock()
+
+	gutter := 0
+	for _, a := range annotations {
+		if len(a.Marker) > gutter {
+			gutter = len(a.Marker)
+		}
+	}
+
+	tokens := h.processTokens(h.lex(h.newLexer(StripANSI(input))))
+
+	var buf bytes.Buffer
+	for i, line := range splitTokenLines(tokens) {
+		style, annotated := annotations[i+1]
+		hasNewline := len(line) > 0 && line[len(line)-1].Value == "\n"
+		if hasNewline {
+			line = line[:len(line)-1]
+		}
+
+		if gutter > 0 {
+			marker := ""
+			if annotated {
+				marker = style.Marker
+			}
+			pad := strings.Repeat(" ", gutter-len(marker))
+			if marker != "" && style.MarkerColor != "" {
+				buf.WriteString(style.MarkerColor + marker + Reset + pad + " ")
+			} else {
+				buf.WriteString(marker + pad + " ")
+			}
+		}
+
+		bg := ""
+		if annotated {
+			bg = style.Background
+		}
+		buf.WriteString(bg)
+		for _, token := range line {
+			color := theme.GetColor(token.Type)
+			if color != "" {
+				buf.WriteString(color)
+				buf.WriteString(token.Value)
+				buf.WriteString(Reset)
+				buf.WriteString(bg)
+			} else {
+				buf.WriteString(token.Value)
+			}
+		}
+		if bg != "" {
+			buf.WriteString(eraseToEOL)
+			buf.WriteString(Reset)
+		}
+
+		if hasNewline {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.String()
+}
+
+// Annotation is a note attached to a line of input, such as a finding of an
+// audit tool. It covers the whole line, or the display columns
+// [Column, EndColumn) of it, which Annotate underlines.
+type Annotation struct {
+	Line      int             // 1-based line number
+	Column    int             // 1-based column where the span starts, 0 for the whole line
+	EndColumn int             // column just after the span, 0 for the rest of the line
+	Text      string          // "weak SNMP community"
+	Type      lexer.TokenType // token type whose color the note gets, such as TokenStateBad; TokenText for the comment color
+}
+
+// TokenAnnotation returns an annotation of tok, as returned by Tokens, with
+// the note text.
+func TokenAnnotation(tok lexer.Token, text string) Annotation {
+	return Annotation{Line: tok.Line, Column: tok.Column, EndColumn: tok.EndColumn(), Text: text}
+}
+
+// Widest line whose notes are lined up with those of the other lines; the
+// notes of wider lines follow them directly
+const annotationMargin = 80
+
+// Annotate highlights input with notes attached to its lines. Each note is
+// printed as a "!" comment in the right margin of its line, lined up with
+// the notes of the other lines, and a note on a span of the line also
+// underlines the span:
+//
+//	snmp-server community public RO    ! weak SNMP community
+//	snmp-server location DC1
+//	logging host 10.0.0.5              ! not in the syslog allowlist
+//
+// Notes of lines beyond the input are dropped. With highlighting disabled,
+// the notes are added as plain text.
+func (h *Highlighter) Annotate(input string, notes []Annotation) string {
+	if input == "" {
+		return input
+	}
+	h.mu.RLock()
+	theme := h.theme
+	h.mu.RUnlock()
+
+	byLine := make(map[int][]Annotation)
+	for _, n := range notes {
+		if n.Line > 0 {
+			byLine[n.Line] = append(byLine[n.Line], n)
+		}
+	}
+
+	cleaned := StripANSI(input)
+	tokens := []lexer.Token{{Type: lexer.TokenText, Value: cleaned, Line: 1, Column: 1}}
+	if h.IsEnabled() {
+		tokens = h.processTokens(h.lex(h.newLexer(cleaned)))
+	} else {
+		theme = nil
+	}
+	lines := splitTokenLines(tokens)
+
+	// The notes start two columns after the widest annotated line
+	margin := 0
+	for i, line := range lines {
+		if width := lineWidth(line); byLine[i+1] != nil && width <= annotationMargin {
+			margin = max(margin, width+2)
+		}
+	}
+
+	var buf strings.Builder
+	for i, line := range lines {
+		notes := byLine[i+1]
+		if notes == nil {
+			for _, token := range line {
+				writeToken(&buf, theme, token, false)
+			}
+			continue
+		}
+
+		// The note goes before the line ending, "\r" included
+		eol := ""
+		if n := len(line); n > 0 && line[n-1].Value == "\n" {
+			eol, line = "\n", line[:n-1]
+		}
+		if n := len(line); n > 0 && strings.HasSuffix(line[n-1].Value, "\r") {
+			last := line[n-1]
+			last.Value = strings.TrimSuffix(last.Value, "\r")
+			line = append(line[:n-1:n-1], last)
+			eol = "\r" + eol
+		}
+
+		for _, token := range line {
+			writeAnnotatedToken(&buf, theme, token, notes)
+		}
+		buf.WriteString(strings.Repeat(" ", max(margin-lineWidth(line), 2)))
+		for j, n := range notes {
+			if j > 0 {
+				buf.WriteString("  ")
+			}
+			t := n.Type
+			if t == lexer.TokenText {
+				t = lexer.TokenComment
+			}
+			writeToken(&buf, theme, lexer.Token{Type: t, Value: "! " + n.Text}, false)
+		}
+		buf.WriteString(eol)
+	}
+	return buf.String()
+}
+
+// lineWidth returns the display width of a line of tokens.
+func lineWidth(line []lexer.Token) int {
+	width := 0
+	for _, token := range line {
+		if token.Value != "\n" {
+			width = max(width, lexer.AdvanceColumn(token.Column, strings.TrimSuffix(token.Value, "\r"))-1)
+		}
+	}
+	return width
+}
+
+// writeAnnotatedToken writes token, underlining the parts of it within the
+// spans of notes.
+func writeAnnotatedToken(buf *strings.Builder, theme *Theme, token lexer.Token, notes []Annotation) {
+	underlined := func(col int) bool {
+		for _, n := range notes {
+			if n.Column > 0 && col >= n.Column && (n.EndColumn <= 0 || col < n.EndColumn) {
+				return true
+			}
+		}
+		return false
+	}
+
+	col, start := token.Column, 0
+	under := underlined(col)
+	for i, r := range token.Value {
+		if u := underlined(col); u != under {
+			writeToken(buf, theme, lexer.Token{Type: token.Type, Value: token.Value[start:i]}, under)
+			start, under = i, u
+		}
+		col = lexer.AdvanceColumn(col, string(r))
+	}
+	writeToken(buf, theme, lexer.Token{Type: token.Type, Value: token.Value[start:]}, under)
+}
+
+// writeToken writes token in its theme color, underlined if asked to. A nil
+// theme writes it as is.
+func writeToken(buf *strings.Builder, theme *Theme, token lexer.Token, underline bool) {
+	if theme == nil || token.Value == "" {
+		buf.WriteString(token.Value)
+		return
+	}
+	style := theme.GetColor(token.Type)
+	if underline && strings.TrimSpace(token.Value) != "" {
+		style += Underline
+	}
+	if style == "" {
+		buf.WriteString(token.Value)
+		return
+	}
+	buf.WriteString(style + token.Value + Reset)
+}
+
+// splitTokenLines groups tokens by output line, splitting tokens that contain
+// newlines so each line ends with a "\n" text token (except possibly the last).
+func splitTokenLines(tokens []lexer.Token) [][]lexer.Token {
+	var lines [][]lexer.Token
+	var current []lexer.Token
+	line := 1
+
+	for _, token := range tokens {
+		value, col := token.Value, token.Column
+		for {
+			idx := strings.IndexByte(value, '\n')
+			if idx < 0 {
+				break
+			}
+			if idx > 0 {
+				current = append(current, lexer.Token{Type: token.Type, Value: value[:idx], Line: line, Column: col})
+			}
+			current = append(current, lexer.Token{Type: lexer.TokenText, Value: "\n", Line: line, Column: lexer.AdvanceColumn(col, value[:idx])})
+			lines = append(lines, current)
+			current = nil
+			line++
+			value, col = value[idx+1:], 1
+		}
+		if value != "" {
+			token.Value = value
+			token.Line = line
+			token.Column = col
+			current = append(current, token)
+		}
+	}
+	if len(current) > 0 {
+		lines = append(lines, current)
+	}
+	return lines
+}
@@ -0,0 +1,100 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestHighlightAnnotated(t *testing.T) {
+	h := New()
+	input := "interface Gi0/0/1\n description uplink\n no shutdown\n"
+
+	result := h.HighlightAnnotated(input, map[int]AnnotationStyle{
+		2: AnnotationChanged,
+	})
+
+	lines := strings.Split(result, "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 line segments, got %d", len(lines))
+	}
+	if !strings.Contains(lines[1], AnnotationChanged.Background) {
+		t.Error("annotated line should carry the background color")
+	}
+	if strings.Contains(lines[0], AnnotationChanged.Background) || strings.Contains(lines[2], AnnotationChanged.Background) {
+		t.Error("unannotated lines should not carry the background color")
+	}
+
+	stripped := strings.Split(StripANSI(result), "\n")
+	if stripped[1] != "~ "+" description uplink" {
+		t.Errorf("expected gutter marker on annotated line, got %q", stripped[1])
+	}
+	if stripped[0] != "  interface Gi0/0/1" {
+		t.Errorf("expected blank gutter on plain line, got %q", stripped[0])
+	}
+}
+
+func TestHighlightAnnotatedNoMarker(t *testing.T) {
+	h := New()
+	input := "hostname r1\nip routing"
+
+	result := h.HighlightAnnotated(input, map[int]AnnotationStyle{
+		1: {Background: BgColor256(236)},
+	})
+
+	if StripANSI(result) != input {
+		t.Errorf("background-only annotations should not change text, got %q", StripANSI(result))
+	}
+	if !strings.Contains(result, BgColor256(236)) {
+		t.Error("expected background escape in output")
+	}
+}
+
+func TestSplitTokenLines(t *testing.T) {
+	h := New()
+	result := h.HighlightAnnotated("a\n\nb", nil)
+	if StripANSI(result) != "a\n\nb" {
+		t.Errorf("blank lines should be preserved, got %q", StripANSI(result))
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	h := New()
+	input := "snmp-server community public RO\r\nsnmp-server location DC1\r\nlogging host 10.0.0.5\r\n"
+
+	var notes []Annotation
+	for _, tok := range h.Tokens(input) {
+		if tok.Value == "public" {
+			notes = append(notes, TokenAnnotation(tok, "weak SNMP community"))
+		}
+	}
+	notes = append(notes,
+		Annotation{Line: 3, Text: "not in the syslog allowlist", Type: lexer.TokenStateWarning},
+		Annotation{Line: 9, Text: "beyond the input"},
+	)
+
+	result := h.Annotate(input, notes)
+	want := "snmp-server community public RO  ! weak SNMP community\r\n" +
+		"snmp-server location DC1\r\n" +
+		"logging host 10.0.0.5            ! not in the syslog allowlist\r\n"
+	if got := StripANSI(result); got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+
+	theme := h.Theme()
+	if !strings.Contains(result, Underline+"public"+Reset) {
+		t.Errorf("span should be underlined: %q", result)
+	}
+	if strings.Count(result, Underline) != 1 {
+		t.Errorf("only the span should be underlined: %q", result)
+	}
+	if !strings.Contains(result, theme.GetColor(lexer.TokenStateWarning)+"! not in the syslog allowlist"+Reset) {
+		t.Errorf("note should take the color of its type: %q", result)
+	}
+
+	h.Disable()
+	if got := h.Annotate(input, notes); got != want {
+		t.Errorf("disabled: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,280 @@
+package highlighter
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Anonymize is a convenience function that scrubs identifying values from
+// Cisco config/output using a fresh Anonymizer.
+func Anonymize(input string) string {
+	return NewAnonymizer().Anonymize(input)
+}
+
+// Anonymizer deterministically rewrites IP addresses, hostnames, usernames and
+// SNMP locations to consistent placeholders while preserving the structure of
+// the input. The same original value always maps to the same placeholder for
+// the lifetime of the Anonymizer, so one instance can be reused across the
+// lines of a stream. All methods are safe for concurrent use.
+type Anonymizer struct {
+	mu        sync.Mutex
+	ipv4      map[string]string
+	ipv6      map[string]string
+	hosts     map[string]string
+	users     map[string]string
+	locations map[string]string
+}
+
+// NewAnonymizer creates a new Anonymizer with empty mappings.
+func NewAnonymizer() *Anonymizer {
+	return &Anonymizer{
+		ipv4:      make(map[string]string),
+		ipv6:      make(map[string]string),
+		hosts:     make(map[string]string),
+		users:     make(map[string]string),
+		locations: make(map[string]string),
+	}
+}
+
+// SetRedaction makes the highlighter scrub identifying values from input with
+// a before tokenizing it, so highlighted output never shows the originals.
+// Input that Highlight passes through unhighlighted is scrubbed too. Pass nil
+// to turn redaction off.
+func (h *Highlighter) SetRedaction(a *Anonymizer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.redactor = a
+}
+
+// Redaction returns the Anonymizer used for redaction, or nil if it is off.
+func (h *Highlighter) Redaction() *Anonymizer {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.redactor
+}
+
+// redact returns input scrubbed by the redaction Anonymizer, if any.
+func (h *Highlighter) redact(input string) string {
+	if a := h.Redaction(); a != nil {
+		if h.ColumnAlignment() {
+			return a.AnonymizeAligned(input)
+		}
+		return a.Anonymize(input)
+	}
+	return input
+}
+
+// Anonymize returns input with identifying values replaced by placeholders.
+// The output is plain text; highlight it separately if needed.
+func (a *Anonymizer) Anonymize(input string) string {
+	return joinTokens(a.anonymizeTokens(input))
+}
+
+// AnonymizeAligned is Anonymize for show output: placeholders that are
+// narrower or wider than the values they replace would shift the columns of
+// a table, so the spaces between fields are adjusted to keep them in place
+// (see AlignColumns).
+func (a *Anonymizer) AnonymizeAligned(input string) string {
+	return joinTokens(AlignColumns(a.anonymizeTokens(input)))
+}
+
+// anonymizeTokens tokenizes input and returns its tokens with identifying
+// values replaced. Columns are those of the original values.
+func (a *Anonymizer) anonymizeTokens(input string) []lexer.Token {
+	if input == "" {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	tokens := lexer.New(input).Tokenize()
+
+	out := make([]lexer.Token, 0, len(tokens))
+	var prev, prevPrev string // previous two non-whitespace words (lowercase)
+	inLocation := false       // consuming the rest of an "snmp-server location" line
+	var location strings.Builder
+	var locationStart lexer.Token
+
+	flushLocation := func() {
+		if location.Len() == 0 {
+			return
+		}
+		locationStart.Type = lexer.TokenText
+		locationStart.Value = a.mapLocation(location.String())
+		out = append(out, locationStart)
+		location.Reset()
+	}
+
+	for _, tok := range tokens {
+		if inLocation {
+			if location.Len() == 0 {
+				locationStart = tok
+			}
+			if idx := strings.IndexByte(tok.Value, '\n'); idx >= 0 {
+				location.WriteString(tok.Value[:idx])
+				flushLocation()
+				tok.Column = lexer.AdvanceColumn(tok.Column, tok.Value[:idx])
+				tok.Value = tok.Value[idx:]
+				out = append(out, tok)
+				inLocation = false
+				prev, prevPrev = "", ""
+				continue
+			}
+			location.WriteString(tok.Value)
+			continue
+		}
+
+		if tok.Type == lexer.TokenText {
+			out = append(out, tok)
+			if strings.Contains(tok.Value, "\n") {
+				prev, prevPrev = "", ""
+			}
+			continue
+		}
+
+		value := tok.Value
+		tok.Value = a.anonymizeToken(tok, prev)
+		out = append(out, tok)
+		prevPrev, prev = prev, strings.ToLower(value)
+		inLocation = prevPrev == "snmp-server" && prev == "location"
+	}
+
+	if inLocation {
+		flushLocation()
+	}
+
+	return out
+}
+
+// joinTokens returns the values of tokens concatenated.
+func joinTokens(tokens []lexer.Token) string {
+	var buf strings.Builder
+	for _, tok := range tokens {
+		buf.WriteString(tok.Value)
+	}
+	return buf.String()
+}
+
+// anonymizeToken returns the placeholder for a single token, or its original
+// value when the token carries no identifying information.
+func (a *Anonymizer) anonymizeToken(tok lexer.Token, prev string) string {
+	switch tok.Type {
+	case lexer.TokenIPv4:
+		return a.mapIPv4(tok.Value)
+	case lexer.TokenIPv4Prefix:
+		addr, length, _ := strings.Cut(tok.Value, "/")
+		return a.mapIPv4(addr) + "/" + length
+	case lexer.TokenIPv6:
+		return a.mapIPv6(tok.Value)
+	case lexer.TokenIPv6Prefix:
+		addr, length, _ := strings.Cut(tok.Value, "/")
+		return a.mapIPv6(addr) + "/" + length
+	case lexer.TokenVirtualIP:
+		if strings.Contains(tok.Value, ":") {
+			return a.mapIPv6(tok.Value)
+		}
+		return a.mapIPv4(tok.Value)
+	case lexer.TokenRouteDistinguisher, lexer.TokenRouteTarget:
+		// The IPv4 half of an IP:nn value; ASN:nn values are kept
+		addr, nn, _ := strings.Cut(tok.Value, ":")
+		if mapped := a.mapIPv4(addr); mapped != addr {
+			return mapped + ":" + nn
+		}
+	case lexer.TokenPromptHost:
+		return a.mapHost(tok.Value)
+	case lexer.TokenValue, lexer.TokenIdentifier:
+		if prev == "hostname" {
+			return a.mapHost(tok.Value)
+		}
+		if prev == "username" {
+			return a.mapUser(tok.Value)
+		}
+		if placeholder, ok := a.hosts[tok.Value]; ok {
+			return placeholder
+		}
+	}
+	return tok.Value
+}
+
+// mapIPv4 maps an IPv4 address to a placeholder in 10.0.0.0/8.
+// Netmasks, wildcard masks and the unspecified/broadcast addresses are kept
+// because they describe structure rather than identity.
+func (a *Anonymizer) mapIPv4(value string) string {
+	addr, err := netip.ParseAddr(value)
+	if err != nil || !addr.Is4() || isMaskLike(addr) {
+		return value
+	}
+	if placeholder, ok := a.ipv4[value]; ok {
+		return placeholder
+	}
+	n := len(a.ipv4) + 1
+	placeholder := fmt.Sprintf("10.%d.%d.%d", (n>>16)&0xff, (n>>8)&0xff, n&0xff)
+	a.ipv4[value] = placeholder
+	return placeholder
+}
+
+// mapIPv6 maps an IPv6 address to a placeholder in the 2001:db8::/32
+// documentation range.
+func (a *Anonymizer) mapIPv6(value string) string {
+	key := strings.ToLower(value)
+	if key == "::" {
+		return value
+	}
+	if placeholder, ok := a.ipv6[key]; ok {
+		return placeholder
+	}
+	placeholder := "2001:db8::" + strconv.FormatInt(int64(len(a.ipv6)+1), 16)
+	a.ipv6[key] = placeholder
+	return placeholder
+}
+
+func (a *Anonymizer) mapHost(value string) string {
+	return mapPlaceholder(a.hosts, value, "host")
+}
+
+func (a *Anonymizer) mapUser(value string) string {
+	return mapPlaceholder(a.users, value, "user")
+}
+
+// mapLocation maps an SNMP location, keeping surrounding whitespace and quotes.
+func (a *Anonymizer) mapLocation(value string) string {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return value
+	}
+	start := strings.Index(value, trimmed)
+	leading, trailing := value[:start], value[start+len(trimmed):]
+	if len(trimmed) >= 2 && trimmed[0] == '"' && trimmed[len(trimmed)-1] == '"' {
+		return leading + `"` + mapPlaceholder(a.locations, trimmed[1:len(trimmed)-1], "location") + `"` + trailing
+	}
+	return leading + mapPlaceholder(a.locations, trimmed, "location") + trailing
+}
+
+// mapPlaceholder returns the placeholder for value in m, allocating the next
+// "<prefix>-N" name the first time a value is seen.
+func mapPlaceholder(m map[string]string, value, prefix string) string {
+	if value == "" {
+		return value
+	}
+	if placeholder, ok := m[value]; ok {
+		return placeholder
+	}
+	placeholder := prefix + "-" + strconv.Itoa(len(m)+1)
+	m[value] = placeholder
+	return placeholder
+}
+
+// isMaskLike reports whether addr is a contiguous netmask (255.255.255.0),
+// a contiguous wildcard mask (0.0.0.255), or 0.0.0.0/255.255.255.255.
+func isMaskLike(addr netip.Addr) bool {
+	b := addr.As4()
+	v := uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	inv := ^v
+	return inv&(inv+1) == 0 || v&(v+1) == 0
+}
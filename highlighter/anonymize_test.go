@@ -0,0 +1,113 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnonymizeConfig(t *testing.T) {
+	input := `hostname core-rtr-01
+username admin privilege 15 secret 5 $1$abc
+interface GigabitEthernet0/0/0
+ ip address 203.0.113.1 255.255.255.252
+router ospf 1
+ network 203.0.113.0 0.0.0.3 area 0
+snmp-server location "Main DC, Rack 42"
+`
+	expected := `hostname host-1
+username user-1 privilege 15 secret 5 $1$abc
+interface GigabitEthernet0/0/0
+ ip address 10.0.0.1 255.255.255.252
+router ospf 1
+ network 10.0.0.2 0.0.0.3 area 0
+snmp-server location "location-1"
+`
+
+	result := Anonymize(input)
+	if result != expected {
+		t.Errorf("Anonymize mismatch:\ngot:\n%s\nwant:\n%s", result, expected)
+	}
+}
+
+func TestAnonymizeConsistentMapping(t *testing.T) {
+	a := NewAnonymizer()
+
+	first := a.Anonymize("neighbor 192.0.2.1 remote-as 65000\n")
+	second := a.Anonymize("neighbor 192.0.2.1 description peer\n")
+
+	if !strings.Contains(first, "10.0.0.1") || !strings.Contains(second, "10.0.0.1") {
+		t.Errorf("same IP should map to same placeholder across calls: %q, %q", first, second)
+	}
+	if strings.Contains(first+second, "192.0.2.1") {
+		t.Error("original IP should not appear in output")
+	}
+}
+
+func TestAnonymizeVirtualIP(t *testing.T) {
+	input := "Vlan10 - Group 10\n  State is Active\n  Virtual IP address is 192.0.2.1\n  Active router is local\n"
+	if result := Anonymize(input); strings.Contains(result, "192.0.2.1") {
+		t.Errorf("virtual IP not scrubbed: %q", result)
+	}
+}
+
+func TestAnonymizeRouteDistinguisherAndTarget(t *testing.T) {
+	input := "vrf definition BLUE\n rd 192.168.1.1:100\n route-target export 192.168.1.1:100\n route-target import 65000:100\n"
+	expected := "vrf definition BLUE\n rd 10.0.0.1:100\n route-target export 10.0.0.1:100\n route-target import 65000:100\n"
+	if result := Anonymize(input); result != expected {
+		t.Errorf("Anonymize mismatch:\ngot:\n%s\nwant:\n%s", result, expected)
+	}
+}
+
+func TestAnonymizeDeterministic(t *testing.T) {
+	input := "ip route 0.0.0.0 0.0.0.0 198.51.100.1\nlogging host 198.51.100.7\n"
+	if Anonymize(input) != Anonymize(input) {
+		t.Error("Anonymize should be deterministic")
+	}
+}
+
+func TestAnonymizeHostnameReferences(t *testing.T) {
+	a := NewAnonymizer()
+	a.Anonymize("hostname edge-01\n")
+
+	result := a.Anonymize("edge-01#")
+	if strings.Contains(result, "edge-01") {
+		t.Errorf("prompt hostname should be anonymized, got %q", result)
+	}
+}
+
+func TestAnonymizePrefixesAndIPv6(t *testing.T) {
+	result := Anonymize("ip prefix-list P seq 5 permit 198.51.100.0/24\nipv6 address 2001:470::1/64\n")
+
+	if !strings.Contains(result, "10.0.0.1/24") {
+		t.Errorf("IPv4 prefix length should be preserved, got %q", result)
+	}
+	if !strings.Contains(result, "2001:db8::1/64") {
+		t.Errorf("IPv6 prefix should map into documentation range, got %q", result)
+	}
+}
+
+func TestAnonymizeEmpty(t *testing.T) {
+	if Anonymize("") != "" {
+		t.Error("empty input should stay empty")
+	}
+}
+
+func TestAnonymizeAligned(t *testing.T) {
+	input := "Interface              IP-Address      OK? Method Status                Protocol\n" +
+		"GigabitEthernet0/0     192.168.100.200 YES NVRAM  up                    up\n" +
+		"Vlan10                 2001:db8:ffff::1 YES manual up                   up\n" +
+		" ip address 172.16.1.1 255.255.255.0\n"
+	expected := "Interface              IP-Address      OK? Method Status                Protocol\n" +
+		"GigabitEthernet0/0     10.0.0.1        YES NVRAM  up                    up\n" +
+		"Vlan10                 2001:db8::1      YES manual up                   up\n" +
+		" ip address 10.0.0.2 255.255.255.0\n"
+
+	if got := NewAnonymizer().AnonymizeAligned(input); got != expected {
+		t.Errorf("AnonymizeAligned mismatch:\ngot:\n%s\nwant:\n%s", got, expected)
+	}
+
+	h := New(WithRedaction(NewAnonymizer()), WithColumnAlignment())
+	if got := StripANSI(h.HighlightForced(input)); got != expected {
+		t.Errorf("redacted highlight should keep columns:\ngot:\n%s\nwant:\n%s", got, expected)
+	}
+}
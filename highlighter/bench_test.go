@@ -0,0 +1,91 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/internal/benchdata"
+	"github.com/lasseh/cink/lexer"
+)
+
+// Benchmark inputs, built once so their generation is not measured.
+var (
+	benchConfig     = benchdata.Config(5000)
+	benchShowTech   = benchdata.ShowTech(1 << 20)
+	benchTranscript = benchdata.Transcript(200)
+)
+
+func benchmarkHighlight(b *testing.B, h *Highlighter, input string) {
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		h.Highlight(input)
+	}
+}
+
+// BenchmarkHighlightLine is one line of an interactive session, after a
+// prompt has pinned detection.
+func BenchmarkHighlightLine(b *testing.B) {
+	h := New(WithPinnedDetection())
+	h.Highlight("core01#show ip interface brief\n")
+	benchmarkHighlight(b, h, benchdata.Line)
+}
+
+func BenchmarkHighlightConfig(b *testing.B) {
+	benchmarkHighlight(b, New(), benchConfig)
+}
+
+func BenchmarkHighlightShowTech(b *testing.B) {
+	benchmarkHighlight(b, New(), benchShowTech)
+}
+
+func BenchmarkHighlightTranscript(b *testing.B) {
+	h := New()
+	h.SetParseMode(lexer.ParseModeTranscript)
+	benchmarkHighlight(b, h, benchTranscript)
+}
+
+// BenchmarkSessionLongLine is a 64 KB line arriving in the chunks a terminal
+// proxy flushes, which the session collects until the line is complete.
+func BenchmarkSessionLongLine(b *testing.B) {
+	chunk := strings.Repeat("description x ", 4096/14)
+	b.SetBytes(int64(16*len(chunk) + 1))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := NewSession()
+		for j := 0; j < 16; j++ {
+			s.Highlight(chunk)
+		}
+		s.Highlight("\n")
+	}
+}
+
+// BenchmarkDetect measures content detection on its own, without the cache.
+func BenchmarkDetect(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		detectionScore(benchdata.Line)
+	}
+}
+
+// BenchmarkRenderTokens measures rendering already lexed tokens to ANSI.
+func BenchmarkRenderTokens(b *testing.B) {
+	h := New()
+	tokens := h.Tokens(benchConfig)
+	b.SetBytes(int64(len(benchConfig)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.renderTokens(tokens, h.Theme())
+	}
+}
+
+// BenchmarkInputSegments is one keystroke of a command being typed.
+func BenchmarkInputSegments(b *testing.B) {
+	in := NewInput()
+	line := "ip route 0.0.0.0 0.0.0.0 GigabitEthernet0/0 10.0.0.1 name default"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		in.Segments(line, len(line))
+	}
+}
@@ -0,0 +1,58 @@
+package highlighter
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/internal/benchdata"
+	"github.com/lasseh/cink/lexer"
+)
+
+// performanceBudget is cink's published performance budget (see "Performance"
+// in the README). Allocations per input line are deterministic and checked on
+// every test run; throughput depends on the machine and is only checked when
+// CINK_PERF_BUDGET is set, as "make bench-budget" does.
+var performanceBudget = []struct {
+	name          string
+	input         string
+	mode          lexer.ParseMode
+	allocsPerLine float64 // maximum
+	mbPerSecond   float64 // minimum
+}{
+	{"line", benchdata.Line, lexer.ParseModeShow, 40, 1},
+	{"config", benchdata.Config(1000), lexer.ParseModeConfig, 1, 2},
+	{"show tech", benchdata.ShowTech(128 << 10), lexer.ParseModeShow, 12, 1},
+	{"transcript", benchdata.Transcript(50), lexer.ParseModeTranscript, 20, 1},
+}
+
+func TestPerformanceBudget(t *testing.T) {
+	checkSpeed := os.Getenv("CINK_PERF_BUDGET") != ""
+	for _, tt := range performanceBudget {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New()
+			h.SetParseMode(tt.mode)
+			lines := float64(max(strings.Count(tt.input, "\n"), 1))
+
+			allocs := testing.AllocsPerRun(2, func() { h.HighlightForced(tt.input) })
+			if perLine := allocs / lines; perLine > tt.allocsPerLine {
+				t.Errorf("%.2f allocations per line, budget is %.2f", perLine, tt.allocsPerLine)
+			}
+
+			if !checkSpeed {
+				return
+			}
+			result := testing.Benchmark(func(b *testing.B) {
+				b.SetBytes(int64(len(tt.input)))
+				for i := 0; i < b.N; i++ {
+					h.HighlightForced(tt.input)
+				}
+			})
+			mbps := float64(result.Bytes) * float64(result.N) / 1e6 / result.T.Seconds()
+			if mbps < tt.mbPerSecond {
+				t.Errorf("%.2f MB/s, budget is %.2f MB/s", mbps, tt.mbPerSecond)
+			}
+			t.Logf("%.2f MB/s, %.2f allocations per line", mbps, allocs/lines)
+		})
+	}
+}
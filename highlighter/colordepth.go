@@ -0,0 +1,190 @@
+package highlighter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// ColorDepth is the number of colors a terminal can show. Themes use true
+// color; at lower depths each color is replaced by the nearest one available.
+type ColorDepth int
+
+const (
+	TrueColor ColorDepth = iota // 24-bit RGB
+	Colors256                   // xterm 256-color palette
+	Colors16                    // the 16 basic ANSI colors
+	NoColor                     // no colors or attributes at all
+)
+
+// String returns the name of the color depth.
+func (d ColorDepth) String() string {
+	switch d {
+	case TrueColor:
+		return "truecolor"
+	case Colors256:
+		return "256"
+	case Colors16:
+		return "16"
+	case NoColor:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
+
+// ColorDepthByName returns a color depth by its name ("truecolor", "24bit",
+// "256", "16", "none"). Returns TrueColor for unknown names.
+func ColorDepthByName(name string) ColorDepth {
+	switch strings.ToLower(name) {
+	case "256", "8bit":
+		return Colors256
+	case "16", "basic", "4bit":
+		return Colors16
+	case "none", "0":
+		return NoColor
+	default:
+		return TrueColor
+	}
+}
+
+// SetColorDepth limits theme colors to depth. The theme itself is not
+// changed; the highlighter renders with a converted copy of it.
+func (h *Highlighter) SetColorDepth(depth ColorDepth) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.colorDepth = depth
+	h.theme = h.sourceTheme.WithColorDepth(depth)
+}
+
+// ColorDepth returns the color depth themes are rendered at.
+func (h *Highlighter) ColorDepth() ColorDepth {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.colorDepth
+}
+
+// WithColorDepth returns a copy of the theme with every color converted to
+// the nearest one available at depth. For TrueColor it returns t itself.
+func (t *Theme) WithColorDepth(depth ColorDepth) *Theme {
+	if depth == TrueColor || t == nil {
+		return t
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	colors := make(map[lexer.TokenType]string, len(t.colors))
+	for tokenType, color := range t.colors {
+		colors[tokenType] = ConvertColorDepth(color, depth)
+	}
+	convert := func(color string) string { return ConvertColorDepth(color, depth) }
+	return &Theme{
+		palette:  t.palette,
+		colors:   colors,
+		modes:    cloneOverrides(t.modes, convert),
+		dialects: cloneOverrides(t.dialects, convert),
+	}
+}
+
+// ConvertColorDepth rewrites the 256-color and true color codes in an ANSI
+// SGR style to the nearest color available at depth, keeping attributes such
+// as bold and underline. At NoColor it returns "".
+func ConvertColorDepth(ansi string, depth ColorDepth) string {
+	if depth == TrueColor || ansi == "" {
+		return ansi
+	}
+	if depth == NoColor {
+		return ""
+	}
+
+	var buf strings.Builder
+	for _, seq := range strings.Split(ansi, "\033[")[1:] {
+		params := strings.Split(strings.TrimSuffix(seq, "m"), ";")
+		var out []string
+		for i := 0; i < len(params); i++ {
+			n, _ := strconv.Atoi(params[i])
+			if n != 38 && n != 48 || i+1 >= len(params) {
+				out = append(out, params[i])
+				continue
+			}
+
+			var r, g, b int
+			switch {
+			case params[i+1] == "5" && i+2 < len(params):
+				c, _ := strconv.Atoi(params[i+2])
+				if depth == Colors256 {
+					out = append(out, params[i:i+3]...)
+					i += 2
+					continue
+				}
+				r, g, b = color256ToRGB(c)
+				i += 2
+			case params[i+1] == "2" && i+4 < len(params):
+				r, _ = strconv.Atoi(params[i+2])
+				g, _ = strconv.Atoi(params[i+3])
+				b, _ = strconv.Atoi(params[i+4])
+				i += 4
+			default:
+				out = append(out, params[i])
+				continue
+			}
+
+			if depth == Colors256 {
+				out = append(out, strconv.Itoa(n), "5", strconv.Itoa(nearestColor256(r, g, b)))
+			} else {
+				code := nearestBasicColor(r, g, b)
+				if n == 48 {
+					code += 10 // background codes are 40-47 and 100-107
+				}
+				out = append(out, strconv.Itoa(code))
+			}
+		}
+		buf.WriteString("\033[" + strings.Join(out, ";") + "m")
+	}
+	return buf.String()
+}
+
+// nearestColor256 returns the index of the 256-color palette entry closest to
+// r, g, b, from the 6x6x6 color cube or the grayscale ramp.
+func nearestColor256(r, g, b int) int {
+	level := func(v int) int {
+		switch {
+		case v < 48:
+			return 0
+		case v < 115:
+			return 1
+		default:
+			return min((v-35)/40, 5)
+		}
+	}
+	cube := 16 + 36*level(r) + 6*level(g) + level(b)
+
+	gray := min(max((r+g+b)/3-3, 0)/10, 23)
+	grayIndex := 232 + gray
+
+	cr, cg, cb := color256ToRGB(cube)
+	gr, gg, gb := color256ToRGB(grayIndex)
+	if colorDistance(r, g, b, gr, gg, gb) < colorDistance(r, g, b, cr, cg, cb) {
+		return grayIndex
+	}
+	return cube
+}
+
+// nearestBasicColor returns the basic ANSI foreground code (30-37, 90-97)
+// closest to r, g, b.
+func nearestBasicColor(r, g, b int) int {
+	best, bestDist := 30, -1
+	for _, code := range []int{30, 31, 32, 33, 34, 35, 36, 37, 90, 91, 92, 93, 94, 95, 96, 97} {
+		c := ircPalette[ansiToIRCColor[code]]
+		if dist := colorDistance(r, g, b, c[0], c[1], c[2]); bestDist < 0 || dist < bestDist {
+			best, bestDist = code, dist
+		}
+	}
+	return best
+}
+
+func colorDistance(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
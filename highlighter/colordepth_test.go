@@ -0,0 +1,84 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestConvertColorDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		ansi     string
+		depth    ColorDepth
+		expected string
+	}{
+		{"truecolor unchanged", RGB(255, 158, 100), TrueColor, RGB(255, 158, 100)},
+		{"rgb to 256 cube", RGB(255, 0, 0), Colors256, Color256(196)},
+		{"rgb to 256 grey", RGB(128, 128, 128), Colors256, Color256(244)},
+		{"256 kept at 256", Color256(33), Colors256, Color256(33)},
+		{"rgb to 16", RGB(250, 10, 10), Colors16, BrightRed},
+		{"256 to 16", Color256(244), Colors16, BrightBlack},
+		{"basic kept", Green, Colors16, Green},
+		{"bold kept", Bold + RGB(0, 0, 252), Colors16, Bold + BrightBlue},
+		{"background", BgRGB(0, 147, 147), Colors16, "\033[46m"},
+		{"combined params", "\033[1;38;2;255;0;0m", Colors256, "\033[1;38;5;196m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConvertColorDepth(tt.ansi, tt.depth); got != tt.expected {
+				t.Errorf("ConvertColorDepth(%q, %v) = %q, want %q", tt.ansi, tt.depth, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColorDepthByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected ColorDepth
+	}{
+		{"truecolor", TrueColor},
+		{"256", Colors256},
+		{"16", Colors16},
+		{"basic", Colors16},
+		{"none", NoColor},
+		{"bogus", TrueColor},
+	}
+	for _, tt := range tests {
+		if got := ColorDepthByName(tt.name); got != tt.expected {
+			t.Errorf("ColorDepthByName(%q) = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestHighlightColorDepth(t *testing.T) {
+	theme := TokyoNightTheme()
+	h := New(WithTheme(theme), WithColorDepth(Colors256))
+	out := h.Highlight("interface GigabitEthernet0/1\n no shutdown\n")
+	if strings.Contains(out, "38;2;") {
+		t.Errorf("true color escape in 256-color output: %q", out)
+	}
+
+	// The theme passed in is left alone, and switching back restores it
+	if !strings.Contains(theme.GetColor(lexer.TokenCommand), "38;2;") {
+		t.Error("source theme was modified")
+	}
+	h.SetColorDepth(TrueColor)
+	if h.theme != theme {
+		t.Error("SetColorDepth(TrueColor) should render with the original theme")
+	}
+
+	h.SetColorDepth(Colors16)
+	h.SetTheme(NordTheme())
+	if strings.Contains(h.Highlight("interface Gi0/1\n"), "38;") {
+		t.Error("SetTheme should keep the color depth")
+	}
+
+	h.SetColorDepth(NoColor)
+	if input := "interface Gi0/1\n"; h.HighlightForced(input) != input {
+		t.Error("NoColor output should have no escapes")
+	}
+}
@@ -0,0 +1,113 @@
+package highlighter
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// SetCompactOutput coalesces adjacent tokens of the same style into one
+// escape sequence. Each token normally gets its own color and Reset; in
+// compact mode a style stays on across tokens of that color and the spaces
+// between them, a style change is a single sequence, and Reset is written
+// only before uncolored text and at the end of each line. Output is smaller
+// and text copied out of the terminal carries fewer stray escapes.
+func (h *Highlighter) SetCompactOutput(enabled bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.compact = enabled
+}
+
+// CompactOutput returns whether compact output is on.
+func (h *Highlighter) CompactOutput() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.compact
+}
+
+// renderCompact writes tokens with coalesced styles (see SetCompactOutput).
+func renderCompact(buf *bytes.Buffer, tokens []lexer.Token, theme *Theme) {
+	active := "" // style in effect, "" after a Reset
+	write := func(text, color string) {
+		if text == "" {
+			return
+		}
+		switch {
+		case color == "":
+			// Blank text may keep a style on unless the style would show on it
+			if active != "" && (!isBlank(text) || showsOnBlank(active)) {
+				buf.WriteString(Reset)
+				active = ""
+			}
+		case color == active:
+		case active == "":
+			buf.WriteString("\033[" + sgrParams(color) + "m")
+			active = color
+		default:
+			// Resetting in the same sequence drops attributes such as bold
+			// that the new style does not set
+			buf.WriteString("\033[0;" + sgrParams(color) + "m")
+			active = color
+		}
+		buf.WriteString(text)
+	}
+
+	for _, token := range tokens {
+		color := theme.GetColor(token.Type)
+		for i, line := range strings.Split(token.Value, "\n") {
+			if i > 0 {
+				// End styles at line ends, for pagers and copied lines
+				if active != "" {
+					buf.WriteString(Reset)
+					active = ""
+				}
+				buf.WriteByte('\n')
+			}
+			write(line, color)
+		}
+	}
+	if active != "" {
+		buf.WriteString(Reset)
+	}
+}
+
+// isBlank reports whether s is spaces and tabs only.
+func isBlank(s string) bool {
+	return strings.Trim(s, " \t") == ""
+}
+
+// showsOnBlank reports whether a style is visible on spaces: underline,
+// reverse video, strikethrough and background colors are, foreground colors
+// are not.
+func showsOnBlank(style string) bool {
+	params := strings.Split(sgrParams(style), ";")
+	for i := 0; i < len(params); i++ {
+		switch p := params[i]; {
+		case p == "4", p == "7", p == "9", p == "48":
+			return true
+		case len(p) == 2 && p[0] == '4', len(p) == 3 && strings.HasPrefix(p, "10"):
+			return true
+		case p == "38":
+			// Skip the color: 38;5;n or 38;2;r;g;b
+			if i+1 < len(params) && params[i+1] == "2" {
+				i += 4
+			} else {
+				i += 2
+			}
+		}
+	}
+	return false
+}
+
+// sgrParams joins the parameters of a run of SGR escape sequences:
+// "\033[1m\033[38;5;42m" gives "1;38;5;42".
+func sgrParams(style string) string {
+	var params []string
+	for _, seq := range strings.Split(style, "\033[") {
+		if seq = strings.TrimSuffix(seq, "m"); seq != "" {
+			params = append(params, seq)
+		}
+	}
+	return strings.Join(params, ";")
+}
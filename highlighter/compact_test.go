@@ -0,0 +1,108 @@
+package highlighter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestCompactOutput(t *testing.T) {
+	input := "interface GigabitEthernet0/1\n description uplink to core\n ip address 10.0.0.1 255.255.255.0\n no shutdown\n"
+
+	h := New()
+	normal := h.HighlightForced(input)
+	h.SetCompactOutput(true)
+	if !h.CompactOutput() {
+		t.Fatal("CompactOutput should report true after SetCompactOutput(true)")
+	}
+	compact := h.HighlightForced(input)
+
+	if got := StripANSI(compact); got != input {
+		t.Errorf("compact output should keep the text, got %q", got)
+	}
+	if len(compact) >= len(normal) {
+		t.Errorf("compact output should be smaller: %d >= %d bytes", len(compact), len(normal))
+	}
+	for i, line := range strings.Split(compact, "\n") {
+		if strings.Contains(line, "\033[") && !strings.HasSuffix(line, Reset) {
+			t.Errorf("line %d should end in a reset: %q", i, line)
+		}
+	}
+}
+
+func TestRenderCompact(t *testing.T) {
+	theme := &Theme{colors: map[lexer.TokenType]string{
+		lexer.TokenKeyword:  Blue,
+		lexer.TokenCommand:  Bold + Green,
+		lexer.TokenSecret:   Underline + Red,
+		lexer.TokenIPv4:     RGB(1, 2, 3),
+		lexer.TokenNegation: BgRGB(4, 5, 6),
+	}}
+	tok := func(typ lexer.TokenType, value string) lexer.Token {
+		return lexer.Token{Type: typ, Value: value}
+	}
+
+	tests := []struct {
+		name   string
+		tokens []lexer.Token
+		want   string
+	}{
+		{
+			"same style across spaces",
+			[]lexer.Token{tok(lexer.TokenKeyword, "ip"), tok(lexer.TokenText, " "), tok(lexer.TokenKeyword, "address")},
+			Blue + "ip address" + Reset,
+		},
+		{
+			"uncolored word resets",
+			[]lexer.Token{tok(lexer.TokenKeyword, "ip"), tok(lexer.TokenText, " x "), tok(lexer.TokenKeyword, "address")},
+			Blue + "ip" + Reset + " x " + Blue + "address" + Reset,
+		},
+		{
+			"style change is one sequence",
+			[]lexer.Token{tok(lexer.TokenCommand, "set"), tok(lexer.TokenText, " "), tok(lexer.TokenKeyword, "ip")},
+			"\033[1;32mset " + "\033[0;34mip" + Reset,
+		},
+		{
+			"true color style change",
+			[]lexer.Token{tok(lexer.TokenKeyword, "ip"), tok(lexer.TokenIPv4, "10.0.0.1")},
+			Blue + "ip" + "\033[0;38;2;1;2;3m10.0.0.1" + Reset,
+		},
+		{
+			"underline does not extend over spaces",
+			[]lexer.Token{tok(lexer.TokenSecret, "a"), tok(lexer.TokenText, " "), tok(lexer.TokenSecret, "b")},
+			"\033[4;31ma" + Reset + " " + "\033[4;31mb" + Reset,
+		},
+		{
+			"background does not extend over spaces",
+			[]lexer.Token{tok(lexer.TokenNegation, "no"), tok(lexer.TokenText, " "), tok(lexer.TokenNegation, "no")},
+			BgRGB(4, 5, 6) + "no" + Reset + " " + BgRGB(4, 5, 6) + "no" + Reset,
+		},
+		{
+			"reset at line ends",
+			[]lexer.Token{tok(lexer.TokenKeyword, "ip"), tok(lexer.TokenText, "\n"), tok(lexer.TokenKeyword, "ip")},
+			Blue + "ip" + Reset + "\n" + Blue + "ip" + Reset,
+		},
+		{
+			"newline inside a token",
+			[]lexer.Token{tok(lexer.TokenKeyword, "a\nb")},
+			Blue + "a" + Reset + "\n" + Blue + "b" + Reset,
+		},
+		{
+			"uncolored only",
+			[]lexer.Token{tok(lexer.TokenText, "plain text\n")},
+			"plain text\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			renderCompact(&buf, tt.tokens, theme)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package highlighter
+
+import (
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// DefaultMinConfidence is the detection score required by default for input
+// to be treated as Cisco content. A single matching heuristic is enough.
+const DefaultMinConfidence = 1
+
+// DetectionResult explains how Highlight classified a piece of input, so
+// callers can decide what to do instead of guessing why output wasn't colored.
+type DetectionResult struct {
+	IsCisco bool            // Score reached the highlighter's minimum confidence
+	Score   int             // number of detection heuristics that matched
+	Mode    lexer.ParseMode // config or show output, as the lexer would detect it
+}
+
+// Detect runs the content detection heuristics used by Highlight and reports
+// the result. ANSI escape codes are ignored. Results come from the detection
+// cache when one is set (see SetDetectionCache).
+func (h *Highlighter) Detect(input string) DetectionResult {
+	return h.detect(StripANSI(input), true)
+}
+
+// detect scores already-cleaned input, using the detection cache if set.
+// The parse mode is detected only when withMode is set; Highlight does not
+// need it, and leaves it ParseModeAuto.
+func (h *Highlighter) detect(input string, withMode bool) DetectionResult {
+	h.mu.RLock()
+	minConfidence, cache := h.minConfidence, h.detectCache
+	h.mu.RUnlock()
+
+	result, ok := cache.get(input)
+	if !ok {
+		result = DetectionResult{Score: detectionScore(input)}
+	}
+	if withMode && result.Mode == lexer.ParseModeAuto {
+		result.Mode = lexer.DetectParseMode(input)
+		ok = false
+	}
+	if !ok {
+		cache.add(input, result)
+	}
+	result.IsCisco = result.Score >= minConfidence
+	return result
+}
+
+// detectionScore scores input. Each matching prompt, indicator, separator
+// block and Cisco-specific keyword adds one point.
+func detectionScore(input string) int {
+	score := 0
+	if isPromptLine(input) {
+		score++
+	}
+
+	lower := strings.ToLower(input)
+	score += countConfigIndicators(lower)
+	score += countShowIndicators(lower)
+	if hasCiscoSeparators(input) {
+		score++
+	}
+	score += countCiscoKeywords(lower)
+	return score
+}
+
+// SetDetectionCache caches the detection results of the last size inputs,
+// keyed on a hash of their first DetectionCachePrefix bytes, so repeated
+// chunks from the same session skip the heuristics. A size of 0 turns the
+// cache off. Setting the cache empties it.
+func (h *Highlighter) SetDetectionCache(size int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.detectCache = newDetectionCache(size)
+}
+
+// SetPinDetection pins content detection for a Highlighter bound to one
+// session: once input is detected as Cisco, later input is highlighted
+// without running detection again, until pinning is turned off. Calling it
+// forgets an earlier detection.
+func (h *Highlighter) SetPinDetection(pin bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pinDetection = pin
+	h.pinned = false
+}
+
+// DetectionPinned reports whether detection is pinned and Cisco content has
+// been seen, so input is highlighted without detection.
+func (h *Highlighter) DetectionPinned() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.pinned
+}
+
+// SetAlwaysHighlight makes Highlight skip content detection and highlight
+// every input, like HighlightForced.
+func (h *Highlighter) SetAlwaysHighlight(always bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.alwaysOn = always
+}
+
+// AlwaysHighlight returns whether content detection is bypassed.
+func (h *Highlighter) AlwaysHighlight() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.alwaysOn
+}
+
+// SetMinConfidence sets the detection score required to treat input as Cisco.
+// Higher values reduce false positives on non-Cisco text; values below 1 are
+// clamped to 1.
+func (h *Highlighter) SetMinConfidence(score int) {
+	if score < 1 {
+		score = 1
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.minConfidence = score
+}
+
+// MinConfidence returns the detection score required to treat input as Cisco.
+func (h *Highlighter) MinConfidence() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.minConfidence
+}
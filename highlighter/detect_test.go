@@ -0,0 +1,163 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestDetect(t *testing.T) {
+	h := New()
+
+	result := h.Detect("!\nhostname r1\n!\ninterface Gi0/0/0\n ip address 10.0.0.1 255.255.255.0\n!")
+	if !result.IsCisco {
+		t.Error("config should be detected as Cisco")
+	}
+	if result.Score < 3 {
+		t.Errorf("expected several heuristics to match, got score %d", result.Score)
+	}
+	if result.Mode != lexer.ParseModeConfig {
+		t.Errorf("expected config mode, got %v", result.Mode)
+	}
+
+	result = h.Detect("Hello world")
+	if result.IsCisco || result.Score != 0 {
+		t.Errorf("plain text should not be detected, got %+v", result)
+	}
+}
+
+func TestDetectShowMode(t *testing.T) {
+	h := New()
+	result := h.Detect("GigabitEthernet0/0/0 is up, line protocol is up\n  5 minute input rate 1000 bits/sec")
+	if !result.IsCisco || result.Mode != lexer.ParseModeShow {
+		t.Errorf("expected Cisco show output, got %+v", result)
+	}
+}
+
+func TestDetectErrorLine(t *testing.T) {
+	h := New()
+	input := "% Invalid input detected at '^' marker.\n"
+	if !h.Detect(input).IsCisco {
+		t.Fatal("a CLI error line should be detected as Cisco")
+	}
+	out := h.Highlight(input)
+	if !strings.Contains(out, h.Theme().GetColor(lexer.TokenError)+"% Invalid input") {
+		t.Errorf("error line not colored as TokenError: %q", out)
+	}
+}
+
+func TestMinConfidence(t *testing.T) {
+	h := New()
+	if h.MinConfidence() != DefaultMinConfidence {
+		t.Errorf("expected default min confidence %d, got %d", DefaultMinConfidence, h.MinConfidence())
+	}
+
+	input := "no shutdown"
+	if h.Highlight(input) == input {
+		t.Fatal("single-indicator input should be highlighted at default confidence")
+	}
+
+	h.SetMinConfidence(5)
+	if h.Highlight(input) != input {
+		t.Error("single-indicator input should not be highlighted at confidence 5")
+	}
+
+	h.SetMinConfidence(0)
+	if h.MinConfidence() != 1 {
+		t.Errorf("min confidence should clamp to 1, got %d", h.MinConfidence())
+	}
+}
+
+func TestAlwaysHighlight(t *testing.T) {
+	h := New()
+	input := "Hello world"
+
+	if h.Highlight(input) != input {
+		t.Fatal("plain text should not be highlighted by default")
+	}
+
+	h.SetAlwaysHighlight(true)
+	if !h.AlwaysHighlight() {
+		t.Error("AlwaysHighlight should report true")
+	}
+	if h.Highlight(input) == input {
+		t.Error("plain text should be highlighted when detection is bypassed")
+	}
+}
+
+func TestDetectionCache(t *testing.T) {
+	h := New(WithDetectionCache(2))
+	config := "interface Gi0/1\n no shutdown\n"
+	first := h.Detect(config)
+	if !first.IsCisco {
+		t.Fatal("config should be detected as Cisco")
+	}
+
+	// Input sharing a cached prefix reuses the cached result
+	filler := strings.Repeat("x", DetectionCachePrefix)
+	h.Detect(filler)
+	if h.Detect(filler + "\nrouter bgp 65000\n").IsCisco {
+		t.Error("input with a cached prefix should reuse the cached result")
+	}
+
+	// The result is recomputed against the current minimum confidence
+	h.SetMinConfidence(first.Score + 1)
+	if h.Detect(config).IsCisco {
+		t.Error("cached score should be checked against the new minimum confidence")
+	}
+	h.SetMinConfidence(DefaultMinConfidence)
+
+	// A third entry evicts the least recently used one
+	h.Detect(filler)
+	h.Detect("show version")
+	if _, ok := h.detectCache.get(config); ok {
+		t.Error("least recently used entry should be evicted")
+	}
+
+	h.SetDetectionCache(0)
+	if h.detectCache != nil {
+		t.Error("size 0 should turn the cache off")
+	}
+}
+
+func TestDetectionCacheMode(t *testing.T) {
+	h := New(WithDetectionCache(2))
+	config := "!\nhostname r1\n!\ninterface Gi0/0/0\n no shutdown\n!\n"
+
+	// Highlight only needs the score, so the mode is left to Detect
+	h.Highlight(config)
+	if cached, _ := h.detectCache.get(config); cached.Mode != lexer.ParseModeAuto {
+		t.Errorf("Highlight detected the parse mode %v", cached.Mode)
+	}
+	if got := h.Detect(config).Mode; got != lexer.ParseModeConfig {
+		t.Errorf("Detect mode = %v, want %v", got, lexer.ParseModeConfig)
+	}
+	if cached, _ := h.detectCache.get(config); cached.Mode != lexer.ParseModeConfig {
+		t.Errorf("cached mode = %v, want %v", cached.Mode, lexer.ParseModeConfig)
+	}
+}
+
+func TestPinDetection(t *testing.T) {
+	h := New(WithPinnedDetection())
+	plain := "Hello world"
+	if h.Highlight(plain) != plain {
+		t.Fatal("plain text should not be highlighted before Cisco is seen")
+	}
+	if h.DetectionPinned() {
+		t.Fatal("detection should not be pinned before Cisco is seen")
+	}
+
+	h.Highlight("interface Gi0/1\n no shutdown\n")
+	if !h.DetectionPinned() {
+		t.Fatal("detection should be pinned after Cisco is seen")
+	}
+	if h.Highlight(plain) == plain {
+		t.Error("pinned highlighter should highlight without detection")
+	}
+
+	h.SetPinDetection(false)
+	if h.DetectionPinned() || h.Highlight(plain) != plain {
+		t.Error("turning pinning off should forget the detection")
+	}
+}
@@ -0,0 +1,77 @@
+package highlighter
+
+import (
+	"container/list"
+	"hash/fnv"
+	"sync"
+)
+
+// DetectionCachePrefix is the number of leading bytes of input that key the
+// detection cache. Inputs that share their first DetectionCachePrefix bytes
+// share a detection result.
+const DetectionCachePrefix = 256
+
+// detectionCache is a least recently used cache of detection results keyed
+// on a hash of the input prefix. A nil cache stores nothing.
+type detectionCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // of *detectionEntry, most recently used first
+	entries map[uint64]*list.Element
+}
+
+type detectionEntry struct {
+	key    uint64
+	result DetectionResult
+}
+
+func newDetectionCache(size int) *detectionCache {
+	if size <= 0 {
+		return nil
+	}
+	return &detectionCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[uint64]*list.Element, size),
+	}
+}
+
+func detectionKey(input string) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(input[:min(len(input), DetectionCachePrefix)]))
+	return hash.Sum64()
+}
+
+func (c *detectionCache) get(input string) (DetectionResult, bool) {
+	if c == nil {
+		return DetectionResult{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[detectionKey(input)]
+	if !ok {
+		return DetectionResult{}, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*detectionEntry).result, true
+}
+
+func (c *detectionCache) add(input string, result DetectionResult) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := detectionKey(input)
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*detectionEntry).result = result
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&detectionEntry{key: key, result: result})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*detectionEntry).key)
+	}
+}
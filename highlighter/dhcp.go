@@ -0,0 +1,148 @@
+package highlighter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Default DHCP pool utilization thresholds for SetPoolUsage, in percent.
+const (
+	DefaultPoolWarning  = 80
+	DefaultPoolCritical = 95
+)
+
+// SetPoolUsage colors the leased address counts and utilization percentages
+// of show ip dhcp pool output by how full the pool is: from warning percent
+// in the warning color, from critical percent in the bad-state color. Pass 0
+// for both to turn it off.
+func (h *Highlighter) SetPoolUsage(warning, critical float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.poolWarning, h.poolCritical = warning, critical
+}
+
+// PoolUsage returns the thresholds set with SetPoolUsage.
+func (h *Highlighter) PoolUsage() (warning, critical float64) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.poolWarning, h.poolCritical
+}
+
+// classifyUsage returns the token type for a pool utilization in percent,
+// or false if it is below the thresholds.
+func classifyUsage(percent, warning, critical float64) (lexer.TokenType, bool) {
+	switch {
+	case critical > 0 && percent >= critical:
+		return lexer.TokenStateBad, true
+	case warning > 0 && percent >= warning:
+		return lexer.TokenStateWarning, true
+	}
+	return lexer.TokenText, false
+}
+
+// applyPoolUsage reclassifies the usage figures of DHCP pools:
+//
+//	Pool LAN :
+//	 Total addresses                : 254
+//	 Leased addresses               : 250
+//	 ...
+//	 Current index        IP address range                    Leased/Excluded/Total
+//	 10.1.1.253           10.1.1.1         - 10.1.1.254        250    / 2     / 254
+//
+// The leased count is compared with the pool's total addresses, which is
+// remembered across calls, and a subnet row's leased count with its own
+// total. Percentages within a pool's output are taken as they are.
+func (h *Highlighter) applyPoolUsage(tokens []lexer.Token) []lexer.Token {
+	h.mu.RLock()
+	warning, critical, pool := h.poolWarning, h.poolCritical, h.pool
+	h.mu.RUnlock()
+	if warning <= 0 && critical <= 0 {
+		return tokens
+	}
+
+	out := make([]lexer.Token, len(tokens))
+	copy(out, tokens)
+	for start := 0; start < len(out); {
+		end := start
+		for end < len(out) && out[end].Line == out[start].Line {
+			end++
+		}
+		pool = pool.line(out[start:end], warning, critical)
+		start = end
+	}
+
+	h.mu.Lock()
+	h.pool = pool
+	h.mu.Unlock()
+	return out
+}
+
+// poolState is what is known of the DHCP pool being shown.
+type poolState struct {
+	in    bool // within a pool's output
+	total int  // its total addresses, 0 until seen
+}
+
+// line reclassifies the usage figures on one line of output and returns the
+// state after it.
+func (p poolState) line(line []lexer.Token, warning, critical float64) poolState {
+	var words []string
+	var numbers []int // indexes of the number tokens
+	for i, tok := range line {
+		switch tok.Type {
+		case lexer.TokenPromptHost:
+			return poolState{}
+		case lexer.TokenNumber:
+			numbers = append(numbers, i)
+		}
+		if w := strings.TrimSpace(tok.Value); w != "" {
+			words = append(words, strings.ToLower(w))
+		}
+	}
+	if len(words) == 0 {
+		return p
+	}
+	text := strings.Join(words, " ")
+	if words[0] == "pool" && strings.HasSuffix(text, ":") {
+		return poolState{in: true}
+	}
+	if !p.in {
+		return p
+	}
+
+	usage := func(i, of int) {
+		leased, err := strconv.Atoi(line[i].Value)
+		if err != nil || of <= 0 {
+			return
+		}
+		if t, ok := classifyUsage(float64(leased)*100/float64(of), warning, critical); ok {
+			line[i].Type = t
+		}
+	}
+	switch {
+	case strings.HasPrefix(text, "total addresses") && len(numbers) > 0:
+		p.total, _ = strconv.Atoi(line[numbers[len(numbers)-1]].Value)
+	case strings.HasPrefix(text, "leased addresses") && len(numbers) > 0:
+		usage(numbers[len(numbers)-1], p.total)
+	case len(numbers) >= 3 && strings.HasSuffix(text, " / "+line[numbers[len(numbers)-1]].Value):
+		// Subnet rows end in leased / excluded / total
+		last := numbers[len(numbers)-1]
+		of, _ := strconv.Atoi(line[last].Value)
+		usage(numbers[len(numbers)-3], of)
+	}
+	for i, tok := range line {
+		if tok.Type != lexer.TokenPercentage {
+			continue
+		}
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(tok.Value, "%"), 64)
+		if err != nil {
+			continue
+		}
+		if t, ok := classifyUsage(percent, warning, critical); ok {
+			line[i].Type = t
+		}
+	}
+	return p
+}
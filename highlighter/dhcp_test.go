@@ -0,0 +1,86 @@
+package highlighter
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestPoolUsage(t *testing.T) {
+	pool := func(name string, total, leased int) string {
+		return "Pool " + name + " :\n" +
+			" Utilization mark (high/low)    : 100 / 0\n" +
+			" Total addresses                : " + strconv.Itoa(total) + "\n" +
+			" Leased addresses               : " + strconv.Itoa(leased) + "\n" +
+			" Excluded addresses             : 2\n" +
+			" Current index        IP address range                    Leased/Excluded/Total\n" +
+			" 10.1.1.253           10.1.1.1         - 10.1.1.254        " + strconv.Itoa(leased) + "    / 2     / " + strconv.Itoa(total) + "\n"
+	}
+	// Leased counts on the "Leased addresses" line and the subnet row
+	leased := func(h *Highlighter, input string) []lexer.TokenType {
+		lex := lexer.New(input)
+		lex.SetParseMode(lexer.ParseModeShow)
+		var types []lexer.TokenType
+		for _, tok := range h.processTokens(lex.Tokenize()) {
+			if tok.Value == "250" || tok.Value == "210" || tok.Value == "20" {
+				types = append(types, tok.Type)
+			}
+		}
+		return types
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  lexer.TokenType
+	}{
+		{"full", pool("FULL", 254, 250), lexer.TokenStateBad},
+		{"nearly full", pool("NEAR", 254, 210), lexer.TokenStateWarning},
+		{"plenty left", pool("LAN", 254, 20), lexer.TokenNumber},
+	}
+	h := New(WithPoolUsage(DefaultPoolWarning, DefaultPoolCritical))
+	for _, tt := range tests {
+		types := leased(h, tt.input)
+		if len(types) != 2 || types[0] != tt.want || types[1] != tt.want {
+			t.Errorf("%s: leased counts are %v, want %v", tt.name, types, tt.want)
+		}
+	}
+
+	// The pool's total is remembered across calls
+	h = New(WithPoolUsage(DefaultPoolWarning, DefaultPoolCritical))
+	leased(h, "Pool FULL :\n Total addresses                : 254\n")
+	if types := leased(h, " Leased addresses               : 250\n"); len(types) != 1 || types[0] != lexer.TokenStateBad {
+		t.Errorf("leased count in a later call: %v", types)
+	}
+
+	// Percentages count only within a pool
+	percent := func(h *Highlighter, input string) lexer.TokenType {
+		lex := lexer.New(input)
+		lex.SetParseMode(lexer.ParseModeShow)
+		for _, tok := range h.processTokens(lex.Tokenize()) {
+			if tok.Value == "97%" {
+				return tok.Type
+			}
+		}
+		return lexer.TokenText
+	}
+	h = New(WithPoolUsage(DefaultPoolWarning, DefaultPoolCritical), WithPercentThresholds(0, 0))
+	if got := percent(h, "CPU utilization for five seconds: 97%\n"); got != lexer.TokenPercentage {
+		t.Errorf("percentage outside a pool: %v", got)
+	}
+	if got := percent(h, "Pool LAN :\n Utilization : 97%\n"); got != lexer.TokenStateBad {
+		t.Errorf("percentage within a pool: %v", got)
+	}
+	if got := percent(h, "R1#show processes cpu\nCPU utilization for five seconds: 97%\n"); got != lexer.TokenPercentage {
+		t.Errorf("percentage after a prompt ends the pool: %v", got)
+	}
+
+	if warning, critical := h.PoolUsage(); warning != DefaultPoolWarning || critical != DefaultPoolCritical {
+		t.Errorf("PoolUsage() = %v, %v", warning, critical)
+	}
+	h.SetPoolUsage(0, 0)
+	if types := leased(h, pool("FULL", 254, 250)); types[0] != lexer.TokenNumber {
+		t.Errorf("usage still applied after turning it off: %v", types)
+	}
+}
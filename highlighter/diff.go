@@ -0,0 +1,100 @@
+package highlighter
+
+import (
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// HighlightDiff highlights unified diff output such as "git diff". The
+// content of each hunk is highlighted as configuration, while the diff itself
+// stays readable: file headers are bold, hunk headers use the comment color,
+// and the +/- markers use the good and bad state colors. Colors already in the
+// input (from git --color) are replaced.
+//
+// Each hunk is tokenized as one text, so constructs spanning lines (banners,
+// descriptions) keep their context.
+func (h *Highlighter) HighlightDiff(input string) string {
+	if !h.IsEnabled() || input == "" {
+		return input
+	}
+
+	h.mu.RLock()
+	theme := h.theme
+	h.mu.RUnlock()
+
+	var buf strings.Builder
+	var hunk []string // content lines of the current hunk, including the marker
+	inHunk := false
+
+	flush := func() {
+		if len(hunk) > 0 {
+			h.writeDiffHunk(&buf, theme, hunk)
+			hunk = hunk[:0]
+		}
+	}
+
+	for _, line := range strings.SplitAfter(StripANSI(input), "\n") {
+		if line == "" {
+			continue
+		}
+		if inHunk && (line[0] == ' ' || line[0] == '+' || line[0] == '-') {
+			hunk = append(hunk, line)
+			continue
+		}
+		flush()
+
+		body, newline := strings.CutSuffix(line, "\n")
+		style := ""
+		switch {
+		case strings.HasPrefix(body, "@@"):
+			inHunk = true
+			style = theme.GetColor(lexer.TokenComment)
+		case strings.HasPrefix(body, `\`):
+			// "\ No newline at end of file" belongs to the hunk
+			style = theme.GetColor(lexer.TokenComment)
+		default:
+			inHunk = false
+			style = Bold
+		}
+		buf.WriteString(style + body + Reset)
+		if newline {
+			buf.WriteByte('\n')
+		}
+	}
+	flush()
+
+	return buf.String()
+}
+
+// writeDiffHunk highlights the content of hunk lines and writes them with
+// their colored +/-/space markers.
+func (h *Highlighter) writeDiffHunk(buf *strings.Builder, theme *Theme, hunk []string) {
+	var text strings.Builder
+	for _, line := range hunk {
+		text.WriteString(line[1:])
+	}
+	rendered := splitTokenLines(h.tokenize(text.String()))
+
+	for i, line := range hunk {
+		switch line[0] {
+		case '+':
+			buf.WriteString(theme.GetColor(lexer.TokenStateGood) + "+" + Reset)
+		case '-':
+			buf.WriteString(theme.GetColor(lexer.TokenStateBad) + "-" + Reset)
+		default:
+			buf.WriteByte(' ')
+		}
+		if i >= len(rendered) {
+			buf.WriteString(line[1:])
+			continue
+		}
+		for _, token := range rendered[i] {
+			if color := theme.GetColor(token.Type); color != "" && token.Value != "\n" {
+				buf.WriteString(color + token.Value + Reset)
+			} else {
+				buf.WriteString(token.Value)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+const sampleDiff = `diff --git a/r1.cfg b/r1.cfg
+index 3b18e51..a9c1d7e 100644
+--- a/r1.cfg
++++ b/r1.cfg
+@@ -1,4 +1,4 @@
+ interface GigabitEthernet0/1
+- ip address 10.0.0.1 255.255.255.0
++ ip address 10.0.0.2 255.255.255.0
+  no shutdown
+`
+
+func TestHighlightDiff(t *testing.T) {
+	h := New()
+	theme := h.theme
+	result := h.HighlightDiff(sampleDiff)
+
+	if got := StripANSI(result); got != sampleDiff {
+		t.Errorf("text should be unchanged:\n%s", got)
+	}
+
+	expected := []string{
+		Bold + "diff --git a/r1.cfg b/r1.cfg" + Reset,
+		theme.GetColor(lexer.TokenComment) + "@@ -1,4 +1,4 @@" + Reset,
+		theme.GetColor(lexer.TokenStateBad) + "-" + Reset + " " + theme.GetColor(lexer.TokenCommand) + "ip",
+		theme.GetColor(lexer.TokenStateGood) + "+" + Reset,
+		theme.GetColor(lexer.TokenIPv4) + "10.0.0.2" + Reset,
+		theme.GetColor(lexer.TokenInterface) + "GigabitEthernet0/1" + Reset,
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in output:\n%q", want, result)
+		}
+	}
+
+	// Each output line keeps its marker in the first column
+	for _, line := range strings.Split(StripANSI(result), "\n")[5:] {
+		if line != "" && !strings.ContainsRune(" +-", rune(line[0])) {
+			t.Errorf("hunk line lost its marker: %q", line)
+		}
+	}
+}
+
+func TestHighlightDiffReplacesGitColors(t *testing.T) {
+	colored := "\033[1mdiff --git a/x b/x\033[m\n\033[36m@@ -1 +1 @@\033[m\n\033[31m-hostname r1\033[m\n\033[32m+hostname r2\033[m\n"
+	result := New().HighlightDiff(colored)
+	if strings.Contains(result, "\033[31m") || strings.Contains(result, "\033[36m") {
+		t.Errorf("git colors should be replaced: %q", result)
+	}
+	if StripANSI(result) != StripANSI(colored) {
+		t.Errorf("text should be unchanged: %q", StripANSI(result))
+	}
+}
+
+func TestHighlightDiffBannerContext(t *testing.T) {
+	diff := "@@ -1,3 +1,3 @@\n banner motd ^C\n-Old text\n+New text\n ^C\n"
+	h := New()
+	result := h.HighlightDiff(diff)
+	want := h.theme.GetColor(lexer.TokenValue) + "New text" + Reset
+	if !strings.Contains(result, want) {
+		t.Errorf("banner body should be highlighted as a value across hunk lines: %q", result)
+	}
+}
@@ -0,0 +1,124 @@
+package highlighter
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Longest value shown in an Explain legend, in runes
+const explainValueWidth = 30
+
+// SetExplain makes Tokens attach a lexer.Explanation to every token, telling
+// which rule classified it (see lexer.Lexer.SetExplain).
+func (h *Highlighter) SetExplain(enabled bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.explain = enabled
+}
+
+// Explaining reports whether tokens carry explanations (see SetExplain).
+func (h *Highlighter) Explaining() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.explain
+}
+
+// Explain returns input highlighted line by line, each line followed by a
+// legend of its tokens: the type each got, the rule that chose it and the
+// types its word could otherwise have had, for reporting misclassifications:
+//
+//	interface Gi0/1
+//	  interface  Section        sections word list (also Command)
+//	  Gi0/1      Interface      interface pattern
+//
+// A type changed after lexing, such as by the heatmap or a token hook, is
+// shown with the lexer's type it replaced.
+func (h *Highlighter) Explain(input string) string {
+	cleaned := StripANSI(input)
+	if h.RemovePagination() {
+		cleaned = StripPagination(cleaned)
+	}
+	if cleaned == "" {
+		return ""
+	}
+	lex := h.newLexer(cleaned)
+	lex.SetExplain(true)
+	tokens := h.processTokens(h.lex(lex))
+	theme := h.Theme()
+
+	var b strings.Builder
+	var legend []lexer.Token // tokens of the current line
+	endLine := func() {
+		width := 0
+		for _, tok := range legend {
+			width = max(width, utf8.RuneCountInString(legendValue(tok)))
+		}
+		for _, tok := range legend {
+			fmt.Fprintf(&b, "  %-*s  %s\n", width, legendValue(tok), explanation(tok))
+		}
+		legend = legend[:0]
+	}
+	for _, tok := range tokens {
+		if strings.TrimSpace(tok.Value) != "" {
+			legend = append(legend, tok)
+		}
+		color := theme.GetColor(tok.Type)
+		lines := strings.SplitAfter(tok.Value, "\n")
+		for i, text := range lines {
+			if text == "" {
+				continue
+			}
+			if color != "" && strings.TrimSpace(text) != "" {
+				b.WriteString(color + strings.TrimSuffix(text, "\n") + Reset)
+				if strings.HasSuffix(text, "\n") {
+					b.WriteByte('\n')
+				}
+			} else {
+				b.WriteString(text)
+			}
+			if i < len(lines)-1 || strings.HasSuffix(text, "\n") {
+				endLine()
+			}
+		}
+	}
+	if len(legend) > 0 {
+		b.WriteByte('\n')
+		endLine()
+	}
+	return b.String()
+}
+
+// legendValue returns the value of tok as shown in an Explain legend.
+func legendValue(tok lexer.Token) string {
+	v := strings.TrimSpace(tok.Value)
+	if i := strings.IndexByte(v, '\n'); i >= 0 {
+		v = strings.TrimSpace(v[:i]) + "…"
+	}
+	if utf8.RuneCountInString(v) > explainValueWidth {
+		v = string([]rune(v)[:explainValueWidth-1]) + "…"
+	}
+	return v
+}
+
+// explanation describes how tok got its type.
+func explanation(tok lexer.Token) string {
+	e := tok.Explain
+	if e == nil {
+		return fmt.Sprintf("%-14s added after lexing", tok.Type)
+	}
+	s := fmt.Sprintf("%-14s %s", tok.Type, e.Rule)
+	if tok.Type != e.Type {
+		s += fmt.Sprintf(", then changed from %s", e.Type)
+	}
+	if len(e.Alternatives) > 0 {
+		names := make([]string, len(e.Alternatives))
+		for i, t := range e.Alternatives {
+			names[i] = t.String()
+		}
+		s += " (also " + strings.Join(names, ", ") + ")"
+	}
+	return s
+}
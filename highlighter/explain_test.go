@@ -0,0 +1,52 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestExplain(t *testing.T) {
+	h := New()
+	got := StripANSI(h.Explain("interface Gi0/1\n shutdown\n"))
+
+	want := []string{
+		"interface Gi0/1",
+		"  interface  Command        commands word list",
+		"  Gi0/1      Interface      interface pattern",
+		" shutdown",
+		"  shutdown  Command        commands word list",
+	}
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), got)
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(lines[i], w) {
+			t.Errorf("line %d = %q, want prefix %q", i+1, lines[i], w)
+		}
+	}
+
+	if got := h.Explain(""); got != "" {
+		t.Errorf("Explain(\"\") = %q", got)
+	}
+	if h.Explaining() {
+		t.Error("Explain should not turn on SetExplain")
+	}
+}
+
+func TestExplanation(t *testing.T) {
+	// Types changed after lexing show the lexer's type
+	h := New(WithFocus(lexer.CategoryAddress), WithExplain())
+	if !h.Explaining() {
+		t.Fatal("WithExplain should turn explanations on")
+	}
+	tokens := h.Tokens("interface Gi0/1\n")
+	if len(tokens) == 0 || tokens[0].Explain == nil {
+		t.Fatalf("tokens not explained: %+v", tokens)
+	}
+	if got := explanation(tokens[0]); !strings.Contains(got, "then changed from Command") {
+		t.Errorf("explanation(%+v) = %q, want the lexer's type", tokens[0], got)
+	}
+}
@@ -0,0 +1,116 @@
+package highlighter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// span is the columns [start, end) a token covers on its line.
+type span struct {
+	start, end int
+}
+
+func tokenSpan(tok lexer.Token) span {
+	return span{tok.Column, tok.EndColumn()}
+}
+
+func (s span) overlaps(o span) bool {
+	return s.start < o.end && o.start < s.end
+}
+
+// SetFlapThreshold highlights neighbor uptimes shorter than d in the warning
+// color, so recently flapped BGP and OSPF sessions stand out even when their
+// state is good. It applies to the Up/Down column of neighbor tables and to
+// "up for" durations in detail output. Pass 0 to turn it off.
+func (h *Highlighter) SetFlapThreshold(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.flapThreshold = d
+}
+
+// FlapThreshold returns the uptime below which neighbors are highlighted as
+// recently flapped, or 0 if this is off.
+func (h *Highlighter) FlapThreshold() time.Duration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.flapThreshold
+}
+
+// applyFlapEmphasis reclassifies neighbor uptimes below the flap threshold as
+// warnings. The position of the uptime column is remembered across calls, so
+// a table header and its rows may be highlighted separately.
+func (h *Highlighter) applyFlapEmphasis(tokens []lexer.Token) []lexer.Token {
+	h.mu.RLock()
+	threshold, column := h.flapThreshold, h.uptimeColumn
+	h.mu.RUnlock()
+	if threshold <= 0 {
+		return tokens
+	}
+
+	out := make([]lexer.Token, len(tokens))
+	copy(out, tokens)
+	var prev, prevPrev string // previous two words on the line (lowercase)
+	for i, tok := range out {
+		switch {
+		case strings.Contains(tok.Value, "\n"):
+			prev, prevPrev = "", ""
+			continue
+		case strings.TrimSpace(tok.Value) == "":
+			continue
+		case tok.Type == lexer.TokenPromptHost:
+			column = span{}
+		case tok.Type == lexer.TokenTimeDuration:
+			inColumn := column != span{} && tokenSpan(tok).overlaps(column)
+			upFor := prevPrev == "up" && prev == "for"
+			if inColumn || upFor {
+				if d, err := lexer.ParseCiscoDuration(tok.Value); err == nil && d < threshold {
+					out[i].Type = lexer.TokenStateWarning
+				}
+			}
+		case tok.Type == lexer.TokenColumnHeader || isHeaderContinuation(out, i):
+			// Each table header replaces the column of the last one. A header
+			// line on its own may only have some of its words recognized, so
+			// the uptime header counts when any earlier word on the line is one.
+			if tok.Type == lexer.TokenColumnHeader && !isHeaderContinuation(out, i) {
+				column = span{}
+			}
+			if s, ok := uptimeHeader(out, i); ok {
+				column = s
+			}
+		}
+		prevPrev, prev = prev, strings.ToLower(strings.TrimRight(tok.Value, ","))
+	}
+
+	h.mu.Lock()
+	h.uptimeColumn = column
+	h.mu.Unlock()
+	return out
+}
+
+// uptimeHeader returns the columns of the uptime header starting at
+// tokens[i]: "Up/Down", "Uptime" or "Up Time".
+func uptimeHeader(tokens []lexer.Token, i int) (span, bool) {
+	tok := tokens[i]
+	switch strings.ToLower(tok.Value) {
+	case "up/down", "uptime":
+		return tokenSpan(tok), true
+	case "up":
+		if i+2 < len(tokens) && strings.EqualFold(tokens[i+2].Value, "time") {
+			return span{tok.Column, tokenSpan(tokens[i+2]).end}, true
+		}
+	}
+	return span{}, false
+}
+
+// isHeaderContinuation reports whether an earlier token on the line of
+// tokens[i] is also a column header.
+func isHeaderContinuation(tokens []lexer.Token, i int) bool {
+	for j := i - 1; j >= 0 && tokens[j].Line == tokens[i].Line; j-- {
+		if tokens[j].Type == lexer.TokenColumnHeader {
+			return true
+		}
+	}
+	return false
+}
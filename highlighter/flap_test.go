@@ -0,0 +1,100 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+const flapBGPSummary = `Neighbor        V           AS MsgRcvd MsgSent   TblVer  InQ OutQ Up/Down  State/PfxRcd
+10.0.0.2        4        65002     100     101       10    0    0 1d02h           5
+10.0.0.3        4        65003      12      14       10    0    0 00:12:31        7
+`
+
+// durationTypes returns the type of each token that the lexer classified as
+// a duration, after the highlighter's processing.
+func durationTypes(h *Highlighter, input string) map[string]lexer.TokenType {
+	types := make(map[string]lexer.TokenType)
+	raw := lexer.New(input)
+	raw.SetParseMode(lexer.ParseModeShow)
+	tokens := raw.Tokenize()
+	processed := h.processTokens(tokens)
+	for i, tok := range tokens {
+		if tok.Type == lexer.TokenTimeDuration {
+			types[tok.Value] = processed[i].Type
+		}
+	}
+	return types
+}
+
+func TestFlapThreshold(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]lexer.TokenType
+	}{
+		{
+			name:  "bgp summary",
+			input: flapBGPSummary,
+			expected: map[string]lexer.TokenType{
+				"1d02h":    lexer.TokenTimeDuration,
+				"00:12:31": lexer.TokenStateWarning,
+			},
+		},
+		{
+			name: "ospf dead time is not an uptime",
+			input: "Neighbor ID     Pri   State           Dead Time   Address         Interface\n" +
+				"10.0.0.2          1   FULL/DR         00:00:38    10.1.1.2        Gi0/0\n",
+			expected: map[string]lexer.TokenType{"00:00:38": lexer.TokenTimeDuration},
+		},
+		{
+			name: "nx-os up time column",
+			input: " Neighbor ID     Pri State            Up Time  Address         Interface\n" +
+				" 10.0.0.2          1 FULL/DR          00:05:12 10.1.1.2        Eth1/1\n" +
+				" 10.0.0.3          1 FULL/BDR         3w2d     10.1.2.2        Eth1/2\n",
+			expected: map[string]lexer.TokenType{
+				"00:05:12": lexer.TokenStateWarning,
+				"3w2d":     lexer.TokenTimeDuration,
+			},
+		},
+		{
+			name:     "bgp neighbor detail",
+			input:    "  BGP state = Established, up for 00:40:02\n  Last read 00:00:12\n",
+			expected: map[string]lexer.TokenType{"00:40:02": lexer.TokenStateWarning, "00:00:12": lexer.TokenTimeDuration},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(WithFlapThreshold(time.Hour))
+			got := durationTypes(h, tt.input)
+			for value, want := range tt.expected {
+				if got[value] != want {
+					t.Errorf("%s: got %v, want %v", value, got[value], want)
+				}
+			}
+		})
+	}
+}
+
+func TestFlapThresholdAcrossCalls(t *testing.T) {
+	h := New(WithFlapThreshold(time.Hour), WithMode(lexer.ParseModeShow), WithDetection(false))
+	theme := h.theme
+
+	// Streamed line by line, the rows still line up with the header
+	var lines []string
+	for _, line := range strings.SplitAfter(flapBGPSummary, "\n") {
+		lines = append(lines, h.Highlight(line))
+	}
+	want := theme.GetColor(lexer.TokenStateWarning) + "00:12:31" + Reset
+	if !strings.Contains(lines[2], want) {
+		t.Errorf("recent uptime not highlighted: %q", lines[2])
+	}
+
+	h.SetFlapThreshold(0)
+	if got := h.Highlight(strings.SplitAfter(flapBGPSummary, "\n")[2]); strings.Contains(got, want) {
+		t.Errorf("flap emphasis not turned off: %q", got)
+	}
+}
@@ -0,0 +1,52 @@
+package highlighter
+
+import (
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// SetFocus shows only tokens of the given categories in full color and dims
+// everything else, e.g. lexer.CategoryAddress and lexer.CategoryName to scan
+// long output for addressing, or lexer.CategoryState for status. Call it
+// without categories to turn focus off.
+func (h *Highlighter) SetFocus(categories ...lexer.Category) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.focus = append([]lexer.Category(nil), categories...)
+}
+
+// Focus returns the categories set with SetFocus, nil when focus is off.
+func (h *Highlighter) Focus() []lexer.Category {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.focus) == 0 {
+		return nil
+	}
+	return append([]lexer.Category(nil), h.focus...)
+}
+
+// applyFocus reclassifies tokens outside the focused categories as stale, so
+// they are dimmed. It runs after the token hook, which still sees the
+// tokens' own types.
+func (h *Highlighter) applyFocus(tokens []lexer.Token) []lexer.Token {
+	h.mu.RLock()
+	focus := h.focus
+	h.mu.RUnlock()
+	if len(focus) == 0 {
+		return tokens
+	}
+
+	focused := make(map[lexer.Category]bool, len(focus))
+	for _, c := range focus {
+		focused[c] = true
+	}
+	out := make([]lexer.Token, len(tokens))
+	copy(out, tokens)
+	for i, tok := range out {
+		if !focused[tok.Type.Category()] && strings.TrimSpace(tok.Value) != "" {
+			out[i].Type = lexer.TokenStale
+		}
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package highlighter
+
+import (
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestFocus(t *testing.T) {
+	input := "interface GigabitEthernet0/1\n ip address 10.0.0.1 255.255.255.0\n shutdown\n"
+
+	var hooked []lexer.TokenType
+	h := New(
+		WithFocus(lexer.CategoryAddress, lexer.CategoryName),
+		WithTokenHook(func(tok lexer.Token) lexer.Token {
+			hooked = append(hooked, tok.Type)
+			return tok
+		}),
+	)
+	if got := h.Focus(); len(got) != 2 {
+		t.Fatalf("Focus() = %v, want 2 categories", got)
+	}
+
+	types := make(map[string]lexer.TokenType)
+	for _, tok := range h.tokenize(input) {
+		types[tok.Value] = tok.Type
+	}
+	tests := []struct {
+		value string
+		want  lexer.TokenType
+	}{
+		{"interface", lexer.TokenStale},
+		{"GigabitEthernet0/1", lexer.TokenInterface},
+		{"10.0.0.1", lexer.TokenIPv4},
+		{"255.255.255.0", lexer.TokenSubnetMask},
+		{"shutdown", lexer.TokenStale},
+		{" ", lexer.TokenText}, // blanks are left alone
+	}
+	for _, tt := range tests {
+		if got := types[tt.value]; got != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	for _, typ := range hooked {
+		if typ == lexer.TokenStale {
+			t.Error("the token hook should see tokens before focus dims them")
+			break
+		}
+	}
+
+	h.SetFocus()
+	if h.Focus() != nil {
+		t.Error("SetFocus() without categories should turn focus off")
+	}
+	for _, tok := range h.tokenize(input) {
+		if tok.Type == lexer.TokenStale {
+			t.Errorf("%q dimmed with focus off", tok.Value)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package highlighter
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// FoldMarker is printed after the header of a collapsed section, followed by
+// the number of hidden lines.
+const FoldMarker = "▸"
+
+// Section is a foldable block of configuration: an unindented line such as
+// "interface Gi0/1" or "router bgp 65000" followed by indented lines.
+type Section struct {
+	Line   int    // 1-based line number of the header
+	Header string // header line text
+	Hidden int    // number of indented lines under the header
+}
+
+// Folding renders configuration with sections collapsed to their header
+// line. Sections start collapsed; expand them by header line number with
+// Expand or Toggle and call Render again. A Folding is not safe for
+// concurrent use.
+type Folding struct {
+	h        *Highlighter
+	lines    [][]lexer.Token
+	sections []Section
+	index    map[int]int // header line -> position in sections
+	expanded map[int]bool
+}
+
+// Fold tokenizes input and returns a Folding of its sections, all collapsed.
+// ANSI codes are removed first, and pagination too if enabled. Input is
+// always tokenized, as in HighlightForced.
+func (h *Highlighter) Fold(input string) *Folding {
+	cleaned := StripANSI(input)
+	if h.RemovePagination() {
+		cleaned = StripPagination(cleaned)
+	}
+
+	f := &Folding{
+		h:        h,
+		sections: findSections(cleaned),
+		index:    make(map[int]int),
+		expanded: make(map[int]bool),
+	}
+	if cleaned != "" {
+		f.lines = splitTokenLines(h.processTokens(h.lex(h.newLexer(cleaned))))
+	}
+	for i, s := range f.sections {
+		f.index[s.Line] = i
+	}
+	return f
+}
+
+// findSections returns the sections of input in order.
+func findSections(input string) []Section {
+	var sections []Section
+	lines := strings.Split(input, "\n")
+	for i := 0; i < len(lines); i++ {
+		header := strings.TrimRight(lines[i], " \t\r")
+		if header == "" || isIndented(header) {
+			continue
+		}
+		n := 0
+		for i+n+1 < len(lines) && isIndented(lines[i+n+1]) {
+			n++
+		}
+		if n > 0 {
+			sections = append(sections, Section{Line: i + 1, Header: header, Hidden: n})
+			i += n
+		}
+	}
+	return sections
+}
+
+func isIndented(line string) bool {
+	return strings.TrimSpace(line) != "" && (line[0] == ' ' || line[0] == '\t')
+}
+
+// Sections returns the foldable sections in order.
+func (f *Folding) Sections() []Section {
+	return f.sections
+}
+
+// Expand shows the section whose header is on line. It reports whether
+// line starts a section.
+func (f *Folding) Expand(line int) bool {
+	return f.set(line, true)
+}
+
+// Collapse hides the body of the section whose header is on line. It reports
+// whether line starts a section.
+func (f *Folding) Collapse(line int) bool {
+	return f.set(line, false)
+}
+
+// Toggle expands the section whose header is on line if it is collapsed and
+// collapses it otherwise. It reports whether line starts a section.
+func (f *Folding) Toggle(line int) bool {
+	return f.set(line, !f.expanded[line])
+}
+
+// Expanded reports whether the section whose header is on line is expanded.
+func (f *Folding) Expanded(line int) bool {
+	return f.expanded[line]
+}
+
+// ExpandAll expands every section.
+func (f *Folding) ExpandAll() {
+	for _, s := range f.sections {
+		f.expanded[s.Line] = true
+	}
+}
+
+// CollapseAll collapses every section.
+func (f *Folding) CollapseAll() {
+	clear(f.expanded)
+}
+
+func (f *Folding) set(line int, expanded bool) bool {
+	if _, ok := f.index[line]; !ok {
+		return false
+	}
+	if expanded {
+		f.expanded[line] = true
+	} else {
+		delete(f.expanded, line)
+	}
+	return true
+}
+
+// Render returns the highlighted input with collapsed sections shown as their
+// header followed by FoldMarker and the number of hidden lines, e.g.
+// "interface Gi0/1 ▸ 5 lines". Output is plain text when highlighting is
+// disabled.
+func (f *Folding) Render() string {
+	h := f.h
+	h.mu.RLock()
+	theme := h.theme
+	h.mu.RUnlock()
+	enabled := h.IsEnabled()
+	markerColor := theme.GetColor(lexer.TokenComment)
+
+	var buf bytes.Buffer
+	for i := 0; i < len(f.lines); i++ {
+		line := f.lines[i]
+		hasNewline := len(line) > 0 && line[len(line)-1].Value == "\n"
+		if hasNewline {
+			line = line[:len(line)-1]
+		}
+		s, collapsed := f.index[i+1]
+		collapsed = collapsed && !f.expanded[i+1]
+		if collapsed {
+			// Keep the marker next to the text, and before any carriage return
+			for len(line) > 0 && strings.TrimSpace(line[len(line)-1].Value) == "" {
+				line = line[:len(line)-1]
+			}
+		}
+
+		for _, token := range line {
+			color := theme.GetColor(token.Type)
+			if enabled && color != "" {
+				buf.WriteString(color)
+				buf.WriteString(token.Value)
+				buf.WriteString(Reset)
+			} else {
+				buf.WriteString(token.Value)
+			}
+		}
+
+		if collapsed {
+			hidden := f.sections[s].Hidden
+			marker := FoldMarker + " " + strconv.Itoa(hidden) + " line"
+			if hidden != 1 {
+				marker += "s"
+			}
+			buf.WriteByte(' ')
+			if enabled && markerColor != "" {
+				buf.WriteString(markerColor + marker + Reset)
+			} else {
+				buf.WriteString(marker)
+			}
+			i += hidden
+			// The last hidden line carries the newline, if any
+			if i < len(f.lines) {
+				last := f.lines[i]
+				hasNewline = len(last) > 0 && last[len(last)-1].Value == "\n"
+			} else {
+				hasNewline = false
+			}
+		}
+
+		if hasNewline {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,76 @@
+package highlighter
+
+import (
+	"reflect"
+	"testing"
+)
+
+const foldConfig = `hostname R1
+!
+interface GigabitEthernet0/1
+ description uplink
+ ip address 10.0.0.1 255.255.255.0
+ no shutdown
+!
+router bgp 65000
+ neighbor 10.0.0.2 remote-as 65001
+!
+`
+
+func TestFoldSections(t *testing.T) {
+	f := New().Fold(foldConfig)
+	expected := []Section{
+		{Line: 3, Header: "interface GigabitEthernet0/1", Hidden: 3},
+		{Line: 8, Header: "router bgp 65000", Hidden: 1},
+	}
+	if got := f.Sections(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Sections() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestFoldRender(t *testing.T) {
+	h := New()
+	f := h.Fold(foldConfig)
+
+	collapsed := "hostname R1\n!\ninterface GigabitEthernet0/1 ▸ 3 lines\n!\nrouter bgp 65000 ▸ 1 line\n!\n"
+	if got := StripANSI(f.Render()); got != collapsed {
+		t.Errorf("collapsed = %q, want %q", got, collapsed)
+	}
+
+	if !f.Expand(3) {
+		t.Fatal("Expand(3) should find the interface section")
+	}
+	if f.Expand(4) {
+		t.Error("Expand(4) should not find a section")
+	}
+	expanded := "hostname R1\n!\ninterface GigabitEthernet0/1\n description uplink\n ip address 10.0.0.1 255.255.255.0\n no shutdown\n!\nrouter bgp 65000 ▸ 1 line\n!\n"
+	if got := StripANSI(f.Render()); got != expanded {
+		t.Errorf("expanded = %q, want %q", got, expanded)
+	}
+
+	f.Toggle(3)
+	if f.Expanded(3) {
+		t.Error("Toggle should collapse an expanded section")
+	}
+
+	f.ExpandAll()
+	if got := StripANSI(f.Render()); got != foldConfig {
+		t.Errorf("ExpandAll = %q, want the input", got)
+	}
+	if got, want := f.Render(), h.HighlightForced(foldConfig); got != want {
+		t.Errorf("ExpandAll should render like HighlightForced\ngot:  %q\nwant: %q", got, want)
+	}
+
+	f.CollapseAll()
+	h.Disable()
+	if got := f.Render(); got != collapsed {
+		t.Errorf("disabled = %q, want %q", got, collapsed)
+	}
+}
+
+func TestFoldRenderCRLF(t *testing.T) {
+	f := New().Fold("interface Gi0/1\r\n shutdown\r\n")
+	if got, want := StripANSI(f.Render()), "interface Gi0/1 ▸ 1 line\n"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
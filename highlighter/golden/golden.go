@@ -0,0 +1,176 @@
+// Package golden renders a corpus of sample inputs in every built-in theme as
+// plain text golden files, so a change to a theme or to the lexer shows up in
+// review as a diff of the escape sequences it changes:
+//
+//	go test ./highlighter/golden            # compare with testdata/*.golden
+//	go test ./highlighter/golden -update    # regenerate them
+//
+// Rendering is deterministic: each sample is highlighted by a new
+// Highlighter in 24-bit color, whatever the terminal, and samples and themes
+// are always in the same order. Escape makes the output printable, one
+// golden line per line of input, and Unescape turns a golden file back into
+// something to cat to a terminal.
+package golden
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+)
+
+// corpusFiles holds the built-in corpus.
+//
+//go:embed corpus
+var corpusFiles embed.FS
+
+// Sample is an input of the corpus.
+type Sample struct {
+	Name  string          // file name, such as "bgp-summary.show"
+	Mode  lexer.ParseMode // named by the file extension, auto if it names none
+	Input string
+}
+
+// Corpus returns the built-in corpus: a running config, show output and a
+// session transcript, in the files of highlighter/golden/corpus.
+func Corpus() []Sample {
+	samples, err := LoadCorpus(corpusFiles, "corpus")
+	if err != nil {
+		panic(err)
+	}
+	return samples
+}
+
+// LoadCorpus reads every file in dir of fsys as a sample, sorted by name.
+// The file extension is the parse mode the sample is highlighted in: .config,
+// .show or .transcript (see lexer.ParseModeByName); other files are detected.
+func LoadCorpus(fsys fs.FS, dir string) ([]Sample, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var samples []Sample
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, Sample{
+			Name:  e.Name(),
+			Mode:  lexer.ParseModeByName(strings.TrimPrefix(path.Ext(e.Name()), ".")),
+			Input: string(data),
+		})
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Name < samples[j].Name })
+	return samples, nil
+}
+
+// Render returns s highlighted in theme, escaped.
+func Render(theme *highlighter.Theme, s Sample) string {
+	h := highlighter.New(
+		highlighter.WithTheme(theme),
+		highlighter.WithColorDepth(highlighter.TrueColor),
+		highlighter.WithMode(s.Mode),
+	)
+	return Escape(h.HighlightForced(s.Input))
+}
+
+// File returns the golden file of theme: each sample of corpus rendered under
+// a header line naming it and its mode.
+func File(theme *highlighter.Theme, corpus []Sample) []byte {
+	var buf strings.Builder
+	for i, s := range corpus {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		fmt.Fprintf(&buf, "=== %s (%s)\n", s.Name, s.Mode)
+		out := Render(theme, s)
+		buf.WriteString(out)
+		if !strings.HasSuffix(out, "\n") {
+			buf.WriteByte('\n')
+		}
+	}
+	return []byte(buf.String())
+}
+
+// Update writes the golden file of every theme in highlighter.ThemeNames to
+// dir, as <theme>.golden.
+func Update(dir string, corpus []Sample) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, name := range highlighter.ThemeNames() {
+		file := filepath.Join(dir, name+".golden")
+		if err := os.WriteFile(file, File(highlighter.ThemeByName(name), corpus), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check compares the golden files in dir with what Update would write, and
+// returns an error naming the first line that differs in each, or nil if
+// they all match.
+func Check(dir string, corpus []Sample) error {
+	var errs []error
+	for _, name := range highlighter.ThemeNames() {
+		file := filepath.Join(dir, name+".golden")
+		want, err := os.ReadFile(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := compare(file, string(File(highlighter.ThemeByName(name), corpus)), string(want)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// compare returns an error naming the first line where got and want differ.
+func compare(file, got, want string) error {
+	if got == want {
+		return nil
+	}
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(want, "\n")
+	for i := 0; ; i++ {
+		if i >= len(gotLines) || i >= len(wantLines) || gotLines[i] != wantLines[i] {
+			return fmt.Errorf("%s:%d: got\n\t%s\nwant\n\t%s", file, i+1, line(gotLines, i), line(wantLines, i))
+		}
+	}
+}
+
+// line returns lines[i], or a note that there is none.
+func line(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return "(end of file)"
+}
+
+// Escape makes highlighted output printable: the escape character becomes
+// `\e` and backslashes are doubled.
+func Escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// Unescape reverses Escape.
+func Unescape(s string) string {
+	return unescaper.Replace(s)
+}
+
+var (
+	escaper   = strings.NewReplacer(`\`, `\\`, "\033", `\e`)
+	unescaper = strings.NewReplacer(`\\`, `\`, `\e`, "\033")
+)
@@ -0,0 +1,101 @@
+package golden
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestGolden(t *testing.T) {
+	corpus := Corpus()
+	if *update {
+		if err := Update("testdata", corpus); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	if err := Check("testdata", corpus); err != nil {
+		t.Errorf("%v\nIf the change is intended, run: go test ./highlighter/golden -update", err)
+	}
+}
+
+func TestLoadCorpus(t *testing.T) {
+	fsys := fstest.MapFS{
+		"c/b.show":       {Data: []byte("Interface  Status\n")},
+		"c/a.config":     {Data: []byte("hostname R1\n")},
+		"c/c.txt":        {Data: []byte("R1#\n")},
+		"c/sub/d.config": {Data: []byte("end\n")},
+	}
+	samples, err := LoadCorpus(fsys, "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Sample{
+		{"a.config", lexer.ParseModeConfig, "hostname R1\n"},
+		{"b.show", lexer.ParseModeShow, "Interface  Status\n"},
+		{"c.txt", lexer.ParseModeAuto, "R1#\n"},
+	}
+	if len(samples) != len(want) {
+		t.Fatalf("got %d samples, want %d", len(samples), len(want))
+	}
+	for i := range want {
+		if samples[i] != want[i] {
+			t.Errorf("sample %d = %+v, want %+v", i, samples[i], want[i])
+		}
+	}
+
+	if _, err := LoadCorpus(fsys, "missing"); err == nil {
+		t.Error("a missing corpus should be an error")
+	}
+}
+
+func TestRenderDeterministic(t *testing.T) {
+	theme := highlighter.DefaultTheme()
+	for _, s := range Corpus() {
+		first := Render(theme, s)
+		if strings.Contains(first, "\033") {
+			t.Errorf("%s: rendering should be escaped", s.Name)
+		}
+		if Render(theme, s) != first {
+			t.Errorf("%s: rendering should not change between runs", s.Name)
+		}
+		if got := highlighter.StripANSI(Unescape(first)); got != s.Input {
+			t.Errorf("%s: unescaped rendering should highlight the input, got %q", s.Name, got)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	for _, s := range []string{"", `as-path ^65000\_`, "\033[1mR1\033[0m#", `\e`} {
+		if got := Unescape(Escape(s)); got != s {
+			t.Errorf("Unescape(Escape(%q)) = %q", s, got)
+		}
+	}
+	if got := Escape("\033[1mR1\033[0m"); got != `\e[1mR1\e[0m` {
+		t.Errorf("Escape = %q", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	corpus := Corpus()[:1]
+	if err := Check(dir, corpus); err == nil {
+		t.Error("missing golden files should fail the check")
+	}
+	if err := Update(dir, corpus); err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(dir, corpus); err != nil {
+		t.Errorf("freshly updated golden files should match: %v", err)
+	}
+	corpus[0].Input += "interface Loopback1\n"
+	if err := Check(dir, corpus); err == nil || !strings.Contains(err.Error(), ".golden:") {
+		t.Errorf("a changed rendering should be reported with its line, got %v", err)
+	}
+}
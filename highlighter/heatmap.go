@@ -0,0 +1,103 @@
+package highlighter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Heatmap colors the counters in per-interface tables such as "show interface
+// counters errors" and "show interface summary" by value: zero is dimmed,
+// counts from Warning up are shown in the warning color and counts from
+// Critical up in the bad-state color. A row is treated as counters when it
+// starts with an interface name followed only by numbers.
+type Heatmap struct {
+	Warning  uint64 // smallest count in the warning color; 0 is treated as 1
+	Critical uint64 // smallest count in the bad-state color
+}
+
+// DefaultHeatmap flags any non-zero counter, and counts of 1000 or more as critical.
+func DefaultHeatmap() *Heatmap {
+	return &Heatmap{Warning: 1, Critical: 1000}
+}
+
+// SetHeatmap enables counter heatmap coloring with the given thresholds.
+// Pass nil to disable it.
+func (h *Highlighter) SetHeatmap(heatmap *Heatmap) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.heatmap = heatmap
+}
+
+// Heatmap returns the heatmap thresholds, or nil if heatmap coloring is off.
+func (h *Highlighter) Heatmap() *Heatmap {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.heatmap
+}
+
+// classify returns the token type for a counter value.
+func (hm *Heatmap) classify(n uint64) lexer.TokenType {
+	switch {
+	case n == 0:
+		return lexer.TokenStateNeutral
+	case n >= hm.Critical:
+		return lexer.TokenStateBad
+	case n >= max(hm.Warning, 1):
+		return lexer.TokenStateWarning
+	default:
+		return lexer.TokenNumber
+	}
+}
+
+// applyHeatmap reclassifies the numbers on counter rows by value.
+func (h *Highlighter) applyHeatmap(tokens []lexer.Token) []lexer.Token {
+	hm := h.Heatmap()
+	if hm == nil {
+		return tokens
+	}
+
+	out := make([]lexer.Token, len(tokens))
+	copy(out, tokens)
+	for start := 0; start < len(out); {
+		end := start
+		for end < len(out) && out[end].Line == out[start].Line {
+			end++
+		}
+		if isCounterRow(out[start:end]) {
+			for i := start; i < end; i++ {
+				if out[i].Type != lexer.TokenNumber {
+					continue
+				}
+				if n, err := strconv.ParseUint(out[i].Value, 10, 64); err == nil {
+					out[i].Type = hm.classify(n)
+				}
+			}
+		}
+		start = end
+	}
+	return out
+}
+
+// isCounterRow reports whether a line's tokens are an interface name
+// (optionally after a status marker such as "*") followed only by numbers.
+func isCounterRow(line []lexer.Token) bool {
+	seenInterface := false
+	numbers := 0
+	for _, tok := range line {
+		switch {
+		case strings.TrimSpace(tok.Value) == "":
+			continue
+		case !seenInterface && numbers == 0 && tok.Type == lexer.TokenStatusSymbol:
+			continue
+		case !seenInterface && tok.Type == lexer.TokenInterface:
+			seenInterface = true
+		case seenInterface && tok.Type == lexer.TokenNumber:
+			numbers++
+		default:
+			return false
+		}
+	}
+	return numbers >= 2
+}
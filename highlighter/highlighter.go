@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/lasseh/cink/lexer"
 )
@@ -17,32 +18,147 @@ func Highlight(input string) string {
 // It supports multiple color themes and can be toggled on/off at runtime.
 // All methods are safe for concurrent use.
 type Highlighter struct {
-	theme   *Theme
-	enabled bool
-	mu      sync.RWMutex
-}
-
-// New creates a new Highlighter with the default theme (Tokyo Night).
-func New() *Highlighter {
-	return &Highlighter{
-		theme:   DefaultTheme(),
-		enabled: true,
+	theme         *Theme // sourceTheme converted to colorDepth
+	sourceTheme   *Theme
+	colorDepth    ColorDepth
+	dialect       lexer.Dialect
+	parseMode     lexer.ParseMode
+	enabled       bool
+	alwaysOn      bool // skip detection in Highlight
+	minConfidence int  // minimum detection score to treat input as Cisco
+	tokenHook     TokenHook
+	commandHook   lexer.CommandHook
+	removePager   bool             // strip --More-- artifacts before tokenizing
+	heatmap       *Heatmap         // counter table coloring, nil when off
+	host          string           // hostname from the last prompt seen
+	markHost      bool             // highlight the hostname wherever it appears
+	learnNames    bool             // color names defined in the input and references to them
+	names         []string         // names learned so far, across calls
+	redactor      *Anonymizer      // scrubs input before tokenizing, nil when off
+	flapThreshold time.Duration    // warn about neighbor uptimes below this, 0 when off
+	uptimeColumn  span             // uptime column of the last neighbor table seen
+	lsaFresh      time.Duration    // warn about LSA ages below this, 0 when off
+	lsaOld        time.Duration    // dim LSAs at least this old, 0 when off
+	lsaAgeColumn  span             // age column of the last OSPF database table seen
+	poolWarning   float64          // warn about DHCP pools this full (percent), 0 when off
+	poolCritical  float64          // flag DHCP pools this full (percent), 0 when off
+	pool          poolState        // DHCP pool being shown
+	usageWarning  float64          // warn about percentages this high, 0 when off
+	usageCritical float64          // flag percentages this high, 0 when off
+	routeExpect   routeRules       // expected route counts by source, nil when off
+	routeColumns  [2]span          // Networks and Subnets columns of the last route summary
+	detectCache   *detectionCache  // recent detection results, nil when off
+	pinDetection  bool             // stop detecting once Cisco content is seen
+	pinned        bool             // Cisco content seen while pinning
+	indentGuides  bool             // draw guides in the indentation of nested lines
+	compact       bool             // coalesce adjacent tokens of the same style
+	alignColumns  bool             // keep table columns aligned when values change width
+	focus         []lexer.Category // categories shown in full color, nil when off
+	explain       bool             // attach explanations to tokens
+	sectionStack  []int            // indentation of the enclosing section lines
+	lineNumbers   bool             // prefix each line with its number
+	linesNumbered int              // lines numbered so far, across calls
+	midLine       bool             // the last numbered input ended without a newline
+	stateWords    lexer.StateWords // state list overrides, copied on write
+	filterLogs    bool             // hide syslog messages less severe than logSeverity
+	logSeverity   int              // least severe syslog message shown
+	suppressed    int              // syslog messages hidden since the last line shown
+	inMessage     bool             // the last line highlighted was a hidden message
+	midMessage    bool             // and it was cut off at the end of the input
+	mu            sync.RWMutex
+}
+
+// TokenHook is called for every token before it is rendered. It may change the
+// token's Type or Value; returning a token with an empty Value suppresses it.
+type TokenHook func(lexer.Token) lexer.Token
+
+// New creates a new Highlighter configured by opts. Without options it uses
+// the default theme (Tokyo Night) and detects the dialect, the parse mode and
+// whether input is Cisco at all:
+//
+//	hl := highlighter.New(
+//		highlighter.WithTheme(highlighter.NordTheme()),
+//		highlighter.WithMode(lexer.ParseModeShow),
+//		highlighter.WithColorDepth(highlighter.Colors256),
+//	)
+func New(opts ...Option) *Highlighter {
+	h := &Highlighter{
+		sourceTheme:   DefaultTheme(),
+		dialect:       lexer.DialectAuto,
+		enabled:       true,
+		minConfidence: DefaultMinConfidence,
+		usageWarning:  DefaultPercentWarning,
+		usageCritical: DefaultPercentCritical,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.theme = h.sourceTheme.WithColorDepth(h.colorDepth)
+	return h
 }
 
-// NewWithTheme creates a new Highlighter with a specific theme
+// NewWithTheme creates a new Highlighter with a specific theme.
+// It is equivalent to New(WithTheme(theme)).
 func NewWithTheme(theme *Theme) *Highlighter {
-	return &Highlighter{
-		theme:   theme,
-		enabled: true,
-	}
+	return New(WithTheme(theme))
 }
 
 // SetTheme changes the highlighting theme.
 func (h *Highlighter) SetTheme(theme *Theme) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	h.theme = theme
+	h.sourceTheme = theme
+	h.theme = theme.WithColorDepth(h.colorDepth)
+}
+
+// SetDialect changes the dialect used for tokenization.
+func (h *Highlighter) SetDialect(dialect lexer.Dialect) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.dialect = dialect
+}
+
+// Dialect returns the dialect used for tokenization.
+func (h *Highlighter) Dialect() lexer.Dialect {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.dialect
+}
+
+// SetParseMode forces config or show classification for all input.
+// ParseModeAuto (the default) detects the mode per input.
+func (h *Highlighter) SetParseMode(mode lexer.ParseMode) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.parseMode = mode
+}
+
+// ParseMode returns the configured parse mode.
+func (h *Highlighter) ParseMode() lexer.ParseMode {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.parseMode
+}
+
+// SetTokenHook installs a hook that can reclassify, rewrite or suppress tokens
+// before rendering. Pass nil to remove it. The hook may be called concurrently
+// by HighlightParallel.
+func (h *Highlighter) SetTokenHook(hook TokenHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tokenHook = hook
+}
+
+// SetCommandHook installs a hook called with each command typed at a prompt
+// in highlighted input, such as "show ip route" after "core-rtr-01#", so
+// session recorders can index a capture by command (see
+// lexer.SetCommandHook). Line numbers count from the start of each input
+// highlighted. Pass nil to remove it. The hook may be called concurrently by
+// HighlightParallel.
+func (h *Highlighter) SetCommandHook(hook lexer.CommandHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.commandHook = hook
 }
 
 // Enable turns highlighting on.
@@ -76,7 +192,8 @@ func (h *Highlighter) Toggle() bool {
 
 // Highlight applies syntax highlighting to the input text.
 // Returns input unchanged if highlighting is disabled, input is empty,
-// or input doesn't look like Cisco config/output (uses heuristic detection).
+// or input doesn't look like Cisco config/output (uses heuristic detection,
+// see Detect, SetAlwaysHighlight and SetMinConfidence).
 func (h *Highlighter) Highlight(input string) string {
 	if !h.IsEnabled() || input == "" {
 		return input
@@ -84,7 +201,13 @@ func (h *Highlighter) Highlight(input string) string {
 
 	cleaned := StripANSI(input)
 
-	if !h.looksLikeCisco(cleaned) {
+	if !h.AlwaysHighlight() && !h.looksLikeCisco(cleaned) {
+		if h.LineNumbers() {
+			return h.numberLines(h.redact(cleaned))
+		}
+		if h.Redaction() != nil {
+			return h.redact(cleaned)
+		}
 		return input
 	}
 
@@ -115,20 +238,120 @@ func (h *Highlighter) highlightTokens(input string) string {
 	return buf.String()
 }
 
+// newLexer creates a lexer for input configured with the highlighter's
+// dialect, parse mode, state words and current hostname, redacting input first if enabled
+func (h *Highlighter) newLexer(input string) *lexer.Lexer {
+	h.mu.RLock()
+	dialect, mode := h.dialect, h.parseMode
+	host, markHost := h.host, h.markHost
+	states := h.stateWords
+	commandHook := h.commandHook
+	explain := h.explain
+	learnNames, names := h.learnNames, h.names
+	h.mu.RUnlock()
+
+	lex := lexer.New(h.redact(input))
+	lex.SetDialect(dialect)
+	if mode != lexer.ParseModeAuto {
+		lex.SetParseMode(mode)
+	}
+	lex.SetHost(host)
+	lex.SetHighlightHost(markHost)
+	lex.SetStateWords(states)
+	lex.SetCommandHook(commandHook)
+	lex.SetExplain(explain)
+	if learnNames {
+		lex.SetLearnedNames(names)
+	}
+	return lex
+}
+
+// lex tokenizes input and remembers the hostname of the last prompt in it
+// and the names it defines, so prompts and output that arrive in separate
+// calls stay connected.
+func (h *Highlighter) lex(lex *lexer.Lexer) []lexer.Token {
+	tokens := lex.Tokenize()
+	if host := lex.CurrentHost(); host != "" {
+		h.mu.Lock()
+		h.host = host
+		h.mu.Unlock()
+	}
+	if h.LearnNames() {
+		names := lex.LearnedNames()
+		h.mu.Lock()
+		h.names = names
+		h.mu.Unlock()
+	}
+	return tokens
+}
+
 // highlightTokensCleaned tokenizes and colorizes already-cleaned input
 func (h *Highlighter) highlightTokensCleaned(cleaned string) string {
-	lex := lexer.New(cleaned)
-	tokens := lex.Tokenize()
-	return h.renderTokens(tokens)
+	if h.RemovePagination() {
+		cleaned = StripPagination(cleaned)
+	}
+	lex := h.newLexer(cleaned)
+	tokens := h.lex(lex)
+	return h.renderTokens(tokens, h.themeFor(lex))
+}
+
+// tokenize returns the tokens for already-cleaned input with pagination
+// removal and the token hook applied, for renderers other than ANSI.
+func (h *Highlighter) tokenize(cleaned string) []lexer.Token {
+	tokens, _ := h.tokenizeThemed(cleaned)
+	return tokens
+}
+
+// tokenizeThemed is tokenize that also returns the theme variant for the
+// dialect and parse mode the input was tokenized in.
+func (h *Highlighter) tokenizeThemed(cleaned string) ([]lexer.Token, *Theme) {
+	if h.RemovePagination() {
+		cleaned = StripPagination(cleaned)
+	}
+	lex := h.newLexer(cleaned)
+	return h.processTokens(h.lex(lex)), h.themeFor(lex)
+}
+
+// Tokens returns the tokens Highlight would color for input, with ANSI codes
+// and pagination removed and redaction, heatmap coloring, flap emphasis and
+// the token hook applied. Input is always tokenized, as in HighlightForced.
+// Use it with Theme to render through something other than ANSI escapes.
+func (h *Highlighter) Tokens(input string) []lexer.Token {
+	if input == "" {
+		return nil
+	}
+	return h.tokenize(StripANSI(input))
+}
+
+// Theme returns the theme in use, converted to the configured color depth.
+func (h *Highlighter) Theme() *Theme {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.theme
+}
+
+// themeFor returns the theme variant for the dialect and parse mode lex
+// tokenized its input in, once it has.
+func (h *Highlighter) themeFor(lex *lexer.Lexer) *Theme {
+	return h.Theme().Variant(lex.GetDialect().Name(), lex.GetParseMode())
 }
 
 // renderTokens applies theme colors to a slice of tokens and returns the colorized string
-func (h *Highlighter) renderTokens(tokens []lexer.Token) string {
+func (h *Highlighter) renderTokens(tokens []lexer.Token, theme *Theme) string {
+	return h.writeTokens(h.processTokens(tokens), theme)
+}
+
+// writeTokens colors tokens that have already been through processTokens.
+func (h *Highlighter) writeTokens(tokens []lexer.Token, theme *Theme) string {
 	h.mu.RLock()
-	theme := h.theme
+	compact := h.compact
 	h.mu.RUnlock()
 
 	var buf bytes.Buffer
+	if compact {
+		renderCompact(&buf, tokens, theme)
+		return buf.String()
+	}
 	for _, token := range tokens {
 		color := theme.GetColor(token.Type)
 		if color != "" {
@@ -142,6 +365,33 @@ func (h *Highlighter) renderTokens(tokens []lexer.Token) string {
 	return buf.String()
 }
 
+// processTokens applies log severity filtering, heatmap coloring, flap
+// emphasis, LSA aging, DHCP pool usage, percentage thresholds, route count
+// expectations, indent guides, the token hook, column alignment, focus and
+// line numbers to lexer output.
+func (h *Highlighter) processTokens(tokens []lexer.Token) []lexer.Token {
+	return h.applyLineNumbers(h.applyFocus(h.applyColumnAlignment(h.applyTokenHook(h.applyIndentGuides(h.applyRouteExpectations(h.applyPercentThresholds(h.applyPoolUsage(h.applyLSAAging(h.applyFlapEmphasis(h.applyHeatmap(h.applyLogSeverity(tokens))))))))))))
+}
+
+// applyTokenHook runs the token hook over tokens, dropping suppressed ones.
+func (h *Highlighter) applyTokenHook(tokens []lexer.Token) []lexer.Token {
+	h.mu.RLock()
+	hook := h.tokenHook
+	h.mu.RUnlock()
+
+	if hook == nil {
+		return tokens
+	}
+	out := make([]lexer.Token, 0, len(tokens))
+	for _, token := range tokens {
+		token = hook(token)
+		if token.Value != "" {
+			out = append(out, token)
+		}
+	}
+	return out
+}
+
 // HighlightLines highlights multiple lines preserving line structure
 func (h *Highlighter) HighlightLines(lines []string) []string {
 	result := make([]string, len(lines))
@@ -161,33 +411,20 @@ var ciscoSpecificKeywords = []string{
 
 // looksLikeCisco performs a quick check to see if text appears to be Cisco config or show output
 func (h *Highlighter) looksLikeCisco(input string) bool {
-	// Check for Cisco CLI prompts
-	if isPromptLine(input) {
-		return true
-	}
-
-	lower := strings.ToLower(input)
-
-	if hasConfigIndicators(lower) {
-		return true
-	}
-
-	if hasShowIndicators(lower) {
-		return true
-	}
-
-	// Check for ! section separators (lines with just "!")
-	if hasCiscoSeparators(input) {
+	h.mu.RLock()
+	pinned := h.pinned
+	h.mu.RUnlock()
+	if pinned {
 		return true
 	}
 
-	// Check absence of JunOS indicators (helps disambiguate)
-	// If we see braces or semicolons, it's probably not Cisco
-	if hasCiscoKeywords(lower) {
-		return true
+	if !h.detect(input, false).IsCisco {
+		return false
 	}
-
-	return false
+	h.mu.Lock()
+	h.pinned = h.pinDetection
+	h.mu.Unlock()
+	return true
 }
 
 // isPromptLine checks if the input looks like a Cisco CLI prompt
@@ -232,24 +469,14 @@ func isValidHostname(s string) bool {
 	return true
 }
 
-// hasConfigIndicators checks for common Cisco config keywords/patterns
-func hasConfigIndicators(lower string) bool {
-	for _, indicator := range lexer.ConfigIndicators {
-		if strings.Contains(lower, indicator) {
-			return true
-		}
-	}
-	return false
+// countConfigIndicators counts common Cisco config keywords/patterns
+func countConfigIndicators(lower string) int {
+	return countContains(lower, lexer.ConfigIndicators)
 }
 
-// hasShowIndicators checks for show command output patterns
-func hasShowIndicators(lower string) bool {
-	for _, indicator := range lexer.ShowIndicators {
-		if strings.Contains(lower, indicator) {
-			return true
-		}
-	}
-	return false
+// countShowIndicators counts show command output patterns
+func countShowIndicators(lower string) int {
+	return countContains(lower, lexer.ShowIndicators)
 }
 
 // hasCiscoSeparators checks for ! section separators
@@ -279,14 +506,20 @@ func hasCiscoSeparators(input string) bool {
 	return false
 }
 
-// hasCiscoKeywords checks for Cisco-specific command patterns
-func hasCiscoKeywords(lower string) bool {
-	for _, kw := range ciscoSpecificKeywords {
-		if strings.Contains(lower, kw) {
-			return true
+// countCiscoKeywords counts Cisco-specific command patterns
+func countCiscoKeywords(lower string) int {
+	return countContains(lower, ciscoSpecificKeywords)
+}
+
+// countContains returns how many of the patterns occur in s
+func countContains(s string, patterns []string) int {
+	n := 0
+	for _, p := range patterns {
+		if strings.Contains(s, p) {
+			n++
 		}
 	}
-	return false
+	return n
 }
 
 // HighlightShowOutput highlights show command output specifically using show mode.
@@ -295,10 +528,10 @@ func (h *Highlighter) HighlightShowOutput(input string) string {
 		return input
 	}
 
-	lex := lexer.New(input)
+	lex := h.newLexer(input)
 	lex.SetParseMode(lexer.ParseModeShow)
-	tokens := lex.Tokenize()
-	return h.renderTokens(tokens)
+	tokens := h.lex(lex)
+	return h.renderTokens(tokens, h.themeFor(lex))
 }
 
 // segment represents either an escape sequence or text content
@@ -298,6 +298,8 @@ func TestAllThemes(t *testing.T) {
 		{"Dracula", DraculaTheme()},
 		{"Gruvbox", GruvboxDarkTheme()},
 		{"OneDark", OneDarkTheme()},
+		{"Colorblind", ColorblindTheme()},
+		{"HighContrast", HighContrastTheme()},
 	}
 
 	for _, tt := range themes {
@@ -315,6 +317,24 @@ func TestAllThemes(t *testing.T) {
 	}
 }
 
+func TestAccessibleThemesMarkBadStates(t *testing.T) {
+	// State must not be conveyed by hue alone
+	for _, theme := range []*Theme{ColorblindTheme(), HighContrastTheme()} {
+		good := theme.GetColor(lexer.TokenStateGood)
+		bad := theme.GetColor(lexer.TokenStateBad)
+		warning := theme.GetColor(lexer.TokenStateWarning)
+		if !strings.Contains(bad, Underline) {
+			t.Errorf("bad state should be underlined, got %q", bad)
+		}
+		if strings.Contains(good, Underline) {
+			t.Errorf("good state should not be underlined, got %q", good)
+		}
+		if !strings.Contains(warning, Italic) {
+			t.Errorf("warning state should be italic, got %q", warning)
+		}
+	}
+}
+
 func TestDefaultThemeIsTokyoNight(t *testing.T) {
 	defaultTheme := DefaultTheme()
 	tokyoTheme := TokyoNightTheme()
@@ -335,6 +355,53 @@ func TestThemeSetColor(t *testing.T) {
 	}
 }
 
+func TestThemeClone(t *testing.T) {
+	theme := DefaultTheme()
+	clone := theme.Clone()
+	clone.SetColor(lexer.TokenCommand, Red)
+
+	if theme.GetColor(lexer.TokenCommand) == Red {
+		t.Error("SetColor on a clone should not change the original")
+	}
+	if clone.GetColor(lexer.TokenInterface) != theme.GetColor(lexer.TokenInterface) {
+		t.Error("clone should keep the original colors")
+	}
+}
+
+func TestThemeMerge(t *testing.T) {
+	base := TokyoNightTheme()
+	theme := base.Clone()
+	theme.SetColor(lexer.TokenCommand, Red)
+	theme.Merge(Palette{StateGood: BrightGreen, Duration: BrightWhite})
+
+	tests := []struct {
+		tokenType lexer.TokenType
+		expected  string
+	}{
+		{lexer.TokenStateGood, Bold + BrightGreen},
+		{lexer.TokenPercentage, BrightGreen},
+		{lexer.TokenTimeDuration, BrightWhite},
+		{lexer.TokenUptime, BrightWhite},
+		{lexer.TokenStateBad, base.GetColor(lexer.TokenStateBad)},
+		{lexer.TokenCommand, Red},
+	}
+	for _, tt := range tests {
+		if got := theme.GetColor(tt.tokenType); got != tt.expected {
+			t.Errorf("%s = %q, want %q", tt.tokenType, got, tt.expected)
+		}
+	}
+
+	// A converted theme merges against the same palette
+	converted := TokyoNightTheme().WithColorDepth(Colors16)
+	converted.Merge(Palette{StateBad: Red})
+	if got := converted.GetColor(lexer.TokenStateBad); got != Bold+Red {
+		t.Errorf("converted StateBad = %q, want %q", got, Bold+Red)
+	}
+	if base.GetColor(lexer.TokenStateGood) == Bold+BrightGreen {
+		t.Error("Merge on a clone should not change the original")
+	}
+}
+
 func TestThemeGetColorUnknown(t *testing.T) {
 	theme := DefaultTheme()
 
@@ -418,3 +485,130 @@ func TestThemeByName(t *testing.T) {
 		t.Error("ThemeByName with unknown name should return default, not nil")
 	}
 }
+
+func TestSetTokenHook(t *testing.T) {
+	h := New()
+	h.SetTokenHook(func(tok lexer.Token) lexer.Token {
+		switch {
+		case tok.Value == "CORE-SW":
+			tok.Type = lexer.TokenCommand // reclassify
+		case tok.Type == lexer.TokenComment:
+			tok.Value = "" // suppress
+		case tok.Value == "secret":
+			tok.Value = "<redacted>" // rewrite
+		}
+		return tok
+	})
+
+	result := h.HighlightForced("hostname CORE-SW\n! comment\ndescription secret\n")
+	if !strings.Contains(result, h.theme.GetColor(lexer.TokenCommand)+"CORE-SW") {
+		t.Errorf("hook should reclassify CORE-SW as a command: %q", result)
+	}
+	if strings.Contains(result, "comment") {
+		t.Errorf("hook should suppress comments: %q", result)
+	}
+	if !strings.Contains(StripANSI(result), "<redacted>") || strings.Contains(result, "secret") {
+		t.Errorf("hook should rewrite values: %q", result)
+	}
+
+	h.SetTokenHook(nil)
+	if result := h.HighlightForced("! comment"); !strings.Contains(result, "comment") {
+		t.Errorf("removing the hook should restore default rendering: %q", result)
+	}
+}
+
+func TestSetCommandHook(t *testing.T) {
+	var commands []string
+	h := New(WithCommandHook(func(cmd lexer.Command) {
+		commands = append(commands, cmd.Host+" "+cmd.Text)
+	}))
+
+	h.Highlight("core-rtr-01#show ip interface brief\n")
+	h.Highlight("Interface  IP-Address  OK? Method Status  Protocol\nGi0/1  10.0.0.1  YES manual up  up\ncore-rtr-01#conf t\n")
+	if want := "core-rtr-01 show ip interface brief|core-rtr-01 conf t"; strings.Join(commands, "|") != want {
+		t.Errorf("commands = %q, want %q", commands, want)
+	}
+
+	h.SetCommandHook(nil)
+	h.Highlight("core-rtr-01#show clock\n")
+	if len(commands) != 2 {
+		t.Errorf("removed hook still called: %q", commands)
+	}
+}
+
+func TestSetParseMode(t *testing.T) {
+	h := New()
+	if h.ParseMode() != lexer.ParseModeAuto {
+		t.Errorf("default parse mode should be auto, got %v", h.ParseMode())
+	}
+
+	// "Gi0/1 is up" reads as show output; forcing config mode keeps "up" uncolored
+	h.SetParseMode(lexer.ParseModeConfig)
+	result := h.HighlightForced("Gi0/1 is up")
+	if strings.Contains(result, h.theme.GetColor(lexer.TokenStateGood)+"up") {
+		t.Errorf("config mode should not classify show states: %q", result)
+	}
+
+	h.SetParseMode(lexer.ParseModeShow)
+	result = h.HighlightForced("Gi0/1 is up")
+	if !strings.Contains(result, h.theme.GetColor(lexer.TokenStateGood)+"up") {
+		t.Errorf("show mode should classify up as a good state: %q", result)
+	}
+}
+
+func TestSetDialect(t *testing.T) {
+	h := New()
+	if h.Dialect() != lexer.DialectAuto {
+		t.Errorf("default dialect should be Auto, got %v", h.Dialect())
+	}
+
+	h.SetDialect(lexer.DialectFRR)
+	if h.Dialect() != lexer.DialectFRR {
+		t.Errorf("expected FRR dialect, got %v", h.Dialect())
+	}
+
+	result := h.HighlightForced("interface swp1")
+	if !strings.Contains(result, h.theme.GetColor(lexer.TokenInterface)+"swp1") {
+		t.Errorf("swp1 should be highlighted as an interface in FRR dialect: %q", result)
+	}
+}
+
+func TestHeatmap(t *testing.T) {
+	input := "Port        Align-Err     FCS-Err    Xmit-Err     Rcv-Err  UnderSize  OutDiscards\n" +
+		"Gi1/0/1             0           0           0           0          0            0\n" +
+		"Gi1/0/2             0          12           0           0          0         5021\n" +
+		"Gi1/0/3  connected  10  a-full  a-1000\n"
+
+	h := New()
+	h.SetParseMode(lexer.ParseModeShow)
+	h.SetHeatmap(&Heatmap{Warning: 1, Critical: 1000})
+	theme := h.theme
+
+	result := h.HighlightForced(input)
+	tests := []struct {
+		value string
+		token lexer.TokenType
+	}{
+		{"0", lexer.TokenStateNeutral},
+		{"12", lexer.TokenStateWarning},
+		{"5021", lexer.TokenStateBad},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(result, theme.GetColor(tt.token)+tt.value+Reset) {
+			t.Errorf("%s should be colored as %v: %q", tt.value, tt.token, result)
+		}
+	}
+
+	// Rows with other columns are not counter rows
+	if strings.Contains(result, theme.GetColor(lexer.TokenStateWarning)+"10"+Reset) {
+		t.Errorf("VLAN column should not be heatmapped: %q", result)
+	}
+
+	h.SetHeatmap(nil)
+	if h.Heatmap() != nil {
+		t.Fatal("SetHeatmap(nil) should disable the heatmap")
+	}
+	if strings.Contains(h.HighlightForced(input), theme.GetColor(lexer.TokenStateBad)+"5021") {
+		t.Error("counters should not be heatmapped when disabled")
+	}
+}
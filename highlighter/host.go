@@ -0,0 +1,33 @@
+package highlighter
+
+// CurrentHost returns the hostname from the last prompt the highlighter has
+// seen, such as "core-rtr-01" for "core-rtr-01#", or "" if none. Session
+// loggers can use it to tag highlighted output with the device it came from.
+func (h *Highlighter) CurrentHost() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.host
+}
+
+// SetHost sets the current hostname, e.g. when a session is resumed. A prompt
+// in later input replaces it.
+func (h *Highlighter) SetHost(host string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.host = host
+}
+
+// SetHighlightHost enables coloring the current hostname wherever it appears
+// in output, in the prompt hostname color.
+func (h *Highlighter) SetHighlightHost(enabled bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.markHost = enabled
+}
+
+// HighlightHost returns whether the hostname is highlighted in output.
+func (h *Highlighter) HighlightHost() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.markHost
+}
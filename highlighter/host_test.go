@@ -0,0 +1,34 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestCurrentHostAcrossCalls(t *testing.T) {
+	h := New()
+	h.SetAlwaysHighlight(true)
+	if got := h.CurrentHost(); got != "" {
+		t.Fatalf("CurrentHost() before any prompt = %q", got)
+	}
+
+	h.Highlight("core-rtr-01#")
+	if got := h.CurrentHost(); got != "core-rtr-01" {
+		t.Fatalf("CurrentHost() = %q, want core-rtr-01", got)
+	}
+
+	// Output without a prompt keeps the host
+	h.Highlight("interface GigabitEthernet0/1\n")
+	if got := h.CurrentHost(); got != "core-rtr-01" {
+		t.Errorf("CurrentHost() after output = %q, want core-rtr-01", got)
+	}
+
+	h.SetHighlightHost(true)
+	theme := h.theme
+	out := h.Highlight("Neighbor core-rtr-01 is up\n")
+	if !strings.Contains(out, theme.GetColor(lexer.TokenPromptHost)+"core-rtr-01") {
+		t.Errorf("hostname not highlighted in output: %q", out)
+	}
+}
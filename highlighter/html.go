@@ -0,0 +1,124 @@
+package highlighter
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// HighlightHTML highlights input like Highlight, but emits HTML: each token
+// becomes a <span> with a "cink-<type>" class (e.g. "cink-interface") and an
+// inline style converted from the theme, and all text is escaped. Wrap the
+// result in <pre> to keep the layout.
+func (h *Highlighter) HighlightHTML(input string) string {
+	if !h.IsEnabled() || input == "" {
+		return html.EscapeString(input)
+	}
+
+	cleaned := StripANSI(input)
+	if !h.AlwaysHighlight() && !h.looksLikeCisco(cleaned) {
+		return html.EscapeString(h.redact(cleaned))
+	}
+
+	tokens, theme := h.tokenizeThemed(cleaned)
+	styles := make(map[lexer.TokenType]string)
+	var buf strings.Builder
+	for _, token := range tokens {
+		value := html.EscapeString(token.Value)
+		if token.Type == lexer.TokenText {
+			buf.WriteString(value)
+			continue
+		}
+		style, ok := styles[token.Type]
+		if !ok {
+			style = ANSIToCSS(theme.GetColor(token.Type))
+			if token.Type == lexer.TokenLineNumber {
+				// Leave line numbers out of text selected in the page
+				style = strings.TrimPrefix(style+";user-select:none", ";")
+			}
+			styles[token.Type] = style
+		}
+		fmt.Fprintf(&buf, `<span class="%s"`, HTMLClass(token.Type))
+		if style != "" {
+			fmt.Fprintf(&buf, ` style="%s"`, style)
+		}
+		buf.WriteString(">")
+		buf.WriteString(value)
+		buf.WriteString("</span>")
+	}
+	return buf.String()
+}
+
+// HTMLClass returns the CSS class HighlightHTML gives tokens of type t.
+func HTMLClass(t lexer.TokenType) string {
+	return "cink-" + strings.ToLower(t.String())
+}
+
+// ThemeCSS returns a style sheet with a rule for each HTMLClass, for pages
+// that keep the classes of HighlightHTML but strip inline styles:
+//
+//	.cink-command { color:#7aa2f7;font-weight:bold }
+func ThemeCSS(theme *Theme) string {
+	var buf strings.Builder
+	for _, t := range lexer.AllTokenTypes() {
+		if style := ANSIToCSS(theme.GetColor(t)); style != "" {
+			fmt.Fprintf(&buf, ".%s { %s }\n", HTMLClass(t), style)
+		}
+	}
+	return buf.String()
+}
+
+// ANSIToCSS converts an ANSI SGR style (as used in themes) to CSS
+// declarations. Bold, dim, italic and underline are kept; foreground colors in
+// 16-color, 256-color and true color form become hex colors.
+func ANSIToCSS(ansi string) string {
+	var decls []string
+	color := ""
+
+	for _, seq := range strings.Split(ansi, "\033[") {
+		params := strings.Split(strings.TrimSuffix(seq, "m"), ";")
+		for i := 0; i < len(params); i++ {
+			n, err := strconv.Atoi(params[i])
+			if err != nil {
+				continue
+			}
+			switch {
+			case n == 1:
+				decls = append(decls, "font-weight:bold")
+			case n == 2:
+				decls = append(decls, "opacity:0.6")
+			case n == 3:
+				decls = append(decls, "font-style:italic")
+			case n == 4:
+				decls = append(decls, "text-decoration:underline")
+			case n == 38 && i+2 < len(params) && params[i+1] == "5":
+				c, _ := strconv.Atoi(params[i+2])
+				color = hexColor(color256ToRGB(c))
+				i += 2
+			case n == 38 && i+4 < len(params) && params[i+1] == "2":
+				r, _ := strconv.Atoi(params[i+2])
+				g, _ := strconv.Atoi(params[i+3])
+				b, _ := strconv.Atoi(params[i+4])
+				color = hexColor(r, g, b)
+				i += 4
+			default:
+				if c, ok := ansiToIRCColor[n]; ok {
+					rgb := ircPalette[c]
+					color = hexColor(rgb[0], rgb[1], rgb[2])
+				}
+			}
+		}
+	}
+
+	if color != "" {
+		decls = append([]string{"color:" + color}, decls...)
+	}
+	return strings.Join(decls, ";")
+}
+
+func hexColor(r, g, b int) string {
+	return fmt.Sprintf("#%02x%02x%02x", r&0xff, g&0xff, b&0xff)
+}
@@ -0,0 +1,77 @@
+package highlighter
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestANSIToCSS(t *testing.T) {
+	tests := []struct {
+		name     string
+		ansi     string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"basic red", Red, "color:#7f0000"},
+		{"bold blue", Bold + Blue, "color:#00007f;font-weight:bold"},
+		{"italic only", Italic, "font-style:italic"},
+		{"dim", Dim, "opacity:0.6"},
+		{"truecolor", RGB(255, 158, 100), "color:#ff9e64"},
+		{"256 grey", Color256(244), "color:#808080"},
+		{"underline truecolor", Underline + RGB(1, 2, 3), "color:#010203;text-decoration:underline"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ANSIToCSS(tt.ansi); got != tt.expected {
+				t.Errorf("ANSIToCSS(%q) = %q, want %q", tt.ansi, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHighlightHTML(t *testing.T) {
+	h := NewWithTheme(VibrantTheme())
+	input := "interface GigabitEthernet0/1\n description <uplink> & \"core\"\n no shutdown\n"
+
+	result := h.HighlightHTML(input)
+	if strings.Contains(result, "\033[") {
+		t.Errorf("HTML output should not contain ANSI escapes: %q", result)
+	}
+
+	want := `<span class="cink-command" style="` + ANSIToCSS(h.theme.GetColor(lexer.TokenCommand)) + `">interface</span>`
+	if !strings.Contains(result, want) {
+		t.Errorf("expected %q in %q", want, result)
+	}
+	if strings.Contains(result, "<uplink>") {
+		t.Errorf("text not escaped: %q", result)
+	}
+
+	stripped := html.UnescapeString(regexp.MustCompile(`<[^>]*>`).ReplaceAllString(result, ""))
+	if stripped != input {
+		t.Errorf("content not preserved:\n%q\n%q", stripped, input)
+	}
+}
+
+func TestHighlightHTMLNonCisco(t *testing.T) {
+	h := New()
+	input := "just <some> text"
+	if got := h.HighlightHTML(input); got != "just &lt;some&gt; text" {
+		t.Errorf("HighlightHTML(%q) = %q", input, got)
+	}
+}
+
+func TestThemeCSS(t *testing.T) {
+	theme := &Theme{colors: map[lexer.TokenType]string{
+		lexer.TokenCommand: Bold + Blue,
+		lexer.TokenIPv4:    RGB(1, 2, 3),
+	}}
+	want := ".cink-command { color:#00007f;font-weight:bold }\n.cink-ipv4 { color:#010203 }\n"
+	if got := ThemeCSS(theme); got != want {
+		t.Errorf("ThemeCSS() = %q, want %q", got, want)
+	}
+}
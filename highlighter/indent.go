@@ -0,0 +1,106 @@
+package highlighter
+
+import (
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// IndentGuide is drawn in the leading whitespace of a nested line, below the
+// first character of each enclosing section line.
+const IndentGuide = "│"
+
+// SetIndentGuides draws faint vertical guides in the leading whitespace of
+// nested lines, one for each enclosing section, so the depth of policy-map,
+// class-map and address-family blocks is visible at a glance. The section
+// stack is kept across calls, so input may be highlighted a line at a time.
+// Enabling or disabling guides resets it.
+func (h *Highlighter) SetIndentGuides(enabled bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.indentGuides = enabled
+	h.sectionStack = nil
+}
+
+// IndentGuides returns whether indent guides are drawn.
+func (h *Highlighter) IndentGuides() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.indentGuides
+}
+
+// applyIndentGuides replaces the spaces below enclosing section lines with
+// IndentGuide tokens.
+func (h *Highlighter) applyIndentGuides(tokens []lexer.Token) []lexer.Token {
+	h.mu.RLock()
+	enabled := h.indentGuides
+	stack := append([]int(nil), h.sectionStack...)
+	h.mu.RUnlock()
+	if !enabled {
+		return tokens
+	}
+
+	out := make([]lexer.Token, 0, len(tokens))
+	for _, line := range splitTokenLines(tokens) {
+		out, stack = appendGuidedLine(out, line, stack)
+	}
+
+	h.mu.Lock()
+	h.sectionStack = stack
+	h.mu.Unlock()
+	return out
+}
+
+// appendGuidedLine appends line to out with guides in its indentation. stack
+// holds the indentation of the enclosing section lines, innermost last; it is
+// updated for the line and returned.
+func appendGuidedLine(out, line []lexer.Token, stack []int) ([]lexer.Token, []int) {
+	content := line
+	if n := len(content); n > 0 && content[n-1].Value == "\n" {
+		content = content[:n-1]
+	}
+	if len(content) == 0 || isBlankLine(content) {
+		return append(out, line...), stack
+	}
+	// A prompt starts a new command, so earlier sections are closed
+	for _, tok := range content {
+		if tok.Type == lexer.TokenPromptHost || tok.Type == lexer.TokenPromptConf || tok.Type == lexer.TokenPromptOper {
+			return append(out, line...), nil
+		}
+	}
+
+	lead := lexer.Token{Line: content[0].Line, Column: content[0].Column}
+	if content[0].Type == lexer.TokenText && strings.TrimSpace(content[0].Value) == "" {
+		lead = content[0]
+		line = line[1:]
+	}
+	indent := len(lead.Value)
+	for len(stack) > 0 && stack[len(stack)-1] >= indent {
+		stack = stack[:len(stack)-1]
+	}
+
+	start := 0
+	for _, col := range stack {
+		if lead.Value[col] != ' ' {
+			continue
+		}
+		if col > start {
+			out = append(out, lexer.Token{Type: lexer.TokenText, Value: lead.Value[start:col], Line: lead.Line, Column: lexer.AdvanceColumn(lead.Column, lead.Value[:start])})
+		}
+		out = append(out, lexer.Token{Type: lexer.TokenIndentGuide, Value: IndentGuide, Line: lead.Line, Column: lexer.AdvanceColumn(lead.Column, lead.Value[:col])})
+		start = col + 1
+	}
+	if start < indent {
+		out = append(out, lexer.Token{Type: lexer.TokenText, Value: lead.Value[start:], Line: lead.Line, Column: lexer.AdvanceColumn(lead.Column, lead.Value[:start])})
+	}
+	return append(out, line...), append(stack, indent)
+}
+
+func isBlankLine(tokens []lexer.Token) bool {
+	for _, tok := range tokens {
+		if strings.TrimSpace(tok.Value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestIndentGuides(t *testing.T) {
+	input := "policy-map PM\n class VOICE\n  police 8000\n   conform-action transmit\n class DATA\n  bandwidth 5000\n\n!\ninterface Gi0/1\n    description wide indent\n"
+	expected := "policy-map PM\n│class VOICE\n││police 8000\n│││conform-action transmit\n│class DATA\n││bandwidth 5000\n\n!\ninterface Gi0/1\n│   description wide indent\n"
+
+	h := New(WithIndentGuides())
+	if got := StripANSI(h.HighlightForced(input)); got != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", got, expected)
+	}
+	if !strings.Contains(h.HighlightForced(input), h.Theme().GetColor(lexer.TokenIndentGuide)+IndentGuide) {
+		t.Error("guides should be drawn in the IndentGuide color")
+	}
+
+	h.SetIndentGuides(false)
+	if got := StripANSI(h.HighlightForced(input)); got != input {
+		t.Errorf("disabled guides should leave input unchanged, got:\n%s", got)
+	}
+}
+
+func TestIndentGuidesAcrossCalls(t *testing.T) {
+	h := New(WithIndentGuides())
+	lines := []string{"router bgp 65000\n", " address-family ipv4\n", "  neighbor 10.0.0.1 activate\n"}
+	var got string
+	for _, line := range lines {
+		got += StripANSI(h.HighlightForced(line))
+	}
+	if want := "router bgp 65000\n│address-family ipv4\n││neighbor 10.0.0.1 activate\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// A prompt closes the sections seen before it
+	h.HighlightForced("R1#show run\n")
+	if got := StripANSI(h.HighlightForced("  neighbor 10.0.0.1 activate\n")); strings.Contains(got, IndentGuide) {
+		t.Errorf("guides should not continue past a prompt, got %q", got)
+	}
+}
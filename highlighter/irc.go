@@ -0,0 +1,172 @@
+package highlighter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// mIRC formatting control characters
+const (
+	IRCBold      = "\x02"
+	IRCColor     = "\x03"
+	IRCItalic    = "\x1d"
+	IRCUnderline = "\x1f"
+	IRCReset     = "\x0f"
+)
+
+// ircPalette holds the RGB values of the 16 standard mIRC colors.
+var ircPalette = [16][3]int{
+	{255, 255, 255}, // 0 white
+	{0, 0, 0},       // 1 black
+	{0, 0, 127},     // 2 blue
+	{0, 147, 0},     // 3 green
+	{255, 0, 0},     // 4 light red
+	{127, 0, 0},     // 5 brown
+	{156, 0, 156},   // 6 purple
+	{252, 127, 0},   // 7 orange
+	{255, 255, 0},   // 8 yellow
+	{0, 252, 0},     // 9 light green
+	{0, 147, 147},   // 10 cyan
+	{0, 255, 255},   // 11 light cyan
+	{0, 0, 252},     // 12 light blue
+	{255, 0, 255},   // 13 pink
+	{127, 127, 127}, // 14 grey
+	{210, 210, 210}, // 15 light grey
+}
+
+// ansiToIRCColor maps the 16 basic ANSI foreground codes to mIRC colors.
+var ansiToIRCColor = map[int]int{
+	30: 1, 31: 5, 32: 3, 33: 7, 34: 2, 35: 6, 36: 10, 37: 15,
+	90: 14, 91: 4, 92: 9, 93: 8, 94: 12, 95: 13, 96: 11, 97: 0,
+}
+
+// HighlightIRC highlights input like Highlight, but emits mIRC color codes
+// instead of ANSI escapes, for pasting into IRC (and IRC-bridged Matrix rooms).
+// Theme colors are mapped to the nearest of the 16 standard mIRC colors.
+func (h *Highlighter) HighlightIRC(input string) string {
+	if !h.IsEnabled() || input == "" {
+		return input
+	}
+
+	cleaned := StripANSI(input)
+	if !h.AlwaysHighlight() && !h.looksLikeCisco(cleaned) {
+		return h.redact(cleaned)
+	}
+
+	tokens, theme := h.tokenizeThemed(cleaned)
+	codes := make(map[lexer.TokenType]string)
+	var buf strings.Builder
+	for _, token := range tokens {
+		code, ok := codes[token.Type]
+		if !ok {
+			code = ANSIToIRC(theme.GetColor(token.Type))
+			codes[token.Type] = code
+		}
+		if code == "" {
+			buf.WriteString(token.Value)
+			continue
+		}
+		buf.WriteString(code)
+		// A leading comma would be read as a background color separator
+		if strings.HasPrefix(token.Value, ",") {
+			buf.WriteString(IRCBold + IRCBold)
+		}
+		buf.WriteString(token.Value)
+		buf.WriteString(IRCReset)
+	}
+	return buf.String()
+}
+
+// ANSIToIRC converts an ANSI SGR style (as used in themes) to mIRC control
+// codes. Bold, italic and underline are kept; foreground colors in 16-color,
+// 256-color and true color form become the nearest mIRC color.
+func ANSIToIRC(ansi string) string {
+	var attrs string
+	color := -1
+
+	for _, seq := range strings.Split(ansi, "\033[") {
+		params := strings.Split(strings.TrimSuffix(seq, "m"), ";")
+		for i := 0; i < len(params); i++ {
+			n, err := strconv.Atoi(params[i])
+			if err != nil {
+				continue
+			}
+			switch {
+			case n == 1:
+				attrs += IRCBold
+			case n == 3:
+				attrs += IRCItalic
+			case n == 4:
+				attrs += IRCUnderline
+			case n == 38 && i+2 < len(params) && params[i+1] == "5":
+				c, _ := strconv.Atoi(params[i+2])
+				r, g, b := color256ToRGB(c)
+				color = nearestIRCColor(r, g, b)
+				i += 2
+			case n == 38 && i+4 < len(params) && params[i+1] == "2":
+				r, _ := strconv.Atoi(params[i+2])
+				g, _ := strconv.Atoi(params[i+3])
+				b, _ := strconv.Atoi(params[i+4])
+				color = nearestIRCColor(r, g, b)
+				i += 4
+			default:
+				if c, ok := ansiToIRCColor[n]; ok {
+					color = c
+				}
+			}
+		}
+	}
+
+	if color < 0 {
+		return attrs
+	}
+	// Always two digits so a value starting with a digit is not read as part of the code
+	return attrs + IRCColor + twoDigits(color)
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// color256ToRGB converts a 256-color palette index to RGB.
+func color256ToRGB(n int) (int, int, int) {
+	switch {
+	case n < 0 || n > 255:
+		return 0, 0, 0
+	case n < 16:
+		// Approximate the basic palette with the mIRC equivalents
+		basic := []int{1, 5, 3, 7, 2, 6, 10, 15, 14, 4, 9, 8, 12, 13, 11, 0}
+		c := ircPalette[basic[n]]
+		return c[0], c[1], c[2]
+	case n < 232:
+		n -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return level(n / 36), level(n / 6 % 6), level(n % 6)
+	default:
+		v := 8 + (n-232)*10
+		return v, v, v
+	}
+}
+
+// nearestIRCColor returns the mIRC color closest to r, g, b.
+func nearestIRCColor(r, g, b int) int {
+	best, bestDist := 0, -1
+	for i, c := range ircPalette {
+		dr, dg, db := r-c[0], g-c[1], b-c[2]
+		dist := dr*dr + dg*dg + db*db
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
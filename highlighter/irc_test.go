@@ -0,0 +1,66 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestANSIToIRC(t *testing.T) {
+	tests := []struct {
+		name     string
+		ansi     string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"basic red", Red, IRCColor + "05"},
+		{"bright green", BrightGreen, IRCColor + "09"},
+		{"bright white", BrightWhite, IRCColor + "00"},
+		{"bold blue", Bold + Blue, IRCBold + IRCColor + "02"},
+		{"italic only", Italic, IRCItalic},
+		{"truecolor red", RGB(250, 10, 10), IRCColor + "04"},
+		{"truecolor orange", RGB(255, 158, 100), IRCColor + "07"},
+		{"256 grey", Color256(244), IRCColor + "14"},
+		{"underline 256 cube", Underline + Color256(196), IRCUnderline + IRCColor + "04"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ANSIToIRC(tt.ansi); got != tt.expected {
+				t.Errorf("ANSIToIRC(%q) = %q, want %q", tt.ansi, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHighlightIRC(t *testing.T) {
+	h := NewWithTheme(VibrantTheme())
+	input := "interface GigabitEthernet0/1\n ip address 10.0.0.1 255.255.255.0\n no shutdown\n"
+
+	result := h.HighlightIRC(input)
+	if strings.Contains(result, "\033[") {
+		t.Errorf("IRC output should not contain ANSI escapes: %q", result)
+	}
+
+	cmd := ANSIToIRC(h.theme.GetColor(lexer.TokenCommand))
+	if !strings.Contains(result, cmd+"interface"+IRCReset) {
+		t.Errorf("expected IRC-colored command, got %q", result)
+	}
+
+	stripped := strings.NewReplacer(IRCBold, "", IRCItalic, "", IRCUnderline, "", IRCReset, "").Replace(result)
+	for i := 0; i < 16; i++ {
+		stripped = strings.ReplaceAll(stripped, IRCColor+twoDigits(i), "")
+	}
+	if stripped != input {
+		t.Errorf("content not preserved:\n%q\n%q", stripped, input)
+	}
+}
+
+func TestHighlightIRCNonCisco(t *testing.T) {
+	h := New()
+	input := "just a regular chat message"
+	if got := h.HighlightIRC(input); got != input {
+		t.Errorf("non-Cisco input should pass through, got %q", got)
+	}
+}
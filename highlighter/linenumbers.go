@@ -0,0 +1,115 @@
+package highlighter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// LineNumberSeparator follows the line number in the gutter.
+const LineNumberSeparator = "│"
+
+// minLineNumberWidth is the narrowest the numbers of a gutter are, so
+// highlighting a line at a time keeps the gutter the same width up to line
+// 9999.
+const minLineNumberWidth = 4
+
+// lineGutterPattern matches the gutter written before each line.
+var lineGutterPattern = regexp.MustCompile(`^ *\d+ ` + LineNumberSeparator + ` `)
+
+// SetLineNumbers prefixes each line with a right-aligned line number and
+// LineNumberSeparator, as a TokenLineNumber, for pointing at config lines in
+// reviews. Every line is counted, blank ones included, so the numbers are
+// those of the input. The count is kept across calls, so input may be
+// highlighted a line at a time; enabling or disabling line numbers restarts
+// it at 1. CopyText removes the gutter again.
+func (h *Highlighter) SetLineNumbers(enabled bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lineNumbers = enabled
+	h.linesNumbered = 0
+	h.midLine = false
+}
+
+// LineNumbers returns whether lines are numbered.
+func (h *Highlighter) LineNumbers() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.lineNumbers
+}
+
+// applyLineNumbers inserts a TokenLineNumber at the start of every line.
+func (h *Highlighter) applyLineNumbers(tokens []lexer.Token) []lexer.Token {
+	h.mu.RLock()
+	enabled, numbered, midLine := h.lineNumbers, h.linesNumbered, h.midLine
+	h.mu.RUnlock()
+	if !enabled || len(tokens) == 0 {
+		return tokens
+	}
+
+	lines := splitTokenLines(tokens)
+	last := numbered + len(lines)
+	if midLine {
+		last--
+	}
+	width := max(minLineNumberWidth, len(fmt.Sprint(last)))
+
+	out := make([]lexer.Token, 0, len(tokens)+2*len(lines))
+	for i, line := range lines {
+		// A line continued from the last call already has its number
+		if i > 0 || !midLine {
+			numbered++
+			out = append(out, lexer.Token{
+				Type:   lexer.TokenLineNumber,
+				Value:  fmt.Sprintf("%*d %s ", width, numbered, LineNumberSeparator),
+				Line:   line[0].Line,
+				Column: 1,
+			})
+		}
+		out = append(out, line...)
+	}
+	end := lines[len(lines)-1]
+
+	h.mu.Lock()
+	h.linesNumbered = numbered
+	h.midLine = end[len(end)-1].Value != "\n"
+	h.mu.Unlock()
+	return out
+}
+
+// numberLines adds the line number gutter to text left uncolored, so lines
+// that do not look like Cisco keep their place in the count.
+func (h *Highlighter) numberLines(text string) string {
+	tokens := h.applyLineNumbers([]lexer.Token{{Type: lexer.TokenText, Value: text, Line: 1, Column: 1}})
+	color := h.Theme().GetColor(lexer.TokenLineNumber)
+
+	var buf strings.Builder
+	for _, token := range tokens {
+		if token.Type == lexer.TokenLineNumber && color != "" {
+			buf.WriteString(color + token.Value + Reset)
+		} else {
+			buf.WriteString(token.Value)
+		}
+	}
+	return buf.String()
+}
+
+// CopyText returns highlighted output as plain text to copy: ANSI escapes
+// are removed, and so is the line number gutter if every line has one.
+func CopyText(highlighted string) string {
+	lines := strings.SplitAfter(StripANSI(highlighted), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for _, line := range lines {
+		if !lineGutterPattern.MatchString(line) {
+			return strings.Join(lines, "")
+		}
+	}
+	for i, line := range lines {
+		lines[i] = line[len(lineGutterPattern.FindString(line)):]
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,85 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestLineNumbers(t *testing.T) {
+	input := "interface Gi0/1\n description uplink\n\n!\nbanner motd ^C\nhello\n^C\n"
+	expected := "   1 │ interface Gi0/1\n" +
+		"   2 │  description uplink\n" +
+		"   3 │ \n" +
+		"   4 │ !\n" +
+		"   5 │ banner motd ^C\n" +
+		"   6 │ hello\n" +
+		"   7 │ ^C\n"
+
+	h := New(WithLineNumbers())
+	out := h.HighlightForced(input)
+	if got := StripANSI(out); got != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", got, expected)
+	}
+	if !strings.Contains(out, h.Theme().GetColor(lexer.TokenLineNumber)+"   1 │ ") {
+		t.Error("line numbers should be drawn in the LineNumber color")
+	}
+	if got := CopyText(out); got != input {
+		t.Errorf("CopyText = %q, want %q", got, input)
+	}
+
+	h.SetLineNumbers(false)
+	if got := StripANSI(h.HighlightForced(input)); got != input {
+		t.Errorf("disabled line numbers should leave input unchanged, got:\n%s", got)
+	}
+}
+
+func TestLineNumbersWidth(t *testing.T) {
+	input := strings.Repeat("!\n", 10000)
+	got := StripANSI(New(WithLineNumbers()).HighlightForced(input))
+	lines := strings.Split(got, "\n")
+	if lines[0] != "    1 │ !" || lines[9999] != "10000 │ !" {
+		t.Errorf("numbers should be right-aligned to the widest, got %q and %q", lines[0], lines[9999])
+	}
+}
+
+func TestLineNumbersAcrossCalls(t *testing.T) {
+	h := New(WithLineNumbers())
+	var got string
+	for _, part := range []string{"hostname R1\n", "\n", "just some ", "text\n", "interface Gi0/1\n"} {
+		got += StripANSI(h.Highlight(part))
+	}
+	want := "   1 │ hostname R1\n   2 │ \n   3 │ just some text\n   4 │ interface Gi0/1\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestCopyText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"gutter", "   1 │ hostname R1\n   2 │ \n", "hostname R1\n\n"},
+		{"no trailing newline", "  12 │ end", "end"},
+		{"escapes", "\033[2m   1 │ \033[0m\033[1mhostname\033[0m R1\n", "hostname R1\n"},
+		{"not every line", "   1 │ hostname R1\nend\n", "   1 │ hostname R1\nend\n"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CopyText(tt.input); got != tt.want {
+				t.Errorf("CopyText(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineNumbersHTML(t *testing.T) {
+	out := New(WithLineNumbers()).HighlightHTML("hostname R1\n")
+	if !strings.Contains(out, `class="cink-linenumber"`) || !strings.Contains(out, "user-select:none") {
+		t.Errorf("line numbers should be unselectable spans, got %s", out)
+	}
+}
@@ -0,0 +1,145 @@
+package highlighter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// LogSeverityNames are the syslog severities from 0 to 7, as the logging
+// commands name them.
+var LogSeverityNames = [8]string{
+	"emergencies", "alerts", "critical", "errors",
+	"warnings", "notifications", "informational", "debugging",
+}
+
+// Syslog messages carry their severity in the mnemonic: "%LINK-3-UPDOWN:",
+// "%PKT_INFRA-LINK-3-UPDOWN :" on IOS XR
+var logMessagePattern = regexp.MustCompile(`%[A-Z][A-Z0-9_]*(?:-[A-Z0-9_]+)*-([0-7])-[A-Z0-9_]+ ?:`)
+
+// LogSeverityByName returns the severity for a name from LogSeverityNames
+// or a number from 0 to 7.
+func LogSeverityByName(name string) (int, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if n, err := strconv.Atoi(name); err == nil {
+		return n, n >= 0 && n < len(LogSeverityNames)
+	}
+	for i, s := range LogSeverityNames {
+		if name == s {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// SetLogSeverity hides syslog messages, as in show logging or a console
+// session, less severe than level (0 emergencies to 7 debugging), so errors
+// stand out in a noisy log. Each run of hidden messages is replaced by a
+// dimmed line counting them, shown before the next line that is not hidden;
+// call FlushSuppressed at the end of the input for a run that ends it.
+// Traceback lines following a hidden message are hidden with it. Lines that
+// are not syslog messages are always shown. A level outside 0-7 shows all
+// messages.
+func (h *Highlighter) SetLogSeverity(level int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.logSeverity = level
+	h.filterLogs = level >= 0 && level < len(LogSeverityNames)
+	h.suppressed = 0
+	h.inMessage, h.midMessage = false, false
+}
+
+// LogSeverity returns the least severe level of syslog message shown, and
+// whether messages are filtered at all.
+func (h *Highlighter) LogSeverity() (int, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.logSeverity, h.filterLogs
+}
+
+// FlushSuppressed returns the dimmed count of the syslog messages hidden
+// since the last line shown, or "" if there are none, and resets it.
+func (h *Highlighter) FlushSuppressed() string {
+	h.mu.Lock()
+	n, level, theme := h.suppressed, h.logSeverity, h.theme
+	h.suppressed = 0
+	h.inMessage, h.midMessage = false, false
+	h.mu.Unlock()
+	if n == 0 {
+		return ""
+	}
+	var buf strings.Builder
+	if color := theme.GetColor(lexer.TokenStale); color != "" {
+		buf.WriteString(color + suppressedLine(n, level) + Reset)
+	} else {
+		buf.WriteString(suppressedLine(n, level))
+	}
+	buf.WriteByte('\n')
+	return buf.String()
+}
+
+// applyLogSeverity drops syslog messages less severe than the log severity,
+// and puts the count of each run of them before the line that follows it.
+func (h *Highlighter) applyLogSeverity(tokens []lexer.Token) []lexer.Token {
+	h.mu.RLock()
+	enabled, level := h.filterLogs, h.logSeverity
+	suppressed, inMessage, midMessage := h.suppressed, h.inMessage, h.midMessage
+	h.mu.RUnlock()
+	if !enabled || len(tokens) == 0 {
+		return tokens
+	}
+
+	out := make([]lexer.Token, 0, len(tokens))
+	for i, line := range splitTokenLines(tokens) {
+		var text strings.Builder
+		for _, token := range line {
+			text.WriteString(token.Value)
+		}
+		// The rest of a message cut off at the end of the last call, or the
+		// traceback of a hidden one
+		if i == 0 && midMessage || inMessage && strings.HasPrefix(strings.TrimSpace(text.String()), "-Traceback=") {
+			continue
+		}
+		if m := logMessagePattern.FindStringSubmatch(text.String()); m != nil && int(m[1][0]-'0') > level {
+			suppressed++
+			inMessage = true
+			continue
+		}
+		if suppressed > 0 {
+			out = append(out, lexer.Token{
+				Type:   lexer.TokenStale,
+				Value:  suppressedLine(suppressed, level) + "\n",
+				Line:   line[0].Line,
+				Column: 1,
+			})
+			suppressed = 0
+		}
+		inMessage = false
+		out = append(out, line...)
+	}
+	end := tokens[len(tokens)-1].Value
+
+	h.mu.Lock()
+	h.suppressed = suppressed
+	h.inMessage = inMessage
+	h.midMessage = inMessage && !strings.HasSuffix(end, "\n")
+	h.mu.Unlock()
+	return out
+}
+
+// suppressedLine describes n syslog messages hidden for being less severe
+// than level.
+func suppressedLine(n, level int) string {
+	messages := "messages"
+	if n == 1 {
+		messages = "message"
+	}
+	severity := fmt.Sprintf("severity %d", level+1)
+	if level+1 < len(LogSeverityNames)-1 {
+		severity += fmt.Sprintf("-%d", len(LogSeverityNames)-1)
+	}
+	return fmt.Sprintf("... %d %s of %s hidden", n, messages, severity)
+}
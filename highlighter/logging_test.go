@@ -0,0 +1,97 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+const loggingOutput = `R1#show logging
+Log Buffer (8192 bytes):
+*Mar  1 00:01:02.123: %LINK-3-UPDOWN: Interface GigabitEthernet0/1, changed state to down
+*Mar  1 00:01:03.123: %LINEPROTO-5-UPDOWN: Line protocol on Interface GigabitEthernet0/1, changed state to down
+*Mar  1 00:01:04.001: %SYS-6-LOGGINGHOST_STARTSTOP: Logging to host 10.0.0.5 port 514 started
+*Mar  1 00:01:05.250: %SYS-2-MALLOCFAIL: Memory allocation of 65536 bytes failed
+*Mar  1 00:01:06.000: %SYS-4-CONFIG_RESOLVE_FAILURE: System config parse from (tftp://255.255.255.255/network-confg) failed
+-Traceback= 1A2B3C 4D5E6F
+R1#
+`
+
+func TestLogSeverity(t *testing.T) {
+	h := New(WithLogSeverity(3))
+	got := StripANSI(h.HighlightForced(loggingOutput))
+	want := `R1#show logging
+Log Buffer (8192 bytes):
+*Mar  1 00:01:02.123: %LINK-3-UPDOWN: Interface GigabitEthernet0/1, changed state to down
+... 2 messages of severity 4-7 hidden
+*Mar  1 00:01:05.250: %SYS-2-MALLOCFAIL: Memory allocation of 65536 bytes failed
+... 1 message of severity 4-7 hidden
+R1#
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	stale := h.Theme().GetColor(lexer.TokenStale)
+	if out := h.HighlightForced(loggingOutput); !strings.Contains(out, stale+"... 2 messages") {
+		t.Error("the count of hidden messages should be dimmed")
+	}
+
+	h.SetLogSeverity(-1)
+	if got := StripANSI(h.HighlightForced(loggingOutput)); got != loggingOutput {
+		t.Errorf("a level outside 0-7 should show every message, got:\n%s", got)
+	}
+}
+
+func TestLogSeverityAcrossCalls(t *testing.T) {
+	h := New(WithLogSeverity(4))
+	var got strings.Builder
+	for _, line := range strings.SplitAfter(loggingOutput, "\n") {
+		got.WriteString(StripANSI(h.HighlightForced(line)))
+	}
+	if !strings.Contains(got.String(), "down\n... 2 messages of severity 5-7 hidden\n*Mar  1 00:01:05.250") {
+		t.Errorf("a run hidden a line at a time should be counted once, got:\n%s", got.String())
+	}
+
+	h.SetLogSeverity(3)
+	for _, line := range []string{"*Mar  1 00:01:06.000: %SYS-4-CONFIG_RESOLVE_FAILURE: failed\n", "-Traceback= 1A2B3C\n"} {
+		if got := h.HighlightForced(line); got != "" {
+			t.Errorf("a hidden message and its traceback should be hidden, got %q", got)
+		}
+	}
+	h.FlushSuppressed()
+
+	h.SetLogSeverity(0)
+	h.HighlightForced("*Mar  1 00:01:02.123: %LINK-3-UPDOWN: Interface Gi0/1, changed state ")
+	if got := StripANSI(h.HighlightForced("to down\n")); got != "" {
+		t.Errorf("the rest of a hidden message should be hidden, got %q", got)
+	}
+	if got := StripANSI(h.FlushSuppressed()); got != "... 1 message of severity 1-7 hidden\n" {
+		t.Errorf("FlushSuppressed = %q", got)
+	}
+	if got := h.FlushSuppressed(); got != "" {
+		t.Errorf("FlushSuppressed should reset the count, got %q", got)
+	}
+}
+
+func TestLogSeverityByName(t *testing.T) {
+	tests := []struct {
+		name  string
+		level int
+		ok    bool
+	}{
+		{"errors", 3, true},
+		{" Warnings ", 4, true},
+		{"7", 7, true},
+		{"0", 0, true},
+		{"8", 8, false},
+		{"-1", -1, false},
+		{"verbose", 0, false},
+	}
+	for _, tt := range tests {
+		level, ok := LogSeverityByName(tt.name)
+		if ok != tt.ok || ok && level != tt.level {
+			t.Errorf("LogSeverityByName(%q) = %d, %v, want %d, %v", tt.name, level, ok, tt.level, tt.ok)
+		}
+	}
+}
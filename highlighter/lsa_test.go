@@ -0,0 +1,57 @@
+package highlighter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestLSAAging(t *testing.T) {
+	header := "                Router Link States (Area 0)\n\n" +
+		"Link ID         ADV Router      Age         Seq#       Checksum Link count\n"
+	rows := "10.0.0.1        10.0.0.1        1900        0x80000005 0x00A1B2 3\n" +
+		"10.0.0.2        10.0.0.2        45          0x80000003 0x004C21 2\n" +
+		"10.0.0.3        10.0.0.3        600         0x80000009 0x00BEEF 2\n"
+
+	lineTypes := func(h *Highlighter, input string) map[string]lexer.TokenType {
+		lex := lexer.New(input)
+		lex.SetParseMode(lexer.ParseModeShow)
+		types := make(map[string]lexer.TokenType)
+		for _, tok := range h.processTokens(lex.Tokenize()) {
+			types[tok.Value] = tok.Type
+		}
+		return types
+	}
+
+	h := New(WithLSAAging(DefaultLSAFresh, DefaultLSAOld))
+	types := lineTypes(h, header+rows)
+	expected := map[string]lexer.TokenType{
+		"1900":       lexer.TokenStale,
+		"0x80000005": lexer.TokenStale,
+		"10.0.0.1":   lexer.TokenStale,
+		"45":         lexer.TokenStateWarning,
+		"0x80000003": lexer.TokenNumber,
+		"600":        lexer.TokenTimeDuration,
+	}
+	for value, want := range expected {
+		if types[value] != want {
+			t.Errorf("%q = %v, want %v", value, types[value], want)
+		}
+	}
+
+	// Rows highlighted in a later call than their header
+	h = New(WithLSAAging(DefaultLSAFresh, 0))
+	lineTypes(h, header)
+	if types := lineTypes(h, rows); types["45"] != lexer.TokenStateWarning || types["1900"] == lexer.TokenStale {
+		t.Errorf("age column not remembered across calls: %v", types)
+	}
+
+	if fresh, old := h.LSAAging(); fresh != time.Minute || old != 0 {
+		t.Errorf("LSAAging() = %v, %v", fresh, old)
+	}
+	h.SetLSAAging(0, 0)
+	if types := lineTypes(h, header+rows); types["45"] != lexer.TokenTimeDuration {
+		t.Errorf("aging still applied after turning it off: %v", types["45"])
+	}
+}
@@ -0,0 +1,38 @@
+package highlighter
+
+import "strings"
+
+// HighlightMarkdown highlights input for pasting into Markdown (GitHub and
+// GitLab comments, wikis): the spans of HighlightHTML in a <pre> block, which
+// Markdown passes through as raw HTML. Where the renderer strips the inline
+// styles but keeps classes, ThemeCSS styles the spans instead.
+func (h *Highlighter) HighlightMarkdown(input string) string {
+	if input == "" {
+		return ""
+	}
+	// A final newline would show as an empty last line inside <pre>
+	return "<pre>" + h.HighlightHTML(strings.TrimSuffix(input, "\n")) + "</pre>\n"
+}
+
+// MarkdownFence returns input as a fenced code block with the "cisco" info
+// string, for renderers that drop raw HTML altogether and highlight fences
+// themselves. The fence is longer than any run of backticks in input, so the
+// block cannot be closed early.
+func MarkdownFence(input string) string {
+	input = StripANSI(input)
+	longest, run := 0, 0
+	for _, c := range input {
+		if c != '`' {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+
+	if !strings.HasSuffix(input, "\n") {
+		input += "\n"
+	}
+	return fence + "cisco\n" + input + fence + "\n"
+}
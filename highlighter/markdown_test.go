@@ -0,0 +1,44 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightMarkdown(t *testing.T) {
+	h := New()
+	input := "interface GigabitEthernet0/1\n\n no shutdown\n"
+
+	got := h.HighlightMarkdown(input)
+	want := "<pre>" + h.HighlightHTML(strings.TrimSuffix(input, "\n")) + "</pre>\n"
+	if got != want {
+		t.Errorf("HighlightMarkdown() = %q, want %q", got, want)
+	}
+	if !strings.Contains(got, `<span class="cink-command"`) {
+		t.Errorf("expected highlighted spans in %q", got)
+	}
+	if h.HighlightMarkdown("") != "" {
+		t.Error("empty input should give empty output")
+	}
+}
+
+func TestMarkdownFence(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "interface Gi0/1\n", "```cisco\ninterface Gi0/1\n```\n"},
+		{"no final newline", "interface Gi0/1", "```cisco\ninterface Gi0/1\n```\n"},
+		{"ANSI removed", Blue + "interface" + Reset + " Gi0/1\n", "```cisco\ninterface Gi0/1\n```\n"},
+		{"backticks in input", "banner motd ````x````\n", "`````cisco\nbanner motd ````x````\n`````\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownFence(tt.input); got != tt.want {
+				t.Errorf("MarkdownFence(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package highlighter
+
+// SetLearnNames enables learning the route-map, ACL, prefix list, VRF,
+// class-map, policy-map and object-group names defined in the input, and
+// coloring them and later references to them as TokenUserDefinedName (see
+// lexer.SetLearnNames). Names are remembered across calls, so a definition
+// seen earlier in a session colors the references that follow it. Enabling or
+// disabling learning forgets the names learned so far.
+func (h *Highlighter) SetLearnNames(enabled bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.learnNames = enabled
+	h.names = nil
+}
+
+// LearnNames returns whether defined names are learned.
+func (h *Highlighter) LearnNames() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.learnNames
+}
+
+// LearnedNames returns the names learned so far, sorted.
+func (h *Highlighter) LearnedNames() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return append([]string(nil), h.names...)
+}
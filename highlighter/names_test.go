@@ -0,0 +1,34 @@
+package highlighter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestLearnedNamesAcrossCalls(t *testing.T) {
+	h := New(WithLearnedNames(), WithMode(lexer.ParseModeConfig))
+	h.SetAlwaysHighlight(true)
+	color := h.Theme().GetColor(lexer.TokenUserDefinedName)
+
+	h.Highlight("ip access-list standard MGMT\n")
+	if got := h.LearnedNames(); !reflect.DeepEqual(got, []string{"MGMT"}) {
+		t.Fatalf("LearnedNames() = %v, want [MGMT]", got)
+	}
+
+	// A definition seen in an earlier call colors references in later ones
+	out := h.Highlight(" access-class MGMT in\n")
+	if !strings.Contains(out, color+"MGMT"+Reset) {
+		t.Errorf("reference not colored as a defined name: %q", out)
+	}
+
+	h.SetLearnNames(false)
+	if names := h.LearnedNames(); names != nil {
+		t.Errorf("disabling learning should forget names, got %v", names)
+	}
+	if out := h.Highlight(" access-class MGMT in\n"); strings.Contains(out, color+"MGMT") {
+		t.Errorf("reference colored with learning off: %q", out)
+	}
+}
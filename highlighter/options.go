@@ -0,0 +1,207 @@
+package highlighter
+
+import (
+	"time"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Option configures a Highlighter created with New.
+type Option func(*Highlighter)
+
+// WithTheme sets the highlighting theme (see SetTheme).
+func WithTheme(theme *Theme) Option {
+	return func(h *Highlighter) {
+		h.sourceTheme = theme
+	}
+}
+
+// WithMode sets the parse mode instead of detecting it (see SetParseMode).
+func WithMode(mode lexer.ParseMode) Option {
+	return func(h *Highlighter) {
+		h.parseMode = mode
+	}
+}
+
+// WithDialect sets the CLI dialect (see SetDialect).
+func WithDialect(dialect lexer.Dialect) Option {
+	return func(h *Highlighter) {
+		h.dialect = dialect
+	}
+}
+
+// WithRedaction scrubs identifying values from input with the Anonymizer a
+// before highlighting it (see SetRedaction).
+func WithRedaction(a *Anonymizer) Option {
+	return func(h *Highlighter) {
+		h.redactor = a
+	}
+}
+
+// WithColorDepth limits theme colors to what the terminal supports (see
+// SetColorDepth).
+func WithColorDepth(depth ColorDepth) Option {
+	return func(h *Highlighter) {
+		h.colorDepth = depth
+	}
+}
+
+// WithDetection turns content detection on or off. With detection off all
+// input is highlighted, as with SetAlwaysHighlight(true).
+func WithDetection(enabled bool) Option {
+	return func(h *Highlighter) {
+		h.alwaysOn = !enabled
+	}
+}
+
+// WithDetectionCache caches the detection results of the last size inputs
+// (see SetDetectionCache).
+func WithDetectionCache(size int) Option {
+	return func(h *Highlighter) {
+		h.detectCache = newDetectionCache(size)
+	}
+}
+
+// WithPinnedDetection stops running content detection once input has been
+// detected as Cisco (see SetPinDetection).
+func WithPinnedDetection() Option {
+	return func(h *Highlighter) {
+		h.pinDetection = true
+	}
+}
+
+// WithHeatmap colors interface counters by value (see SetHeatmap).
+func WithHeatmap(heatmap *Heatmap) Option {
+	return func(h *Highlighter) {
+		h.heatmap = heatmap
+	}
+}
+
+// WithTokenHook installs a hook called for every token before it is
+// rendered (see SetTokenHook).
+func WithTokenHook(hook TokenHook) Option {
+	return func(h *Highlighter) {
+		h.tokenHook = hook
+	}
+}
+
+// WithCommandHook installs a hook called with each command typed at a
+// prompt (see SetCommandHook).
+func WithCommandHook(hook lexer.CommandHook) Option {
+	return func(h *Highlighter) {
+		h.commandHook = hook
+	}
+}
+
+// WithLSAAging emphasizes fresh and old LSAs in OSPF database output (see
+// SetLSAAging).
+func WithLSAAging(fresh, old time.Duration) Option {
+	return func(h *Highlighter) {
+		h.lsaFresh, h.lsaOld = fresh, old
+	}
+}
+
+// WithPoolUsage colors DHCP pools by utilization (see SetPoolUsage).
+func WithPoolUsage(warning, critical float64) Option {
+	return func(h *Highlighter) {
+		h.poolWarning, h.poolCritical = warning, critical
+	}
+}
+
+// WithPercentThresholds sets the thresholds for coloring percentages (see
+// SetPercentThresholds).
+func WithPercentThresholds(warning, critical float64) Option {
+	return func(h *Highlighter) {
+		h.usageWarning, h.usageCritical = warning, critical
+	}
+}
+
+// WithIndentGuides draws guides in the indentation of nested config lines
+// (see SetIndentGuides).
+func WithIndentGuides() Option {
+	return func(h *Highlighter) {
+		h.indentGuides = true
+	}
+}
+
+// WithLineNumbers prefixes each line with its line number (see
+// SetLineNumbers).
+func WithLineNumbers() Option {
+	return func(h *Highlighter) {
+		h.lineNumbers = true
+	}
+}
+
+// WithColumnAlignment keeps table columns aligned when redaction or the token
+// hook changes the width of values (see SetColumnAlignment).
+func WithColumnAlignment() Option {
+	return func(h *Highlighter) {
+		h.alignColumns = true
+	}
+}
+
+// WithLogSeverity hides syslog messages less severe than level (see
+// SetLogSeverity).
+func WithLogSeverity(level int) Option {
+	return func(h *Highlighter) {
+		h.logSeverity = level
+		h.filterLogs = level >= 0 && level < len(LogSeverityNames)
+	}
+}
+
+// WithCompactOutput coalesces adjacent tokens of the same style into one
+// escape sequence (see SetCompactOutput).
+func WithCompactOutput() Option {
+	return func(h *Highlighter) {
+		h.compact = true
+	}
+}
+
+// WithFocus shows only tokens of the given categories in full color (see
+// SetFocus).
+func WithFocus(categories ...lexer.Category) Option {
+	return func(h *Highlighter) {
+		h.focus = append([]lexer.Category(nil), categories...)
+	}
+}
+
+// WithFlapThreshold highlights neighbor uptimes shorter than d as warnings
+// (see SetFlapThreshold).
+func WithFlapThreshold(d time.Duration) Option {
+	return func(h *Highlighter) {
+		h.flapThreshold = d
+	}
+}
+
+// WithStateWords colors words as the given state list in show output (see
+// AddStateWords). Lists other than the four state lists are ignored.
+func WithStateWords(list lexer.WordList, words ...string) Option {
+	return func(h *Highlighter) {
+		if state, ok := stateLists[list]; ok {
+			h.stateWords = withStateWords(h.stateWords, state, words)
+		}
+	}
+}
+
+// WithLearnedNames colors names defined in the input, and references to
+// them, as TokenUserDefinedName (see SetLearnNames).
+func WithLearnedNames() Option {
+	return func(h *Highlighter) {
+		h.learnNames = true
+	}
+}
+
+// WithExplain makes Tokens attach explanations to tokens (see SetExplain).
+func WithExplain() Option {
+	return func(h *Highlighter) {
+		h.explain = true
+	}
+}
+
+// WithRouteExpectations colors unexpected route counts in show ip route
+// summary output (see SetRouteExpectations).
+func WithRouteExpectations(expect map[string]RouteExpectation) Option {
+	return func(h *Highlighter) {
+		h.routeExpect = newRouteRules(expect)
+	}
+}
@@ -0,0 +1,77 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestNewOptions(t *testing.T) {
+	theme := NordTheme()
+	h := New(
+		WithTheme(theme),
+		WithMode(lexer.ParseModeShow),
+		WithDialect(lexer.DialectFRR),
+		WithDetection(false),
+		WithHeatmap(DefaultHeatmap()),
+	)
+
+	if h.theme != theme {
+		t.Error("WithTheme not applied")
+	}
+	if h.ParseMode() != lexer.ParseModeShow {
+		t.Errorf("ParseMode() = %v, want Show", h.ParseMode())
+	}
+	if h.Dialect() != lexer.DialectFRR {
+		t.Errorf("Dialect() = %v, want frr", h.Dialect().Name())
+	}
+	if !h.AlwaysHighlight() {
+		t.Error("WithDetection(false) should highlight all input")
+	}
+	if h.Heatmap() == nil {
+		t.Error("WithHeatmap not applied")
+	}
+
+	// Defaults match the old constructor
+	d := New()
+	if d.Dialect() != lexer.DialectAuto || d.AlwaysHighlight() || d.MinConfidence() != DefaultMinConfidence || !d.IsEnabled() {
+		t.Errorf("unexpected defaults: %+v", d)
+	}
+}
+
+func TestWithTokenHook(t *testing.T) {
+	h := New(WithTokenHook(func(tok lexer.Token) lexer.Token {
+		if tok.Type == lexer.TokenInterface {
+			tok.Value = "IFACE"
+		}
+		return tok
+	}))
+	out := StripANSI(h.HighlightForced("interface GigabitEthernet0/1\n"))
+	if out != "interface IFACE\n" {
+		t.Errorf("hook not applied: %q", out)
+	}
+}
+
+func TestWithRedaction(t *testing.T) {
+	h := New(WithRedaction(NewAnonymizer()))
+
+	out := StripANSI(h.Highlight("interface Gi0/1\n ip address 10.1.2.3 255.255.255.0\n"))
+	if strings.Contains(out, "10.1.2.3") {
+		t.Errorf("address not redacted: %q", out)
+	}
+	if !strings.Contains(out, "255.255.255.0") {
+		t.Errorf("mask should be kept: %q", out)
+	}
+
+	// Input that is not highlighted is still scrubbed
+	out = h.Highlight("ping 10.1.2.3 please")
+	if strings.Contains(out, "10.1.2.3") {
+		t.Errorf("pass-through input not redacted: %q", out)
+	}
+
+	h.SetRedaction(nil)
+	if out := h.Highlight("ping 10.1.2.3 please"); out != "ping 10.1.2.3 please" {
+		t.Errorf("redaction not turned off: %q", out)
+	}
+}
@@ -0,0 +1,91 @@
+package highlighter
+
+import (
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// StripPagination removes pagination artifacts from a terminal capture:
+// --More-- prompts, and the backspace, carriage return and space sequences
+// used to erase them. Overwrites are resolved the way a terminal displays
+// them, so "sh\b\bshow" becomes "show". Lines that contained nothing but a
+// pager prompt are dropped.
+func StripPagination(input string) string {
+	var buf strings.Builder
+	for len(input) > 0 {
+		line, rest, hasNewline := strings.Cut(input, "\n")
+		input = rest
+
+		cleaned, keep := stripPagerLine(line)
+		if !keep {
+			continue
+		}
+		buf.WriteString(cleaned)
+		if hasNewline {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.String()
+}
+
+// stripPagerLine cleans a single line. It returns false if the line held only
+// pagination artifacts and should be dropped.
+func stripPagerLine(line string) (string, bool) {
+	body := strings.TrimSuffix(line, "\r")
+	crlf := len(body) < len(line)
+
+	hasPager := lexer.PagerPattern.MatchString(body)
+	if !hasPager && !strings.ContainsAny(body, "\b\r") {
+		return line, true
+	}
+
+	// Replay the line on a virtual cursor
+	out := make([]byte, 0, len(body))
+	cursor := 0
+	for i := 0; i < len(body); i++ {
+		switch ch := body[i]; ch {
+		case '\b':
+			if cursor > 0 {
+				cursor--
+			}
+		case '\r':
+			cursor = 0
+		default:
+			if cursor < len(out) {
+				out[cursor] = ch
+			} else {
+				out = append(out, ch)
+			}
+			cursor++
+		}
+	}
+
+	cleaned := lexer.PagerPattern.ReplaceAllString(string(out), "")
+	if hasPager || strings.Contains(body, "\b") {
+		cleaned = strings.TrimRight(cleaned, " ")
+		if strings.TrimSpace(cleaned) == "" && hasPager {
+			return "", false
+		}
+	}
+	if crlf {
+		cleaned += "\r"
+	}
+	return cleaned, true
+}
+
+// SetRemovePagination enables stripping of pagination artifacts (see
+// StripPagination) before highlighting. When disabled (the default) they are
+// kept and rendered dimmed as TokenPager.
+func (h *Highlighter) SetRemovePagination(remove bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removePager = remove
+}
+
+// RemovePagination reports whether pagination artifacts are stripped.
+func (h *Highlighter) RemovePagination() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.removePager
+}
@@ -0,0 +1,72 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestStripPagination(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			"ios erase sequence",
+			"Gi0/1  up\n --More-- \b\b\b\b\b\b\b\b\b\b          \b\b\b\b\b\b\b\b\b\bGi0/2  down\n",
+			"Gi0/1  up\nGi0/2  down\n",
+		},
+		{
+			"prompt on its own line",
+			"Gi0/1 up\n<--- More --->\nGi0/2 down\n",
+			"Gi0/1 up\nGi0/2 down\n",
+		},
+		{
+			"carriage return redraw",
+			"--More--\r        \rGi0/2 down\r\n",
+			"Gi0/2 down\r\n",
+		},
+		{
+			"typed correction",
+			"Router#sh\b\bshow version\n",
+			"Router#show version\n",
+		},
+		{
+			"clean input unchanged",
+			"interface Gi0/1\r\n no shutdown\n",
+			"interface Gi0/1\r\n no shutdown\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripPagination(tt.input); got != tt.expected {
+				t.Errorf("StripPagination(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRemovePagination(t *testing.T) {
+	input := "Gi0/1 is up\n --More-- \b\b\b\b\b\b\b\b\b\b          \b\b\b\b\b\b\b\b\b\bGi0/2 is down\n"
+
+	h := New()
+	if h.RemovePagination() {
+		t.Error("pagination should be kept by default")
+	}
+	result := h.HighlightForced(input)
+	if !strings.Contains(result, h.theme.GetColor(lexer.TokenPager)+"--More--") {
+		t.Errorf("pager prompt should be rendered as TokenPager: %q", result)
+	}
+
+	h.SetRemovePagination(true)
+	result = h.HighlightForced(input)
+	if strings.Contains(result, "More") || strings.Contains(result, "\b") {
+		t.Errorf("pager artifacts should be removed: %q", result)
+	}
+	if StripANSI(result) != "Gi0/1 is up\nGi0/2 is down\n" {
+		t.Errorf("unexpected content: %q", StripANSI(result))
+	}
+}
@@ -0,0 +1,106 @@
+package highlighter
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// parallelMinSize is the input size below which HighlightParallel falls back
+// to sequential highlighting, since goroutine overhead outweighs the gain.
+const parallelMinSize = 64 * 1024
+
+// HighlightParallel highlights large configurations by splitting the input on
+// "!" separator lines and tokenizing the sections across a pool of workers.
+// Sections are reassembled in their original order. The parse mode and
+// dialect, unless they were set, are detected once on the whole input so
+// every section is classified the same way. workers <= 0 uses
+// runtime.GOMAXPROCS(0). With line numbers, redaction or learned names on
+// the input is highlighted sequentially.
+// Like Highlight, returns input unchanged if it doesn't look like Cisco.
+func (h *Highlighter) HighlightParallel(input string, workers int) string {
+	if !h.IsEnabled() || input == "" {
+		return input
+	}
+
+	cleaned := StripANSI(input)
+	if h.RemovePagination() {
+		cleaned = StripPagination(cleaned)
+	}
+	if !h.AlwaysHighlight() && !h.looksLikeCisco(cleaned) {
+		return input
+	}
+
+	// Line numbers count on from section to section, redaction numbers its
+	// placeholders in the order it meets the values, and names are learned
+	// from definitions in other sections, so such output is highlighted in
+	// one piece
+	sections := splitSections(cleaned)
+	if len(cleaned) < parallelMinSize || len(sections) < 2 || h.LineNumbers() || h.Redaction() != nil || h.LearnNames() {
+		return h.highlightTokensCleaned(cleaned)
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(sections) {
+		workers = len(sections)
+	}
+
+	mode := h.ParseMode()
+	if mode == lexer.ParseModeAuto {
+		mode = lexer.DetectParseMode(cleaned)
+	}
+	dialect := h.Dialect()
+	if dialect == lexer.DialectAuto {
+		dialect = lexer.DetectDialect(cleaned)
+	}
+	results := make([]string, len(sections))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				lex := h.newLexer(sections[i])
+				lex.SetParseMode(mode)
+				lex.SetDialect(dialect)
+				results[i] = h.renderTokens(lex.Tokenize(), h.themeFor(lex))
+			}
+		}()
+	}
+	for i := range sections {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return strings.Join(results, "")
+}
+
+// splitSections splits input after each "!" separator line. Separators stay
+// attached to the section they close, so joining the result restores input.
+func splitSections(input string) []string {
+	var sections []string
+	start := 0
+	for i := 0; i < len(input); {
+		end := strings.IndexByte(input[i:], '\n')
+		if end < 0 {
+			break
+		}
+		lineEnd := i + end + 1
+		if strings.TrimSpace(input[i:lineEnd]) == "!" {
+			sections = append(sections, input[start:lineEnd])
+			start = lineEnd
+		}
+		i = lineEnd
+	}
+	if start < len(input) {
+		sections = append(sections, input[start:])
+	}
+	return sections
+}
@@ -0,0 +1,132 @@
+package highlighter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func largeConfig(n int) string {
+	var b strings.Builder
+	b.WriteString("hostname core-router-01\n!\n")
+	for i := 0; i < n; i++ {
+		b.WriteString("interface GigabitEthernet0/0/1\n")
+		b.WriteString(" description Uplink to ISP\n")
+		b.WriteString(" ip address 203.0.113.1 255.255.255.252\n")
+		b.WriteString(" no shutdown\n")
+		b.WriteString("!\n")
+	}
+	b.WriteString("end\n")
+	return b.String()
+}
+
+func TestHighlightParallelMatchesSequential(t *testing.T) {
+	h := New()
+	input := largeConfig(2000)
+
+	if len(input) < parallelMinSize {
+		t.Fatalf("test input too small (%d bytes) to exercise the parallel path", len(input))
+	}
+
+	sequential := h.Highlight(input)
+	for _, workers := range []int{0, 1, 4} {
+		if got := h.HighlightParallel(input, workers); got != sequential {
+			t.Errorf("workers=%d: parallel output differs from sequential", workers)
+		}
+	}
+}
+
+func TestHighlightParallelLineNumbers(t *testing.T) {
+	input := largeConfig(2000)
+	sequential, parallel := New(), New()
+	sequential.SetLineNumbers(true)
+	parallel.SetLineNumbers(true)
+
+	want := sequential.Highlight(input)
+	if got := parallel.HighlightParallel(input, 4); got != want {
+		t.Error("numbered parallel output differs from sequential")
+	}
+}
+
+func TestHighlightParallelRedaction(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&b, "interface Vlan%d\n ip address 192.0.%d.%d 255.255.255.0\n!\n", i, i/250, i%250+1)
+	}
+	input := b.String()
+
+	want := New(WithRedaction(NewAnonymizer())).Highlight(input)
+	for _, workers := range []int{0, 4, 8} {
+		if got := New(WithRedaction(NewAnonymizer())).HighlightParallel(input, workers); got != want {
+			t.Errorf("workers=%d: redacted parallel output differs from sequential", workers)
+		}
+	}
+}
+
+func TestHighlightParallelLearnedNames(t *testing.T) {
+	// The access list is defined in the first section and used in the others
+	var b strings.Builder
+	b.WriteString("ip access-list standard MGMT\n permit 192.0.2.0 0.0.0.255\n!\n")
+	for i := 0; i < 2000; i++ {
+		b.WriteString("line vty 0 4\n access-class MGMT in\n!\n")
+	}
+	input := b.String()
+
+	want := New(WithLearnedNames()).Highlight(input)
+	if got := New(WithLearnedNames()).HighlightParallel(input, 4); got != want {
+		t.Error("parallel output differs from sequential with learned names")
+	}
+}
+
+func TestHighlightParallelAutoDialect(t *testing.T) {
+	// Only the first section says it is FRR
+	var b strings.Builder
+	b.WriteString("frr version 8.4\nfrr defaults traditional\nhostname r1\n!\n")
+	for i := 0; i < 2000; i++ {
+		b.WriteString("router bgp 65000\n bgp bestpath as-path multipath-relax\n!\n")
+	}
+	input := b.String()
+
+	h := New(WithDialect(lexer.DialectAuto))
+	if got := h.HighlightParallel(input, 4); got != h.Highlight(input) {
+		t.Error("parallel output differs from sequential")
+	}
+}
+
+func TestHighlightParallelExplicitMode(t *testing.T) {
+	input := largeConfig(2000)
+	h := New(WithMode(lexer.ParseModeShow))
+	if got := h.HighlightParallel(input, 4); got != h.Highlight(input) {
+		t.Error("parallel output should keep the mode set on the highlighter")
+	}
+}
+
+func TestHighlightParallelSmallInput(t *testing.T) {
+	h := New()
+	input := "interface GigabitEthernet0/0/0\n no shutdown\n!\n"
+	if got := h.HighlightParallel(input, 4); got != h.Highlight(input) {
+		t.Error("small input should match sequential highlighting")
+	}
+}
+
+func TestHighlightParallelNonCisco(t *testing.T) {
+	h := New()
+	input := "Hello, this is just some random text"
+	if got := h.HighlightParallel(input, 4); got != input {
+		t.Error("non-Cisco text should be returned unchanged")
+	}
+}
+
+func TestSplitSections(t *testing.T) {
+	input := "hostname r1\n!\ninterface Lo0\n!\nend"
+	sections := splitSections(input)
+
+	if len(sections) != 3 {
+		t.Fatalf("expected 3 sections, got %d: %q", len(sections), sections)
+	}
+	if strings.Join(sections, "") != input {
+		t.Error("joined sections should reproduce the input")
+	}
+}
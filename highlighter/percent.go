@@ -0,0 +1,106 @@
+package highlighter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Default utilization thresholds for SetPercentThresholds, in percent.
+const (
+	DefaultPercentWarning  = 70
+	DefaultPercentCritical = 90
+)
+
+// Labels of percentages where a high value is good, such as idle CPU time
+// or free memory
+var headroomLabels = map[string]bool{
+	"free": true, "idle": true, "available": true, "unused": true,
+	"remaining": true, "success": true,
+}
+
+// SetPercentThresholds colors percentages, such as CPU, memory and interface
+// utilization, by value: below warning in the good-state color, from warning
+// in the warning color, from critical in the bad-state color. Percentages of
+// free or idle capacity are left in the good-state color. It is on by
+// default with DefaultPercentWarning and DefaultPercentCritical; pass 0 for
+// both to color every percentage as good.
+func (h *Highlighter) SetPercentThresholds(warning, critical float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.usageWarning, h.usageCritical = warning, critical
+}
+
+// PercentThresholds returns the thresholds set with SetPercentThresholds.
+func (h *Highlighter) PercentThresholds() (warning, critical float64) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.usageWarning, h.usageCritical
+}
+
+// applyPercentThresholds reclassifies percentages at or above the thresholds:
+//
+//	CPU utilization for five seconds: 95%/2%; one minute: 75%; five minutes: 10%
+//	Memory (kB): Total: 3950000 Used: 3700000 (94%) Free: 250000 (6%)
+func (h *Highlighter) applyPercentThresholds(tokens []lexer.Token) []lexer.Token {
+	h.mu.RLock()
+	warning, critical := h.usageWarning, h.usageCritical
+	h.mu.RUnlock()
+	if warning <= 0 && critical <= 0 {
+		return tokens
+	}
+
+	var out []lexer.Token
+	for i, tok := range tokens {
+		if tok.Type != lexer.TokenPercentage || isHeadroom(tokens, i) {
+			continue
+		}
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(tok.Value, "%"), 64)
+		if err != nil {
+			continue
+		}
+		t, ok := classifyUsage(percent, warning, critical)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make([]lexer.Token, len(tokens))
+			copy(out, tokens)
+		}
+		out[i].Type = t
+	}
+	if out == nil {
+		return tokens
+	}
+	return out
+}
+
+// isHeadroom reports whether the percentage tokens[i] is labeled as free or
+// idle capacity, by the label before its value ("Free: 250000 (6%)") or the
+// word after it ("95.3% idle").
+func isHeadroom(tokens []lexer.Token, i int) bool {
+	line := tokens[i].Line
+	for j := i - 1; j >= 0 && tokens[j].Line == line; j-- {
+		w := strings.TrimSpace(tokens[j].Value)
+		if w == "" || w == "(" || tokens[j].Type == lexer.TokenNumber {
+			continue
+		}
+		if headroomLabels[strings.ToLower(strings.TrimRight(w, ":"))] {
+			return true
+		}
+		break
+	}
+	for j := i + 1; j < len(tokens) && tokens[j].Line == line; j++ {
+		w := strings.TrimSpace(tokens[j].Value)
+		if w == "" || strings.Trim(w, ",;)") == "" {
+			continue
+		}
+		// "Used: 94% Free: 6%": a label of the next value
+		if strings.HasSuffix(w, ":") {
+			return false
+		}
+		return headroomLabels[strings.ToLower(strings.TrimRight(w, ",;"))]
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package highlighter
+
+import (
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestPercentThresholds(t *testing.T) {
+	types := func(h *Highlighter, input string) map[string]lexer.TokenType {
+		lex := lexer.New(input)
+		lex.SetParseMode(lexer.ParseModeShow)
+		got := make(map[string]lexer.TokenType)
+		for _, tok := range h.processTokens(lex.Tokenize()) {
+			if tok.Type == lexer.TokenPercentage || tok.Type == lexer.TokenStateWarning || tok.Type == lexer.TokenStateBad {
+				got[tok.Value] = tok.Type
+			}
+		}
+		return got
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]lexer.TokenType
+	}{
+		{
+			name:  "cpu",
+			input: "CPU utilization for five seconds: 95%/2%; one minute: 75%; five minutes: 10%\n",
+			want: map[string]lexer.TokenType{
+				"95%": lexer.TokenStateBad, "2%": lexer.TokenPercentage,
+				"75%": lexer.TokenStateWarning, "10%": lexer.TokenPercentage,
+			},
+		},
+		{
+			name:  "memory",
+			input: "Memory (kB): Total: 3950000 Used: 3700000 (94%) Free: 250000 (6%)\n",
+			want:  map[string]lexer.TokenType{"94%": lexer.TokenStateBad, "6%": lexer.TokenPercentage},
+		},
+		{
+			name:  "free memory is headroom",
+			input: "Used: 20% Free: 80%\n",
+			want:  map[string]lexer.TokenType{"20%": lexer.TokenPercentage, "80%": lexer.TokenPercentage},
+		},
+		{
+			name:  "idle after the value",
+			input: "CPU states  :   3.50% user,   1.20% kernel,   95.30% idle\n",
+			want: map[string]lexer.TokenType{
+				"3.50%": lexer.TokenPercentage, "1.20%": lexer.TokenPercentage, "95.30%": lexer.TokenPercentage,
+			},
+		},
+		{
+			name:  "boundaries",
+			input: "load 69.9% 70% 89.9% 90%\n",
+			want: map[string]lexer.TokenType{
+				"69.9%": lexer.TokenPercentage, "70%": lexer.TokenStateWarning,
+				"89.9%": lexer.TokenStateWarning, "90%": lexer.TokenStateBad,
+			},
+		},
+	}
+
+	h := New()
+	for _, tt := range tests {
+		got := types(h, tt.input)
+		for value, want := range tt.want {
+			if got[value] != want {
+				t.Errorf("%s: %s is %v, want %v", tt.name, value, got[value], want)
+			}
+		}
+	}
+
+	if warning, critical := h.PercentThresholds(); warning != DefaultPercentWarning || critical != DefaultPercentCritical {
+		t.Errorf("PercentThresholds() = %v, %v", warning, critical)
+	}
+	h.SetPercentThresholds(50, 60)
+	if got := types(h, "load 55%\n"); got["55%"] != lexer.TokenStateWarning {
+		t.Errorf("custom thresholds: 55%% is %v", got["55%"])
+	}
+	h.SetPercentThresholds(0, 0)
+	if got := types(h, "load 99%\n"); got["99%"] != lexer.TokenPercentage {
+		t.Errorf("thresholds still applied after turning them off: %v", got["99%"])
+	}
+}
@@ -0,0 +1,123 @@
+package highlighter
+
+import (
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// previewSamples are the words ThemePreview shows for each token type.
+var previewSamples = map[lexer.TokenType]string{
+	lexer.TokenCommand:            "router",
+	lexer.TokenSection:            "interface",
+	lexer.TokenProtocol:           "ospf",
+	lexer.TokenAction:             "permit",
+	lexer.TokenKeyword:            "remote-as",
+	lexer.TokenOperator:           "eq",
+	lexer.TokenNegation:           "no",
+	lexer.TokenComment:            "!",
+	lexer.TokenColumnHeader:       "Interface",
+	lexer.TokenStatusSymbol:       "*>",
+	lexer.TokenPromptMode:         "(config-if)",
+	lexer.TokenPromptOper:         ">",
+	lexer.TokenPromptConf:         "#",
+	lexer.TokenInterface:          "GigabitEthernet0/1",
+	lexer.TokenIdentifier:         "RM-IN",
+	lexer.TokenVRF:                "CUST-A",
+	lexer.TokenPromptHost:         "R1",
+	lexer.TokenUserDefinedName:    "RM-IN",
+	lexer.TokenIPv4:               "10.0.0.1",
+	lexer.TokenIPv4Prefix:         "10.0.0.0/24",
+	lexer.TokenIPv6:               "2001:db8::1",
+	lexer.TokenIPv6Prefix:         "2001:db8::/32",
+	lexer.TokenMAC:                "0011.2233.4455",
+	lexer.TokenSubnetMask:         "255.255.255.0",
+	lexer.TokenWildcardMask:       "0.0.0.255",
+	lexer.TokenVirtualIP:          "10.0.0.254",
+	lexer.TokenNumber:             "1500",
+	lexer.TokenString:             `"text"`,
+	lexer.TokenValue:              "Uplink",
+	lexer.TokenASN:                "65000",
+	lexer.TokenPrivateASN:         "64512",
+	lexer.TokenCommunity:          "65000:100",
+	lexer.TokenTimeDuration:       "1d02h",
+	lexer.TokenPercentage:         "42%",
+	lexer.TokenByteSize:           "1.5G",
+	lexer.TokenRouteProtocol:      "[OSPF/110]",
+	lexer.TokenBridgeID:           "32769.0011.2233.4455",
+	lexer.TokenRouteDistinguisher: "65000:1",
+	lexer.TokenRouteTarget:        "65000:2",
+	lexer.TokenVersion:            "17.9.4",
+	lexer.TokenModel:              "C9300-48P",
+	lexer.TokenSerial:             "FCW2233L0AB",
+	lexer.TokenUptime:             "3 weeks",
+	lexer.TokenMemorySize:         "2048K",
+	lexer.TokenConfigRegister:     "0x2102",
+	lexer.TokenMPLSLabel:          "16",
+	lexer.TokenRate:               "10Gbps",
+	lexer.TokenTimestamp:          "Mar 01 2024",
+	lexer.TokenHex:                "0x7F3A2B10",
+	lexer.TokenHexDump:            "3082032E",
+	lexer.TokenStateGood:          "up",
+	lexer.TokenStateBad:           "down",
+	lexer.TokenStateWarning:       "init",
+	lexer.TokenStateNeutral:       "standby",
+	lexer.TokenError:              "% Invalid input",
+	lexer.TokenStale:              "stale",
+	lexer.TokenSecret:             "0822455D0A16",
+}
+
+// previewWidth is the column ThemePreview wraps sample lines before.
+const previewWidth = 80
+
+// ThemePreview renders a sample of every token type in theme, one line per
+// token category (wrapped if long) with the category name in front:
+//
+//	Structure  router interface ospf permit remote-as eq no ! ...
+//	Name       GigabitEthernet0/1 RM-IN CUST-A R1
+//	Address    10.0.0.1 10.0.0.0/24 2001:db8::1 ...
+//
+// It is for choosing and developing themes without highlighting a whole
+// config to see every color.
+func ThemePreview(theme *Theme) string {
+	labelWidth := 0
+	for _, c := range lexer.AllCategories() {
+		labelWidth = max(labelWidth, len(c.String()))
+	}
+	indent := strings.Repeat(" ", labelWidth+2)
+
+	var buf strings.Builder
+	for _, c := range lexer.AllCategories() {
+		if c == lexer.CategoryText {
+			continue
+		}
+		buf.WriteString(c.String())
+		buf.WriteString(indent[len(c.String()):])
+		col := len(indent)
+		first := true
+		for _, t := range lexer.AllTokenTypes() {
+			sample, ok := previewSamples[t]
+			if !ok || t.Category() != c {
+				continue
+			}
+			switch {
+			case first:
+			case col+1+len(sample) > previewWidth:
+				buf.WriteString("\n" + indent)
+				col = len(indent)
+			default:
+				buf.WriteByte(' ')
+				col++
+			}
+			first = false
+			if color := theme.GetColor(t); color != "" {
+				buf.WriteString(color + sample + Reset)
+			} else {
+				buf.WriteString(sample)
+			}
+			col += len(sample)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
@@ -0,0 +1,35 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestThemePreview(t *testing.T) {
+	// Every type a theme colors must show up in the preview
+	for _, tt := range lexer.AllTokenTypes() {
+		if _, ok := previewSamples[tt]; !ok && tt.Category() != lexer.CategoryText {
+			t.Errorf("%v has no preview sample", tt)
+		}
+	}
+
+	theme := NordTheme()
+	out := ThemePreview(theme)
+	if !strings.Contains(out, theme.GetColor(lexer.TokenInterface)+"GigabitEthernet0/1"+Reset) {
+		t.Error("samples should be colored by the theme")
+	}
+
+	plain := StripANSI(out)
+	for _, line := range strings.Split(strings.TrimSuffix(plain, "\n"), "\n") {
+		if len(line) > previewWidth {
+			t.Errorf("line longer than %d columns: %q", previewWidth, line)
+		}
+	}
+	for _, c := range lexer.AllCategories() {
+		if has := strings.Contains(plain, "\n"+c.String()+" ") || strings.HasPrefix(plain, c.String()+" "); has != (c != lexer.CategoryText) {
+			t.Errorf("label %s shown = %v", c, has)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package highlighter
+
+import (
+	"sort"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Result is highlighted input together with the tokens it was rendered from
+// and what was found in it, for integrators that display input and act on
+// it without tokenizing it twice.
+type Result struct {
+	// Rendered is the input with ANSI colors, as HighlightForced returns it,
+	// or the input unchanged when highlighting is disabled.
+	Rendered string
+
+	// Tokens are the tokens Rendered was colored from, as Tokens returns them.
+	Tokens []lexer.Token
+
+	// Findings are the bad and warning states Summarize finds in show output
+	// (interfaces down, BGP and OSPF neighbors not up, other state words),
+	// and the reversible type 7 passwords in any input, in order of line.
+	Findings []Finding
+}
+
+// HighlightResult highlights input and reports the tokens and findings of
+// the same tokenization. Input is always tokenized, as in HighlightForced,
+// with ANSI codes and pagination removed and redaction applied; unlike
+// HighlightForced, cursor control sequences in the input are not kept.
+func (h *Highlighter) HighlightResult(input string) *Result {
+	if input == "" {
+		return &Result{}
+	}
+
+	cleaned := StripANSI(input)
+	if h.RemovePagination() {
+		cleaned = StripPagination(cleaned)
+	}
+	lex := h.newLexer(cleaned)
+	raw := h.lex(lex)
+
+	// Findings come from the lexer's tokens, before options such as focus
+	// and line numbers change them
+	findings := summarizeTokens(joinTokens(raw), raw, lex.GetParseMode()).Findings
+	for _, tok := range raw {
+		if tok.Type == lexer.TokenSecret {
+			findings = append(findings, Finding{
+				Severity: lexer.TokenStateWarning,
+				Kind:     "secret",
+				State:    "type 7",
+				Line:     tok.Line,
+			})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Line < findings[j].Line
+	})
+
+	tokens := h.processTokens(raw)
+	rendered := input
+	if h.IsEnabled() {
+		rendered = h.writeTokens(tokens, h.themeFor(lex))
+	}
+	return &Result{Rendered: rendered, Tokens: tokens, Findings: findings}
+}
@@ -0,0 +1,60 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestHighlightResult(t *testing.T) {
+	h := New()
+	input := "Interface              IP-Address      OK? Method Status                Protocol\n" +
+		"GigabitEthernet0/1     10.0.0.1        YES NVRAM  up                    up\n" +
+		"GigabitEthernet0/2     unassigned      YES NVRAM  down                  down\n"
+
+	r := h.HighlightResult(input)
+	if r.Rendered != h.HighlightForced(input) {
+		t.Errorf("Rendered differs from HighlightForced:\n%q\n%q", r.Rendered, h.HighlightForced(input))
+	}
+	if got := joinTokens(r.Tokens); got != input {
+		t.Errorf("tokens spell %q, want the input", got)
+	}
+	want := []Finding{{Severity: lexer.TokenStateBad, Kind: "interface", State: "down", Name: "GigabitEthernet0/2", Line: 3}}
+	if len(r.Findings) != 1 || r.Findings[0] != want[0] {
+		t.Errorf("Findings = %+v, want %+v", r.Findings, want)
+	}
+}
+
+func TestHighlightResultSecrets(t *testing.T) {
+	h := New()
+	r := h.HighlightResult("hostname r1\nusername admin password 7 0822455D0A16\ninterface Gi0/1\n shutdown\n")
+
+	// Configuration has no states, only secrets
+	want := Finding{Severity: lexer.TokenStateWarning, Kind: "secret", State: "type 7", Line: 2}
+	if len(r.Findings) != 1 || r.Findings[0] != want {
+		t.Errorf("Findings = %+v, want %+v", r.Findings, want)
+	}
+}
+
+func TestHighlightResultOptions(t *testing.T) {
+	h := New(WithLineNumbers())
+	h.Disable()
+	input := "Interface              IP-Address      OK? Method Status                Protocol\n" +
+		"GigabitEthernet0/1     unassigned      YES NVRAM  down                  down\n"
+
+	r := h.HighlightResult(input)
+	if r.Rendered != input {
+		t.Errorf("disabled highlighting should return input, got %q", r.Rendered)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(joinTokens(r.Tokens)), "1 ") {
+		t.Errorf("tokens should carry line numbers, got %q", joinTokens(r.Tokens))
+	}
+	// Findings count lines of the input, not of the numbered output
+	if len(r.Findings) == 0 || r.Findings[0].Line != 2 || r.Findings[0].Kind != "interface" {
+		t.Errorf("Findings = %+v, want the interface down on line 2", r.Findings)
+	}
+	if got := h.HighlightResult(""); got.Rendered != "" || got.Tokens != nil || got.Findings != nil {
+		t.Errorf("empty input should give an empty result, got %+v", got)
+	}
+}
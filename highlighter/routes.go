@@ -0,0 +1,177 @@
+package highlighter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// RouteExpectation is the number of routes a route source is expected to
+// hold in show ip route summary output, counting its networks and subnets.
+type RouteExpectation struct {
+	Min int // fewer routes are a warning, none at all is bad
+	Max int // more routes are a warning, 0 for no limit
+}
+
+// routeRules maps lowercase route sources to their expectations.
+type routeRules map[string]RouteExpectation
+
+// SetRouteExpectations colors the route counts of show ip route summary
+// output that are outside what is expected of their source: a source with no
+// routes at all in the bad-state color, fewer or more routes than expected in
+// the warning color. Sources are named as in the table's first column, with
+// or without the process ID or AS number: "bgp", "ospf 1", "connected",
+// "total". The counts of sources not listed are left alone; pass nil to turn
+// it off.
+func (h *Highlighter) SetRouteExpectations(expect map[string]RouteExpectation) {
+	rules := newRouteRules(expect)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.routeExpect = rules
+}
+
+// newRouteRules copies expect with the sources normalized, or returns nil
+// if it is empty.
+func newRouteRules(expect map[string]RouteExpectation) routeRules {
+	if len(expect) == 0 {
+		return nil
+	}
+	rules := make(routeRules, len(expect))
+	for source, e := range expect {
+		rules[strings.Join(strings.Fields(strings.ToLower(source)), " ")] = e
+	}
+	return rules
+}
+
+// RouteExpectations returns the expectations set with SetRouteExpectations.
+func (h *Highlighter) RouteExpectations() map[string]RouteExpectation {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.routeExpect) == 0 {
+		return nil
+	}
+	expect := make(map[string]RouteExpectation, len(h.routeExpect))
+	for source, e := range h.routeExpect {
+		expect[source] = e
+	}
+	return expect
+}
+
+// applyRouteExpectations reclassifies the Networks and Subnets counts of
+// route sources whose total is unexpected:
+//
+//	Route Source    Networks    Subnets     Replicates  Overhead    Memory (bytes)
+//	connected       0           4           0           384         1216
+//	bgp 65000       120         450         0           54720       173280
+//
+// Like the uptime column of applyFlapEmphasis, the columns of the counts are
+// taken from the header and remembered across calls.
+func (h *Highlighter) applyRouteExpectations(tokens []lexer.Token) []lexer.Token {
+	h.mu.RLock()
+	rules, columns := h.routeExpect, h.routeColumns
+	h.mu.RUnlock()
+	if len(rules) == 0 {
+		return tokens
+	}
+
+	out := make([]lexer.Token, len(tokens))
+	copy(out, tokens)
+	for start := 0; start < len(out); {
+		end := start
+		for end < len(out) && out[end].Line == out[start].Line {
+			end++
+		}
+		columns = routeSummaryLine(out[start:end], rules, columns)
+		start = end
+	}
+
+	h.mu.Lock()
+	h.routeColumns = columns
+	h.mu.Unlock()
+	return out
+}
+
+// routeSummaryLine reclassifies the counts on one line of output, given the
+// Networks and Subnets columns of the table being shown, and returns the
+// columns after it.
+func routeSummaryLine(line []lexer.Token, rules routeRules, columns [2]span) [2]span {
+	var words []int // indexes of the non-blank tokens
+	for i, tok := range line {
+		if tok.Type == lexer.TokenPromptHost {
+			return [2]span{}
+		}
+		if strings.TrimSpace(tok.Value) != "" {
+			words = append(words, i)
+		}
+	}
+	if len(words) == 0 {
+		return columns
+	}
+
+	if first := line[words[0]]; first.Type == lexer.TokenColumnHeader {
+		// A new table: its header either has the count columns or not
+		columns = [2]span{}
+		for _, i := range words {
+			switch strings.ToLower(line[i].Value) {
+			case "networks":
+				columns[0] = tokenSpan(line[i])
+			case "subnets":
+				columns[1] = tokenSpan(line[i])
+			}
+		}
+		if columns[0] == (span{}) || columns[1] == (span{}) {
+			return [2]span{}
+		}
+		return columns
+	}
+	if columns == ([2]span{}) {
+		return columns
+	}
+
+	// Rows start with the source, "ospf 1", "connected" or "Total", in the
+	// first column; the lines indented below them break its routes down.
+	// Highlighted a line at a time, the lexer does not know the table, so
+	// the source is not necessarily a protocol token.
+	first := line[words[0]]
+	if first.Column != 1 {
+		return columns
+	}
+	protocol := strings.ToLower(first.Value)
+	source := protocol
+	var counts []int
+	total := 0
+	for _, i := range words[1:] {
+		tok := line[i]
+		inCounts := tokenSpan(tok).overlaps(columns[0]) || tokenSpan(tok).overlaps(columns[1])
+		switch {
+		case inCounts && tok.Type == lexer.TokenNumber:
+			n, _ := strconv.Atoi(tok.Value)
+			total += n
+			counts = append(counts, i)
+		case len(counts) == 0 && tokenSpan(tok).end <= columns[0].start:
+			source += " " + strings.ToLower(tok.Value)
+		}
+	}
+	e, ok := rules[source]
+	if !ok {
+		e, ok = rules[protocol]
+	}
+	if !ok || len(counts) == 0 {
+		return columns
+	}
+
+	var t lexer.TokenType
+	switch {
+	case total == 0 && e.Min > 0:
+		t = lexer.TokenStateBad
+	case total < e.Min, e.Max > 0 && total > e.Max:
+		t = lexer.TokenStateWarning
+	default:
+		return columns
+	}
+	for _, i := range counts {
+		line[i].Type = t
+	}
+	return columns
+}
@@ -0,0 +1,77 @@
+package highlighter
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestRouteExpectations(t *testing.T) {
+	input := `Route Source    Networks    Subnets     Replicates  Overhead    Memory (bytes)
+application     0           0           0           0           0
+connected       0           4           0           384         1216
+static          1           0           0           96          304
+ospf 1          0           12          0           1152        3648
+  Intra-area: 6 Inter-area: 6 External-1: 0 External-2: 0
+bgp 65000       120         450         0           54720       173280
+Total           126         466         0           56352       202428
+`
+	h := New(WithRouteExpectations(map[string]RouteExpectation{
+		"Connected":   {Min: 1},
+		"application": {Min: 1},
+		"ospf  1":     {Min: 1, Max: 10},
+		"ospf":        {Min: 100}, // "ospf 1" is more specific
+		"bgp":         {Min: 1000},
+		"total":       {Max: 1000},
+	}))
+	if got := h.RouteExpectations(); got["ospf 1"] != (RouteExpectation{Min: 1, Max: 10}) {
+		t.Errorf("RouteExpectations() = %v, want sources normalized", got)
+	}
+
+	// The Networks and Subnets counts by line, each line highlighted on its
+	// own as the CLI does, so the lexer does not recognize the table
+	counts := make(map[int][]lexer.TokenType)
+	for i, line := range strings.SplitAfter(input, "\n") {
+		lex := lexer.New(line)
+		lex.SetParseMode(lexer.ParseModeShow)
+		for _, tok := range h.processTokens(lex.Tokenize()) {
+			if tok.Column >= 17 && tok.Column < 41 && strings.TrimSpace(tok.Value) != "" {
+				counts[i+1] = append(counts[i+1], tok.Type)
+			}
+			if tok.Value == "1" && tok.Column == 6 && tok.Type != lexer.TokenNumber {
+				t.Errorf("process ID 1 is %v, want it left alone", tok.Type)
+			}
+		}
+	}
+
+	bad, warning, number := lexer.TokenStateBad, lexer.TokenStateWarning, lexer.TokenNumber
+	tests := []struct {
+		line int
+		want []lexer.TokenType
+	}{
+		{2, []lexer.TokenType{bad, bad}},         // no routes at all
+		{3, []lexer.TokenType{number, number}},   // as expected
+		{4, []lexer.TokenType{number, number}},   // no expectation
+		{5, []lexer.TokenType{warning, warning}}, // more than expected of ospf 1
+		{7, []lexer.TokenType{warning, warning}}, // fewer than expected
+		{8, []lexer.TokenType{number, number}},
+	}
+	for _, tt := range tests {
+		if got := counts[tt.line]; !slices.Equal(got, tt.want) {
+			t.Errorf("line %d: counts %v, want %v", tt.line, got, tt.want)
+		}
+	}
+
+	// Off by default, and without a header the columns are unknown
+	for _, h := range []*Highlighter{New(), New(WithRouteExpectations(map[string]RouteExpectation{"application": {Min: 1}}))} {
+		lex := lexer.New("application     0           0           0           0           0\n")
+		lex.SetParseMode(lexer.ParseModeShow)
+		for _, tok := range h.processTokens(lex.Tokenize()) {
+			if tok.Type == lexer.TokenStateBad {
+				t.Errorf("%q flagged outside a route summary", tok.Value)
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package highlighter
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// SessionHighlighter highlights the output of one interactive session as it
+// arrives, a line or a partial line at a time, for terminal proxies. Unlike
+// Highlighter, which classifies every call on its own, it carries context
+// from chunk to chunk:
+//
+//   - the parse mode of the current command's output, taken from the command
+//     typed at the prompt ("show run" is config, other show commands are
+//     show output) or detected from the first lines of output, and kept
+//     until the next prompt
+//   - the dialect, detected once from the session
+//   - the hostname from the last prompt
+//
+// A parse mode or dialect set on the highlighter itself is used as is.
+type SessionHighlighter struct {
+	hl *Highlighter
+
+	mu      sync.Mutex
+	mode    lexer.ParseMode // mode of the current command's output, ParseModeAuto until known
+	dialect lexer.Dialect   // dialect detected from the session, DialectAuto until known
+	line    []byte          // text of the incomplete last line, without escapes
+}
+
+// NewSession creates a SessionHighlighter configured by opts, like New.
+func NewSession(opts ...Option) *SessionHighlighter {
+	return &SessionHighlighter{
+		hl:      New(opts...),
+		dialect: lexer.DialectAuto,
+	}
+}
+
+// Highlighter returns the highlighter used for the session, to change its
+// theme or other settings while the session runs.
+func (s *SessionHighlighter) Highlighter() *Highlighter {
+	return s.hl
+}
+
+// Highlight highlights the next chunk of session output, like
+// HighlightForced. Chunks may end anywhere, but a token split across two
+// chunks is classified in two halves.
+func (s *SessionHighlighter) Highlight(chunk string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var buf strings.Builder
+	start := 0
+	for i := 0; i < len(chunk); {
+		end := len(chunk)
+		if j := strings.IndexByte(chunk[i:], '\n'); j >= 0 {
+			end = i + j + 1
+		}
+		command, prompt := s.observe(chunk[i:end])
+		if prompt {
+			// What follows is the output of a new command
+			buf.WriteString(s.highlight(chunk[start:end]))
+			start = end
+			s.startCommand(command)
+		}
+		i = end
+	}
+	buf.WriteString(s.highlight(chunk[start:]))
+	return buf.String()
+}
+
+// observe adds text, at most one line, to the current line. Once the line is
+// complete it reports the command if the line was a prompt line.
+func (s *SessionHighlighter) observe(text string) (command string, prompt bool) {
+	for _, r := range StripANSI(text) {
+		switch r {
+		case '\b':
+			// Command line editing erases what was echoed
+			_, size := utf8.DecodeLastRune(s.line)
+			s.line = s.line[:len(s.line)-size]
+		case '\n':
+		default:
+			s.line = utf8.AppendRune(s.line, r)
+		}
+	}
+	if !strings.HasSuffix(text, "\n") {
+		return "", false
+	}
+
+	line := strings.TrimRight(string(s.line), "\r")
+	s.line = s.line[:0]
+	return lexer.PromptCommand(line)
+}
+
+// startCommand resets the per-command state for the output of command.
+func (s *SessionHighlighter) startCommand(command string) {
+	s.mode = lexer.CommandParseMode(command)
+}
+
+// highlight highlights text with the session's parse mode and dialect,
+// detecting them from text while unknown.
+func (s *SessionHighlighter) highlight(text string) string {
+	h := s.hl
+	if !h.IsEnabled() || text == "" {
+		return text
+	}
+
+	fixedMode := h.ParseMode() != lexer.ParseModeAuto
+	autoDialect := h.Dialect() == lexer.DialectAuto
+	// Only whole lines of output tell what the output is
+	if cleaned := StripANSI(text); strings.Contains(cleaned, "\n") {
+		if !fixedMode && s.mode == lexer.ParseModeAuto {
+			s.mode = lexer.DetectParseMode(cleaned)
+		}
+		if autoDialect && s.dialect == lexer.DialectAuto {
+			if d := lexer.DetectDialect(cleaned); d.DetectScore(cleaned) > 0 {
+				s.dialect = d
+			}
+		}
+	}
+
+	var buf strings.Builder
+	for _, seg := range extractSegments(text) {
+		if seg.isEscape {
+			buf.WriteString(seg.text)
+			continue
+		}
+		cleaned := seg.text
+		if h.RemovePagination() {
+			cleaned = StripPagination(cleaned)
+		}
+		lex := h.newLexer(cleaned)
+		if !fixedMode && s.mode != lexer.ParseModeAuto {
+			lex.SetParseMode(s.mode)
+		}
+		if autoDialect && s.dialect != lexer.DialectAuto {
+			lex.SetDialect(s.dialect)
+		}
+		buf.WriteString(h.renderTokens(h.lex(lex), h.themeFor(lex)))
+	}
+	return buf.String()
+}
+
+// Mode returns the parse mode of the current command's output,
+// lexer.ParseModeAuto until it is known.
+func (s *SessionHighlighter) Mode() lexer.ParseMode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mode
+}
+
+// Dialect returns the dialect detected from the session, lexer.DialectAuto
+// until there is evidence of one.
+func (s *SessionHighlighter) Dialect() lexer.Dialect {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dialect
+}
+
+// Host returns the hostname from the last prompt seen, or "" if none.
+func (s *SessionHighlighter) Host() string {
+	return s.hl.CurrentHost()
+}
+
+// Reset forgets the session context, e.g. after reconnecting to another
+// device. Settings of the highlighter are kept.
+func (s *SessionHighlighter) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mode, s.dialect = lexer.ParseModeAuto, lexer.DialectAuto
+	s.line = s.line[:0]
+	s.hl.SetHost("")
+}
@@ -0,0 +1,75 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestSessionHighlighter(t *testing.T) {
+	s := NewSession()
+
+	// A live session: the prompt, the command echoed a few characters at a
+	// time with a correction, then output a line per chunk
+	chunks := []string{
+		"core-rtr-01#", "sh", "x\b \b", " run\r\n",
+		"Building configuration...\r\n",
+		"interface GigabitEthernet0/1\r\n",
+		" description uplink\r\n",
+	}
+	var out strings.Builder
+	for _, c := range chunks {
+		out.WriteString(s.Highlight(c))
+	}
+	if got := StripANSI(out.String()); got != strings.Join(chunks, "") {
+		t.Errorf("text changed: %q", got)
+	}
+	if s.Mode() != lexer.ParseModeConfig {
+		t.Errorf("Mode() = %v, want Config after show run", s.Mode())
+	}
+	if s.Host() != "core-rtr-01" {
+		t.Errorf("Host() = %q", s.Host())
+	}
+
+	// The next prompt starts a new command, whose mode sticks for a line
+	// that would not be taken for show output on its own
+	s.Highlight("core-rtr-01#show interfaces\r\n")
+	if s.Mode() != lexer.ParseModeShow {
+		t.Errorf("Mode() = %v, want Show", s.Mode())
+	}
+	down := s.hl.theme.GetColor(lexer.TokenStateBad) + "down" + Reset
+	line := "Vlan1 is administratively down, line protocol is down\r\n"
+	if got := s.Highlight(line); !strings.Contains(got, down) {
+		t.Errorf("down should be a bad state in show output, got %q", got)
+	}
+	if got := New().HighlightForced(line); strings.Contains(got, down) {
+		t.Errorf("the line alone should not be taken for show output, got %q", got)
+	}
+
+	s.Reset()
+	if s.Mode() != lexer.ParseModeAuto || s.Host() != "" {
+		t.Errorf("Reset should forget the session, got %v %q", s.Mode(), s.Host())
+	}
+}
+
+func TestSessionDetectsMode(t *testing.T) {
+	s := NewSession()
+	s.Highlight("R1#ping 10.0.0.1\r\n")
+	if s.Mode() != lexer.ParseModeAuto {
+		t.Fatalf("Mode() = %v before any output", s.Mode())
+	}
+	s.Highlight("Interface              IP-Address      OK? Method Status                Protocol\r\n")
+	s.Highlight("GigabitEthernet0/0     10.0.0.1        YES NVRAM  up                    up\r\n")
+	if s.Mode() != lexer.ParseModeShow {
+		t.Errorf("Mode() = %v, want Show detected from the output", s.Mode())
+	}
+}
+
+func TestSessionFixedMode(t *testing.T) {
+	s := NewSession(WithMode(lexer.ParseModeShow))
+	s.Highlight("R1#show run\r\ninterface Gi0/1\r\n")
+	if s.Highlighter().ParseMode() != lexer.ParseModeShow {
+		t.Error("the highlighter's own mode should be left alone")
+	}
+}
@@ -0,0 +1,70 @@
+package highlighter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// stateLists maps the state word lists to the token type of their words.
+var stateLists = map[lexer.WordList]lexer.TokenType{
+	lexer.WordsStatesGood:    lexer.TokenStateGood,
+	lexer.WordsStatesBad:     lexer.TokenStateBad,
+	lexer.WordsStatesWarning: lexer.TokenStateWarning,
+	lexer.WordsStatesNeutral: lexer.TokenStateNeutral,
+}
+
+// AddStateWords colors words as the given state list (lexer.WordsStatesGood,
+// WordsStatesBad, WordsStatesWarning or WordsStatesNeutral) in show output
+// highlighted by h, such as platform-specific port states like "secViolEr".
+// A word already in another state list moves to this one. Unlike
+// lexer.AddWords, the change applies to this highlighter only. Words are
+// matched case-insensitively.
+func (h *Highlighter) AddStateWords(list lexer.WordList, words ...string) error {
+	state, ok := stateLists[list]
+	if !ok {
+		return fmt.Errorf("%q is not a state word list", list)
+	}
+	h.setStateWords(state, words)
+	return nil
+}
+
+// RemoveStateWords stops coloring words as states in show output highlighted
+// by h, whether they come from the built-in lists or AddStateWords.
+func (h *Highlighter) RemoveStateWords(words ...string) {
+	h.setStateWords(lexer.TokenText, words)
+}
+
+// StateWords returns the state overrides added to h, as a map from
+// lowercase word to state type; removed words map to lexer.TokenText.
+func (h *Highlighter) StateWords() lexer.StateWords {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	out := make(lexer.StateWords, len(h.stateWords))
+	for w, t := range h.stateWords {
+		out[w] = t
+	}
+	return out
+}
+
+// setStateWords gives words the state type t. The overrides are copied on
+// write, since lexers created earlier may still be reading the old map.
+func (h *Highlighter) setStateWords(t lexer.TokenType, words []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stateWords = withStateWords(h.stateWords, t, words)
+}
+
+func withStateWords(old lexer.StateWords, t lexer.TokenType, words []string) lexer.StateWords {
+	m := make(lexer.StateWords, len(old)+len(words))
+	for w, s := range old {
+		m[w] = s
+	}
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			m[w] = t
+		}
+	}
+	return m
+}
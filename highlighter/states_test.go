@@ -0,0 +1,56 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestStateWords(t *testing.T) {
+	const input = "Gi1/0/1  secViolEr  Standby  down\n"
+
+	h := New(WithMode(lexer.ParseModeShow), WithStateWords(lexer.WordsStatesBad, "secViolEr"))
+	if err := h.AddStateWords(lexer.WordsStatesGood, "Standby"); err != nil {
+		t.Fatal(err)
+	}
+	h.RemoveStateWords("down")
+	if err := h.AddStateWords(lexer.WordsKeywords, "x"); err == nil {
+		t.Error("expected an error for a list that is not a state list")
+	}
+
+	theme := h.Theme()
+	out := h.HighlightForced(input)
+	for _, want := range []string{
+		theme.GetColor(lexer.TokenStateBad) + "secViolEr",
+		theme.GetColor(lexer.TokenStateGood) + "Standby",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in %q", want, out)
+		}
+	}
+	if strings.Contains(out, theme.GetColor(lexer.TokenStateBad)+"down") {
+		t.Errorf("removed state word still colored: %q", out)
+	}
+
+	// Overrides belong to one highlighter
+	other := New(WithMode(lexer.ParseModeShow))
+	if out := other.HighlightForced(input); !strings.Contains(out, theme.GetColor(lexer.TokenStateBad)+"down") {
+		t.Errorf("built-in state lost in another highlighter: %q", out)
+	}
+
+	want := lexer.StateWords{
+		"secvioler": lexer.TokenStateBad,
+		"standby":   lexer.TokenStateGood,
+		"down":      lexer.TokenText,
+	}
+	got := h.StateWords()
+	if len(got) != len(want) {
+		t.Errorf("StateWords() = %v, want %v", got, want)
+	}
+	for w, typ := range want {
+		if got[w] != typ {
+			t.Errorf("StateWords()[%q] = %v, want %v", w, got[w], typ)
+		}
+	}
+}
@@ -0,0 +1,596 @@
+package highlighter
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Summary counts interface, routing neighbor and ACL state found in show
+// output or configuration. Fields for things not present in the input are zero.
+type Summary struct {
+	Interfaces          int
+	InterfacesUp        int
+	InterfacesDown      int
+	InterfacesAdminDown int
+
+	// BGPNeighbors counts neighbors by state: "Established", "Idle", "Active",
+	// "Idle (Admin)", ...
+	BGPNeighbors map[string]int
+
+	OSPFNeighbors int
+	OSPFNotFull   int // adjacencies in any state other than FULL
+
+	ACLs []ACLCount // in order of appearance
+
+	// Findings are the bad and warning states found in show output, in
+	// order of appearance, for a triage footer (see Footer).
+	Findings []Finding
+}
+
+// Finding is one bad or warning state found in show output: an interface
+// down, a BGP or OSPF neighbor not up, or any other state word. Results of
+// HighlightResult also report each type 7 password as a warning of kind
+// "secret".
+type Finding struct {
+	Severity lexer.TokenType // TokenStateBad or TokenStateWarning
+	Kind     string          // "interface", "BGP neighbor", "OSPF neighbor", or "" for other states
+	State    string          // "down", "Idle", "INIT", "err-disabled", ...
+	Name     string          // the interface or neighbor, "" if unknown
+	Line     int             // 1-based line of input
+}
+
+// ACLCount is the number of permit/deny entries in one access list.
+type ACLCount struct {
+	Name    string
+	Entries int
+}
+
+// BGPNeighborCount returns the number of BGP neighbors in any state.
+func (s *Summary) BGPNeighborCount() int {
+	n := 0
+	for _, c := range s.BGPNeighbors {
+		n += c
+	}
+	return n
+}
+
+// ACLEntries returns the number of entries across all access lists.
+func (s *Summary) ACLEntries() int {
+	n := 0
+	for _, acl := range s.ACLs {
+		n += acl.Entries
+	}
+	return n
+}
+
+var (
+	// "BGP state = Established, up for 1d02h" in show bgp neighbors
+	bgpStateLinePattern = regexp.MustCompile(`(?i)\bBGP state = ([\w-]+)`)
+
+	// "Extended IP access list MGMT", "IPv6 access list V6-IN"
+	showACLHeaderPattern = regexp.MustCompile(`(?i)\baccess list (\S+)`)
+
+	asNumberPattern = regexp.MustCompile(`^\d+(\.\d+)?$`)
+)
+
+// Summarize tokenizes input and counts interfaces by state, BGP neighbors by
+// state, OSPF adjacencies that are not FULL and the entries of each ACL.
+// It understands show interfaces, show ip interface brief, show interfaces
+// status, show ip bgp summary, show bgp neighbors, show ip ospf neighbor,
+// show access-lists and the equivalent configuration.
+func Summarize(input string) *Summary {
+	input = StripANSI(input)
+	lex := lexer.New(input)
+	tokens := lex.Tokenize()
+	return summarizeTokens(input, tokens, lex.GetParseMode())
+}
+
+// summarizeTokens summarizes input from the tokens a lexer produced for it
+// in mode.
+func summarizeTokens(input string, tokens []lexer.Token, mode lexer.ParseMode) *Summary {
+	s := &summarizer{
+		summary: &Summary{BGPNeighbors: make(map[string]int)},
+		seen:    make(map[string]bool),
+		acls:    make(map[string]int),
+	}
+
+	lines := strings.Split(input, "\n")
+	rows := make([][]lexer.Token, len(lines))
+	for _, tok := range tokens {
+		if strings.TrimSpace(tok.Value) != "" && tok.Line <= len(rows) {
+			rows[tok.Line-1] = append(rows[tok.Line-1], tok)
+		}
+	}
+	// Configuration has no states to report, only words such as "shutdown"
+	s.show = mode != lexer.ParseModeConfig
+
+	for i, line := range lines {
+		s.line, s.found = i+1, false
+		s.scanLine(strings.TrimRight(line, "\r"), rows[i])
+		if !s.found {
+			s.scanStates(rows[i])
+		}
+	}
+	s.finishConfigInterface()
+	return s.summary
+}
+
+// summarizer holds the context carried between lines while summarizing.
+type summarizer struct {
+	summary *Summary
+	seen    map[string]bool // interfaces already counted
+	acls    map[string]int  // ACL name -> index in summary.ACLs
+
+	acl         string // ACL whose entries follow, "" outside an ACL
+	bgpTable    bool   // inside show ip bgp summary neighbor table
+	ospfTable   bool   // inside show ip ospf neighbor table
+	cfgIface    string // interface section being read from configuration
+	cfgShutdown bool   // cfgIface has "shutdown"
+	show        bool   // input is show output, whose states are findings
+	line        int    // line being scanned, 1-based
+	found       bool   // a finding was recorded for the line
+}
+
+// addFinding records a finding on the current line of show output.
+func (s *summarizer) addFinding(severity lexer.TokenType, kind, state, name string) {
+	s.found = true
+	if !s.show {
+		return
+	}
+	s.summary.Findings = append(s.summary.Findings, Finding{
+		Severity: severity,
+		Kind:     kind,
+		State:    state,
+		Name:     name,
+		Line:     s.line,
+	})
+}
+
+// scanStates records the bad and warning states on a line that is not an
+// interface or neighbor row, once per word.
+func (s *summarizer) scanStates(row []lexer.Token) {
+	seen := make(map[string]bool)
+	for _, tok := range row {
+		state := stateWord(tok.Value)
+		if !isProblem(tok.Type) || seen[strings.ToLower(state)] {
+			continue
+		}
+		seen[strings.ToLower(state)] = true
+		s.addFinding(tok.Type, "", state, "")
+	}
+}
+
+// isProblem reports whether t is a state worth a finding.
+func isProblem(t lexer.TokenType) bool {
+	return t == lexer.TokenStateBad || t == lexer.TokenStateWarning
+}
+
+// stateWord returns a state token's value without trailing punctuation:
+// "down," in "GigabitEthernet0/1 is down, line protocol is down".
+func stateWord(value string) string {
+	return strings.TrimRight(value, ",;.")
+}
+
+// rowState returns the first bad or warning state in row after its first
+// token, with its type, or "" and TokenText if there is none.
+func rowState(row []lexer.Token) (string, lexer.TokenType) {
+	for i := 1; i < len(row); i++ {
+		if isProblem(row[i].Type) {
+			return stateWord(row[i].Value), row[i].Type
+		}
+	}
+	return "", lexer.TokenText
+}
+
+func (s *summarizer) scanLine(line string, row []lexer.Token) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return
+	}
+	lower := strings.ToLower(line)
+	indented := line[0] == ' ' || line[0] == '\t'
+
+	if !indented {
+		s.finishConfigInterface()
+		s.acl = ""
+	}
+
+	switch {
+	case strings.Contains(lower, "state/pfxrcd"):
+		s.bgpTable = true
+		return
+	case strings.Contains(lower, "dead time") && strings.Contains(lower, "state"):
+		s.ospfTable = true
+		return
+	}
+
+	if m := bgpStateLinePattern.FindStringSubmatch(line); m != nil {
+		s.summary.BGPNeighbors[m[1]]++
+		if !strings.EqualFold(m[1], "established") {
+			s.addFinding(neighborSeverity(m[1]), "BGP neighbor", m[1], "")
+		}
+		return
+	}
+
+	s.scanACL(line, lower, fields, row, indented)
+	if indented {
+		s.scanConfigInterfaceBody(fields)
+		return
+	}
+
+	switch {
+	case s.bgpTable && s.scanBGPRow(fields):
+	case s.ospfTable && s.scanOSPFRow(fields):
+	case strings.EqualFold(fields[0], "interface") && len(row) > 1 && row[1].Type == lexer.TokenInterface:
+		s.cfgIface = row[1].Value
+	case len(row) > 0 && row[0].Type == lexer.TokenInterface:
+		state := interfaceRowState(row)
+		if state == "" {
+			// Short captures can be mistaken for configuration, which has
+			// no state words
+			row = showModeRow(line)
+			state = interfaceRowState(row)
+		}
+		if state == "" {
+			return
+		}
+		s.countInterface(row[0].Value, state)
+		s.found = true
+		if state == "down" {
+			word, _ := rowState(row)
+			s.addFinding(lexer.TokenStateBad, "interface", strings.ToLower(word), row[0].Value)
+		}
+	}
+}
+
+// showModeRow tokenizes a single line as show output.
+func showModeRow(line string) []lexer.Token {
+	lex := lexer.New(line)
+	lex.SetParseMode(lexer.ParseModeShow)
+
+	var row []lexer.Token
+	for _, tok := range lex.Tokenize() {
+		if strings.TrimSpace(tok.Value) != "" {
+			row = append(row, tok)
+		}
+	}
+	return row
+}
+
+// interfaceRowState returns "up", "down" or "admin-down" for a show output
+// row that starts with an interface, or "" if the row carries no state.
+func interfaceRowState(row []lexer.Token) string {
+	if len(row) == 0 {
+		return ""
+	}
+	state := ""
+	for _, tok := range row[1:] {
+		switch {
+		case strings.EqualFold(tok.Value, "administratively"), strings.EqualFold(tok.Value, "disabled"):
+			state = "admin-down"
+		case tok.Type == lexer.TokenStateBad && state != "admin-down":
+			state = "down"
+		case tok.Type == lexer.TokenStateGood && state == "":
+			state = "up"
+		}
+	}
+	return state
+}
+
+func (s *summarizer) countInterface(name, state string) {
+	// Config and show output in one input name the same interface differently
+	name = lexer.NormalizeInterface(name)
+	if s.seen[name] {
+		return
+	}
+	s.seen[name] = true
+	s.summary.Interfaces++
+	switch state {
+	case "up":
+		s.summary.InterfacesUp++
+	case "down":
+		s.summary.InterfacesDown++
+	case "admin-down":
+		s.summary.InterfacesAdminDown++
+	}
+}
+
+// scanConfigInterfaceBody notes "shutdown" inside an interface section.
+func (s *summarizer) scanConfigInterfaceBody(fields []string) {
+	if s.cfgIface != "" && len(fields) == 1 && strings.EqualFold(fields[0], "shutdown") {
+		s.cfgShutdown = true
+	}
+}
+
+// finishConfigInterface counts the interface section that just ended. Without
+// "shutdown" its operational state is unknown, so it only adds to the total.
+func (s *summarizer) finishConfigInterface() {
+	if s.cfgIface == "" {
+		return
+	}
+	state := ""
+	if s.cfgShutdown {
+		state = "admin-down"
+	}
+	s.countInterface(s.cfgIface, state)
+	s.cfgIface, s.cfgShutdown = "", false
+}
+
+// scanBGPRow counts a show ip bgp summary neighbor row:
+// Neighbor V AS MsgRcvd MsgSent TblVer InQ OutQ Up/Down State/PfxRcd
+func (s *summarizer) scanBGPRow(fields []string) bool {
+	if len(fields) < 10 || (fields[1] != "4" && fields[1] != "6") || !asNumberPattern.MatchString(fields[2]) {
+		return false
+	}
+	state := fields[9]
+	if _, err := strconv.Atoi(state); err == nil {
+		state = "Established"
+	} else if len(fields) > 10 && strings.HasPrefix(fields[10], "(") {
+		state += " " + fields[10] // "Idle (Admin)"
+	}
+	s.summary.BGPNeighbors[state]++
+	if state != "Established" {
+		s.addFinding(neighborSeverity(state), "BGP neighbor", state, fields[0])
+	}
+	s.found = true
+	return true
+}
+
+// neighborSeverity returns the severity of a BGP neighbor state: a warning
+// for a neighbor shut down on purpose ("Idle (Admin)") or in a state the
+// state lists call one, otherwise bad.
+func neighborSeverity(state string) lexer.TokenType {
+	if strings.HasSuffix(strings.ToLower(state), "(admin)") || wordState(state) == lexer.TokenStateWarning {
+		return lexer.TokenStateWarning
+	}
+	return lexer.TokenStateBad
+}
+
+// wordState returns the type the state lists give a word of show output,
+// or TokenText if it is not a state.
+func wordState(word string) lexer.TokenType {
+	if row := showModeRow(word); len(row) > 0 {
+		return row[0].Type
+	}
+	return lexer.TokenText
+}
+
+// scanOSPFRow counts a show ip ospf neighbor row:
+// Neighbor ID  Pri  State  Dead Time  Address  Interface
+func (s *summarizer) scanOSPFRow(fields []string) bool {
+	if len(fields) < 5 || net.ParseIP(fields[0]).To4() == nil {
+		return false
+	}
+	if _, err := strconv.Atoi(fields[1]); err != nil {
+		return false
+	}
+	state, _, _ := strings.Cut(fields[2], "/")
+	s.summary.OSPFNeighbors++
+	if !strings.EqualFold(state, "FULL") {
+		s.summary.OSPFNotFull++
+	}
+	// 2WAY is the normal state between routers that are not DR or BDR
+	if t := wordState(state); isProblem(t) && !strings.EqualFold(state, "2WAY") {
+		s.addFinding(t, "OSPF neighbor", state, fields[0])
+	}
+	s.found = true
+	return true
+}
+
+// scanACL tracks ACL headers and counts their permit/deny entries.
+func (s *summarizer) scanACL(line, lower string, fields []string, row []lexer.Token, indented bool) {
+	if !indented {
+		switch {
+		case len(fields) > 2 && strings.EqualFold(fields[1], "access-list") &&
+			(strings.EqualFold(fields[0], "ip") || strings.EqualFold(fields[0], "ipv6") || strings.EqualFold(fields[0], "mac")):
+			name := fields[2]
+			if (strings.EqualFold(name, "standard") || strings.EqualFold(name, "extended")) && len(fields) > 3 {
+				name = fields[3]
+			}
+			s.acl = name
+			s.aclIndex(name)
+		case strings.EqualFold(fields[0], "access-list") && len(fields) > 2:
+			// Numbered ACL: every line is an entry
+			if hasAction(row) {
+				s.summary.ACLs[s.aclIndex(fields[1])].Entries++
+			}
+		case strings.Contains(lower, "access list"):
+			if m := showACLHeaderPattern.FindStringSubmatch(line); m != nil {
+				s.acl = m[1]
+				s.aclIndex(m[1])
+			}
+		}
+		return
+	}
+
+	if s.acl != "" && hasAction(row) {
+		s.summary.ACLs[s.aclIndex(s.acl)].Entries++
+	}
+}
+
+func (s *summarizer) aclIndex(name string) int {
+	i, ok := s.acls[name]
+	if !ok {
+		i = len(s.summary.ACLs)
+		s.acls[name] = i
+		s.summary.ACLs = append(s.summary.ACLs, ACLCount{Name: name})
+	}
+	return i
+}
+
+// hasAction reports whether the row contains a permit or deny action.
+func hasAction(row []lexer.Token) bool {
+	for _, tok := range row {
+		if tok.Type == lexer.TokenAction && (strings.EqualFold(tok.Value, "permit") || strings.EqualFold(tok.Value, "deny")) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the summary as plain text.
+func (s *Summary) String() string {
+	return s.Render(nil)
+}
+
+// Render formats the summary as a block of lines colored with theme, using
+// the theme's state colors for good, bad and neutral counts. A nil theme
+// renders plain text.
+func (s *Summary) Render(theme *Theme) string {
+	color := func(t lexer.TokenType, text string) string {
+		if theme == nil {
+			return text
+		}
+		c := theme.GetColor(t)
+		if c == "" {
+			return text
+		}
+		return c + text + Reset
+	}
+	label := func(text string) string {
+		if theme == nil {
+			return text
+		}
+		return Bold + text + Reset
+	}
+	count := func(n int, t lexer.TokenType, what string) string {
+		if n == 0 {
+			t = lexer.TokenStateNeutral
+		}
+		return color(t, strconv.Itoa(n)) + " " + what
+	}
+
+	var buf strings.Builder
+	if s.Interfaces > 0 {
+		fmt.Fprintf(&buf, "%s %d total, %s, %s, %s\n", label("Interfaces:"), s.Interfaces,
+			count(s.InterfacesUp, lexer.TokenStateGood, "up"),
+			count(s.InterfacesDown, lexer.TokenStateBad, "down"),
+			count(s.InterfacesAdminDown, lexer.TokenStateWarning, "admin-down"))
+	}
+
+	if total := s.BGPNeighborCount(); total > 0 {
+		states := make([]string, 0, len(s.BGPNeighbors))
+		for state := range s.BGPNeighbors {
+			states = append(states, state)
+		}
+		sort.Slice(states, func(i, j int) bool {
+			if (states[i] == "Established") != (states[j] == "Established") {
+				return states[i] == "Established"
+			}
+			return states[i] < states[j]
+		})
+
+		parts := []string{fmt.Sprintf("%d total", total)}
+		for _, state := range states {
+			t := lexer.TokenStateBad
+			if state == "Established" {
+				t = lexer.TokenStateGood
+			}
+			parts = append(parts, count(s.BGPNeighbors[state], t, state))
+		}
+		fmt.Fprintf(&buf, "%s %s\n", label("BGP neighbors:"), strings.Join(parts, ", "))
+	}
+
+	if s.OSPFNeighbors > 0 {
+		fmt.Fprintf(&buf, "%s %d total, %s, %s\n", label("OSPF adjacencies:"), s.OSPFNeighbors,
+			count(s.OSPFNeighbors-s.OSPFNotFull, lexer.TokenStateGood, "FULL"),
+			count(s.OSPFNotFull, lexer.TokenStateBad, "not FULL"))
+	}
+
+	if len(s.ACLs) > 0 {
+		parts := make([]string, len(s.ACLs))
+		for i, acl := range s.ACLs {
+			parts[i] = color(lexer.TokenIdentifier, acl.Name) + " " + strconv.Itoa(acl.Entries)
+		}
+		fmt.Fprintf(&buf, "%s %d lists, %d entries (%s)\n", label("ACL entries:"), len(s.ACLs), s.ACLEntries(), strings.Join(parts, ", "))
+	}
+
+	if buf.Len() == 0 {
+		return "No interfaces, routing neighbors or access lists found\n"
+	}
+	return buf.String()
+}
+
+// Footer formats the findings as a triage line colored with theme, bad
+// states before warnings and, within each, in order of appearance, with the
+// lines each was found on:
+//
+//	Found: 2 interfaces down (lines 3, 5), 1 BGP neighbor Idle (line 12), 1 OSPF neighbor INIT (line 20)
+//
+// A nil theme renders plain text.
+func (s *Summary) Footer(theme *Theme) string {
+	if len(s.Findings) == 0 {
+		return "Found: no bad or warning states\n"
+	}
+
+	type group struct {
+		Finding
+		lines []int
+	}
+	var groups []*group
+	index := make(map[Finding]*group)
+	for _, f := range s.Findings {
+		key := Finding{Severity: f.Severity, Kind: f.Kind, State: f.State}
+		g, ok := index[key]
+		if !ok {
+			g = &group{Finding: key}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.lines = append(g.lines, f.Line)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Severity == lexer.TokenStateBad && groups[j].Severity != lexer.TokenStateBad
+	})
+
+	parts := make([]string, len(groups))
+	for i, g := range groups {
+		count := strconv.Itoa(len(g.lines))
+		if theme != nil {
+			if c := theme.GetColor(g.Severity); c != "" {
+				count = c + count + Reset
+			}
+		}
+		what := g.State
+		if g.Kind != "" {
+			kind := g.Kind
+			if len(g.lines) > 1 {
+				kind += "s"
+			}
+			what = kind + " " + g.State
+		}
+		parts[i] = fmt.Sprintf("%s %s (%s)", count, what, lineList(g.lines))
+	}
+	label := "Found:"
+	if theme != nil {
+		label = Bold + label + Reset
+	}
+	return label + " " + strings.Join(parts, ", ") + "\n"
+}
+
+// Most line numbers listed for one group of findings
+const footerMaxLines = 5
+
+// lineList formats line numbers for Footer: "line 3", "lines 3, 5",
+// "lines 3, 5, 8, 9, 11 and 4 more".
+func lineList(lines []int) string {
+	if len(lines) == 1 {
+		return "line " + strconv.Itoa(lines[0])
+	}
+	shown := lines[:min(len(lines), footerMaxLines)]
+	numbers := make([]string, len(shown))
+	for i, n := range shown {
+		numbers[i] = strconv.Itoa(n)
+	}
+	list := "lines " + strings.Join(numbers, ", ")
+	if more := len(lines) - len(shown); more > 0 {
+		list += fmt.Sprintf(" and %d more", more)
+	}
+	return list
+}
@@ -0,0 +1,260 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(t *testing.T, s *Summary)
+	}{
+		{
+			name: "ip interface brief",
+			input: `Interface              IP-Address      OK? Method Status                Protocol
+GigabitEthernet0/0     10.0.0.1        YES manual up                    up
+GigabitEthernet0/1     10.0.1.1        YES manual up                    down
+GigabitEthernet0/2     unassigned      YES unset  administratively down down
+Loopback0              1.1.1.1         YES manual up                    up
+`,
+			check: func(t *testing.T, s *Summary) {
+				if s.Interfaces != 4 || s.InterfacesUp != 2 || s.InterfacesDown != 1 || s.InterfacesAdminDown != 1 {
+					t.Errorf("interfaces = %d total, %d up, %d down, %d admin-down",
+						s.Interfaces, s.InterfacesUp, s.InterfacesDown, s.InterfacesAdminDown)
+				}
+			},
+		},
+		{
+			name: "config and show output",
+			input: `interface GigabitEthernet0/0
+ ip address 10.0.0.1 255.255.255.0
+interface GigabitEthernet0/1
+ shutdown
+Router#show ip interface brief
+Interface              IP-Address      OK? Method Status                Protocol
+Gi0/0                  10.0.0.1        YES manual up                    up
+`,
+			check: func(t *testing.T, s *Summary) {
+				if s.Interfaces != 2 {
+					t.Errorf("interfaces = %d total, want each counted once by its full name", s.Interfaces)
+				}
+			},
+		},
+		{
+			name: "interfaces status",
+			input: `Port      Name               Status       Vlan       Duplex  Speed Type
+Gi1/0/1   uplink             connected    trunk      a-full a-1000 10/100/1000BaseTX
+Gi1/0/2                      notconnect   1            auto   auto 10/100/1000BaseTX
+Gi1/0/3                      disabled     1            auto   auto 10/100/1000BaseTX
+Gi1/0/4                      err-disabled 1            auto   auto 10/100/1000BaseTX
+`,
+			check: func(t *testing.T, s *Summary) {
+				if s.Interfaces != 4 || s.InterfacesUp != 1 || s.InterfacesDown != 2 || s.InterfacesAdminDown != 1 {
+					t.Errorf("interfaces = %d total, %d up, %d down, %d admin-down",
+						s.Interfaces, s.InterfacesUp, s.InterfacesDown, s.InterfacesAdminDown)
+				}
+			},
+		},
+		{
+			name: "bgp summary",
+			input: `BGP router identifier 10.0.0.1, local AS number 65000
+Neighbor        V           AS MsgRcvd MsgSent   TblVer  InQ OutQ Up/Down  State/PfxRcd
+10.0.0.2        4        65001    1234    1230       42    0    0 1d02h          150
+10.0.0.3        4        65002       0       0        1    0    0 never    Idle
+10.0.0.4        4        65003       0       0        1    0    0 00:01:10 Active
+10.0.0.5        4        65004       0       0        1    0    0 never    Idle (Admin)
+`,
+			check: func(t *testing.T, s *Summary) {
+				want := map[string]int{"Established": 1, "Idle": 1, "Active": 1, "Idle (Admin)": 1}
+				for state, n := range want {
+					if s.BGPNeighbors[state] != n {
+						t.Errorf("BGP %s = %d, want %d (all: %v)", state, s.BGPNeighbors[state], n, s.BGPNeighbors)
+					}
+				}
+				if s.BGPNeighborCount() != 4 {
+					t.Errorf("BGPNeighborCount() = %d, want 4", s.BGPNeighborCount())
+				}
+			},
+		},
+		{
+			name: "bgp neighbors",
+			input: `BGP neighbor is 10.0.0.2,  remote AS 65001, external link
+  BGP version 4, remote router ID 10.0.0.2
+  BGP state = Established, up for 1d02h
+BGP neighbor is 10.0.0.3,  remote AS 65002, external link
+  BGP state = Idle
+`,
+			check: func(t *testing.T, s *Summary) {
+				if s.BGPNeighbors["Established"] != 1 || s.BGPNeighbors["Idle"] != 1 {
+					t.Errorf("BGP neighbors = %v", s.BGPNeighbors)
+				}
+			},
+		},
+		{
+			name: "ospf neighbor",
+			input: `Neighbor ID     Pri   State           Dead Time   Address         Interface
+10.0.0.2          1   FULL/DR         00:00:34    10.1.1.2        GigabitEthernet0/1
+10.0.0.3          1   2WAY/DROTHER    00:00:31    10.1.1.3        GigabitEthernet0/1
+10.0.0.4          0   EXSTART/  -     00:00:39    10.1.2.2        GigabitEthernet0/2
+`,
+			check: func(t *testing.T, s *Summary) {
+				if s.OSPFNeighbors != 3 || s.OSPFNotFull != 2 {
+					t.Errorf("OSPF = %d total, %d not FULL", s.OSPFNeighbors, s.OSPFNotFull)
+				}
+			},
+		},
+		{
+			name: "config",
+			input: `interface GigabitEthernet0/1
+ ip address 10.0.0.1 255.255.255.0
+!
+interface GigabitEthernet0/2
+ shutdown
+!
+ip access-list extended MGMT
+ remark management hosts
+ permit tcp host 10.0.0.5 any eq 22
+ deny   ip any any log
+!
+access-list 10 permit 10.0.0.0 0.0.0.255
+access-list 10 deny any
+route-map RM permit 10
+`,
+			check: func(t *testing.T, s *Summary) {
+				if s.Interfaces != 2 || s.InterfacesAdminDown != 1 {
+					t.Errorf("interfaces = %d total, %d admin-down", s.Interfaces, s.InterfacesAdminDown)
+				}
+				want := []ACLCount{{"MGMT", 2}, {"10", 2}}
+				if len(s.ACLs) != len(want) {
+					t.Fatalf("ACLs = %v, want %v", s.ACLs, want)
+				}
+				for i := range want {
+					if s.ACLs[i] != want[i] {
+						t.Errorf("ACLs[%d] = %v, want %v", i, s.ACLs[i], want[i])
+					}
+				}
+			},
+		},
+		{
+			name: "show access-lists",
+			input: `Standard IP access list 10
+    10 permit 10.0.0.0, wildcard bits 0.0.0.255 (12 matches)
+    20 deny   any
+Extended IP access list MGMT
+    10 permit tcp host 10.0.0.5 any eq 22 (5 matches)
+`,
+			check: func(t *testing.T, s *Summary) {
+				if s.ACLEntries() != 3 || len(s.ACLs) != 2 || s.ACLs[1] != (ACLCount{"MGMT", 1}) {
+					t.Errorf("ACLs = %v", s.ACLs)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, Summarize(tt.input))
+		})
+	}
+}
+
+func TestSummaryRender(t *testing.T) {
+	s := Summarize(`Interface              IP-Address      OK? Method Status                Protocol
+GigabitEthernet0/0     10.0.0.1        YES manual up                    up
+GigabitEthernet0/1     10.0.1.1        YES manual down                  down
+`)
+
+	plain := s.String()
+	if plain != "Interfaces: 2 total, 1 up, 1 down, 0 admin-down\n" {
+		t.Errorf("String() = %q", plain)
+	}
+
+	theme := DefaultTheme()
+	colored := s.Render(theme)
+	if !strings.Contains(colored, theme.GetColor(lexer.TokenStateGood)+"1"+Reset+" up") {
+		t.Errorf("Render() should color the up count: %q", colored)
+	}
+	if StripANSI(colored) != plain {
+		t.Errorf("Render() without colors = %q, want %q", StripANSI(colored), plain)
+	}
+
+	if got := Summarize("hostname R1\n").String(); !strings.HasPrefix(got, "No interfaces") {
+		t.Errorf("empty summary = %q", got)
+	}
+}
+
+func TestSummaryFooter(t *testing.T) {
+	s := Summarize(`R1#show ip interface brief
+Interface              IP-Address      OK? Method Status                Protocol
+GigabitEthernet0/0     10.0.0.1        YES manual up                    up
+GigabitEthernet0/1     10.0.1.1        YES manual up                    down
+GigabitEthernet0/2     unassigned      YES unset  administratively down down
+GigabitEthernet0/3     10.0.3.1        YES manual down                  down
+R1#show ip bgp summary
+Neighbor        V           AS MsgRcvd MsgSent   TblVer  InQ OutQ Up/Down  State/PfxRcd
+10.0.0.2        4        65001    1234    1230       42    0    0 1d02h          150
+10.0.0.5        4        65004       0       0        1    0    0 never    Idle (Admin)
+10.0.0.3        4        65002       0       0        1    0    0 never    Idle
+R1#show ip ospf neighbor
+Neighbor ID     Pri   State           Dead Time   Address         Interface
+10.0.0.10         1   2WAY/DROTHER    00:00:33    10.1.0.10       Gi0/0
+10.0.0.11         1   INIT/DROTHER    00:00:31    10.1.0.11       Gi0/0
+`)
+
+	want := []Finding{
+		{lexer.TokenStateBad, "interface", "down", "GigabitEthernet0/1", 4},
+		{lexer.TokenStateBad, "interface", "down", "GigabitEthernet0/3", 6},
+		{lexer.TokenStateWarning, "BGP neighbor", "Idle (Admin)", "10.0.0.5", 10},
+		{lexer.TokenStateBad, "BGP neighbor", "Idle", "10.0.0.3", 11},
+		{lexer.TokenStateWarning, "OSPF neighbor", "INIT", "10.0.0.11", 15},
+	}
+	if len(s.Findings) != len(want) {
+		t.Fatalf("Findings = %+v, want %+v", s.Findings, want)
+	}
+	for i := range want {
+		if s.Findings[i] != want[i] {
+			t.Errorf("Findings[%d] = %+v, want %+v", i, s.Findings[i], want[i])
+		}
+	}
+
+	// Bad states first, then in order of appearance
+	plain := "Found: 2 interfaces down (lines 4, 6), 1 BGP neighbor Idle (line 11), " +
+		"1 BGP neighbor Idle (Admin) (line 10), 1 OSPF neighbor INIT (line 15)\n"
+	if got := s.Footer(nil); got != plain {
+		t.Errorf("Footer(nil) = %q, want %q", got, plain)
+	}
+	theme := DefaultTheme()
+	colored := s.Footer(theme)
+	if !strings.Contains(colored, theme.GetColor(lexer.TokenStateBad)+"2"+Reset+" interfaces down") {
+		t.Errorf("Footer() should color bad counts: %q", colored)
+	}
+	if StripANSI(colored) != plain {
+		t.Errorf("Footer() without colors = %q, want %q", StripANSI(colored), plain)
+	}
+
+	// Configuration has no findings, only words like shutdown
+	config := Summarize("interface Gi0/1\n shutdown\n!\ninterface Gi0/2\n no shutdown\n")
+	if len(config.Findings) != 0 || config.Footer(nil) != "Found: no bad or warning states\n" {
+		t.Errorf("config findings = %+v", config.Findings)
+	}
+}
+
+func TestLineList(t *testing.T) {
+	tests := []struct {
+		lines []int
+		want  string
+	}{
+		{[]int{3}, "line 3"},
+		{[]int{3, 5}, "lines 3, 5"},
+		{[]int{1, 2, 3, 4, 5, 6, 7}, "lines 1, 2, 3, 4, 5 and 2 more"},
+	}
+	for _, tt := range tests {
+		if got := lineList(tt.lines); got != tt.want {
+			t.Errorf("lineList(%v) = %q, want %q", tt.lines, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package highlighter
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// SVG layout, in pixels. Monospace glyphs are about 0.6 of the font size wide.
+const (
+	svgFontSize   = 14
+	svgCharWidth  = 8.4
+	svgLineHeight = 18
+	svgPadding    = 12
+	svgBackground = "#1a1b26" // the themes are made for dark terminals
+)
+
+// HighlightSVG highlights input like HighlightHTML, but emits a standalone SVG
+// image of it on a dark background, for READMEs, slides and tickets that
+// take images but not HTML. Each line is a <text> element and each colored
+// token a <tspan> with the "cink-<type>" class of HTMLClass and an inline
+// style converted from the theme.
+func (h *Highlighter) HighlightSVG(input string) string {
+	cleaned := StripANSI(input)
+	var tokens []lexer.Token
+	theme := h.Theme()
+	switch {
+	case !h.IsEnabled() || cleaned == "":
+		tokens = []lexer.Token{{Type: lexer.TokenText, Value: cleaned}}
+	case !h.AlwaysHighlight() && !h.looksLikeCisco(cleaned):
+		tokens = []lexer.Token{{Type: lexer.TokenText, Value: h.redact(cleaned)}}
+	default:
+		tokens, theme = h.tokenizeThemed(cleaned)
+	}
+
+	// No empty line after the final newline
+	if last := len(tokens) - 1; last >= 0 && strings.HasSuffix(tokens[last].Value, "\n") {
+		tokens[last].Value = strings.TrimSuffix(tokens[last].Value, "\n")
+	}
+
+	var body strings.Builder
+	lines, width := 0, 0
+	col := 1
+	startLine := func() {
+		y := svgPadding + svgFontSize + lines*svgLineHeight
+		fmt.Fprintf(&body, `<text x="%d" y="%d">`, svgPadding, y)
+	}
+	startLine()
+	for _, token := range tokens {
+		for i, piece := range strings.Split(token.Value, "\n") {
+			if i > 0 {
+				body.WriteString("</text>\n")
+				lines++
+				col = 1
+				startLine()
+			}
+			piece = strings.TrimSuffix(piece, "\r")
+			if piece == "" {
+				continue
+			}
+			next := lexer.AdvanceColumn(col, piece)
+			text := html.EscapeString(expandTabs(col, piece))
+			x := svgPadding + float64(col-1)*svgCharWidth
+			style := ""
+			if token.Type != lexer.TokenText {
+				style = strings.Replace(ANSIToCSS(theme.GetColor(token.Type)), "color:", "fill:", 1)
+			}
+			if style == "" {
+				fmt.Fprintf(&body, `<tspan x="%.1f">%s</tspan>`, x, text)
+			} else {
+				fmt.Fprintf(&body, `<tspan x="%.1f" class="%s" style="%s">%s</tspan>`, x, HTMLClass(token.Type), style, text)
+			}
+			col = next
+			width = max(width, col-1)
+		}
+	}
+	body.WriteString("</text>\n")
+
+	w := 2*svgPadding + int(float64(width)*svgCharWidth+0.5)
+	ht := 2*svgPadding + (lines+1)*svgLineHeight
+	var buf strings.Builder
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", w, ht, w, ht)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgBackground)
+	fmt.Fprintf(&buf, `<g font-family="monospace" font-size="%d" fill="%s" xml:space="preserve">`+"\n", svgFontSize, svgForeground(theme))
+	buf.WriteString(body.String())
+	buf.WriteString("</g>\n</svg>\n")
+	return buf.String()
+}
+
+// svgForeground returns the fill of plain text: the theme's identifier
+// color, or light grey.
+func svgForeground(theme *Theme) string {
+	if css := ANSIToCSS(theme.GetColor(lexer.TokenIdentifier)); strings.HasPrefix(css, "color:") {
+		color, _, _ := strings.Cut(strings.TrimPrefix(css, "color:"), ";")
+		return color
+	}
+	return "#d2d2d2"
+}
+
+// expandTabs replaces the tabs in s, printed from column col, with the spaces
+// a terminal would move over.
+func expandTabs(col int, s string) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var buf strings.Builder
+	for _, r := range s {
+		if r == '\t' {
+			next := lexer.AdvanceColumn(col, "\t")
+			buf.WriteString(strings.Repeat(" ", next-col))
+			col = next
+			continue
+		}
+		buf.WriteRune(r)
+		col = lexer.AdvanceColumn(col, string(r))
+	}
+	return buf.String()
+}
@@ -0,0 +1,35 @@
+package highlighter
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestHighlightSVG(t *testing.T) {
+	h := New()
+	h.SetAlwaysHighlight(true)
+	out := h.HighlightSVG("interface Gi0/1\n\tdescription <core & edge>\n")
+
+	if err := xml.Unmarshal([]byte(out), new(struct{})); err != nil {
+		t.Fatalf("not well-formed XML: %v\n%s", err, out)
+	}
+	if n := strings.Count(out, "<text "); n != 2 {
+		t.Errorf("%d text lines, want 2", n)
+	}
+	if !strings.Contains(out, `class="cink-interface" style="fill:`) {
+		t.Errorf("interface not filled with its theme color:\n%s", out)
+	}
+	if !strings.Contains(out, "&lt;core &amp; edge&gt;") {
+		t.Errorf("text not escaped:\n%s", out)
+	}
+	// The tab moves "description" to column 9
+	if !strings.Contains(out, `x="79.2" class="cink-keyword"`) {
+		t.Errorf("tab not expanded:\n%s", out)
+	}
+
+	h.Disable()
+	if plain := h.HighlightSVG("shutdown\n"); strings.Contains(plain, "class=") || !strings.Contains(plain, ">shutdown<") {
+		t.Errorf("disabled highlighter colored the SVG:\n%s", plain)
+	}
+}
@@ -0,0 +1,151 @@
+package highlighter
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DetectTerminal returns the color depth of the terminal cink is running in,
+// judged from the environment and, when that is not conclusive, the
+// terminal's terminfo entry:
+//
+//   - NO_COLOR set to anything, or TERM=dumb, gives NoColor
+//   - COLORTERM=truecolor or 24bit gives TrueColor
+//   - a TERM ending in -direct, or a terminal known to support 24-bit color
+//     (Windows Terminal, iTerm2, WezTerm, VS Code), gives TrueColor
+//   - a TERM containing 256color gives Colors256
+//   - otherwise the terminfo "colors" capability decides, down to the 16
+//     basic colors for a terminal with fewer
+//   - an empty TERM or one without a terminfo entry, as under cron, CI or
+//     Docker, gives TrueColor: the theme is not downgraded on a guess
+//
+// Pass the result to SetColorDepth, WithColorDepth or Theme.WithColorDepth.
+func DetectTerminal() ColorDepth {
+	return detectTerminal(os.Getenv, terminfoColors)
+}
+
+// detectTerminal implements DetectTerminal with the environment and terminfo
+// lookup passed in, for tests.
+func detectTerminal(getenv func(string) string, colors func(term string) (int, bool)) ColorDepth {
+	if getenv("NO_COLOR") != "" {
+		return NoColor
+	}
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+
+	term := strings.ToLower(getenv("TERM"))
+	switch {
+	case term == "dumb":
+		return NoColor
+	case strings.HasSuffix(term, "-direct"), strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"):
+		return TrueColor
+	}
+	// These terminals support 24-bit color but don't always set COLORTERM,
+	// notably when it is lost across sudo or ssh
+	if getenv("WT_SESSION") != "" {
+		return TrueColor
+	}
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode":
+		return TrueColor
+	}
+	if strings.Contains(term, "256color") {
+		return Colors256
+	}
+	if term == "" {
+		return TrueColor
+	}
+
+	if n, ok := colors(term); ok {
+		switch {
+		case n >= 1<<24:
+			return TrueColor
+		case n >= 256:
+			return Colors256
+		default:
+			return Colors16
+		}
+	}
+	return TrueColor
+}
+
+// terminfoColors returns the "colors" capability of the compiled terminfo
+// entry for term, searching the directories ncurses does. It reports false
+// if there is no readable entry.
+func terminfoColors(term string) (int, bool) {
+	var dirs []string
+	if dir := os.Getenv("TERMINFO"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+	for _, dir := range strings.Split(os.Getenv("TERMINFO_DIRS"), ":") {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	dirs = append(dirs, "/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo")
+
+	for _, dir := range dirs {
+		// Entries are filed under their first letter, or its hex code on
+		// case-insensitive file systems (macOS)
+		for _, sub := range []string{term[:1], strconv.FormatInt(int64(term[0]), 16)} {
+			data, err := os.ReadFile(filepath.Join(dir, sub, term))
+			if err != nil {
+				continue
+			}
+			return parseTerminfoColors(data)
+		}
+	}
+	return 0, false
+}
+
+// terminfoColorsIndex is the position of "colors" among the numeric
+// capabilities of a compiled terminfo entry.
+const terminfoColorsIndex = 13
+
+// parseTerminfoColors reads the "colors" capability from a compiled terminfo
+// entry, 0 if the entry has none. It understands the legacy format (16-bit
+// numbers) and the extended ncurses 6 format (32-bit numbers).
+func parseTerminfoColors(data []byte) (int, bool) {
+	if len(data) < 12 {
+		return 0, false
+	}
+	header := func(i int) int { return int(int16(binary.LittleEndian.Uint16(data[2*i:]))) }
+
+	size := 2
+	switch header(0) {
+	case 0432:
+	case 01036:
+		size = 4
+	default:
+		return 0, false
+	}
+	namesSize, boolCount, numCount := header(1), header(2), header(3)
+	if numCount <= terminfoColorsIndex {
+		return 0, true
+	}
+
+	// Numbers start on an even offset after the names and booleans
+	offset := 12 + namesSize + boolCount
+	offset += offset % 2
+	offset += terminfoColorsIndex * size
+	if offset+size > len(data) {
+		return 0, false
+	}
+
+	var n int
+	if size == 2 {
+		n = int(int16(binary.LittleEndian.Uint16(data[offset:])))
+	} else {
+		n = int(int32(binary.LittleEndian.Uint32(data[offset:])))
+	}
+	// -1 means absent, -2 cancelled: a monochrome terminal
+	return max(n, 0), true
+}
@@ -0,0 +1,96 @@
+package highlighter
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestDetectTerminal(t *testing.T) {
+	// noTerminfo and terminfo stand in for the terminfo database
+	noTerminfo := func(string) (int, bool) { return 0, false }
+	terminfo := func(n int) func(string) (int, bool) {
+		return func(string) (int, bool) { return n, true }
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		colors   func(string) (int, bool)
+		expected ColorDepth
+	}{
+		{"no color", map[string]string{"NO_COLOR": "1", "COLORTERM": "truecolor"}, noTerminfo, NoColor},
+		{"colorterm", map[string]string{"COLORTERM": "truecolor", "TERM": "xterm"}, noTerminfo, TrueColor},
+		{"colorterm 24bit", map[string]string{"COLORTERM": "24bit"}, noTerminfo, TrueColor},
+		{"dumb", map[string]string{"TERM": "dumb"}, terminfo(256), NoColor},
+		{"direct", map[string]string{"TERM": "xterm-direct"}, noTerminfo, TrueColor},
+		{"windows terminal", map[string]string{"TERM": "xterm-256color", "WT_SESSION": "abc"}, noTerminfo, TrueColor},
+		{"iterm", map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "iTerm.app"}, noTerminfo, TrueColor},
+		{"256color", map[string]string{"TERM": "screen-256color"}, noTerminfo, Colors256},
+		{"terminfo 256", map[string]string{"TERM": "foot"}, terminfo(256), Colors256},
+		{"terminfo direct", map[string]string{"TERM": "kitty"}, terminfo(1 << 24), TrueColor},
+		{"terminfo 8", map[string]string{"TERM": "vt220"}, terminfo(8), Colors16},
+		{"terminfo mono", map[string]string{"TERM": "vt100"}, terminfo(0), Colors16},
+		{"unknown term", map[string]string{"TERM": "myterm"}, noTerminfo, TrueColor},
+		{"no term", map[string]string{}, terminfo(256), TrueColor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := detectTerminal(getenv, tt.colors); got != tt.expected {
+				t.Errorf("detectTerminal() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTerminfoColors(t *testing.T) {
+	// entry builds a compiled terminfo entry with an odd-length name so the
+	// numbers need padding, and colors as the 14th number
+	entry := func(magic, size, colors int) []byte {
+		names := "test|test terminal\x00"
+		data := binary.LittleEndian.AppendUint16(nil, uint16(magic))
+		for _, n := range []int{len(names), 2, 15, 0, 0} {
+			data = binary.LittleEndian.AppendUint16(data, uint16(n))
+		}
+		data = append(data, names...)
+		data = append(data, 1, 0)
+		if len(data)%2 == 1 {
+			data = append(data, 0)
+		}
+		for i := 0; i < 15; i++ {
+			n := -1
+			if i == terminfoColorsIndex {
+				n = colors
+			}
+			if size == 2 {
+				data = binary.LittleEndian.AppendUint16(data, uint16(int16(n)))
+			} else {
+				data = binary.LittleEndian.AppendUint32(data, uint32(int32(n)))
+			}
+		}
+		return data
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected int
+		ok       bool
+	}{
+		{"legacy", entry(0432, 2, 256), 256, true},
+		{"extended", entry(01036, 4, 1<<24), 1 << 24, true},
+		{"absent", entry(0432, 2, -1), 0, true},
+		{"bad magic", entry(0777, 2, 256), 0, false},
+		{"truncated", entry(0432, 2, 256)[:20], 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseTerminfoColors(tt.data)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("parseTerminfoColors() = %d, %v, want %d, %v", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package highlighter
 
 import (
+	"reflect"
 	"strconv"
 	"sync"
 
@@ -50,6 +51,16 @@ func RGB(r, g, b int) string {
 	return "\033[38;2;" + strconv.Itoa(r) + ";" + strconv.Itoa(g) + ";" + strconv.Itoa(b) + "m"
 }
 
+// BgColor256 returns an ANSI background escape for 256-color mode
+func BgColor256(n int) string {
+	return "\033[48;5;" + strconv.Itoa(n) + "m"
+}
+
+// BgRGB returns an ANSI background escape for true color mode
+func BgRGB(r, g, b int) string {
+	return "\033[48;2;" + strconv.Itoa(r) + ";" + strconv.Itoa(g) + ";" + strconv.Itoa(b) + "m"
+}
+
 // Palette defines the semantic colors used to build a theme.
 type Palette struct {
 	// Base colors
@@ -68,7 +79,8 @@ type Palette struct {
 	Keyword   string // other keywords
 	Operator  string // eq, gt, lt, any, host
 	ASN       string // AS numbers
-	Community string // BGP communities
+	Community string // BGP communities, route distinguishers, route targets
+	VRF       string // VRF names
 	Value     string // values after keywords
 	MAC       string // MAC addresses
 	Negation  string // "no" prefix (typically red/warning)
@@ -92,6 +104,7 @@ type Palette struct {
 // buildTheme creates a Theme from a Palette by mapping semantic colors to token types.
 func buildTheme(p Palette) *Theme {
 	return &Theme{
+		palette: p,
 		colors: map[lexer.TokenType]string{
 			// Config tokens
 			lexer.TokenCommand:    Bold + p.Command,
@@ -127,6 +140,64 @@ func buildTheme(p Palette) *Theme {
 			lexer.TokenPercentage:    p.StateGood,
 			lexer.TokenByteSize:      p.Protocol,
 			lexer.TokenRouteProtocol: Bold + p.RouteProtocol,
+			lexer.TokenBridgeID:      Bold + p.MAC,
+
+			// L3VPN tokens
+			lexer.TokenVRF:                Bold + p.VRF,
+			lexer.TokenRouteDistinguisher: p.Community,
+			lexer.TokenRouteTarget:        p.Community,
+
+			// Terminal capture artifacts
+			lexer.TokenPager: Dim + p.Comment,
+
+			// Mask tokens
+			lexer.TokenSubnetMask:   p.Number,
+			lexer.TokenWildcardMask: Italic + p.Number,
+
+			// show version tokens
+			lexer.TokenVersion:        Bold + p.Number,
+			lexer.TokenModel:          Bold + p.Section,
+			lexer.TokenSerial:         Bold + p.MAC,
+			lexer.TokenUptime:         p.Duration,
+			lexer.TokenMemorySize:     p.Protocol,
+			lexer.TokenConfigRegister: Bold + p.Keyword,
+
+			// Credentials
+			lexer.TokenSecret: Underline + p.StateWarning,
+
+			// MPLS
+			lexer.TokenMPLSLabel: Bold + p.ASN,
+
+			// Rates
+			lexer.TokenRate: p.Duration,
+
+			// Rendering aids
+			lexer.TokenIndentGuide: Dim + p.Comment,
+
+			// CLI feedback
+			lexer.TokenError: p.StateBad,
+
+			// First-hop redundancy
+			lexer.TokenVirtualIP: Bold + p.IP,
+
+			// BGP
+			lexer.TokenPrivateASN: Italic + p.ASN,
+
+			// Age emphasis
+			lexer.TokenStale: Dim + p.Comment,
+
+			// Dates
+			lexer.TokenTimestamp: Italic + p.Duration,
+
+			// Hexadecimal
+			lexer.TokenHex:     Italic + p.Number,
+			lexer.TokenHexDump: Dim + p.Comment,
+
+			// Names defined in the input
+			lexer.TokenUserDefinedName: Italic + p.VRF,
+
+			// Line numbers
+			lexer.TokenLineNumber: Dim + p.Comment,
 
 			// Cisco prompt tokens
 			lexer.TokenPromptHost: Bold + p.PromptHost,
@@ -140,8 +211,14 @@ func buildTheme(p Palette) *Theme {
 // Theme defines ANSI color mappings for each token type.
 // All methods are safe for concurrent use.
 type Theme struct {
-	mu     sync.RWMutex
-	colors map[lexer.TokenType]string
+	mu      sync.RWMutex
+	palette Palette // the palette the theme was built from, for Merge
+	colors  map[lexer.TokenType]string
+
+	// Overrides for output of one parse mode or dialect (see Variant)
+	modes    map[lexer.ParseMode]map[lexer.TokenType]string
+	dialects map[string]map[lexer.TokenType]string
+	variants map[variantKey]*Theme // built by Variant, dropped on any change
 }
 
 // DefaultTheme returns the default theme (Tokyo Night)
@@ -178,6 +255,7 @@ func TokyoNightTheme() *Theme {
 		Operator:       blue,
 		ASN:            orange,
 		Community:      magenta,
+		VRF:            purple,
 		Value:          cyan,
 		MAC:            cyan,
 		Negation:       red,
@@ -210,6 +288,7 @@ func VibrantTheme() *Theme {
 		Operator:       BrightWhite,
 		ASN:            BrightMagenta,
 		Community:      Magenta,
+		VRF:            Magenta,
 		Value:          BrightCyan,
 		MAC:            Cyan,
 		Negation:       BrightRed,
@@ -253,6 +332,7 @@ func SolarizedDarkTheme() *Theme {
 		Operator:       base0,
 		ASN:            magenta,
 		Community:      violet,
+		VRF:            violet,
 		Value:          cyan,
 		MAC:            cyan,
 		Negation:       red,
@@ -295,6 +375,7 @@ func MonokaiTheme() *Theme {
 		Operator:       pink,
 		ASN:            orange,
 		Community:      purple,
+		VRF:            purple,
 		Value:          cyan,
 		MAC:            cyan,
 		Negation:       red,
@@ -338,6 +419,7 @@ func NordTheme() *Theme {
 		Operator:       nord9,
 		ASN:            nord12,
 		Community:      nord15,
+		VRF:            nord7,
 		Value:          nord8,
 		MAC:            nord7,
 		Negation:       nord11,
@@ -384,6 +466,7 @@ func CatppuccinMochaTheme() *Theme {
 		Operator:       sky,
 		ASN:            peach,
 		Community:      pink,
+		VRF:            pink,
 		Value:          sky,
 		MAC:            sky,
 		Negation:       red,
@@ -426,6 +509,7 @@ func DraculaTheme() *Theme {
 		Operator:       pink,
 		ASN:            orange,
 		Community:      purple,
+		VRF:            purple,
 		Value:          cyan,
 		MAC:            cyan,
 		Negation:       red,
@@ -468,6 +552,7 @@ func GruvboxDarkTheme() *Theme {
 		Operator:       foreground,
 		ASN:            orange,
 		Community:      purple,
+		VRF:            purple,
 		Value:          aqua,
 		MAC:            aqua,
 		Negation:       red,
@@ -510,6 +595,7 @@ func OneDarkTheme() *Theme {
 		Operator:       foreground,
 		ASN:            orange,
 		Community:      purple,
+		VRF:            purple,
 		Value:          cyan,
 		MAC:            cyan,
 		Negation:       red,
@@ -525,6 +611,85 @@ func OneDarkTheme() *Theme {
 	})
 }
 
+// ColorblindTheme returns a theme safe for deuteranopia and protanopia, based
+// on the Okabe-Ito palette. Good and bad states are told apart by blue versus
+// vermillion and by underlining bad states, never by red versus green alone.
+func ColorblindTheme() *Theme {
+	foreground := RGB(230, 230, 230) // #e6e6e6
+	comment := RGB(128, 128, 128)    // #808080
+	orange := RGB(230, 159, 0)       // #e69f00
+	skyBlue := RGB(86, 180, 233)     // #56b4e9
+	bluishGreen := RGB(0, 158, 115)  // #009e73
+	yellow := RGB(240, 228, 66)      // #f0e442
+	blue := RGB(0, 114, 178)         // #0072b2
+	vermillion := RGB(213, 94, 0)    // #d55e00
+	purple := RGB(204, 121, 167)     // #cc79a7
+
+	return buildTheme(Palette{
+		Foreground:     foreground,
+		Comment:        comment,
+		Command:        skyBlue,
+		Section:        blue,
+		Protocol:       bluishGreen,
+		Action:         skyBlue,
+		Interface:      orange,
+		IP:             bluishGreen,
+		Number:         purple,
+		String:         yellow,
+		Keyword:        yellow,
+		Operator:       skyBlue,
+		ASN:            orange,
+		Community:      purple,
+		VRF:            purple,
+		Value:          bluishGreen,
+		MAC:            bluishGreen,
+		Negation:       Underline + vermillion,
+		StateGood:      skyBlue,
+		StateBad:       Underline + vermillion,
+		StateWarning:   Italic + yellow,
+		Duration:       orange,
+		RouteProtocol:  purple,
+		PromptHost:     skyBlue,
+		PromptMode:     yellow,
+		PromptOper:     skyBlue,
+		PromptConf:     vermillion,
+	})
+}
+
+// HighContrastTheme returns a theme for low-vision users: bright colors on a
+// dark background, no dimmed text, and bad states underlined.
+func HighContrastTheme() *Theme {
+	return buildTheme(Palette{
+		Foreground:     BrightWhite,
+		Comment:        White,
+		Command:        BrightYellow,
+		Section:        BrightCyan,
+		Protocol:       BrightCyan,
+		Action:         BrightWhite,
+		Interface:      BrightMagenta,
+		IP:             BrightGreen,
+		Number:         BrightWhite,
+		String:         BrightYellow,
+		Keyword:        BrightYellow,
+		Operator:       BrightWhite,
+		ASN:            BrightMagenta,
+		Community:      BrightMagenta,
+		VRF:            BrightMagenta,
+		Value:          BrightCyan,
+		MAC:            BrightCyan,
+		Negation:       Underline + BrightRed,
+		StateGood:      BrightGreen,
+		StateBad:       Underline + BrightRed,
+		StateWarning:   Italic + BrightYellow,
+		Duration:       BrightWhite,
+		RouteProtocol:  BrightMagenta,
+		PromptHost:     BrightCyan,
+		PromptMode:     BrightYellow,
+		PromptOper:     BrightGreen,
+		PromptConf:     BrightRed,
+	})
+}
+
 // GetColor returns the color string for a token type
 func (t *Theme) GetColor(tokenType lexer.TokenType) string {
 	t.mu.RLock()
@@ -537,7 +702,7 @@ func (t *Theme) GetColor(tokenType lexer.TokenType) string {
 
 // ThemeNames returns a list of available theme names.
 func ThemeNames() []string {
-	return []string{"tokyonight", "vibrant", "solarized", "monokai", "nord", "catppuccin", "dracula", "gruvbox", "onedark"}
+	return []string{"tokyonight", "vibrant", "solarized", "monokai", "nord", "catppuccin", "dracula", "gruvbox", "onedark", "colorblind", "high-contrast"}
 }
 
 // ThemeByName returns a theme by its name. Returns DefaultTheme for unknown names.
@@ -561,6 +726,10 @@ func ThemeByName(name string) *Theme {
 		return GruvboxDarkTheme()
 	case "onedark", "one-dark":
 		return OneDarkTheme()
+	case "colorblind", "deuteranopia", "protanopia", "okabe-ito":
+		return ColorblindTheme()
+	case "high-contrast", "highcontrast", "contrast":
+		return HighContrastTheme()
 	default:
 		return DefaultTheme()
 	}
@@ -572,4 +741,65 @@ func (t *Theme) SetColor(tokenType lexer.TokenType, color string) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	t.colors[tokenType] = color
+	t.variants = nil
+}
+
+// Clone returns an independent copy of the theme, so it can be changed with
+// SetColor or Merge without affecting the original.
+func (t *Theme) Clone() *Theme {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	colors := make(map[lexer.TokenType]string, len(t.colors))
+	for tokenType, color := range t.colors {
+		colors[tokenType] = color
+	}
+	return &Theme{
+		palette:  t.palette,
+		colors:   colors,
+		modes:    cloneOverrides(t.modes, keepColor),
+		dialects: cloneOverrides(t.dialects, keepColor),
+	}
+}
+
+// Merge overrides the palette slots that are set in partial, recoloring every
+// token type derived from them (including the Bold or Italic the theme adds).
+// Empty fields keep their current colors, as do token types that do not use a
+// changed slot, so SetColor customizations elsewhere survive:
+//
+//	theme := highlighter.TokyoNightTheme().Clone()
+//	theme.Merge(highlighter.Palette{StateGood: highlighter.BrightGreen})
+func (t *Theme) Merge(partial Palette) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for tokenType, color := range paletteChanges(t.palette, partial) {
+		t.colors[tokenType] = color
+	}
+	t.palette = mergePalette(t.palette, partial)
+	t.variants = nil
+}
+
+// mergePalette returns base with the slots set in partial replaced.
+func mergePalette(base, partial Palette) Palette {
+	merged := base
+	src, dst := reflect.ValueOf(partial), reflect.ValueOf(&merged).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if color := src.Field(i).String(); color != "" {
+			dst.Field(i).SetString(color)
+		}
+	}
+	return merged
+}
+
+// paletteChanges returns the token colors that change when partial is
+// merged into base.
+func paletteChanges(base, partial Palette) map[lexer.TokenType]string {
+	before, after := buildTheme(base).colors, buildTheme(mergePalette(base, partial)).colors
+	changes := make(map[lexer.TokenType]string)
+	for tokenType, color := range after {
+		if color != before[tokenType] {
+			changes[tokenType] = color
+		}
+	}
+	return changes
 }
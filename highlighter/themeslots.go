@@ -0,0 +1,150 @@
+package highlighter
+
+import (
+	"github.com/lasseh/cink/lexer"
+)
+
+// variantKey identifies a theme built by Variant.
+type variantKey struct {
+	dialect string
+	mode    lexer.ParseMode
+}
+
+// SetModeColor sets the color of a token type in output of one parse mode
+// only, such as interfaces in show output, leaving the other modes on the
+// theme's color.
+func (t *Theme) SetModeColor(mode lexer.ParseMode, tokenType lexer.TokenType, color string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.modes == nil {
+		t.modes = make(map[lexer.ParseMode]map[lexer.TokenType]string)
+	}
+	if t.modes[mode] == nil {
+		t.modes[mode] = make(map[lexer.TokenType]string)
+	}
+	t.modes[mode][tokenType] = color
+	t.variants = nil
+}
+
+// SetDialectColor sets the color of a token type in input of one dialect
+// only, by the name lexer.Dialect.Name returns ("ios", "frr", "aruba" or
+// a registered dialect's).
+func (t *Theme) SetDialectColor(dialect string, tokenType lexer.TokenType, color string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.dialects == nil {
+		t.dialects = make(map[string]map[lexer.TokenType]string)
+	}
+	if t.dialects[dialect] == nil {
+		t.dialects[dialect] = make(map[lexer.TokenType]string)
+	}
+	t.dialects[dialect][tokenType] = color
+	t.variants = nil
+}
+
+// MergeMode overlays a partial palette on the theme for output of one parse
+// mode, as Merge does for all of them:
+//
+//	theme.MergeMode(lexer.ParseModeShow, highlighter.Palette{Interface: highlighter.Cyan})
+//
+// Only the token colors that differ from the theme's palette with partial
+// merged are overridden, so colors set with SetColor stay in effect for the
+// rest. Later calls for the same mode add to the earlier ones.
+func (t *Theme) MergeMode(mode lexer.ParseMode, partial Palette) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.modes == nil {
+		t.modes = make(map[lexer.ParseMode]map[lexer.TokenType]string)
+	}
+	t.modes[mode] = mergeOverrides(t.modes[mode], paletteChanges(t.palette, partial))
+	t.variants = nil
+}
+
+// MergeDialect overlays a partial palette on the theme for input of one
+// dialect, by name, as MergeMode does for a parse mode.
+func (t *Theme) MergeDialect(dialect string, partial Palette) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.dialects == nil {
+		t.dialects = make(map[string]map[lexer.TokenType]string)
+	}
+	t.dialects[dialect] = mergeOverrides(t.dialects[dialect], paletteChanges(t.palette, partial))
+	t.variants = nil
+}
+
+// Variant returns the theme as it applies to input of a dialect, by name,
+// tokenized in a parse mode: the theme's colors with the mode's overrides,
+// then the dialect's, on top. Token types without an override fall back to
+// the theme's color. A theme without overrides for either is returned as is.
+//
+// Variants are built once and reused until the theme is changed; changing a
+// variant does not change the theme it came from.
+func (t *Theme) Variant(dialect string, mode lexer.ParseMode) *Theme {
+	if t == nil {
+		return nil
+	}
+	key := variantKey{dialect: dialect, mode: mode}
+
+	t.mu.RLock()
+	modeColors, dialectColors := t.modes[mode], t.dialects[dialect]
+	variant := t.variants[key]
+	t.mu.RUnlock()
+	if len(modeColors) == 0 && len(dialectColors) == 0 {
+		return t
+	}
+	if variant != nil {
+		return variant
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if variant := t.variants[key]; variant != nil {
+		return variant
+	}
+	colors := make(map[lexer.TokenType]string, len(t.colors))
+	for tokenType, color := range t.colors {
+		colors[tokenType] = color
+	}
+	for _, overrides := range []map[lexer.TokenType]string{t.modes[mode], t.dialects[dialect]} {
+		for tokenType, color := range overrides {
+			colors[tokenType] = color
+		}
+	}
+	variant = &Theme{palette: t.palette, colors: colors}
+	if t.variants == nil {
+		t.variants = make(map[variantKey]*Theme)
+	}
+	t.variants[key] = variant
+	return variant
+}
+
+// mergeOverrides adds changes to overrides, allocating it if needed.
+func mergeOverrides(overrides, changes map[lexer.TokenType]string) map[lexer.TokenType]string {
+	if overrides == nil {
+		overrides = make(map[lexer.TokenType]string, len(changes))
+	}
+	for tokenType, color := range changes {
+		overrides[tokenType] = color
+	}
+	return overrides
+}
+
+// keepColor is the identity conversion for cloneOverrides.
+func keepColor(color string) string { return color }
+
+// cloneOverrides returns a deep copy of per-mode or per-dialect overrides,
+// with each color passed through convert.
+func cloneOverrides[K comparable](src map[K]map[lexer.TokenType]string, convert func(string) string) map[K]map[lexer.TokenType]string {
+	if src == nil {
+		return nil
+	}
+	dst := make(map[K]map[lexer.TokenType]string, len(src))
+	for key, overrides := range src {
+		colors := make(map[lexer.TokenType]string, len(overrides))
+		for tokenType, color := range overrides {
+			colors[tokenType] = convert(color)
+		}
+		dst[key] = colors
+	}
+	return dst
+}
@@ -0,0 +1,89 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestThemeVariant(t *testing.T) {
+	theme := TokyoNightTheme()
+	base := theme.GetColor(lexer.TokenInterface)
+	theme.SetModeColor(lexer.ParseModeShow, lexer.TokenInterface, Cyan)
+	theme.MergeMode(lexer.ParseModeShow, Palette{StateGood: BrightGreen})
+	theme.SetDialectColor("frr", lexer.TokenInterface, Magenta)
+	theme.MergeDialect("frr", Palette{Command: Red})
+
+	tests := []struct {
+		dialect   string
+		mode      lexer.ParseMode
+		tokenType lexer.TokenType
+		expected  string
+	}{
+		{"ios", lexer.ParseModeConfig, lexer.TokenInterface, base},
+		{"ios", lexer.ParseModeShow, lexer.TokenInterface, Cyan},
+		{"ios", lexer.ParseModeShow, lexer.TokenStateGood, Bold + BrightGreen},
+		{"ios", lexer.ParseModeConfig, lexer.TokenStateGood, theme.GetColor(lexer.TokenStateGood)},
+		{"frr", lexer.ParseModeConfig, lexer.TokenInterface, Magenta},
+		{"frr", lexer.ParseModeShow, lexer.TokenInterface, Magenta}, // dialect over mode
+		{"frr", lexer.ParseModeShow, lexer.TokenStateGood, Bold + BrightGreen},
+		{"frr", lexer.ParseModeConfig, lexer.TokenCommand, Bold + Red},
+	}
+	for _, tt := range tests {
+		if got := theme.Variant(tt.dialect, tt.mode).GetColor(tt.tokenType); got != tt.expected {
+			t.Errorf("%s %s %s = %q, want %q", tt.dialect, tt.mode, tt.tokenType, got, tt.expected)
+		}
+	}
+
+	if theme.GetColor(lexer.TokenInterface) != base {
+		t.Error("overrides should not change the base colors")
+	}
+	if theme.Variant("ios", lexer.ParseModeConfig) != theme {
+		t.Error("a variant without overrides should be the theme itself")
+	}
+	if theme.Variant("ios", lexer.ParseModeShow) != theme.Variant("ios", lexer.ParseModeShow) {
+		t.Error("variants should be reused")
+	}
+	theme.SetModeColor(lexer.ParseModeShow, lexer.TokenInterface, Blue)
+	if got := theme.Variant("ios", lexer.ParseModeShow).GetColor(lexer.TokenInterface); got != Blue {
+		t.Errorf("variant after SetModeColor = %q, want %q", got, Blue)
+	}
+}
+
+func TestThemeVariantCopies(t *testing.T) {
+	theme := TokyoNightTheme()
+	theme.SetModeColor(lexer.ParseModeShow, lexer.TokenInterface, "\033[38;2;0;200;200m")
+
+	clone := theme.Clone()
+	clone.SetModeColor(lexer.ParseModeShow, lexer.TokenInterface, Red)
+	if got := theme.Variant("ios", lexer.ParseModeShow).GetColor(lexer.TokenInterface); got == Red {
+		t.Error("SetModeColor on a clone should not change the original")
+	}
+
+	converted := theme.WithColorDepth(Colors16)
+	got := converted.Variant("ios", lexer.ParseModeShow).GetColor(lexer.TokenInterface)
+	if want := ConvertColorDepth("\033[38;2;0;200;200m", Colors16); got != want {
+		t.Errorf("converted override = %q, want %q", got, want)
+	}
+}
+
+func TestHighlightThemeVariant(t *testing.T) {
+	theme := DefaultTheme()
+	theme.SetModeColor(lexer.ParseModeShow, lexer.TokenInterface, Magenta)
+	h := New(WithTheme(theme), WithDialect(lexer.DialectIOS))
+
+	show := "Interface              IP-Address      OK? Method Status                Protocol\n" +
+		"GigabitEthernet0/1     10.0.0.1        YES NVRAM  up                    up\n"
+	if got := h.HighlightForced(show); !strings.Contains(got, Magenta+"GigabitEthernet0/1"+Reset) {
+		t.Errorf("show output should use the show mode color:\n%q", got)
+	}
+	if got := h.HighlightHTML(show); !strings.Contains(got, ANSIToCSS(Magenta)) {
+		t.Errorf("HTML should use the show mode color:\n%s", got)
+	}
+
+	config := "interface GigabitEthernet0/1\n ip address 10.0.0.1 255.255.255.0\n"
+	if got := h.HighlightForced(config); strings.Contains(got, Magenta) {
+		t.Errorf("config should keep the theme's color:\n%q", got)
+	}
+}
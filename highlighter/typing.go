@@ -0,0 +1,96 @@
+package highlighter
+
+import (
+	"sync"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Segment is a styled run of a command line returned by InputHighlighter.
+type Segment struct {
+	Start int             // byte offset of the run in the line
+	End   int             // byte offset just past the run
+	Type  lexer.TokenType // token type of the run
+	Color string          // theme escape sequence, "" for plain text
+}
+
+// InputHighlighter colors a command line while it is being typed, for
+// terminal emulators and REPLs that highlight input on every keystroke. It
+// takes the theme, dialect, token hook and enabled state of its Highlighter,
+// but none of the output features such as heatmaps or focus, and returns
+// segments of the line instead of a copy with escape sequences. Lexing goes
+// through lexer.CommandLexer, and the segment buffer is reused, so a call
+// takes microseconds and allocates next to nothing.
+type InputHighlighter struct {
+	hl *Highlighter
+
+	mu       sync.Mutex
+	lex      *lexer.CommandLexer
+	segments []Segment
+}
+
+// NewInput creates an InputHighlighter configured by opts, like New.
+func NewInput(opts ...Option) *InputHighlighter {
+	return &InputHighlighter{
+		hl:  New(opts...),
+		lex: lexer.NewCommandLexer(),
+	}
+}
+
+// Highlighter returns the highlighter whose settings are used, to change its
+// theme or dialect while input is typed.
+func (in *InputHighlighter) Highlighter() *Highlighter {
+	return in.hl
+}
+
+// Segments returns the styled runs covering line, the command typed so far
+// without the prompt. No run spans cursor, the byte offset of the cursor in
+// line: the token under it is split in two, so the cursor can be drawn
+// between runs. The segments are only valid until the next call. A token
+// hook may change the type of tokens but not their value; tokens it
+// suppresses are left plain.
+func (in *InputHighlighter) Segments(line string, cursor int) []Segment {
+	h := in.hl
+	h.mu.RLock()
+	theme, dialect, hook, enabled := h.theme, h.dialect, h.tokenHook, h.enabled
+	h.mu.RUnlock()
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.segments = in.segments[:0]
+	if !enabled {
+		return in.appendSegment(0, len(line), lexer.TokenText, "", cursor)
+	}
+
+	in.lex.SetDialect(dialect)
+	start := 0
+	for _, tok := range in.lex.Tokenize(line) {
+		end := start + len(tok.Value)
+		t := tok.Type
+		if hook != nil {
+			if hooked := hook(tok); hooked.Value == "" {
+				t = lexer.TokenText
+			} else {
+				t = hooked.Type
+			}
+		}
+		in.appendSegment(start, end, t, theme.GetColor(t), cursor)
+		start = end
+	}
+	return in.segments
+}
+
+// appendSegment appends the run [start, end) of type t, split at cursor, and
+// returns the segments.
+func (in *InputHighlighter) appendSegment(start, end int, t lexer.TokenType, color string, cursor int) []Segment {
+	if start == end {
+		return in.segments
+	}
+	if start < cursor && cursor < end {
+		in.segments = append(in.segments, Segment{start, cursor, t, color})
+		start = cursor
+	}
+	in.segments = append(in.segments, Segment{start, end, t, color})
+	return in.segments
+}
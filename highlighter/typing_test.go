@@ -0,0 +1,70 @@
+package highlighter
+
+import (
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestInputSegments(t *testing.T) {
+	in := NewInput()
+	theme := in.Highlighter().Theme()
+
+	line := "ip address 10.0.0.1 255.255.255.0"
+	segments := in.Segments(line, len(line))
+	var text string
+	end := 0
+	for _, s := range segments {
+		if s.Start != end {
+			t.Fatalf("segment %+v does not follow offset %d", s, end)
+		}
+		if s.Color != theme.GetColor(s.Type) {
+			t.Errorf("segment %q has color %q, want the theme's %v color", line[s.Start:s.End], s.Color, s.Type)
+		}
+		text += line[s.Start:s.End]
+		end = s.End
+	}
+	if text != line {
+		t.Errorf("segments cover %q, want %q", text, line)
+	}
+	types := map[string]lexer.TokenType{}
+	for _, s := range segments {
+		types[line[s.Start:s.End]] = s.Type
+	}
+	if types["10.0.0.1"] != lexer.TokenIPv4 || types["255.255.255.0"] != lexer.TokenSubnetMask {
+		t.Errorf("address and mask typed %v and %v", types["10.0.0.1"], types["255.255.255.0"])
+	}
+}
+
+func TestInputSegmentsCursor(t *testing.T) {
+	in := NewInput()
+	line := "interface GigabitEthernet0/1"
+	segments := in.Segments(line, 3) // inside "interface"
+	if len(segments) < 2 || segments[0].End != 3 || segments[1].Start != 3 {
+		t.Fatalf("segments not split at the cursor: %+v", segments)
+	}
+	if segments[0].Type != segments[1].Type {
+		t.Errorf("halves typed %v and %v", segments[0].Type, segments[1].Type)
+	}
+}
+
+func TestInputSegmentsDisabled(t *testing.T) {
+	in := NewInput()
+	in.Highlighter().Disable()
+	segments := in.Segments("shutdown", 8)
+	if len(segments) != 1 || segments[0].Color != "" || segments[0].End != 8 {
+		t.Errorf("disabled highlighter returned %+v", segments)
+	}
+	if got := in.Segments("", 0); len(got) != 0 {
+		t.Errorf("empty line returned %+v", got)
+	}
+}
+
+func TestInputSegmentsAllocations(t *testing.T) {
+	in := NewInput()
+	line := "ip route 0.0.0.0 0.0.0.0 GigabitEthernet0/0 10.0.0.1 name default"
+	in.Segments(line, len(line))
+	if allocs := testing.AllocsPerRun(100, func() { in.Segments(line, 12) }); allocs > 4 {
+		t.Errorf("%.0f allocations per call", allocs)
+	}
+}
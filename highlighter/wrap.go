@@ -0,0 +1,75 @@
+package highlighter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// tabWidth is the tab stop interval assumed when measuring line width.
+const tabWidth = 8
+
+// WrapANSI wraps highlighted text so no line is wider than width columns.
+// Escape sequences are never split and take no width. At each wrap the active
+// style is reset before the line break and re-emitted at the start of the
+// continuation line, so colors neither bleed into margins nor drop mid-token.
+// Lines are broken at exactly width columns, like a terminal does. A width of
+// zero or less returns input unchanged.
+func WrapANSI(input string, width int) string {
+	if width <= 0 || input == "" {
+		return input
+	}
+
+	var buf strings.Builder
+	var active strings.Builder // SGR sequences in effect since the last reset
+	col := 0
+
+	for _, seg := range extractSegments(input) {
+		if seg.isEscape {
+			buf.WriteString(seg.text)
+			trackSGR(&active, seg.text)
+			continue
+		}
+
+		text := seg.text
+		for len(text) > 0 {
+			r, size := utf8.DecodeRuneInString(text)
+			if r == '\n' {
+				col = 0
+				buf.WriteString(text[:size])
+				text = text[size:]
+				continue
+			}
+
+			if col >= width {
+				if active.Len() > 0 {
+					buf.WriteString(Reset)
+				}
+				buf.WriteByte('\n')
+				buf.WriteString(active.String())
+				col = 0
+			}
+			w := 1
+			if r == '\t' {
+				// Like terminals, tabs stop at the right margin
+				w = min(tabWidth-col%tabWidth, width-col)
+			}
+			buf.WriteString(text[:size])
+			col += w
+			text = text[size:]
+		}
+	}
+	return buf.String()
+}
+
+// trackSGR updates the active style for an escape sequence. Resets clear it;
+// other SGR sequences add to it. Non-SGR sequences are ignored.
+func trackSGR(active *strings.Builder, seq string) {
+	if !strings.HasPrefix(seq, "\033[") || !strings.HasSuffix(seq, "m") {
+		return
+	}
+	if seq == Reset || seq == "\033[m" {
+		active.Reset()
+		return
+	}
+	active.WriteString(seq)
+}
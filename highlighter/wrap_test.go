@@ -0,0 +1,53 @@
+package highlighter
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWrapANSI(t *testing.T) {
+	red, blue := "\033[31m", "\033[1m\033[34m"
+
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		expected string
+	}{
+		{"fits", "short line", 20, "short line"},
+		{"plain", "abcdefgh", 3, "abc\ndef\ngh"},
+		{"style carried over", red + "abcdef" + Reset, 4, red + "abcd" + Reset + "\n" + red + "ef" + Reset},
+		{"style ends before wrap", red + "ab" + Reset + "cdef", 4, red + "ab" + Reset + "cd\nef"},
+		{"compound style", blue + "abcde" + Reset, 3, blue + "abc" + Reset + "\n" + blue + "de" + Reset},
+		{"existing newlines", "ab\ncdef", 3, "ab\ncde\nf"},
+		{"exact width", "abc\n", 3, "abc\n"},
+		{"multibyte runes", "héllo", 2, "hé\nll\no"},
+		{"tabs", "a\tb", 4, "a\t\nb"},
+		{"disabled", "abcdef", 0, "abcdef"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WrapANSI(tt.input, tt.width); got != tt.expected {
+				t.Errorf("WrapANSI(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWrapANSIHighlighted(t *testing.T) {
+	input := "interface GigabitEthernet0/0/1\n description Uplink to core router in building 42\n ip address 192.168.100.1 255.255.255.0\n"
+	highlighted := New().HighlightForced(input)
+	wrapped := WrapANSI(highlighted, 20)
+
+	for _, line := range strings.Split(wrapped, "\n") {
+		if n := utf8.RuneCountInString(StripANSI(line)); n > 20 {
+			t.Errorf("line is %d columns wide: %q", n, StripANSI(line))
+		}
+	}
+	// Only line breaks are added
+	if strings.ReplaceAll(StripANSI(wrapped), "\n", "") != strings.ReplaceAll(input, "\n", "") {
+		t.Errorf("wrapping changed the text: %q", StripANSI(wrapped))
+	}
+}
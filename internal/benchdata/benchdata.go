@@ -0,0 +1,97 @@
+// Package benchdata generates the inputs used by cink's benchmarks and
+// performance budget tests: realistic configs, show output and session
+// transcripts of a given size, built the same way on every run.
+package benchdata
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Line is a single interactive show output line.
+const Line = "GigabitEthernet0/0/1   10.0.0.1   YES NVRAM  up                    up\n"
+
+// Config returns a running configuration of about n lines: a header, then
+// interface, BGP neighbor, ACL and route-map stanzas repeated with varying
+// names and addresses.
+func Config(n int) string {
+	var b strings.Builder
+	b.WriteString("!\nversion 17.9\nservice timestamps log datetime msec\nhostname core01\n!\n")
+	for i := 0; lineCount(&b) < n; i++ {
+		fmt.Fprintf(&b, "interface GigabitEthernet1/0/%d\n", i%48+1)
+		fmt.Fprintf(&b, " description uplink to access%02d port Gi1/0/%d\n", i%100, i%48+1)
+		fmt.Fprintf(&b, " ip address 10.%d.%d.1 255.255.255.0\n", i/256%256, i%256)
+		fmt.Fprintf(&b, " ipv6 address 2001:db8:%x::1/64\n", i)
+		b.WriteString(" ip ospf network point-to-point\n bandwidth 10000\n no shutdown\n!\n")
+		fmt.Fprintf(&b, "router bgp 65000\n neighbor 10.%d.%d.2 remote-as 650%02d\n", i/256%256, i%256, i%100)
+		fmt.Fprintf(&b, " neighbor 10.%d.%d.2 route-map RM-IN-%d in\n!\n", i/256%256, i%256, i%10)
+		fmt.Fprintf(&b, "ip access-list extended ACL-%d\n", i%20)
+		fmt.Fprintf(&b, " permit tcp 10.%d.0.0 0.0.255.255 host 192.0.2.%d eq 443 log\n", i%256, i%254+1)
+		b.WriteString(" deny   ip any any log\n!\n")
+		fmt.Fprintf(&b, "route-map RM-IN-%d permit %d\n match community %d\n set local-preference 200\n set community 65000:%d additive\n!\n",
+			i%10, i%100*10+10, i%100, i)
+	}
+	b.WriteString("end\n")
+	return b.String()
+}
+
+// ShowTech returns about size bytes of mixed show output, as found in a
+// "show tech-support" capture: interface details, the interface brief table,
+// BGP summary, ARP and MAC tables, and logging.
+func ShowTech(size int) string {
+	var b strings.Builder
+	for i := 0; b.Len() < size; i++ {
+		fmt.Fprintf(&b, "GigabitEthernet1/0/%d is up, line protocol is up (connected)\n", i%48+1)
+		fmt.Fprintf(&b, "  Hardware is Gigabit Ethernet, address is 0011.22%02x.%04x (bia 0011.22%02x.%04x)\n", i%256, i%65536, i%256, i%65536)
+		fmt.Fprintf(&b, "  Internet address is 10.%d.%d.1/24\n", i/256%256, i%256)
+		b.WriteString("  MTU 1500 bytes, BW 1000000 Kbit/sec, DLY 10 usec,\n")
+		fmt.Fprintf(&b, "  Last input 00:00:%02d, output 00:00:01, output hang never\n", i%60)
+		fmt.Fprintf(&b, "  5 minute input rate %d bits/sec, %d packets/sec\n", i*1000, i)
+		fmt.Fprintf(&b, "     %d packets input, %d bytes, 0 no buffer\n", i*12345, i*987654)
+		fmt.Fprintf(&b, "     %d input errors, 0 CRC, 0 frame, 0 overrun, 0 ignored\n", i%3)
+		if i%10 == 0 {
+			b.WriteString("\nInterface              IP-Address      OK? Method Status                Protocol\n")
+		}
+		state := "up                    up"
+		if i%7 == 0 {
+			state = "administratively down down"
+		}
+		fmt.Fprintf(&b, "Vlan%-18d 10.%d.%d.1      YES NVRAM  %s\n", i%4094+1, i/256%256, i%256, state)
+		fmt.Fprintf(&b, "10.%d.%d.2        4        650%02d    %5d   %5d       10    0    0 1d02h           %d\n", i/256%256, i%256, i%100, i*3, i*2, i%500)
+		fmt.Fprintf(&b, "Internet  10.%d.%d.%d   %d   0011.22%02x.%04x  ARPA   Vlan%d\n", i/256%256, i%256, i%254+1, i%240, i%256, i%65536, i%4094+1)
+		fmt.Fprintf(&b, " %4d    0011.22%02x.%04x    DYNAMIC     Gi1/0/%d\n", i%4094+1, i%256, i%65536, i%48+1)
+		fmt.Fprintf(&b, "*Mar  1 00:%02d:%02d.%03d: %%LINK-3-UPDOWN: Interface GigabitEthernet1/0/%d, changed state to down\n", i/60%60, i%60, i%1000, i%48+1)
+	}
+	return b.String()
+}
+
+// Transcript returns a session capture of n commands: prompts and the
+// commands typed, configuration changes and show output, with a CLI error.
+func Transcript(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		switch i % 4 {
+		case 0:
+			b.WriteString("core01#show ip interface brief\n")
+			b.WriteString("Interface              IP-Address      OK? Method Status                Protocol\n")
+			fmt.Fprintf(&b, "GigabitEthernet0/0/%d   10.0.%d.1        YES NVRAM  up                    up\n", i%4, i%256)
+			b.WriteString("Loopback0              192.0.2.1       YES NVRAM  up                    up\n")
+		case 1:
+			b.WriteString("core01#configure terminal\nEnter configuration commands, one per line.  End with CNTL/Z.\n")
+			fmt.Fprintf(&b, "core01(config)#interface GigabitEthernet0/0/%d\n", i%4)
+			fmt.Fprintf(&b, "core01(config-if)#description link %d\ncore01(config-if)#no shutdown\ncore01(config-if)#end\n", i)
+		case 2:
+			b.WriteString("core01#show ip bgp summary\nBGP router identifier 192.0.2.1, local AS number 65000\n")
+			b.WriteString("Neighbor        V           AS MsgRcvd MsgSent   TblVer  InQ OutQ Up/Down  State/PfxRcd\n")
+			fmt.Fprintf(&b, "10.0.%d.2        4        65001     100     101       10    0    0 1d02h           5\n", i%256)
+		case 3:
+			b.WriteString("core01#show ip intrface\n                ^\n% Invalid input detected at '^' marker.\n\n")
+		}
+	}
+	b.WriteString("core01#")
+	return b.String()
+}
+
+func lineCount(b *strings.Builder) int {
+	return strings.Count(b.String(), "\n")
+}
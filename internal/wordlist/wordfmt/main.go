@@ -0,0 +1,65 @@
+// Command wordfmt rewrites word list files in canonical form (see
+// wordlist.Format). The lexer runs it with "go generate" on lexer/words:
+//
+//	wordfmt [-l] dir-or-file...
+//
+// With -l it only lists the files that are not formatted, and exits with
+// status 1 if there are any.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lasseh/cink/internal/wordlist"
+)
+
+func main() {
+	list := flag.Bool("l", false, "list files that are not formatted")
+	flag.Parse()
+
+	unformatted := false
+	for _, arg := range flag.Args() {
+		files := []string{arg}
+		if info, err := os.Stat(arg); err == nil && info.IsDir() {
+			files, _ = filepath.Glob(filepath.Join(arg, "*.txt"))
+		}
+		for _, file := range files {
+			changed, err := format(file, !*list)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "wordfmt: %s: %v\n", file, err)
+				os.Exit(2)
+			}
+			if changed {
+				fmt.Println(file)
+				unformatted = true
+			}
+		}
+	}
+	if *list && unformatted {
+		os.Exit(1)
+	}
+}
+
+// format formats file, writing it back if write is set, and reports whether
+// it was not already formatted.
+func format(file string, write bool) (bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	formatted, err := wordlist.Format(data)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(data, formatted) {
+		return false, nil
+	}
+	if write {
+		return true, os.WriteFile(file, formatted, 0o644)
+	}
+	return true, nil
+}
@@ -0,0 +1,82 @@
+// Package wordlist reads and formats the word list files that hold the
+// lexer's vocabulary (lexer/words/*.txt).
+//
+// A word list has one entry per line. Entries are matched case-insensitively
+// and stored in lowercase. Blank lines separate groups, and lines starting
+// with "#" are comments, usually naming the group below them.
+package wordlist
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Parse returns the entries of the word list read from r, in lowercase, in
+// file order. It rejects entries with a "#" after the start of the line,
+// which are most likely a comment written on the same line as a word.
+func Parse(r io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.Contains(line, "#") {
+			return nil, fmt.Errorf("line %d: %q: comments must be on their own line", n, line)
+		}
+		words = append(words, strings.ToLower(line))
+	}
+	return words, scanner.Err()
+}
+
+// Format returns the word list data in canonical form: entries lowercased,
+// sorted and de-duplicated within each group, with comments kept above the
+// group they precede and a single blank line between groups.
+func Format(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+	var group []string
+	seen := make(map[string]bool)
+	flush := func() {
+		sort.Strings(group)
+		for _, w := range group {
+			out.WriteString(w + "\n")
+		}
+		group = nil
+	}
+
+	blank := false // a blank line is pending before the next line written
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			if len(group) > 0 || out.Len() > 0 {
+				flush()
+				blank = out.Len() > 0
+			}
+			continue
+		case blank:
+			out.WriteString("\n")
+			blank = false
+		}
+
+		if strings.HasPrefix(line, "#") {
+			flush()
+			out.WriteString(line + "\n")
+			continue
+		}
+		if strings.Contains(line, "#") {
+			return nil, fmt.Errorf("line %d: %q: comments must be on their own line", i+1, line)
+		}
+		if w := strings.ToLower(line); !seen[w] {
+			seen[w] = true
+			group = append(group, w)
+		}
+	}
+	flush()
+	return out.Bytes(), nil
+}
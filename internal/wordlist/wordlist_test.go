@@ -0,0 +1,38 @@
+package wordlist
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	words, err := Parse(strings.NewReader("# Protocols\n\nBGP\n  ospf \n\n# More\nisis\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"bgp", "ospf", "isis"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("Parse = %q, want %q", words, want)
+	}
+
+	if _, err := Parse(strings.NewReader("bgp # border gateway\n")); err == nil {
+		t.Error("expected an error for a comment after a word")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	input := "# Protocols\n\n\n# Routing\nOSPF\nbgp\nospf\n# Transport\nudp\ntcp\n\n\nisis\n"
+	want := "# Protocols\n\n# Routing\nbgp\nospf\n# Transport\ntcp\nudp\n\nisis\n"
+	got, err := Format([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("Format:\n%s\nwant:\n%s", got, want)
+	}
+
+	again, _ := Format(got)
+	if string(again) != string(got) {
+		t.Errorf("Format is not idempotent:\n%s", again)
+	}
+}
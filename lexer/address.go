@@ -0,0 +1,100 @@
+package lexer
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// isIPv6 reports whether word is an IPv6 address, including zoned link-local
+// addresses (fe80::1%Vlan100) and IPv4-mapped ones (::ffff:10.0.0.1).
+func isIPv6(word string) bool {
+	if !strings.Contains(word, ":") {
+		return false
+	}
+	if i := strings.IndexByte(word, '%'); i >= 0 && !isZone(word[i+1:]) {
+		return false
+	}
+	addr, err := netip.ParseAddr(word)
+	return err == nil && addr.Is6()
+}
+
+// isZone reports whether zone is an interface name or index. netip accepts
+// any zone, which would swallow punctuation after the address.
+func isZone(zone string) bool {
+	if zone == "" {
+		return false
+	}
+	for _, ch := range zone {
+		if !isAlphaNum(ch) && !strings.ContainsRune("/.-_", ch) {
+			return false
+		}
+	}
+	return isAlphaNum(rune(zone[len(zone)-1]))
+}
+
+func isAlphaNum(ch rune) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
+
+// isIPv6Prefix reports whether word is an IPv6 prefix such as 2001:db8::/32.
+func isIPv6Prefix(word string) bool {
+	if !strings.Contains(word, ":") {
+		return false
+	}
+	prefix, err := netip.ParsePrefix(word)
+	return err == nil && prefix.Addr().Is6()
+}
+
+// isAddressWord reports whether word is an IPv4 or IPv6 address or prefix.
+func isAddressWord(word string) bool {
+	if startsWithDigit(word) && (ipv4Pattern.MatchString(word) || ipv4PrefixPattern.MatchString(word)) {
+		return true
+	}
+	return isIPv6(word) || isIPv6Prefix(word)
+}
+
+// valuePunctuation returns the length of the punctuation before and after
+// an address, rate, percentage, interface or duration in word, as in
+// "(2001:db8::1)," "1000Mb/s," "(45%)" or "GigabitEthernet0/0/0," in show
+// output. ok is false unless word is such a value with punctuation around it.
+func valuePunctuation(word string) (lead, trail int, ok bool) {
+	// Most words have no punctuation around them to split off
+	if word == "" || word[0] != '(' && word[0] != '[' && !isPunctuation(word[len(word)-1:]) {
+		return 0, 0, false
+	}
+	if isPunctuatedValue(word) {
+		return 0, 0, false
+	}
+	core := strings.TrimLeft(word, "([")
+	lead = len(word) - len(core)
+	// Trim one character at a time, since IPv6 addresses can end in a colon
+	for end := len(core); end > 0; end-- {
+		if end < len(core) && !isPunctuation(core[end:end+1]) {
+			break
+		}
+		if (lead > 0 || end < len(core)) && isPunctuatedValue(core[:end]) {
+			return lead, len(core) - end, true
+		}
+	}
+	return 0, 0, false
+}
+
+// isPunctuatedValue reports whether word is a value that valuePunctuation
+// splits punctuation off.
+func isPunctuatedValue(word string) bool {
+	if startsWithLetter(word) && interfacePattern.MatchString(word) {
+		return true
+	}
+	if startsWithDigit(word) && (ratePattern.MatchString(word) || percentagePattern.MatchString(word) ||
+		timeDurationPattern.MatchString(word)) {
+		return true
+	}
+	return isAddressWord(word)
+}
+
+// isPunctuation reports whether word consists only of the punctuation split
+// off addresses and rates. "::" is the IPv6 unspecified address, not
+// punctuation.
+func isPunctuation(word string) bool {
+	return word != "" && word != "::" && strings.Trim(word, "([,;:.)]") == ""
+}
@@ -0,0 +1,114 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// arubaDialect is HPE Aruba AOS-CX and the older ProCurve / AOS-S switches.
+// Both number ports rather than name them ("interface 1/1/1", "interface 24")
+// and put VLAN membership under the VLAN ("vlan 10 / tagged 1-24").
+type arubaDialect struct{}
+
+func (arubaDialect) Name() string   { return "aruba" }
+func (arubaDialect) String() string { return "Aruba" }
+
+// Aruba vocabulary that differs from IOS
+var arubaKeywords = Keywords{
+	Config: map[string]TokenType{
+		"exit": TokenCommand, "module": TokenCommand, "vsx": TokenSection,
+		"vsf": TokenCommand, "https-server": TokenCommand, "user-role": TokenSection,
+
+		"tagged": TokenKeyword, "untagged": TokenKeyword, "forbid": TokenKeyword,
+		"lag": TokenKeyword, "routing": TokenKeyword, "dhcp-bootp": TokenKeyword,
+		"loop-protect": TokenKeyword, "inter-switch-link": TokenKeyword,
+		"keepalive": TokenKeyword, "system-mac": TokenKeyword, "mgmt": TokenInterface,
+	},
+}
+
+var (
+	// AOS-CX member/slot/port, with an optional split-port suffix: 1/1/1, 1/1/49:2
+	arubaPortPattern = regexp.MustCompile(`^\d+/\d+/\d+(:\d+)?$`)
+
+	// ProCurve trunks and AOS-CX LAGs: Trk1, lag10
+	arubaTrunkPattern = regexp.MustCompile(`^(?i)(trk|lag)\d+$`)
+
+	// Half duplex in ProCurve port status ("100HDx") usually means a mismatch
+	arubaHalfDuplexPattern = regexp.MustCompile(`^(?i)\d+HDx$`)
+
+	// Port lists after tagged/untagged/interface: 1-24, A1-A24,1/1-1/4,Trk1
+	arubaPortListPattern = func() *regexp.Regexp {
+		item := `(trk\d+|lag\d+|\d+/\d+/\d+|\d+/\d+|[a-z]?\d+)(-(\d+/\d+/\d+|\d+/\d+|[a-z]?\d+))?`
+		return regexp.MustCompile(`^(?i)` + item + `(,` + item + `)*$`)
+	}()
+
+	// AOS-CX port status for a port without a cable or peer
+	arubaWaitingWords = map[string]bool{"waiting": true, "for": true, "link": true}
+
+	// Words whose argument is a port list
+	arubaPortListKeywords = map[string]bool{
+		"tagged": true, "untagged": true, "forbid": true, "interface": true, "trunk": true,
+	}
+)
+
+var arubaPatterns = []Pattern{
+	{arubaPortPattern, TokenInterface, ParseModeAuto},
+	{arubaTrunkPattern, TokenInterface, ParseModeAuto},
+	{arubaHalfDuplexPattern, TokenStateWarning, ParseModeShow},
+}
+
+func (arubaDialect) Keywords() Keywords  { return arubaKeywords }
+func (arubaDialect) Patterns() []Pattern { return arubaPatterns }
+
+// ClassifyWord recognizes port numbers and lists, which are plain numbers
+// without the keyword before them ("untagged 1-24,Trk1", "interface 24",
+// "interface lag 1"), ProCurve "; comment" lines and the AOS-CX "Waiting for
+// link" port status.
+func (arubaDialect) ClassifyWord(l *Lexer, word, lower string) (TokenType, bool) {
+	if l.parseMode == ParseModeShow {
+		if arubaWaitingWords[lower] && strings.Contains(l.lineText(), "waiting for link") {
+			return TokenStateWarning, true
+		}
+		return TokenText, false
+	}
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	if strings.HasPrefix(strings.TrimLeft(l.input[lineStart:l.pos], " \t"), ";") {
+		return TokenComment, true
+	}
+	if arubaPortListKeywords[l.prevWord] || l.prevWord == "lag" && l.lineCommand == "interface" {
+		if arubaPortListPattern.MatchString(word) {
+			return TokenInterface, true
+		}
+	}
+	return TokenText, false
+}
+
+// arubaIndicators is evidence for Aruba found as plain substrings.
+var arubaIndicators = []dialectIndicator{
+	{"arubaos-cx", 3},
+	{"procurve", 3},
+	{"configuration editor; created on release", 3},
+	{"status and counters", 3},
+	{"default_vlan", 2},
+	{"untagged ", 2},
+	{"tagged trk", 2},
+	{"vsx", 1},
+	{"dhcp-bootp", 1},
+	{"loop-protect", 1},
+}
+
+// AOS-CX port naming in config and the first column of show tables:
+// "interface 1/1/1", "1/1/1      1       access 1GbT"
+var arubaInterfaceMention = regexp.MustCompile(`(?m)^(interface )?\d+/\d+/\d+(:\d+)?\s`)
+
+func (arubaDialect) DetectScore(sample string) int {
+	score := scoreIndicators(strings.ToLower(sample), arubaIndicators)
+	if arubaInterfaceMention.MatchString(sample) {
+		score += 2
+	}
+	return score
+}
+
+// AOS show output has no profiles of its own; the IOS ones pick up the
+// tables it shares with IOS, such as LACP and spanning tree.
+func (arubaDialect) ShowProfiles() []*ShowProfile { return iosShowProfiles }
@@ -0,0 +1,112 @@
+package lexer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// 4-byte AS numbers in asdot notation (RFC 5396): 65000.100
+	asdotPattern = regexp.MustCompile(`^\d{1,5}\.\d{1,5}$`)
+
+	// Keywords whose argument is an AS number: "router bgp 65000",
+	// "neighbor 10.0.0.1 remote-as 65001", "remote AS 65001" in show output
+	asnKeywords = map[string]bool{
+		"remote-as": true, "local-as": true, "as": true,
+	}
+)
+
+// ParseASN parses an AS number in asplain ("4200000001"), asdot
+// ("64086.59905") or AS-prefixed ("AS65000", "AS1.10") notation.
+func ParseASN(s string) (uint32, bool) {
+	if len(s) > 2 && strings.EqualFold(s[:2], "as") {
+		s = s[2:]
+	}
+	if high, low, ok := strings.Cut(s, "."); ok {
+		h, err1 := strconv.ParseUint(high, 10, 16)
+		l, err2 := strconv.ParseUint(low, 10, 16)
+		if err1 != nil || err2 != nil {
+			return 0, false
+		}
+		return uint32(h<<16 | l), true
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(n), true
+}
+
+// IsPrivateASN reports whether asn is set aside for private use (RFC 6996):
+// 64512-65534 and 4200000000-4294967294.
+func IsPrivateASN(asn uint32) bool {
+	return asn >= 64512 && asn <= 65534 || asn >= 4200000000 && asn <= 4294967294
+}
+
+// IsReservedASN reports whether asn must not appear in the global routing
+// table as a real AS: 0 (RFC 7607), AS_TRANS 23456 (RFC 6793), the
+// documentation ranges 64496-64511 and 65536-65551 (RFC 5398), 65535 and
+// 4294967295 (RFC 7300), and the IANA reserved 65552-131071.
+func IsReservedASN(asn uint32) bool {
+	switch {
+	case asn == 0, asn == 23456, asn == 65535, asn == 4294967295:
+		return true
+	case asn >= 64496 && asn <= 64511:
+		return true
+	case asn >= 65536 && asn <= 131071:
+		return true
+	}
+	return false
+}
+
+// classifyASN recognizes AS numbers: AS-prefixed forms anywhere, and plain
+// or asdot numbers where the line expects an AS number. Private and reserved
+// numbers are told apart so a leaked private AS stands out.
+func (l *Lexer) classifyASN(word, lower string) (TokenType, bool) {
+	if !strings.HasPrefix(lower, "as") || !asnPattern.MatchString(word) {
+		if !isAllDigits(word) && !(startsWithDigit(word) && asdotPattern.MatchString(word)) || !l.expectsASN(word) {
+			return TokenText, false
+		}
+	}
+	asn, ok := ParseASN(word)
+	if !ok {
+		return TokenText, false
+	}
+	if IsPrivateASN(asn) || IsReservedASN(asn) {
+		return TokenPrivateASN, true
+	}
+	return TokenASN, true
+}
+
+// expectsASN reports whether the word being classified is in an AS number
+// position.
+func (l *Lexer) expectsASN(word string) bool {
+	switch {
+	case asnKeywords[l.prevWord]:
+		return true
+	case l.prevWord == "bgp":
+		return l.lineCommand == "router"
+	case l.prevWord == "identifier":
+		return l.follows(word, "confederation identifier")
+	}
+	// Lists: "bgp confederation peers 65010 65020",
+	// "set as-path prepend 65000 65000" but not "prepend last-as 3"
+	if l.asnList {
+		return l.prevWord != "last-as"
+	}
+	// "BGP router identifier 10.0.0.1, local AS number 65000"
+	return l.prevWord == "number" && l.follows(word, "as number")
+}
+
+// follows reports whether phrase, ignoring case, comes right before word,
+// the word being classified. Only the input just before word is looked at,
+// so long lines are not scanned again for every word.
+func (l *Lexer) follows(word, phrase string) bool {
+	before := strings.TrimRight(l.input[:l.pos-len(word)], " \t")
+	start := len(before) - len(phrase)
+	if start < 0 || !strings.EqualFold(before[start:], phrase) {
+		return false
+	}
+	return start == 0 || isWhitespace(before[start-1])
+}
@@ -0,0 +1,112 @@
+package lexer
+
+import "testing"
+
+func TestParseASN(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected uint32
+		ok       bool
+	}{
+		{"3356", 3356, true},
+		{"4200000001", 4200000001, true},
+		{"1.10", 65546, true},
+		{"65535.65535", 4294967295, true},
+		{"AS65000", 65000, true},
+		{"as1.0", 65536, true},
+		{"4294967296", 0, false},
+		{"65536.1", 0, false},
+		{"1.", 0, false},
+		{"AS", 0, false},
+		{"-1", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseASN(tt.input)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("ParseASN(%q) = %d, %v, want %d, %v", tt.input, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestPrivateASN(t *testing.T) {
+	tests := []struct {
+		asn      uint32
+		private  bool
+		reserved bool
+	}{
+		{3356, false, false},
+		{64511, false, true},
+		{64512, true, false},
+		{65534, true, false},
+		{65535, false, true},
+		{23456, false, true},
+		{0, false, true},
+		{131072, false, false},
+		{4200000000, true, false},
+		{4294967295, false, true},
+	}
+
+	for _, tt := range tests {
+		if got := IsPrivateASN(tt.asn); got != tt.private {
+			t.Errorf("IsPrivateASN(%d) = %v, want %v", tt.asn, got, tt.private)
+		}
+		if got := IsReservedASN(tt.asn); got != tt.reserved {
+			t.Errorf("IsReservedASN(%d) = %v, want %v", tt.asn, got, tt.reserved)
+		}
+	}
+}
+
+func TestASNContext(t *testing.T) {
+	input := `router bgp 65000.100
+ bgp confederation identifier 100
+ bgp confederation peers 65010 3356
+ neighbor 10.0.0.1 remote-as 174
+ neighbor 10.0.0.1 local-as 64512 no-prepend
+route-map OUT permit 10
+ match as-path 10
+ set as-path prepend 1299 1299
+ set as-path prepend last-as 3
+`
+	type at struct {
+		line  int
+		value string
+	}
+	want := []struct {
+		at
+		typ TokenType
+	}{
+		{at{1, "65000.100"}, TokenPrivateASN},
+		{at{2, "100"}, TokenASN},
+		{at{3, "65010"}, TokenPrivateASN},
+		{at{3, "3356"}, TokenASN},
+		{at{4, "174"}, TokenASN},
+		{at{5, "64512"}, TokenPrivateASN},
+		{at{6, "10"}, TokenNumber},
+		{at{7, "10"}, TokenNumber},
+		{at{8, "1299"}, TokenASN},
+		{at{9, "3"}, TokenNumber},
+	}
+
+	l := New(input)
+	l.SetParseMode(ParseModeConfig)
+	seen := make(map[at]TokenType)
+	for _, tok := range l.Tokenize() {
+		seen[at{tok.Line, tok.Value}] = tok.Type
+	}
+	for _, w := range want {
+		if got := seen[w.at]; got != w.typ {
+			t.Errorf("line %d %q = %v, want %v", w.line, w.value, got, w.typ)
+		}
+	}
+
+	l = New("BGP router identifier 10.0.0.1, local AS number 3356\n")
+	l.SetParseMode(ParseModeShow)
+	var found bool
+	for _, tok := range l.Tokenize() {
+		found = found || tok.Value == "3356" && tok.Type == TokenASN
+	}
+	if !found {
+		t.Error("local AS number not recognized in show output")
+	}
+}
@@ -0,0 +1,116 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/internal/benchdata"
+)
+
+// Benchmark inputs, built once so their generation is not measured.
+var (
+	benchConfig     = benchdata.Config(5000)
+	benchShowTech   = benchdata.ShowTech(1 << 20)
+	benchTranscript = benchdata.Transcript(200)
+)
+
+func benchmarkTokenize(b *testing.B, input string, mode ParseMode) {
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l := New(input)
+		l.SetParseMode(mode)
+		l.Tokenize()
+	}
+}
+
+func BenchmarkTokenizeLine(b *testing.B) {
+	benchmarkTokenize(b, benchdata.Line, ParseModeShow)
+}
+
+func BenchmarkTokenizeConfig(b *testing.B) {
+	benchmarkTokenize(b, benchConfig, ParseModeConfig)
+}
+
+func BenchmarkTokenizeShowTech(b *testing.B) {
+	benchmarkTokenize(b, benchShowTech, ParseModeShow)
+}
+
+func BenchmarkTokenizeTranscript(b *testing.B) {
+	benchmarkTokenize(b, benchTranscript, ParseModeTranscript)
+}
+
+func BenchmarkTokenizeAutoDetect(b *testing.B) {
+	benchmarkTokenize(b, benchConfig, ParseModeAuto)
+}
+
+// BenchmarkScanConfig is BenchmarkTokenizeConfig without collecting the
+// tokens in a slice.
+func BenchmarkScanConfig(b *testing.B) {
+	b.SetBytes(int64(len(benchConfig)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l := New(benchConfig)
+		l.SetParseMode(ParseModeConfig)
+		l.Scan(func(Token) bool { return true })
+	}
+}
+
+// BenchmarkClassifyWord measures the regex-heavy word classifier on its own,
+// without scanning, over words that reach each of its patterns.
+func BenchmarkClassifyWord(b *testing.B) {
+	words := []string{
+		"GigabitEthernet1/0/1", "10.0.0.0/24", "10.0.0.1", "0011.2233.4455",
+		"65000:100", "2001:db8::/32", "2001:db8::1", "10Gbps", "1500", "RM-IN",
+	}
+	l := New("")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, word := range words {
+			l.classifySharedPatterns(word)
+		}
+	}
+}
+
+// BenchmarkDetectDialect measures dialect detection, which runs once per
+// Lexer when the dialect is auto and so dominates short inputs.
+func BenchmarkDetectDialect(b *testing.B) {
+	l := New(benchConfig)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.detectDialect()
+	}
+}
+
+// BenchmarkTokenizeHexDump measures a config with a long certificate, which
+// is scanned as one token rather than word by word.
+func BenchmarkTokenizeHexDump(b *testing.B) {
+	input := "crypto pki certificate chain TP-self-signed-1\n certificate self-signed 01\n" +
+		strings.Repeat("  3082032E 30820216 A0030201 02020101 300D0609 2A864886 F70D0101 05050030\n", 2000) +
+		"  \tquit\n"
+	benchmarkTokenize(b, input, ParseModeConfig)
+}
+
+// BenchmarkTokenizeLongMarkerLine measures a single line of "^" markers, each
+// of which checks that nothing comes before it on the line.
+func BenchmarkTokenizeLongMarkerLine(b *testing.B) {
+	input := strings.Repeat("^ ", 32000) + "\n"
+	benchmarkTokenize(b, input, ParseModeShow)
+}
+
+// BenchmarkTokenizeLongValueKeywordLine measures a single line of words that
+// end value keywords of several words, each of which looks back only as far
+// as such a keyword reaches.
+func BenchmarkTokenizeLongValueKeywordLine(b *testing.B) {
+	input := "logging" + strings.Repeat(" location", 16000) + "\n"
+	benchmarkTokenize(b, input, ParseModeConfig)
+}
+
+// BenchmarkTokenizeLongLine measures a single line with a type 7 password
+// after every other word, whose context is matched once per line rather
+// than once per password.
+func BenchmarkTokenizeLongLine(b *testing.B) {
+	input := "key chain " + strings.Repeat("key-string 7 0822455D0A16 ", 4000) + "\n"
+	benchmarkTokenize(b, input, ParseModeConfig)
+}
@@ -0,0 +1,136 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// show ip bgp summary, show bgp ipv6 unicast summary
+var (
+	// Neighbor states in the State/PfxRcd column. A session that is not
+	// Established shows its state instead of a prefix count, so even Active,
+	// good elsewhere, means the session is down.
+	bgpNeighborStates = map[string]TokenType{
+		"idle": TokenStateBad, "active": TokenStateBad, "connect": TokenStateBad,
+		"opensent": TokenStateWarning, "openconfirm": TokenStateWarning,
+		"(admin)": TokenStateWarning, "(pfxct)": TokenStateBad, "(nonegot)": TokenStateWarning,
+	}
+
+	bgpSummaryProfile = &ShowProfile{
+		Name: "bgp-summary",
+		Indicators: []string{
+			"state/pfxrcd", "bgp router identifier", "bgp table version",
+			"network entries using", "path entries using", "msgrcvd",
+		},
+		Classify: classifyBGPSummary,
+	}
+
+	// Status codes before a BGP route: "*>", "*d", "h", "s>", "r i"
+	bgpStatusPattern = regexp.MustCompile(`^[*>sdhirSmbfxacez]{1,4}$`)
+
+	// Words for routes held back by dampening, in the status code legend
+	// and in route details: "(suppressed due to dampening)"
+	bgpDampeningStates = map[string]TokenType{
+		"suppressed": TokenStateWarning, "damped": TokenStateWarning, "dampened": TokenStateWarning,
+		"history": TokenStateNeutral,
+	}
+
+	bgpDampeningProfile = &ShowProfile{
+		Name: "bgp-dampening",
+		Indicators: []string{
+			"flaps duration", "flap-statistics", "dampinfo", "suppressed due to dampening",
+			"suppress penalty", "reuse penalty", "half-life time", "s suppressed, d damped",
+		},
+		Classify: classifyBGPDampening,
+	}
+)
+
+// classifyBGPSummary handles the neighbor rows of a BGP summary, IPv6
+// neighbors too long for the first column wrapped onto a line of their own:
+//
+//	Neighbor        V           AS MsgRcvd MsgSent   TblVer  InQ OutQ Up/Down  State/PfxRcd
+//	2001:DB8:0:1::2 4        65001    1234    1230       12    0    0 1d02h           3
+//	FE80::A8BB:CCFF:FE00:200%GigabitEthernet0/0/1
+//	                4        65003     120     118       12    0    0 00:58:10        2
+//	10.0.0.4        4        65004       0       0        1    0    0 never    Idle (Admin)
+//
+// The AS column is an AS number, and a state in place of the prefix count is
+// colored by how far the session got: an administrative shutdown is a
+// warning rather than a failure.
+func classifyBGPSummary(l *Lexer, word, lower string) (TokenType, bool) {
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, _ := l.lineAt(lineStart)
+	cols := lineColumns(line)
+	if len(cols) < 8 {
+		return TokenText, false
+	}
+
+	// The AS number follows the version, "4" or "6", which starts the
+	// continuation of a wrapped row.
+	as := 2
+	switch first := line[cols[0].start:cols[0].end]; {
+	case isAddressWord(first) && len(cols) >= 9:
+	case (first == "4" || first == "6") && cols[0].start > 0:
+		as = 1
+	default:
+		return TokenText, false
+	}
+
+	switch field := wordColumn(cols, l.pos-len(word)-lineStart); {
+	case field == as:
+		return TokenASN, true
+	case field > as+5:
+		if t, ok := bgpNeighborStates[lower]; ok {
+			if lower == "idle" && strings.ToLower(l.peekWord()) == "(admin)" {
+				return TokenStateWarning, true
+			}
+			return t, true
+		}
+	}
+	return TokenText, false
+}
+
+// classifyBGPDampening handles show ip bgp dampening flap-statistics,
+// dampened-paths and parameters, and the dampening details of a route:
+//
+//	   Network          From             Flaps Duration Reuse    Path
+//	*d 10.1.0.0/16      192.0.2.1        5     00:12:40 00:21:30 65001 i
+//	h  10.2.0.0/16      192.0.2.1        3     00:05:10          65001 i
+//
+//	  65001, (suppressed due to dampening)
+//	      Dampinfo: penalty 2875, flapped 5 times in 00:12:40, reuse in 00:21:30
+//
+// Routes suppressed ("s") or damped ("d") get the warning color, their
+// status code and prefix both, and withdrawn routes kept for their flap
+// history ("h") the neutral one. Flap counts and durations are left to the
+// general rules.
+func classifyBGPDampening(l *Lexer, word, lower string) (TokenType, bool) {
+	if t, ok := bgpDampeningStates[strings.Trim(lower, "(),")]; ok {
+		return t, true
+	}
+	switch {
+	case lower == "dampinfo:":
+		return TokenKeyword, true
+	case l.prevWord == "penalty" && isAllDigits(strings.TrimSuffix(word, ",")):
+		return TokenNumber, true
+	}
+
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, _ := l.lineAt(lineStart)
+	fields := strings.Fields(line)
+	if len(fields) < 3 || line[0] == ' ' || !bgpStatusPattern.MatchString(fields[0]) || !isAddressWord(fields[1]) {
+		return TokenText, false
+	}
+	suppressed := strings.ContainsAny(fields[0], "sd")
+	switch first := l.prevWord == ""; {
+	case first && suppressed:
+		return TokenStateWarning, true
+	case first && strings.Contains(fields[0], "h"):
+		return TokenStateNeutral, true
+	case first:
+		return TokenStatusSymbol, true
+	case suppressed && word == fields[1] && strings.ToLower(fields[0]) == l.prevWord:
+		return TokenStateWarning, true
+	}
+	return TokenText, false
+}
@@ -0,0 +1,144 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// show ip bgp, show bgp ipv6 unicast
+var (
+	// Status codes before a route, with the RPKI validation code some
+	// releases put first: "*>", "*", "i", "r>i", "s>", "V*>", "*m", "h"
+	bgpRouteStatusPattern = regexp.MustCompile(`^[VNI]?[*>sdhirSmbfxacetL]{1,4}$`)
+
+	// Origin codes at the end of the AS path: IGP, EGP, incomplete
+	bgpOriginCodes = map[string]bool{"i": true, "e": true, "?": true}
+
+	bgpTableProfile = &ShowProfile{
+		Name: "bgp-table",
+		Indicators: []string{
+			"next hop", "metric locprf", "locprf", "weight path",
+		},
+		Classify: classifyBGPTable,
+	}
+)
+
+// How many display columns the Path column starts after the Next Hop one.
+// The weight ends two columns before it even at 65535, so the AS path is
+// found without the header, which may be thousands of routes above.
+const bgpPathOffset = 41
+
+// The next hop starts at display column 21 or later; networks start before.
+const bgpNextHopMinColumn = 12
+
+// classifyBGPTable handles the routes of show ip bgp:
+//
+//	    Network          Next Hop            Metric LocPrf Weight Path
+//	*>   10.1.0.0/16      192.0.2.1                0             0 65001 i
+//	* i  10.3.0.0/16      10.0.0.2                 0    100      0 65004 {65005,65006} e
+//	r>i  10.4.0.0/24      10.0.0.2                 0    100      0 i
+//	*>   2001:DB8:1::/48  2001:DB8::2
+//	                                               0             0 65010 i
+//
+// The status codes are one token, colored by what they say about the route:
+// suppressed, damped, RIB-failure and stale routes are warnings, history
+// entries neutral and RPKI invalid ones bad. The AS path is a sequence of AS
+// numbers, followed by the origin code.
+func classifyBGPTable(l *Lexer, word, lower string) (TokenType, bool) {
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, _ := l.lineAt(lineStart)
+	if lowerLine := strings.ToLower(line); strings.Contains(lowerLine, "next hop") && strings.Contains(lowerLine, "path") {
+		return TokenColumnHeader, true
+	}
+
+	cols := lineColumns(line)
+	field := wordColumn(cols, l.pos-len(word)-lineStart)
+	if field < 0 {
+		return TokenText, false
+	}
+	first, nextHop := bgpRouteAddresses(line, cols)
+	if first < 0 && lineStart > 0 {
+		// The rest of a route whose next hop was too long to share a line
+		// with the numbers after it
+		prev, _ := l.lineAt(strings.LastIndexByte(l.input[:lineStart-1], '\n') + 1)
+		_, nextHop = bgpRouteAddresses(prev, lineColumns(prev))
+	}
+
+	switch {
+	case field < first && bgpRouteStatusPattern.MatchString(word):
+		return bgpStatusType(word), true
+	case field == first && len(word) == bgpStatusLength(line[cols[field].start:cols[field].end]):
+		// Split off the network in older releases: "*>i10.2.2.0/24"
+		return bgpStatusType(word), true
+	case nextHop == 0 || cols[field].left < nextHop+bgpPathOffset:
+		return TokenText, false
+	case field == len(cols)-1 && bgpOriginCodes[word]:
+		return TokenKeyword, true
+	}
+	return bgpPathASN(word)
+}
+
+// bgpRouteAddresses returns the field of the first address on a line of the
+// BGP table, the network or the next hop, or -1 if there is none, and the
+// display column of the next hop, or 0 if the line has none. A route whose
+// network is too long to share a line with its next hop has only the network.
+func bgpRouteAddresses(line string, cols []column) (first, nextHop int) {
+	first = -1
+	for i, c := range cols {
+		w := line[c.start:c.end]
+		if !isAddressWord(w) && w != "::" && bgpStatusLength(w) == 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		// The network starts a few columns in, the next hop past it
+		if i > first || c.left > bgpNextHopMinColumn {
+			nextHop = c.left
+		}
+	}
+	return first, nextHop
+}
+
+// bgpStatusType returns the type of a route's status codes.
+func bgpStatusType(code string) TokenType {
+	switch {
+	case strings.HasPrefix(code, "I") && len(code) > 1:
+		return TokenStateBad
+	case strings.ContainsAny(code, "sdrS"):
+		return TokenStateWarning
+	case strings.Contains(code, "h"):
+		return TokenStateNeutral
+	}
+	return TokenStatusSymbol
+}
+
+// bgpPathASN returns the type of a word of an AS path: an AS number, an AS
+// set "{65005,65006}" or a confederation segment "(65010 65020)", private
+// when all its AS numbers are.
+func bgpPathASN(word string) (TokenType, bool) {
+	private := true
+	for _, n := range strings.Split(strings.Trim(word, "{}()"), ",") {
+		asn, ok := ParseASN(n)
+		if !ok {
+			return TokenText, false
+		}
+		private = private && (IsPrivateASN(asn) || IsReservedASN(asn))
+	}
+	if private {
+		return TokenPrivateASN, true
+	}
+	return TokenASN, true
+}
+
+// bgpStatusLength returns the length of the status codes that older
+// releases print without a space before the network, "*>i" in
+// "*>i10.2.2.0/24", or 0 if word is not such a route.
+func bgpStatusLength(word string) int {
+	for i := 1; i <= 4 && i < len(word); i++ {
+		if bgpRouteStatusPattern.MatchString(word[:i]) && isAddressWord(word[i:]) {
+			return i
+		}
+	}
+	return 0
+}
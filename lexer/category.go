@@ -0,0 +1,95 @@
+package lexer
+
+import "strings"
+
+// Category is a coarse grouping of token types, for renderers and theme
+// editors that style or filter tokens by kind rather than by exact type.
+type Category int
+
+const (
+	CategoryText      Category = iota // whitespace, unclassified text, terminal artifacts
+	CategoryStructure                 // commands, sections, keywords, operators, comments, prompts
+	CategoryName                      // interfaces, VRFs, hostnames and other identifiers
+	CategoryAddress                   // IPv4/IPv6 addresses and prefixes, masks, MAC addresses
+	CategoryLiteral                   // numbers, strings, values, communities, durations, sizes
+	CategoryState                     // good/bad/warning/neutral states in show output
+	CategorySecret                    // passwords, keys and other credentials
+)
+
+// String returns a human-readable name for the category.
+func (c Category) String() string {
+	switch c {
+	case CategoryText:
+		return "Text"
+	case CategoryStructure:
+		return "Structure"
+	case CategoryName:
+		return "Name"
+	case CategoryAddress:
+		return "Address"
+	case CategoryLiteral:
+		return "Literal"
+	case CategoryState:
+		return "State"
+	case CategorySecret:
+		return "Secret"
+	default:
+		return "Unknown"
+	}
+}
+
+// CategoryByName returns the category with the given name, case-insensitively
+// ("address", "State"). ok is false for unknown names.
+func CategoryByName(name string) (c Category, ok bool) {
+	for _, c := range AllCategories() {
+		if strings.EqualFold(c.String(), name) {
+			return c, true
+		}
+	}
+	return CategoryText, false
+}
+
+// AllCategories returns every category in declaration order.
+func AllCategories() []Category {
+	return []Category{
+		CategoryText, CategoryStructure, CategoryName, CategoryAddress,
+		CategoryLiteral, CategoryState, CategorySecret,
+	}
+}
+
+// AllTokenTypes returns every token type in declaration order.
+func AllTokenTypes() []TokenType {
+	types := make([]TokenType, 0, tokenTypeCount)
+	for t := TokenText; t < tokenTypeCount; t++ {
+		types = append(types, t)
+	}
+	return types
+}
+
+// Category returns the coarse category of the token type.
+func (t TokenType) Category() Category {
+	switch t {
+	case TokenCommand, TokenSection, TokenProtocol, TokenAction, TokenKeyword,
+		TokenOperator, TokenNegation, TokenComment, TokenColumnHeader,
+		TokenStatusSymbol, TokenPromptMode, TokenPromptOper, TokenPromptConf:
+		return CategoryStructure
+	case TokenInterface, TokenIdentifier, TokenVRF, TokenPromptHost, TokenUserDefinedName:
+		return CategoryName
+	case TokenIPv4, TokenIPv4Prefix, TokenIPv6, TokenIPv6Prefix, TokenMAC,
+		TokenSubnetMask, TokenWildcardMask, TokenVirtualIP:
+		return CategoryAddress
+	case TokenNumber, TokenString, TokenValue, TokenASN, TokenPrivateASN, TokenCommunity,
+		TokenTimeDuration, TokenPercentage, TokenByteSize, TokenRouteProtocol,
+		TokenBridgeID, TokenRouteDistinguisher, TokenRouteTarget,
+		TokenVersion, TokenModel, TokenSerial, TokenUptime, TokenMemorySize,
+		TokenConfigRegister, TokenMPLSLabel, TokenRate, TokenTimestamp, TokenHex, TokenHexDump:
+		return CategoryLiteral
+	case TokenStateGood, TokenStateBad, TokenStateWarning, TokenStateNeutral,
+		TokenError, TokenStale:
+		return CategoryState
+	case TokenSecret:
+		return CategorySecret
+	default:
+		return CategoryText
+	}
+}
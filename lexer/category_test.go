@@ -0,0 +1,95 @@
+package lexer
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAllTokenTypes(t *testing.T) {
+	types := AllTokenTypes()
+	if len(types) == 0 || types[0] != TokenText {
+		t.Fatalf("AllTokenTypes should start with TokenText, got %v", types)
+	}
+	seen := make(map[string]bool)
+	for _, tt := range types {
+		name := tt.String()
+		if name == "Unknown" {
+			t.Errorf("token type %d has no name", tt)
+		}
+		if seen[name] {
+			t.Errorf("duplicate token type name %q", name)
+		}
+		seen[name] = true
+	}
+}
+
+func TestTokenJSON(t *testing.T) {
+	token := Token{Type: TokenInterface, Value: "Gi0/1", Line: 2, Column: 11}
+	data, err := json.Marshal(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"Interface","value":"Gi0/1","line":2,"column":11}`
+	if string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+
+	var back Token
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back != token {
+		t.Errorf("round trip = %+v, want %+v", back, token)
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"Bogus"}`), &back); err == nil {
+		t.Error("expected error for unknown token type")
+	}
+}
+
+func TestTokenCategories(t *testing.T) {
+	// Every type but the text-like ones must be assigned a category
+	textLike := map[TokenType]bool{TokenText: true, TokenPager: true, TokenIndentGuide: true, TokenLineNumber: true}
+	for _, tt := range AllTokenTypes() {
+		if got := tt.Category(); (got == CategoryText) != textLike[tt] {
+			t.Errorf("%v: unexpected category %v", tt, got)
+		}
+	}
+
+	tests := []struct {
+		token    TokenType
+		expected Category
+	}{
+		{TokenCommand, CategoryStructure},
+		{TokenInterface, CategoryName},
+		{TokenIPv6Prefix, CategoryAddress},
+		{TokenWildcardMask, CategoryAddress},
+		{TokenCommunity, CategoryLiteral},
+		{TokenStateBad, CategoryState},
+	}
+	for _, tt := range tests {
+		if got := tt.token.Category(); got != tt.expected {
+			t.Errorf("%v.Category() = %v, want %v", tt.token, got, tt.expected)
+		}
+	}
+
+	for _, c := range AllCategories() {
+		if c.String() == "Unknown" {
+			t.Errorf("category %d has no name", c)
+		}
+	}
+}
+
+func TestCategoryByName(t *testing.T) {
+	for _, c := range AllCategories() {
+		if got, ok := CategoryByName(c.String()); !ok || got != c {
+			t.Errorf("CategoryByName(%q) = %v, %v", c.String(), got, ok)
+		}
+	}
+	if got, ok := CategoryByName("ADDRESS"); !ok || got != CategoryAddress {
+		t.Errorf("CategoryByName should ignore case, got %v, %v", got, ok)
+	}
+	if _, ok := CategoryByName("colors"); ok {
+		t.Error("CategoryByName should reject unknown names")
+	}
+}
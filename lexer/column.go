@@ -0,0 +1,67 @@
+package lexer
+
+import (
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// TabWidth is the number of columns between tab stops used for Token.Column.
+const TabWidth = 8
+
+// AdvanceColumn returns the 1-based display column after s is printed
+// starting at column col, as a terminal would show it: tabs move to the next
+// TabWidth stop, wide runes (CJK) take two columns and combining marks none.
+// A newline in s starts again at column 1.
+func AdvanceColumn(col int, s string) int {
+	for i := 0; i < len(s); {
+		ch := s[i]
+		if ch < utf8.RuneSelf {
+			col = nextColumn(col, ch)
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		col += runewidth.RuneWidth(r)
+		i += size
+	}
+	return col
+}
+
+// EndColumn returns the display column just after the token.
+func (t Token) EndColumn() int {
+	return AdvanceColumn(t.Column, t.Value)
+}
+
+// nextColumn returns the column after the ASCII character ch at column col.
+func nextColumn(col int, ch byte) int {
+	switch ch {
+	case '\n':
+		return 1
+	case '\t':
+		return ((col-1)/TabWidth+1)*TabWidth + 1
+	default:
+		return col + 1
+	}
+}
+
+// byteWidth returns the columns the non-ASCII byte s[i] takes, as
+// AdvanceColumn counts them: a rune takes its display width at its first byte
+// and none at the others, and a byte that is not part of a valid rune takes
+// one column.
+func byteWidth(s string, i int) int {
+	for j := i; j >= 0 && j > i-utf8.UTFMax; j-- {
+		if !utf8.RuneStart(s[j]) {
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[j:])
+		switch {
+		case r == utf8.RuneError && size == 1, j+size <= i:
+			return 1
+		case j == i:
+			return runewidth.RuneWidth(r)
+		}
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,34 @@
+package lexer
+
+// Command is a command typed at a device prompt, as echoed in a session:
+// "core-rtr-01(config-if)#no shutdown" gives Host "core-rtr-01", Mode
+// "config-if", Privileged true and Text "no shutdown".
+type Command struct {
+	Host       string // hostname in the prompt
+	Mode       string // configuration mode without parentheses, "" in exec mode
+	Privileged bool   // the prompt ends in # rather than >
+	Text       string // the command, without surrounding spaces
+	Line       int    // line of the prompt in the input
+	Column     int    // column where the command starts
+}
+
+// CommandHook is called for each command found after a prompt.
+type CommandHook func(Command)
+
+// SetCommandHook installs a hook called for each command typed at a prompt
+// while tokenizing, in input order, so session captures can be indexed by
+// command. Prompts without a command are skipped. Pass nil to remove it.
+//
+// The command's tokens are still classified like any other input; the hook
+// is the only way to tell they were typed. Retokenize calls it again for
+// prompt lines it re-scans.
+func (l *Lexer) SetCommandHook(hook CommandHook) {
+	l.commandHook = hook
+}
+
+// reportCommand passes cmd to the command hook, if one is installed.
+func (l *Lexer) reportCommand(cmd Command) {
+	if l.commandHook != nil {
+		l.commandHook(cmd)
+	}
+}
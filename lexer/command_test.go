@@ -0,0 +1,56 @@
+package lexer
+
+import "testing"
+
+func TestCommandHook(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		mode  ParseMode
+		want  []Command
+	}{
+		{
+			"single prompt line",
+			"core-rtr-01#  show ip route  \n",
+			ParseModeAuto,
+			[]Command{{Host: "core-rtr-01", Privileged: true, Text: "show ip route", Line: 1, Column: 15}},
+		},
+		{
+			"transcript",
+			"r1>show clock\n*10:00:00 UTC Mon Jan 1 2024\nr1#\nr1#show version\nCisco IOS Software\n",
+			ParseModeAuto,
+			[]Command{
+				{Host: "r1", Text: "show clock", Line: 1, Column: 4},
+				{Host: "r1", Privileged: true, Text: "show version", Line: 4, Column: 4},
+			},
+		},
+		{
+			"prompt lines in config",
+			"interface Gi0/1\n shutdown\nsw1(config-if)#no shutdown\n",
+			ParseModeConfig,
+			[]Command{{Host: "sw1", Mode: "config-if", Privileged: true, Text: "no shutdown", Line: 3, Column: 16}},
+		},
+		{"no prompt", "interface Gi0/1\n shutdown\n", ParseModeAuto, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Command
+			l := New(tt.input)
+			if tt.mode != ParseModeAuto {
+				l.SetParseMode(tt.mode)
+			}
+			l.SetCommandHook(func(cmd Command) { got = append(got, cmd) })
+			l.Tokenize()
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d commands %+v, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("command %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
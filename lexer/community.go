@@ -0,0 +1,70 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Well-known communities (RFC 1997, RFC 7999, RFC 8326) and their IOS/FRR spellings
+	wellKnownCommunities = map[string]bool{
+		"no-export": true, "no-advertise": true, "no-export-subconfed": true,
+		"gshut": true, "graceful-shutdown": true, "accept-own": true,
+		"blackhole": true, "no-peer": true, "no-llgr": true, "llgr-stale": true,
+	}
+
+	// Well-known communities that are also ordinary words ("neighbor x local-as"),
+	// recognized only on lines that mention communities
+	contextualCommunities = map[string]bool{
+		"internet": true, "local-as": true,
+	}
+
+	// Large communities (RFC 8092): 65000:1:1
+	largeCommunityPattern = regexp.MustCompile(`^\d{1,10}:\d{1,10}:\d{1,10}$`)
+
+	// Clock times and uptimes that look like large communities: 12:30:00, 100:05:59
+	clockTimePattern = regexp.MustCompile(`^\d{1,3}:[0-5]\d:[0-5]\d$`)
+
+	// Extended communities with a type prefix, as in show output and FRR: RT:65000:100, SoO:10.0.0.1:5
+	taggedExtCommunityPattern = regexp.MustCompile(`^(?i)(rt|soo|ro):(\d+|(\d{1,3}\.){3}\d{1,3}|\d+\.\d+):\d+$`)
+
+	// Extended community type keywords in "set extcommunity rt 65000:100"
+	extCommunityTypes = map[string]bool{
+		"rt": true, "soo": true,
+	}
+)
+
+// classifyCommunity recognizes BGP communities. Large communities, tagged
+// extended communities and well-known community names are recognized
+// anywhere; standard (65000:100) and untagged extended (192.0.2.1:100)
+// values only on lines about communities, since they look like times and
+// route distinguishers.
+func (l *Lexer) classifyCommunity(word, lower string) (TokenType, bool) {
+	if wellKnownCommunities[lower] {
+		return TokenCommunity, true
+	}
+	if contextualCommunities[lower] {
+		return TokenCommunity, l.onCommunityLine()
+	}
+	if !strings.Contains(word, ":") {
+		return TokenText, false
+	}
+
+	switch {
+	case largeCommunityPattern.MatchString(word):
+		return TokenCommunity, !clockTimePattern.MatchString(word)
+	case taggedExtCommunityPattern.MatchString(word):
+		return TokenCommunity, true
+	case extCommunityPattern.MatchString(word):
+		if l.lastToken == "community" || extCommunityTypes[l.prevWord] || l.onCommunityLine() {
+			return TokenCommunity, true
+		}
+	}
+	return TokenText, false
+}
+
+// onCommunityLine reports whether the current line mentions communities:
+// "set community", "ip community-list", "set extcommunity", "Community:" etc.
+func (l *Lexer) onCommunityLine() bool {
+	return strings.Contains(l.lineText(), "community")
+}
@@ -0,0 +1,82 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// show crypto isakmp sa, show crypto ikev2 sa, show crypto ipsec sa,
+// show crypto session
+var (
+	// IKE and session states. Phase 1 states other than QM_IDLE are a
+	// negotiation under way (MM_KEY_EXCH, AG_INIT_EXCH), MM_NO_STATE one that
+	// failed; "(deleted)" marks an SA on its way out.
+	cryptoStates = map[string]TokenType{
+		"qm_idle": TokenStateGood, "ready": TokenStateGood, "up-active": TokenStateGood,
+		"active(active)": TokenStateGood,
+		"mm_no_state":    TokenStateBad, "delete": TokenStateBad, "down": TokenStateBad,
+		"up-idle": TokenStateWarning, "up-no-ike": TokenStateWarning, "down-negotiating": TokenStateWarning,
+		"in-neg": TokenStateWarning, "(deleted)": TokenStateWarning,
+	}
+
+	// Phase 1 negotiation states: MM_SA_SETUP, MM_KEY_EXCH, AG_INIT_EXCH
+	cryptoNegotiatingPattern = regexp.MustCompile(`^(?i)(mm|ag)_[a-z_]+$`)
+
+	// Security parameter indexes, in hex with the decimal value after them on
+	// IOS: "0x1A2B3C4D(439041101)"
+	spiPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{1,8}(\(\d+\))?$`)
+
+	// Remaining SA lifetime in kilobytes and seconds: "(4607999/3540)"
+	saLifetimePattern = regexp.MustCompile(`^\(\d+/\d+\)$`)
+
+	// IKE endpoints with their port: "192.0.2.1/500"
+	ikeEndpointPattern = regexp.MustCompile(`^(\d{1,3}\.){3}\d{1,3}/\d{3,5}$`)
+
+	cryptoProfile = &ShowProfile{
+		Name: "crypto",
+		Indicators: []string{
+			"crypto isakmp sa", "conn-id status", "qm_idle", "mm_no_state", "crypto map tag",
+			"#pkts encaps", "current_peer", "inbound esp sas", "outbound esp sas",
+			"crypto session current status", "session status", "ikev2 sa",
+		},
+		Classify: classifyCrypto,
+	}
+)
+
+// classifyCrypto handles IKE SA tables, IPsec SA details and crypto sessions:
+//
+//	dst             src             state          conn-id status
+//	192.0.2.2       192.0.2.1       QM_IDLE           1001 ACTIVE
+//	198.51.100.2    192.0.2.1       MM_NO_STATE       1002 ACTIVE (deleted)
+//
+//	    #pkts encaps: 1523, #pkts encrypt: 1523, #pkts digest: 1523
+//	     current outbound spi: 0x1A2B3C4D(439041101)
+//	        sa timing: remaining key lifetime (k/sec): (4607999/3540)
+//
+//	Session status: UP-ACTIVE
+//	  IKEv2 SA: local 192.0.2.1/500 remote 192.0.2.2/500 Active
+//
+// SPIs are hex tokens, the packet counters and remaining lifetime numbers,
+// and IKE and session states colored by how far the tunnel got.
+func classifyCrypto(l *Lexer, word, lower string) (TokenType, bool) {
+	if t, ok := cryptoStates[strings.TrimSuffix(lower, ",")]; ok {
+		return t, true
+	}
+	switch {
+	case cryptoNegotiatingPattern.MatchString(word):
+		return TokenStateWarning, true
+	case spiPattern.MatchString(word) && (l.prevWord == "spi:" || l.prevWord == "spi"):
+		return TokenHex, true
+	case saLifetimePattern.MatchString(word):
+		return TokenNumber, true
+	case ikeEndpointPattern.MatchString(word):
+		return TokenIPv4, true
+	case isAllDigits(strings.TrimSuffix(word, ",")) && strings.HasPrefix(strings.TrimSpace(l.lineText()), "#"):
+		// "#pkts encaps: 1523," and "#send errors 0,"
+		return TokenNumber, true
+	case lower == "active" && strings.HasPrefix(strings.ToLower(l.peekWord()), "sas"):
+		// "Active SAs: 2" counts SAs, whatever their state
+		return TokenText, true
+	}
+	return TokenText, false
+}
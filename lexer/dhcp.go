@@ -0,0 +1,85 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// show ip dhcp binding, show ip dhcp pool
+var (
+	// Client identifiers in dotted groups of four hex digits: the hardware
+	// type and MAC (0100.5079.6668.00) or a client name, which wraps onto
+	// the following lines (0063.6973.636f.2d30.)
+	dhcpClientIDPattern = regexp.MustCompile(`^(?i)([0-9a-f]{4}\.)+[0-9a-f]{0,4}$`)
+
+	// Lease expirations: "Mar 01 2024 12:04 AM", "Infinite"
+	dhcpLeasePattern = regexp.MustCompile(`(?i)\b(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\s+\d{1,2}\s+\d{4}\s+\d{1,2}:\d{2}(:\d{2})?(\s+[ap]m)?\b|\binfinite\b`)
+
+	// Continuation lines of the binding table header
+	dhcpHeaderLines = map[string]bool{"hardware address/": true, "user name": true}
+
+	// Binding types and states
+	dhcpBindingWords = map[string]TokenType{
+		"automatic": TokenKeyword, "manual": TokenKeyword, "dynamic": TokenKeyword,
+		"selecting": TokenStateWarning, "expired": TokenStateBad, "declined": TokenStateBad,
+	}
+
+	dhcpProfile = &ShowProfile{
+		Name: "dhcp",
+		Indicators: []string{
+			"lease expiration", "bindings from", "client-id", "hardware address",
+			"total addresses", "leased addresses", "excluded addresses", "utilization mark",
+		},
+		Classify: classifyDHCP,
+	}
+)
+
+// classifyDHCP handles the binding table and the pool summary:
+//
+//	IP address          Client-ID/              Lease expiration        Type       State
+//	                    Hardware address/
+//	                    User name
+//	10.1.1.11           0100.5079.6668.00       Mar 01 2024 12:04 AM    Automatic  Active
+//
+//	Pool LAN :
+//	 Total addresses                : 254
+//	 Leased addresses               : 250
+//
+// Client IDs are MAC addresses and lease expirations timestamps. Pool
+// utilization is left to the highlighter, which knows the pool's size.
+func classifyDHCP(l *Lexer, word, lower string) (TokenType, bool) {
+	line := strings.TrimSpace(l.lineText())
+	switch {
+	case strings.Contains(line, "lease expiration"), dhcpHeaderLines[line],
+		strings.HasPrefix(line, "current index"):
+		return TokenColumnHeader, true
+	case lower == "pool" && l.prevWord == "" && strings.HasSuffix(line, ":"):
+		return TokenSection, true
+	case lower == "pending" && strings.HasPrefix(line, "pending event"):
+		// A field label, not a state
+		return TokenIdentifier, true
+	}
+
+	if dhcpClientIDPattern.MatchString(word) {
+		return TokenMAC, true
+	}
+	if !ipv4Pattern.MatchString(l.lineCommand) {
+		return TokenText, false
+	}
+	if t, ok := dhcpBindingWords[lower]; ok {
+		return t, true
+	}
+	// Whether the word is part of the lease expiration on the row
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	rest := l.input[lineStart:]
+	if end := strings.IndexByte(rest, '\n'); end >= 0 {
+		rest = rest[:end]
+	}
+	start := l.pos - len(word) - lineStart
+	for _, m := range dhcpLeasePattern.FindAllStringIndex(rest, -1) {
+		if start >= m[0] && start < m[1] {
+			return TokenTimestamp, true
+		}
+	}
+	return TokenText, false
+}
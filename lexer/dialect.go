@@ -0,0 +1,259 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Dialect describes the syntax of a network operating system. Dialects refine
+// the IOS rules rather than replace them, since most router CLIs are IOS-like:
+// a dialect's keywords and patterns are consulted before the IOS rules, so it
+// only needs to list what differs.
+//
+// Dialects are registered with RegisterDialect. DialectIOS, DialectFRR and
+// DialectAruba are built in; other packages can register their own.
+type Dialect interface {
+	// Name returns the dialect's lowercase name, as used by DialectByName.
+	Name() string
+
+	// Keywords returns the words the dialect classifies differently from IOS.
+	Keywords() Keywords
+
+	// Patterns returns the patterns the dialect matches before the IOS rules.
+	Patterns() []Pattern
+
+	// DetectScore rates how strongly sample (the start of the input) looks
+	// like this dialect. Zero means no evidence.
+	DetectScore(sample string) int
+
+	// ShowProfiles returns the show output profiles for the dialect's show
+	// commands, in detection priority order.
+	ShowProfiles() []*ShowProfile
+}
+
+// WordClassifier is implemented by dialects that need context to classify
+// some words, such as port numbers that look like any other number. It is
+// called before the dialect's keywords and patterns, and can use the lexer's
+// PrevWord, PeekWord and LineText.
+type WordClassifier interface {
+	ClassifyWord(l *Lexer, word, lower string) (TokenType, bool)
+}
+
+// Keywords maps lowercase words to token types, by the parse mode in which
+// they apply.
+type Keywords struct {
+	Any    map[string]TokenType // config and show output
+	Config map[string]TokenType // config only
+	Show   map[string]TokenType // show output only
+}
+
+// Pattern classifies words that match Regexp as Type. Mode restricts the
+// pattern to ParseModeConfig or ParseModeShow; ParseModeAuto matches in both.
+type Pattern struct {
+	Regexp *regexp.Regexp
+	Type   TokenType
+	Mode   ParseMode
+}
+
+var (
+	// DialectIOS is Cisco IOS/IOS-XE (the default).
+	DialectIOS Dialect = iosDialect{}
+
+	// DialectFRR is FRRouting / Quagga as seen through vtysh.
+	DialectFRR Dialect = frrDialect{}
+
+	// DialectAruba is HPE Aruba AOS-CX and ProCurve / AOS-S switches.
+	DialectAruba Dialect = arubaDialect{}
+
+	// DialectAuto detects the dialect from the input (see DetectDialect).
+	DialectAuto Dialect = autoDialect{}
+)
+
+// autoDialect stands in for the detected dialect until input is seen.
+type autoDialect struct{}
+
+func (autoDialect) Name() string                 { return "auto" }
+func (autoDialect) String() string               { return "Auto" }
+func (autoDialect) Keywords() Keywords           { return Keywords{} }
+func (autoDialect) Patterns() []Pattern          { return nil }
+func (autoDialect) DetectScore(string) int       { return 0 }
+func (autoDialect) ShowProfiles() []*ShowProfile { return nil }
+
+// dialectRegistry holds the registered dialects in registration order.
+var dialectRegistry = struct {
+	sync.RWMutex
+	dialects []Dialect
+	names    map[string]Dialect // names and aliases
+}{names: make(map[string]Dialect)}
+
+func init() {
+	RegisterDialect(DialectIOS)
+	RegisterDialect(DialectFRR, "frrouting", "quagga", "vtysh")
+	RegisterDialect(DialectAruba, "aos-cx", "aoscx", "procurve", "aos-s")
+}
+
+// RegisterDialect makes d available to DialectByName under its name and the
+// given aliases, and to DetectDialect. Registering a dialect with the name of
+// an existing one replaces it.
+func RegisterDialect(d Dialect, aliases ...string) {
+	r := &dialectRegistry
+	r.Lock()
+	defer r.Unlock()
+
+	name := strings.ToLower(d.Name())
+	replaced := false
+	for i, existing := range r.dialects {
+		if strings.ToLower(existing.Name()) == name {
+			r.dialects[i] = d
+			replaced = true
+		}
+	}
+	if !replaced {
+		r.dialects = append(r.dialects, d)
+	}
+
+	r.names[name] = d
+	for _, alias := range aliases {
+		r.names[strings.ToLower(alias)] = d
+	}
+}
+
+// Dialects returns the registered dialects in registration order.
+func Dialects() []Dialect {
+	r := &dialectRegistry
+	r.RLock()
+	defer r.RUnlock()
+	return append([]Dialect(nil), r.dialects...)
+}
+
+// DialectNames returns a list of available dialect names.
+func DialectNames() []string {
+	names := []string{DialectAuto.Name()}
+	for _, d := range Dialects() {
+		names = append(names, d.Name())
+	}
+	return names
+}
+
+// DialectByName returns a dialect by its name or alias. Returns DialectIOS for
+// unknown names.
+func DialectByName(name string) Dialect {
+	name = strings.ToLower(name)
+	if name == DialectAuto.Name() {
+		return DialectAuto
+	}
+
+	r := &dialectRegistry
+	r.RLock()
+	defer r.RUnlock()
+	if d, ok := r.names[name]; ok {
+		return d
+	}
+	return DialectIOS
+}
+
+// SetDialect sets the dialect used for classification. DialectAuto (the
+// default) picks one from the input when tokenizing starts; nil is the same
+// as DialectAuto.
+func (l *Lexer) SetDialect(d Dialect) {
+	if d == nil {
+		d = DialectAuto
+	}
+	l.dialect = d
+	l.autoDialect = d == DialectAuto
+}
+
+// GetDialect returns the current dialect. After tokenizing with DialectAuto
+// it returns the detected dialect.
+func (l *Lexer) GetDialect() Dialect {
+	return l.dialect
+}
+
+// classifyDialectWord applies the dialect's keywords and patterns before the
+// IOS rules.
+func (l *Lexer) classifyDialectWord(word, lower string) (TokenType, bool) {
+	if c, ok := l.dialect.(WordClassifier); ok {
+		if t, ok := c.ClassifyWord(l, word, lower); ok {
+			l.becauseDialect("word classifier")
+			return t, true
+		}
+	}
+
+	kw := l.dialect.Keywords()
+	if t, ok := kw.Any[lower]; ok {
+		l.becauseDialect("keywords")
+		return t, true
+	}
+
+	if l.parseMode == ParseModeShow {
+		if t, ok := kw.Show[lower]; ok {
+			l.becauseDialect("show keywords")
+			return t, true
+		}
+	} else if t, ok := kw.Config[lower]; ok {
+		l.lastToken = lower
+		l.becauseDialect("config keywords")
+		return t, true
+	}
+
+	for _, p := range l.dialect.Patterns() {
+		if p.Mode != ParseModeAuto && p.Mode != l.parseMode {
+			continue
+		}
+		if p.Regexp.MatchString(word) {
+			l.becauseDialect("pattern " + p.Regexp.String())
+			return p.Type, true
+		}
+	}
+	return TokenText, false
+}
+
+// dialectIndicator is a substring of lowercase input that suggests a dialect.
+type dialectIndicator struct {
+	text   string
+	weight int
+}
+
+// scoreIndicators sums the weights of the indicators found in lower.
+func scoreIndicators(lower string, indicators []dialectIndicator) int {
+	score := 0
+	for _, ind := range indicators {
+		if strings.Contains(lower, ind.text) {
+			score += ind.weight
+		}
+	}
+	return score
+}
+
+// minDialectScore is the score a dialect other than IOS needs to be chosen.
+const minDialectScore = 2
+
+// DetectDialect scores the start of input with each registered dialect and
+// returns the best match. Input without clear evidence is DialectIOS.
+func DetectDialect(input string) Dialect {
+	return New(input).detectDialect()
+}
+
+func (l *Lexer) detectDialect() Dialect {
+	sample := l.sample()
+
+	best := DialectIOS
+	bestScore := DialectIOS.DetectScore(sample)
+	for _, d := range Dialects() {
+		if d == DialectIOS {
+			continue
+		}
+		if score := d.DetectScore(sample); score >= minDialectScore && score > bestScore {
+			best, bestScore = d, score
+		}
+	}
+	return best
+}
+
+// resolveDialect replaces DialectAuto with the dialect detected from the input.
+func (l *Lexer) resolveDialect() {
+	if l.dialect == DialectAuto {
+		l.dialect = l.detectDialect()
+	}
+}
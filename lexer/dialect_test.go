@@ -0,0 +1,300 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDialectByName(t *testing.T) {
+	for _, name := range DialectNames() {
+		if got := DialectByName(name).Name(); got != name {
+			t.Errorf("DialectByName(%q) returned dialect %q", name, got)
+		}
+	}
+	if DialectByName("quagga") != DialectFRR {
+		t.Error("quagga should map to DialectFRR")
+	}
+	if DialectByName("nonexistent") != DialectIOS {
+		t.Error("unknown names should map to DialectIOS")
+	}
+}
+
+func TestFRRConfig(t *testing.T) {
+	input := "frr version 8.4\nfrr defaults traditional\ninterface swp1\n neighbor swp1 interface peer-group FABRIC\n"
+
+	l := New(input)
+	l.SetDialect(DialectFRR)
+	tokens := l.Tokenize()
+
+	expected := map[string]TokenType{
+		"frr":         TokenCommand,
+		"defaults":    TokenKeyword,
+		"traditional": TokenKeyword,
+		"swp1":        TokenInterface,
+		"peer-group":  TokenKeyword,
+	}
+	for _, tok := range tokens {
+		if want, ok := expected[tok.Value]; ok && tok.Type != want {
+			t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+		}
+	}
+}
+
+func TestFRRInterfacesOnlyInFRRDialect(t *testing.T) {
+	tokens := New("swp1").Tokenize()
+	if tokens[0].Type == TokenInterface {
+		t.Error("swp1 should not be an interface in the IOS dialect")
+	}
+
+	l := New("swp1")
+	l.SetDialect(DialectFRR)
+	if tokens := l.Tokenize(); tokens[0].Type != TokenInterface {
+		t.Errorf("swp1 should be an interface in the FRR dialect, got %v", tokens[0].Type)
+	}
+}
+
+func TestFRRShowOutput(t *testing.T) {
+	input := "Neighbor        V    AS MsgRcvd MsgSent TblVer InQ OutQ Up/Down State/PfxRcd PfxSnt\n" +
+		"10.0.0.2        4 65002     123     125      0   0    0 01:02:03 (Policy) 5\n" +
+		"K>* 0.0.0.0/0 [0/100] via 10.0.0.1 eth0 00:10:20\n"
+
+	l := New(input)
+	l.SetDialect(DialectFRR)
+	l.SetParseMode(ParseModeShow)
+	tokens := l.Tokenize()
+
+	expected := map[string]TokenType{
+		"MsgRcvd":  TokenColumnHeader,
+		"PfxSnt":   TokenColumnHeader,
+		"(Policy)": TokenStateWarning,
+		"K>*":      TokenStatusSymbol,
+		"[0/100]":  TokenRouteProtocol,
+		"eth0":     TokenInterface,
+		"01:02:03": TokenTimeDuration,
+	}
+	for _, tok := range tokens {
+		if want, ok := expected[tok.Value]; ok && tok.Type != want {
+			t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+		}
+	}
+}
+
+// FRR show output is classified by the IOS show profiles, so states read
+// the same whichever dialect is selected
+func TestFRRShowProfiles(t *testing.T) {
+	input := "Neighbor        V         AS   MsgRcvd   MsgSent   TblVer  InQ OutQ  Up/Down State/PfxRcd   PfxSnt\n" +
+		"10.0.0.2        4      65002         0         0        0    0    0    never       Active        0\n"
+
+	for _, d := range []Dialect{DialectIOS, DialectFRR} {
+		l := New(input)
+		l.SetDialect(d)
+		l.SetParseMode(ParseModeShow)
+		for _, tok := range l.Tokenize() {
+			if tok.Value == "Active" && tok.Type != TokenStateBad {
+				t.Errorf("%v: Active = %v, want %v", d, tok.Type, TokenStateBad)
+			}
+		}
+	}
+}
+
+func TestArubaConfig(t *testing.T) {
+	input := `; J9773A Configuration Editor; Created on release #YA.16.04.0008
+trunk 49-50 trk1 lacp
+vlan 10
+   name "USERS"
+   tagged Trk1
+   untagged 1-24,A1-A4
+   exit
+interface 1/1/49:1
+    vlan trunk native 10
+interface lag 1
+    no shutdown
+`
+	l := New(input)
+	l.SetDialect(DialectAruba)
+	tokens := l.Tokenize()
+
+	expected := map[string]TokenType{
+		"J9773A":     TokenComment,
+		"49-50":      TokenInterface,
+		"trk1":       TokenInterface,
+		"tagged":     TokenKeyword,
+		"Trk1":       TokenInterface,
+		"1-24,A1-A4": TokenInterface,
+		"exit":       TokenCommand,
+		"1/1/49:1":   TokenInterface,
+		"10":         TokenNumber,
+		"1":          TokenInterface,
+	}
+	for _, tok := range tokens {
+		if want, ok := expected[tok.Value]; ok && tok.Type != want {
+			t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+		}
+	}
+}
+
+func TestArubaShowOutput(t *testing.T) {
+	input := "Port       Native  Mode   Type    Enabled Status  Reason\n" +
+		"1/1/2      1       access 1GbT    yes     down    Waiting for link\n" +
+		"  2            100/1000T  | No        Yes     Down   100HDx     Auto off   0\n"
+
+	l := New(input)
+	l.SetDialect(DialectAruba)
+	l.SetParseMode(ParseModeShow)
+
+	expected := map[string]TokenType{
+		"1/1/2":   TokenInterface,
+		"Waiting": TokenStateWarning,
+		"link":    TokenStateWarning,
+		"100HDx":  TokenStateWarning,
+	}
+	for _, tok := range l.Tokenize() {
+		if want, ok := expected[tok.Value]; ok && tok.Type != want {
+			t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+		}
+	}
+}
+
+func TestDetectDialect(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Dialect
+	}{
+		{"frr config", "frr version 8.4\nfrr defaults traditional\nhostname leaf01\n!\ninterface swp1\n", DialectFRR},
+		{"frr routes", "Codes: K - kernel route, C - connected, S - static\n\nK>* 0.0.0.0/0 [0/100] via 10.0.0.1, eth0, 00:10:20\nC>* 10.0.0.0/24 is directly connected, eth0, 00:10:20\n", DialectFRR},
+		{"linux interfaces", "interface swp1\n ip address 10.0.0.1/31\ninterface swp2\n ip address 10.0.0.3/31\n", DialectFRR},
+		{"aos-cx config", "!Version ArubaOS-CX FL.10.06.0110\nhostname sw1\nvlan 10\n    name USERS\ninterface 1/1/1\n    no shutdown\n", DialectAruba},
+		{"procurve config", "; J9773A Configuration Editor; Created on release #YA.16.04.0008\nhostname \"sw1\"\nvlan 10\n   untagged 1-24\n   exit\n", DialectAruba},
+		{"ios config", "Building configuration...\n\nCurrent configuration : 1234 bytes\n!\nversion 17.3\nhostname R1\n!\ninterface GigabitEthernet0/0\n", DialectIOS},
+		{"nx-os config", "!Command: show running-config\n!Time: Mon Jan 1 00:00:00 2024\nversion 9.3(5) Bios:version\nfeature bgp\nfeature lacp\ninterface Ethernet1/1\n", DialectIOS},
+		{"ios mentions zebra", "hostname zebra\ninterface GigabitEthernet0/1\n description to zebra\n switchport mode access\n", DialectIOS},
+		{"no evidence", "hostname R1\n", DialectIOS},
+		{"empty", "", DialectIOS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectDialect(tt.input); got != tt.expected {
+				t.Errorf("DetectDialect() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAutoDialect(t *testing.T) {
+	l := New("frr version 8.4\ninterface swp1\n")
+	if l.GetDialect() != DialectAuto {
+		t.Fatalf("new lexers should default to DialectAuto, got %v", l.GetDialect())
+	}
+	tokens := l.Tokenize()
+	if l.GetDialect() != DialectFRR {
+		t.Errorf("expected FRR to be detected, got %v", l.GetDialect())
+	}
+	for _, tok := range tokens {
+		if tok.Value == "swp1" && tok.Type != TokenInterface {
+			t.Errorf("swp1: expected Interface, got %v", tok.Type)
+		}
+	}
+
+	// An explicit dialect is never overridden
+	l = New("frr version 8.4\ninterface swp1\n")
+	l.SetDialect(DialectIOS)
+	l.Tokenize()
+	if l.GetDialect() != DialectIOS {
+		t.Errorf("explicit dialect changed to %v", l.GetDialect())
+	}
+}
+
+// testDialect is a minimal third-party dialect for the registry tests.
+type testDialect struct{}
+
+var testDialectPattern = regexp.MustCompile(`^xe-\d+/\d+/\d+$`)
+
+func (testDialect) Name() string { return "testos" }
+
+func (testDialect) Keywords() Keywords {
+	return Keywords{
+		Config: map[string]TokenType{"set": TokenCommand},
+		Show:   map[string]TokenType{"up": TokenStateGood},
+	}
+}
+
+func (testDialect) Patterns() []Pattern {
+	return []Pattern{{testDialectPattern, TokenInterface, ParseModeAuto}}
+}
+
+func (testDialect) DetectScore(sample string) int {
+	if strings.Contains(sample, "## Last commit") {
+		return 5
+	}
+	return 0
+}
+
+func (testDialect) ShowProfiles() []*ShowProfile {
+	return []*ShowProfile{{
+		Name:       "test",
+		Indicators: []string{"physical interface"},
+		Classify: func(l *Lexer, word, lower string) (TokenType, bool) {
+			if lower == "physical" && l.PeekWord() == "interface:" {
+				return TokenSection, true
+			}
+			return TokenText, false
+		},
+	}}
+}
+
+func TestRegisterDialect(t *testing.T) {
+	RegisterDialect(testDialect{}, "test-os")
+	t.Cleanup(func() {
+		r := &dialectRegistry
+		r.Lock()
+		defer r.Unlock()
+		r.dialects = r.dialects[:len(r.dialects)-1]
+		delete(r.names, "testos")
+		delete(r.names, "test-os")
+	})
+
+	if DialectByName("TEST-OS") != (testDialect{}) {
+		t.Fatal("registered dialect should be found by alias")
+	}
+	names := DialectNames()
+	if names[len(names)-1] != "testos" {
+		t.Errorf("DialectNames() = %v, want testos last", names)
+	}
+
+	config := "## Last commit: 2024-01-01\nset interfaces xe-0/0/0 unit 0\n"
+	if got := DetectDialect(config); got != (testDialect{}) {
+		t.Errorf("DetectDialect() = %v, want testos", got)
+	}
+
+	l := New(config)
+	l.SetParseMode(ParseModeConfig)
+	checks := map[string]TokenType{"set": TokenCommand, "xe-0/0/0": TokenInterface}
+	for _, tok := range l.Tokenize() {
+		if want, ok := checks[tok.Value]; ok && tok.Type != want {
+			t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+		}
+	}
+
+	l = New("Physical interface: xe-0/0/0 Enabled\n  Link is Up\n")
+	l.SetDialect(testDialect{})
+	l.SetParseMode(ParseModeShow)
+	checks = map[string]TokenType{"physical": TokenSection, "up": TokenStateGood, "xe-0/0/0": TokenInterface}
+	for _, tok := range l.Tokenize() {
+		if want, ok := checks[strings.ToLower(tok.Value)]; ok && tok.Type != want {
+			t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+		}
+	}
+}
+
+func TestBuiltinDialectsRegistered(t *testing.T) {
+	ds := Dialects()
+	if len(ds) < 2 || ds[0] != DialectIOS || ds[1] != DialectFRR {
+		t.Errorf("Dialects() = %v, want IOS and FRR first", ds)
+	}
+	if len(DialectIOS.ShowProfiles()) == 0 {
+		t.Error("IOS should provide show profiles")
+	}
+}
@@ -0,0 +1,52 @@
+package lexer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationUnits maps the unit letters of compact Cisco durations ("1y2w",
+// "2d03h") to their length. Years count as 365 days.
+var durationUnits = map[byte]time.Duration{
+	'y': 365 * 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+	'd': 24 * time.Hour,
+	'h': time.Hour,
+	'm': time.Minute,
+	's': time.Second,
+}
+
+// ParseCiscoDuration converts a duration as printed in show output (the
+// values classified as TokenTimeDuration) to a time.Duration. It accepts the
+// compact form used for longer uptimes ("1y2w", "1w2d", "2d03h", "5h12m") and
+// the clock form used for shorter ones ("01:02:03" as hours, minutes and
+// seconds; "02:03" as minutes and seconds).
+func ParseCiscoDuration(s string) (time.Duration, error) {
+	if !timeDurationPattern.MatchString(s) {
+		return 0, fmt.Errorf("invalid Cisco duration %q", s)
+	}
+
+	if strings.Contains(s, ":") {
+		var d time.Duration
+		for _, part := range strings.Split(s, ":") {
+			n, _ := strconv.Atoi(part)
+			d = d*60 + time.Duration(n)
+		}
+		return d * time.Second, nil
+	}
+
+	var d time.Duration
+	start := 0
+	for i := 0; i < len(s); i++ {
+		unit, ok := durationUnits[s[i]]
+		if !ok {
+			continue
+		}
+		n, _ := strconv.Atoi(s[start:i])
+		d += time.Duration(n) * unit
+		start = i + 1
+	}
+	return d, nil
+}
@@ -0,0 +1,38 @@
+package lexer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCiscoDuration(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"1w2d", 9 * day, false},
+		{"2d03h", 2*day + 3*time.Hour, false},
+		{"1y2w", 365*day + 14*day, false},
+		{"5h12m", 5*time.Hour + 12*time.Minute, false},
+		{"00:38:12", 38*time.Minute + 12*time.Second, false},
+		{"01:00:00", time.Hour, false},
+		{"02:03", 2*time.Minute + 3*time.Second, false},
+		{"never", 0, true},
+		{"", 0, true},
+		{"12", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseCiscoDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCiscoDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseCiscoDuration(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package lexer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// show environment [all | temperature | power | fan], show platform
+var (
+	// Sensor, fan, power supply and slot states, in the words of the
+	// different platforms: "Normal", "OK", "GREEN", "ok, active", "failed"
+	environmentStates = map[string]TokenType{
+		"ok": TokenStateGood, "normal": TokenStateGood, "good": TokenStateGood,
+		"green": TokenStateGood, "ready": TokenStateGood,
+		"warning": TokenStateWarning, "yellow": TokenStateWarning, "minor": TokenStateWarning,
+		"booting": TokenStateWarning, "degraded": TokenStateWarning,
+		"critical": TokenStateBad, "red": TokenStateBad, "major": TokenStateBad,
+		"failed": TokenStateBad, "faulty": TokenStateBad, "bad": TokenStateBad,
+		"fail": TokenStateBad, "shutdown": TokenStateBad,
+		"empty": TokenStateNeutral, "absent": TokenStateNeutral,
+	}
+
+	// Units of sensor readings: "38 Celsius", "31 Degree Celsius", "850 mV"
+	sensorUnits = map[string]bool{
+		"celsius": true, "degree": true, "degrees": true, "c": true,
+		"mv": true, "v": true, "ma": true, "a": true, "rpm": true, "w": true,
+	}
+
+	// The acceptable range after a reading: "31 Celsius    -5 - 56"
+	sensorRangePattern = regexp.MustCompile(`(-?\d+)\s+-\s+(-?\d+)\s*$`)
+
+	environmentProfile = &ShowProfile{
+		Name: "environment",
+		Indicators: []string{
+			"sensor list", "environmental monitoring", "system temperature",
+			"temperature value", "temperature state", "yellow threshold", "red threshold",
+			"insert time", "chassis type", "celsius", "rpm", "sys pwr",
+		},
+		Classify: classifyEnvironment,
+	}
+)
+
+// classifyEnvironment handles hardware health output:
+//
+//	 Sensor           Location          State             Reading
+//	 Temp: CPU Die    R0                Warning           95 Celsius
+//	Inlet Temperature Value: 31 Degree Celsius
+//	Temperature State: GREEN
+//	SYSTEM OUTLET   RED         130 Celsius   -5 - 125
+//	P1        Unknown             empty                 never
+//
+// A reading takes the color of the state reported for its sensor, so an
+// out-of-range temperature, voltage or fan speed stands out as well as the
+// word saying so; where a range is printed, the reading is checked against it.
+func classifyEnvironment(l *Lexer, word, lower string) (TokenType, bool) {
+	// "FAN PS-2 is NOT PRESENT", "1B  Not Present"
+	if lower == "not" && strings.EqualFold(l.peekWord(), "present") ||
+		lower == "present" && l.prevWord == "not" {
+		return TokenStateNeutral, true
+	}
+	if t, ok := environmentStates[strings.TrimSuffix(lower, ",")]; ok {
+		// "Yellow Threshold : 46 Degree Celsius" is a label
+		if strings.EqualFold(l.peekWord(), "threshold") {
+			return TokenText, false
+		}
+		return t, true
+	}
+
+	if !isAllDigits(strings.TrimPrefix(word, "-")) || !sensorUnits[strings.ToLower(l.peekWord())] {
+		return TokenText, false
+	}
+	return l.sensorReading(word), true
+}
+
+// sensorReading returns the type of a sensor reading: a state type when the
+// reading is out of range or its sensor is in a warning or critical state,
+// otherwise TokenNumber.
+func (l *Lexer) sensorReading(word string) TokenType {
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, next := l.lineAt(lineStart)
+
+	if m := sensorRangePattern.FindStringSubmatch(line); m != nil {
+		value, _ := strconv.Atoi(word)
+		low, _ := strconv.Atoi(m[1])
+		high, _ := strconv.Atoi(m[2])
+		if value < low || value > high {
+			return TokenStateBad
+		}
+		return TokenNumber
+	}
+
+	// The state is on the reading's own line, or on the line after it for
+	// "Inlet Temperature Value: 31 Degree Celsius"
+	state := sensorState(line)
+	if state == TokenText && strings.Contains(strings.ToLower(line), "temperature value") {
+		following, _ := l.lineAt(next)
+		state = sensorState(following)
+	}
+	switch state {
+	case TokenStateBad, TokenStateWarning:
+		return state
+	}
+	return TokenNumber
+}
+
+// sensorState returns the worst state named on line, or TokenText if it
+// names none.
+func sensorState(line string) TokenType {
+	state := TokenText
+	words := strings.Fields(strings.ToLower(line))
+	for i, w := range words {
+		t, ok := environmentStates[strings.TrimSuffix(w, ",")]
+		if !ok || i+1 < len(words) && words[i+1] == "threshold" {
+			continue
+		}
+		switch {
+		case t == TokenStateBad:
+			return t
+		case t == TokenStateWarning, state == TokenText:
+			state = t
+		}
+	}
+	return state
+}
@@ -0,0 +1,104 @@
+package lexer
+
+import "strings"
+
+// Explanation tells how the lexer classified a token, for reporting and
+// debugging misclassifications (see SetExplain).
+type Explanation struct {
+	Type         TokenType   `json:"type"`                   // type the lexer gave the token
+	Rule         string      `json:"rule"`                   // rule that chose it: "interface pattern", "show profile arp"
+	Alternatives []TokenType `json:"alternatives,omitempty"` // other types the word has out of context
+}
+
+// SetExplain makes the lexer attach an Explanation to every token: the rule
+// that classified it and the other types its word lists and patterns give
+// the word. It slows tokenizing down and is meant for bug reports and
+// dialect development.
+func (l *Lexer) SetExplain(enabled bool) {
+	l.explain = enabled
+}
+
+// Explaining reports whether the lexer attaches explanations (see
+// SetExplain).
+func (l *Lexer) Explaining() bool {
+	return l.explain
+}
+
+// because records rule as the one that classified the token being scanned.
+func (l *Lexer) because(rule string) {
+	if l.explain {
+		l.rule = rule
+	}
+}
+
+// becauseDialect records a rule of the dialect.
+func (l *Lexer) becauseDialect(rule string) {
+	if l.explain {
+		l.rule = "dialect " + l.dialect.Name() + " " + rule
+	}
+}
+
+// explainToken attaches the explanation of the token just scanned.
+func (l *Lexer) explainToken(tok *Token) {
+	rule := l.rule
+	if rule == "" {
+		rule = "text"
+	}
+	l.rule = ""
+	tok.Explain = &Explanation{Type: tok.Type, Rule: rule}
+	if strings.TrimSpace(tok.Value) != "" {
+		tok.Explain.Alternatives = l.alternatives(tok.Value, tok.Type)
+	}
+}
+
+// alternatives returns the types other than t that the word lists and
+// patterns give word on their own, without the context that decided t.
+func (l *Lexer) alternatives(word string, t TokenType) []TokenType {
+	lower := strings.ToLower(word)
+	kw := l.dialect.Keywords()
+	type candidate struct {
+		typ   TokenType
+		match bool
+	}
+	candidates := []candidate{
+		{kw.Any[lower], kw.Any[lower] != TokenText},
+		{kw.Config[lower], kw.Config[lower] != TokenText},
+		{kw.Show[lower], kw.Show[lower] != TokenText},
+		{TokenStateGood, l.words.statesGood[lower]},
+		{TokenStateBad, l.words.statesBad[lower]},
+		{TokenStateWarning, l.words.statesWarning[lower]},
+		{TokenStateNeutral, l.words.statesNeutral[lower]},
+		{TokenCommand, l.words.commands[lower]},
+		{TokenSection, l.words.sections[lower]},
+		{TokenProtocol, l.words.protocols[lower]},
+		{TokenAction, l.words.actions[lower]},
+		{TokenOperator, l.words.operators[lower]},
+		{TokenKeyword, l.words.keywords[lower]},
+		{TokenColumnHeader, l.words.columnHeaders[lower]},
+		{TokenInterface, interfacePattern.MatchString(word)},
+		{TokenIPv4Prefix, ipv4PrefixPattern.MatchString(word)},
+		{TokenIPv4, ipv4Pattern.MatchString(word)},
+		{TokenMAC, macPatternCisco.MatchString(word) || macPatternColon.MatchString(word)},
+		{TokenIPv6Prefix, isIPv6Prefix(word)},
+		{TokenIPv6, isIPv6(word)},
+		{TokenTimeDuration, timeDurationPattern.MatchString(word)},
+		{TokenPercentage, percentagePattern.MatchString(word)},
+		{TokenByteSize, byteSizePattern.MatchString(word)},
+		{TokenRate, ratePattern.MatchString(word)},
+		{TokenHex, hexNumberPattern.MatchString(word)},
+		{TokenNumber, isAllDigits(word)},
+	}
+	for _, p := range l.dialect.Patterns() {
+		candidates = append(candidates, candidate{p.Type, p.Regexp.MatchString(word)})
+	}
+
+	var alts []TokenType
+	seen := map[TokenType]bool{t: true}
+	for _, c := range candidates {
+		if c.match && !seen[c.typ] {
+			seen[c.typ] = true
+			alts = append(alts, c.typ)
+		}
+	}
+	return alts
+}
@@ -0,0 +1,60 @@
+package lexer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	input := "interface Gi0/1\n ip address 10.0.0.1 255.255.255.0\n"
+
+	l := New(input)
+	l.SetParseMode(ParseModeConfig)
+	l.SetExplain(true)
+	tokens := l.Tokenize()
+
+	explained := make(map[string]*Explanation)
+	for _, tok := range tokens {
+		if tok.Explain == nil {
+			t.Fatalf("token %q has no explanation", tok.Value)
+		}
+		if tok.Explain.Type != tok.Type {
+			t.Errorf("token %q: explanation type %s, token type %s", tok.Value, tok.Explain.Type, tok.Type)
+		}
+		explained[tok.Value] = tok.Explain
+	}
+
+	tests := []struct {
+		value string
+		rule  string // substring of the rule
+		alt   TokenType
+	}{
+		{"interface", "word list", TokenSection},
+		{"Gi0/1", "interface pattern", TokenText},
+		{"255.255.255.0", "IPv4", TokenIPv4},
+	}
+	for _, tt := range tests {
+		e := explained[tt.value]
+		if e == nil {
+			t.Errorf("no token %q", tt.value)
+			continue
+		}
+		if !strings.Contains(e.Rule, tt.rule) {
+			t.Errorf("%q: rule %q, want it to mention %q", tt.value, e.Rule, tt.rule)
+		}
+		if tt.alt != TokenText && !slices.Contains(e.Alternatives, tt.alt) {
+			t.Errorf("%q: alternatives %v, want %s among them", tt.value, e.Alternatives, tt.alt)
+		}
+		if slices.Contains(e.Alternatives, e.Type) {
+			t.Errorf("%q: alternatives %v include the chosen type", tt.value, e.Alternatives)
+		}
+	}
+
+	// Off by default
+	for _, tok := range New(input).Tokenize() {
+		if tok.Explain != nil {
+			t.Fatalf("token %q explained without SetExplain", tok.Value)
+		}
+	}
+}
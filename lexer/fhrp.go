@@ -0,0 +1,83 @@
+package lexer
+
+import "strings"
+
+// show standby [brief], show vrrp [brief], show glbp [brief]
+var (
+	// Group roles, colored by whether the router is doing its job: the
+	// active/master router and a ready standby are healthy, a group stuck in
+	// Init has no working peer or virtual IP
+	fhrpStates = map[string]TokenType{
+		"active": TokenStateGood, "master": TokenStateGood,
+		"standby": TokenStateNeutral, "backup": TokenStateNeutral, "listen": TokenStateNeutral,
+		"speak": TokenStateWarning, "learn": TokenStateWarning,
+		"init": TokenStateBad, "initial": TokenStateBad,
+	}
+
+	// Words after a role that make it a field label: "Active router is local"
+	fhrpLabels = map[string]bool{
+		"router": true, "virtual": true, "is": true, "addr": true,
+	}
+
+	hsrpProfile = &ShowProfile{
+		Name: "hsrp",
+		Indicators: []string{
+			"p indicates configured to preempt", "virtual ip", "virtual mac",
+			"active router", "standby router", "master router",
+			"master addr", "group addr", "preemption",
+		},
+		Classify: func(l *Lexer, word, lower string) (TokenType, bool) {
+			return classifyFHRP(l, word, lower, false)
+		},
+	}
+
+	// GLBP brief output puts the virtual IP right after the state, where
+	// HSRP has the active router
+	glbpProfile = &ShowProfile{
+		Name: "glbp",
+		Indicators: []string{
+			"fwd pri", "active router", "standby router", "forwarder",
+			"redirect time", "load balancing",
+		},
+		Classify: func(l *Lexer, word, lower string) (TokenType, bool) {
+			return classifyFHRP(l, word, lower, true)
+		},
+	}
+)
+
+// classifyFHRP handles group roles, the preempt flag and virtual IPs in
+// first-hop redundancy (HSRP, VRRP, GLBP) output:
+//
+//	Interface   Grp  Pri P State   Active          Standby         Virtual IP
+//	Vl10        10   110 P Active  local           10.0.10.3       10.0.10.1
+func classifyFHRP(l *Lexer, word, lower string, glbp bool) (TokenType, bool) {
+	if t, ok := fhrpStates[lower]; ok {
+		if fhrpLabels[strings.ToLower(l.peekWord())] && l.prevWord != "is" {
+			return TokenColumnHeader, true
+		}
+		return t, true
+	}
+
+	// The preempt flag sits just before the state: "110 P Active", "Y  Master"
+	if (lower == "p" || lower == "y") && fhrpStates[strings.ToLower(l.peekWord())] != TokenText {
+		return TokenStatusSymbol, true
+	}
+
+	if !ipv4Pattern.MatchString(word) && !isIPv6(word) {
+		return TokenText, false
+	}
+	// "Virtual IP address is 10.0.10.1", "Secondary virtual IP address 10.0.10.5"
+	if (l.prevWord == "is" || l.prevWord == "address") && strings.Contains(l.lineText(), "virtual ip address") {
+		return TokenVirtualIP, true
+	}
+	// Brief tables: one row per group, starting with the interface
+	if interfacePattern.MatchString(l.lineCommand) {
+		if glbp && fhrpStates[l.prevWord] != TokenText {
+			return TokenVirtualIP, true
+		}
+		if !glbp && l.peekWord() == "" {
+			return TokenVirtualIP, true
+		}
+	}
+	return TokenText, false
+}
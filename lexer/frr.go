@@ -0,0 +1,104 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// frrDialect is FRRouting / Quagga as seen through vtysh.
+type frrDialect struct{}
+
+func (frrDialect) Name() string   { return "frr" }
+func (frrDialect) String() string { return "FRR" }
+
+// vtysh prints show bgp summary, show ip route and the like in the IOS
+// layouts, so the IOS profiles classify them.
+func (frrDialect) ShowProfiles() []*ShowProfile { return iosShowProfiles }
+
+// FRRouting / Quagga vocabulary that differs from IOS
+var frrKeywords = Keywords{
+	// FRR daemons, shown in "frr defaults", log lines and vtysh banners
+	Any: map[string]TokenType{
+		"zebra": TokenProtocol, "bgpd": TokenProtocol, "ospfd": TokenProtocol,
+		"ospf6d": TokenProtocol, "ripd": TokenProtocol, "ripngd": TokenProtocol,
+		"isisd": TokenProtocol, "staticd": TokenProtocol, "ldpd": TokenProtocol,
+		"pimd": TokenProtocol, "bfdd": TokenProtocol, "vrrpd": TokenProtocol,
+		"pathd": TokenProtocol, "fabricd": TokenProtocol, "vtysh": TokenProtocol,
+		"watchfrr": TokenProtocol,
+	},
+
+	Config: map[string]TokenType{
+		"frr": TokenCommand, "agentx": TokenCommand, "exit-vrf": TokenCommand,
+		"exit-address-family": TokenCommand, "debug": TokenCommand,
+
+		"defaults": TokenKeyword, "traditional": TokenKeyword, "datacenter": TokenKeyword,
+		"peer-group": TokenKeyword, "external": TokenKeyword, "internal": TokenKeyword,
+		"nexthop-group": TokenKeyword, "nht": TokenKeyword, "resolve-via-default": TokenKeyword,
+		"integrated-vtysh-config": TokenKeyword, "bestpath": TokenKeyword,
+		"as-path": TokenKeyword, "multipath-relax": TokenKeyword, "capability": TokenKeyword,
+		"extended-nexthop": TokenKeyword, "advertise-all-vni": TokenKeyword,
+	},
+
+	Show: map[string]TokenType{
+		"(policy)": TokenStateWarning,
+		"(admin)":  TokenStateBad,
+
+		"v": TokenColumnHeader, "msgrcvd": TokenColumnHeader, "msgsent": TokenColumnHeader,
+		"tblver": TokenColumnHeader, "inq": TokenColumnHeader, "state/pfxrcd": TokenColumnHeader,
+		"pfxsnt": TokenColumnHeader, "desc": TokenColumnHeader,
+	},
+}
+
+var (
+	// Linux interface names: eth0, swp1, bond0, br0, ens3, enp0s3, lo, vlan100
+	linuxInterfacePattern = regexp.MustCompile(`^(lo|(eth|swp|bond|br|ens|enp|eno|eni|vlan|vxlan|veth|tap|tun|wg|dummy|peerlink)\d[\w.-]*)$`)
+
+	// zebra route codes: K>*, C>*, B>*, O>*, S>*, O, B>q
+	frrRouteCodePattern = regexp.MustCompile(`^[KCSROIBEFTNDALHPV][>*=qrt]{1,3}$`)
+
+	// zebra [distance/metric]: [20/0], [110/2]
+	frrDistanceMetricPattern = regexp.MustCompile(`^\[\d+/\d+\]$`)
+)
+
+var frrPatterns = []Pattern{
+	{linuxInterfacePattern, TokenInterface, ParseModeAuto},
+	{frrRouteCodePattern, TokenStatusSymbol, ParseModeShow},
+	{frrDistanceMetricPattern, TokenRouteProtocol, ParseModeShow},
+}
+
+func (frrDialect) Keywords() Keywords  { return frrKeywords }
+func (frrDialect) Patterns() []Pattern { return frrPatterns }
+
+// frrIndicators is evidence for FRR found as plain substrings.
+var frrIndicators = []dialectIndicator{
+	{"frr version", 3},
+	{"frr defaults", 3},
+	{"frrouting", 3},
+	{"quagga", 3},
+	{"vtysh", 2},
+	{"codes: k - kernel route", 3},
+	{"zebra", 1},
+	{"bgpd", 1},
+	{"ip forwarding", 1},
+	{"ipv6 forwarding", 1},
+}
+
+// Evidence that needs a pattern rather than a substring
+var (
+	// Linux interfaces in config or routes: "interface swp1", "via 10.0.0.1, eth0"
+	linuxInterfaceMention = regexp.MustCompile(`(?m)(^interface |, |\bdev )(eth|swp|bond|ens|enp|eno)\d`)
+
+	// zebra route entries: "K>* 0.0.0.0/0", "C>* 10.0.0.0/24"
+	frrRouteEntry = regexp.MustCompile(`(?m)^[KCSROBF][>*]\*? \d`)
+)
+
+func (frrDialect) DetectScore(sample string) int {
+	score := scoreIndicators(strings.ToLower(sample), frrIndicators)
+	if linuxInterfaceMention.MatchString(sample) {
+		score += 2
+	}
+	if frrRouteEntry.MatchString(sample) {
+		score += 2
+	}
+	return score
+}
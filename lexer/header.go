@@ -0,0 +1,190 @@
+package lexer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// column is a word within a line: its byte span [start, end) for slicing the
+// line, and the display columns [left, right) it covers, so tabs and wide
+// runes before it do not throw off alignment with other lines.
+type column struct {
+	start, end  int
+	left, right int
+}
+
+// lineColumns returns the whitespace-separated words in line.
+func lineColumns(line string) []column {
+	var cols []column
+	start, left := -1, 0
+	col := 1
+	for i := 0; i <= len(line); i++ {
+		if i < len(line) && !isWhitespace(line[i]) {
+			if start < 0 {
+				start, left = i, col
+			}
+			if line[i] < utf8.RuneSelf || utf8.RuneStart(line[i]) {
+				col = AdvanceColumn(col, line[i:i+runeLen(line[i:])])
+			}
+			continue
+		}
+		if start >= 0 {
+			cols = append(cols, column{start, i, left, col})
+			start = -1
+		}
+		if i < len(line) {
+			col = nextColumn(col, line[i])
+		}
+	}
+	return cols
+}
+
+// runeLen returns the length in bytes of the rune s starts with.
+func runeLen(s string) int {
+	_, size := utf8.DecodeRuneInString(s)
+	return size
+}
+
+// lineAt returns the line of input starting at offset start, without its
+// newline, and the offset of the following line.
+func (l *Lexer) lineAt(start int) (string, int) {
+	end := strings.IndexByte(l.input[start:], '\n')
+	if end < 0 {
+		return l.input[start:], len(l.input)
+	}
+	return l.input[start : start+end], start + end + 1
+}
+
+// isHeaderLine reports whether the current line is a table column header,
+// e.g. "Interface  IP-Address  OK? Method Status  Protocol". It is a line of
+// label words with no values (numbers, addresses, interface names) that is
+// underlined by a row of dashes, or followed by a data row whose columns
+// line up with it, possibly after a second header line.
+func (l *Lexer) isHeaderLine() bool {
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, next := l.lineAt(lineStart)
+	cols := headerLabels(line)
+	if cols == nil {
+		return false
+	}
+	// Labels right below a row of the same table are a row themselves:
+	// "P1  Unknown  empty  never" under "P0  PWR-4330-AC  ok  2w3d"
+	if lineStart > 0 {
+		prev, _ := l.lineAt(strings.LastIndexByte(l.input[:lineStart-1], '\n') + 1)
+		if isDataRow(prev, cols) {
+			return false
+		}
+	}
+
+	row, next := l.nextRow(next)
+	if isUnderline(row) {
+		return true
+	}
+	if len(cols) < 3 || !tabularPattern.MatchString(line) {
+		return false
+	}
+	if isDataRow(row, cols) {
+		return true
+	}
+
+	// The first line of a two-line header ("Local  Outgoing  Prefix" over
+	// "Label  Label  or Tunnel Id"), judged by what follows the second line
+	second := headerLabels(row)
+	if second == nil {
+		return false
+	}
+	after, _ := l.nextRow(next)
+	return isUnderline(after) || isDataRow(after, second)
+}
+
+// headerLabels returns the columns of line if it can be a column header: two
+// or more words that are all labels. It returns nil otherwise.
+func headerLabels(line string) []column {
+	cols := lineColumns(line)
+	if len(cols) < 2 {
+		return nil
+	}
+	for _, c := range cols {
+		if !isHeaderLabel(line[c.start:c.end]) {
+			return nil
+		}
+	}
+	return cols
+}
+
+// mayBeHeader reports whether the line starting at offset start is blank or
+// can be a column header, whether it is one depending on the lines below it.
+func (l *Lexer) mayBeHeader(start int) bool {
+	line, _ := l.lineAt(start)
+	return strings.TrimSpace(line) == "" || headerLabels(line) != nil
+}
+
+// nextRow returns the first non-blank line at or after offset start, and the
+// offset of the line after it.
+func (l *Lexer) nextRow(start int) (string, int) {
+	var row string
+	for start < len(l.input) && strings.TrimSpace(row) == "" {
+		row, start = l.lineAt(start)
+	}
+	return row, start
+}
+
+// isHeaderLabel reports whether word can be a column label: it contains a
+// letter and is not a value such as an address, interface or duration.
+func isHeaderLabel(word string) bool {
+	if !strings.ContainsAny(strings.ToLower(word), "abcdefghijklmnopqrstuvwxyz") {
+		return false
+	}
+	return !interfacePattern.MatchString(word) &&
+		!macPatternCisco.MatchString(word) &&
+		!macPatternColon.MatchString(word) &&
+		!isIPv6(word) &&
+		!timeDurationPattern.MatchString(word)
+}
+
+// isUnderline reports whether line consists of runs of dashes or equals
+// signs, as printed under column headers.
+func isUnderline(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, f := range fields {
+		if len(f) < 2 || strings.Trim(f, "-=") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isDataRow reports whether row is a table row under a header with the given
+// columns: it holds at least one value, and at least two thirds of the header
+// columns overlap one of its words, whether the table is left or right aligned.
+func isDataRow(row string, header []column) bool {
+	cols := lineColumns(row)
+	if len(cols) < 3 {
+		return false
+	}
+
+	hasValue := false
+	for _, c := range cols {
+		if !isHeaderLabel(row[c.start:c.end]) {
+			hasValue = true
+			break
+		}
+	}
+	if !hasValue {
+		return false
+	}
+
+	aligned := 0
+	for _, h := range header {
+		for _, c := range cols {
+			if h.left < c.right && c.left < h.right {
+				aligned++
+				break
+			}
+		}
+	}
+	return aligned*3 >= len(header)*2
+}
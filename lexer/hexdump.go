@@ -0,0 +1,121 @@
+package lexer
+
+import "strings"
+
+// Hex dumps are scanned as one token per block of lines: the certificates
+// of a config and the lines of show memory output,
+//
+//	 certificate self-signed 01
+//	  3082032E 30820216 A0030201 02020101 300D0609 2A864886 F70D0101 05050030
+//	  31312F30 2D060355 04031326 494F532D 53656C66 2D536967 6E65642D 43657274
+//	  D2E2
+//	  	quit
+//
+//	7F3A2B10: 00000000 12345678 DEADBEEF 00000000  ....4Vx.........
+//	7F3A2B20: 0000002A 7F3A2B40 00000000 00000001  ...*.:+@........
+//
+// rather than as thousands of identifiers, which are slow to classify and
+// of no use colored one by one.
+
+// minHexDumpGroups is the number of 8-digit groups on the line that starts
+// a hex dump without addresses.
+const minHexDumpGroups = 4
+
+// scanHexDump scans a hex dump starting at the first word of a line, up to
+// the end of its last line.
+func (l *Lexer) scanHexDump() (Token, bool) {
+	if l.prevWord != "" || l.lineCommand != "" || !isHexDigit(l.input[l.pos]) {
+		return Token{}, false
+	}
+	end := hexDumpLineEnd(l.input, l.pos, true)
+	if end < 0 {
+		return Token{}, false
+	}
+	for {
+		next := end
+		for next < len(l.input) && (l.input[next] == '\r' || l.input[next] == ' ' || l.input[next] == '\t') {
+			next++
+		}
+		if next >= len(l.input) || l.input[next] != '\n' {
+			break
+		}
+		next++
+		for next < len(l.input) && (l.input[next] == ' ' || l.input[next] == '\t') {
+			next++
+		}
+		lineEnd := hexDumpLineEnd(l.input, next, false)
+		if lineEnd < 0 {
+			break
+		}
+		end = lineEnd
+	}
+
+	startLine, startCol := l.line, l.col
+	start := l.pos
+	for l.pos < end {
+		l.advance()
+	}
+	return Token{
+		Type:   TokenHexDump,
+		Value:  l.input[start:end],
+		Line:   startLine,
+		Column: startCol,
+	}, true
+}
+
+// hexDumpLineEnd returns the end of the hex dump line whose first word is at
+// pos, or -1 if the line is not one. A line is a hex dump if it holds groups
+// of 2 to 8 hex digits and nothing else, or an address and a colon, at least
+// two groups and any ASCII column; one starting a dump without addresses
+// needs minHexDumpGroups groups of 8 digits, at least one with a letter.
+func hexDumpLineEnd(s string, pos int, first bool) int {
+	lineEnd := strings.IndexByte(s[pos:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(s)
+	} else {
+		lineEnd += pos
+	}
+	line := strings.TrimRight(s[pos:lineEnd], " \t\r")
+
+	groups, end := 0, 0
+	address, letters, short := false, false, false
+	for i := 0; i < len(line); {
+		for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+			i++
+		}
+		j := i
+		for j < len(line) && isHexDigit(line[j]) {
+			letters = letters || line[j] > '9'
+			j++
+		}
+		n := j - i
+		blank := j == len(line) || line[j] == ' ' || line[j] == '\t'
+		switch {
+		case groups == 0 && !address && n >= 4 && j+1 < len(line) && line[j] == ':' && (line[j+1] == ' ' || line[j+1] == '\t'):
+			address = true
+			j++
+		case n >= 2 && n <= 8 && blank:
+			groups++
+			short = short || n != 8
+			end = j
+		case address && groups >= 2:
+			// The ASCII column of a memory dump
+			return pos + len(line)
+		default:
+			return -1
+		}
+		i = j
+	}
+
+	switch {
+	case address && groups < 2, groups == 0:
+		return -1
+	case first && !address && (groups < minHexDumpGroups || short || !letters):
+		return -1
+	}
+	return pos + end
+}
+
+func isHexDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9' || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
@@ -0,0 +1,86 @@
+package lexer
+
+import "testing"
+
+func TestHexDumpTokens(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  ParseMode
+		input string
+		dump  string
+	}{
+		{
+			"certificate", ParseModeConfig,
+			"crypto pki certificate chain TP-self-signed-1\n certificate self-signed 01\n" +
+				"  3082032E 30820216 A0030201 02020101 300D0609 2A864886 F70D0101 05050030\n" +
+				"  31312F30 2D060355 04031326 494F532D 53656C66 2D536967 6E65642D 43657274\n" +
+				"  D2E2\n  \tquit\n",
+			"3082032E 30820216 A0030201 02020101 300D0609 2A864886 F70D0101 05050030\n" +
+				"  31312F30 2D060355 04031326 494F532D 53656C66 2D536967 6E65642D 43657274\n" +
+				"  D2E2",
+		},
+		{
+			"show memory", ParseModeShow,
+			"7F3A2B10: 00000000 12345678 DEADBEEF 00000000  ....4Vx.........\n" +
+				"7F3A2B20: 0000002A 7F3A2B40 00000000 00000001  ...*.:+@........\n" +
+				"Router#\n",
+			"7F3A2B10: 00000000 12345678 DEADBEEF 00000000  ....4Vx.........\n" +
+				"7F3A2B20: 0000002A 7F3A2B40 00000000 00000001  ...*.:+@........",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			l.SetParseMode(tt.mode)
+			var dumps []string
+			var joined string
+			for _, tok := range l.Tokenize() {
+				joined += tok.Value
+				if tok.Type == TokenHexDump {
+					dumps = append(dumps, tok.Value)
+				}
+			}
+			if len(dumps) != 1 || dumps[0] != tt.dump {
+				t.Errorf("hex dumps = %q, want [%q]", dumps, tt.dump)
+			}
+			if joined != tt.input {
+				t.Errorf("content not preserved: %q", joined)
+			}
+		})
+	}
+}
+
+func TestNotHexDump(t *testing.T) {
+	inputs := []string{
+		// Counters are decimal columns, not a dump
+		"  12345678 23456789 34567890 45678901 56789012\n",
+		// Too few groups to start a dump without addresses
+		"  DEADBEEF 12345678\n",
+		"Gi0/1  00000000 DEADBEEF 12345678 0000000A\n",
+		"description 3082032E 30820216 A0030201 02020101\n",
+	}
+	for _, input := range inputs {
+		l := New(input)
+		l.SetParseMode(ParseModeShow)
+		for _, tok := range l.Tokenize() {
+			if tok.Type == TokenHexDump {
+				t.Errorf("%q: unexpected hex dump %q", input, tok.Value)
+			}
+		}
+	}
+}
+
+func TestHexLiteral(t *testing.T) {
+	l := New("config-register 0x2102\n")
+	l.SetParseMode(ParseModeConfig)
+	for _, tok := range l.Tokenize() {
+		if tok.Value == "0x2102" {
+			if tok.Type != TokenHex {
+				t.Errorf("0x2102 = %v, want Hex", tok.Type)
+			}
+			return
+		}
+	}
+	t.Error("no 0x2102 token")
+}
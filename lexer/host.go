@@ -0,0 +1,21 @@
+package lexer
+
+// CurrentHost returns the hostname from the last prompt in the input, such
+// as "core-rtr-01" for "core-rtr-01#". Before a prompt is seen it returns
+// the host given to SetHost, or "".
+func (l *Lexer) CurrentHost() string {
+	return l.host
+}
+
+// SetHost sets the current hostname, for input that continues a stream whose
+// prompt was tokenized earlier. A prompt in the input replaces it.
+func (l *Lexer) SetHost(host string) {
+	l.host, l.seedHost = host, host
+}
+
+// SetHighlightHost enables classifying words that match the current hostname
+// (case-insensitively) as TokenPromptHost, so the device name stands out
+// wherever it appears in output.
+func (l *Lexer) SetHighlightHost(enabled bool) {
+	l.markHost = enabled
+}
@@ -0,0 +1,60 @@
+package lexer
+
+import "testing"
+
+func TestCurrentHost(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"prompt", "core-rtr-01#", "core-rtr-01"},
+		{"config prompt", "core-rtr-01(config-if)#", "core-rtr-01"},
+		{"prompt with command", "edge1>show version\n", "edge1"},
+		{"transcript keeps last", "r1#show clock\n*10:00:00 UTC Mon Jan 1 2024\nr2#show clock\n*10:00:01 UTC Mon Jan 1 2024\n", "r2"},
+		{"no prompt", "interface GigabitEthernet0/1\n shutdown\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			l.Tokenize()
+			if got := l.CurrentHost(); got != tt.want {
+				t.Errorf("CurrentHost() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightHost(t *testing.T) {
+	input := "core-rtr-01#show cdp neighbors\nDevice ID        Local Intrfce     Holdtme    Capability  Platform  Port ID\nCORE-RTR-01      Gig 0/1           120        R S I       C9300     Gig 0/2\n"
+
+	hosts := func(l *Lexer) int {
+		n := 0
+		for _, tok := range l.Tokenize() {
+			if tok.Type == TokenPromptHost {
+				n++
+			}
+		}
+		return n
+	}
+
+	l := New(input)
+	if got := hosts(l); got != 1 {
+		t.Errorf("without SetHighlightHost, expected only the prompt hostname, got %d", got)
+	}
+
+	l = New(input)
+	l.SetHighlightHost(true)
+	if got := hosts(l); got != 2 {
+		t.Errorf("with SetHighlightHost, expected 2 hostname tokens, got %d", got)
+	}
+
+	// A host seeded from an earlier chunk of the stream
+	l = New("Neighbor core-rtr-01 is up\n")
+	l.SetHost("core-rtr-01")
+	l.SetHighlightHost(true)
+	if got := hosts(l); got != 1 {
+		t.Errorf("seeded host should be highlighted, got %d", got)
+	}
+}
@@ -0,0 +1,226 @@
+package lexer
+
+import (
+	"sort"
+	"strings"
+)
+
+// Range is a half-open span [Start, End) of byte offsets into the lexer input.
+type Range struct {
+	Start int
+	End   int
+}
+
+// scanState is the lexer state carried from one token to the next.
+type scanState struct {
+	expectingValue bool
+	lastToken      string
+	prevWord       string
+	pendingArg     string
+	bannerStage    int
+	bannerDelim    string
+	lineCommand    string
+	lineAction     bool
+	prevAddr       bool
+	inRange        bool
+	headerLine     bool
+	type7Checked   bool
+	type7Line      bool
+	asnList        bool
+	host           string
+}
+
+// checkpoint records where the first token starting on a line begins and
+// the lexer state just before it, so lexing can resume from that point.
+type checkpoint struct {
+	token  int // index of the token in the token list
+	offset int // byte offset of the token
+	line   int
+	col    int
+	state  scanState
+}
+
+func (l *Lexer) saveState() scanState {
+	return scanState{
+		expectingValue: l.expectingValue,
+		lastToken:      l.lastToken,
+		prevWord:       l.prevWord,
+		pendingArg:     l.pendingArg,
+		bannerStage:    l.bannerStage,
+		bannerDelim:    l.bannerDelim,
+		lineCommand:    l.lineCommand,
+		lineAction:     l.lineAction,
+		prevAddr:       l.prevAddr,
+		inRange:        l.inRange,
+		headerLine:     l.headerLine,
+		type7Checked:   l.type7Checked,
+		type7Line:      l.type7Line,
+		asnList:        l.asnList,
+		host:           l.host,
+	}
+}
+
+func (l *Lexer) restoreState(s scanState) {
+	l.expectingValue = s.expectingValue
+	l.lastToken = s.lastToken
+	l.prevWord = s.prevWord
+	l.pendingArg = s.pendingArg
+	l.bannerStage = s.bannerStage
+	l.bannerDelim = s.bannerDelim
+	l.lineCommand = s.lineCommand
+	l.lineAction = s.lineAction
+	l.prevAddr = s.prevAddr
+	l.inRange = s.inRange
+	l.headerLine = s.headerLine
+	l.type7Checked = s.type7Checked
+	l.type7Line = s.type7Line
+	l.asnList = s.asnList
+	l.host = s.host
+}
+
+// markLine records a checkpoint if the next token is the first to start on
+// its line. It reports whether a checkpoint was added.
+func (l *Lexer) markLine(token int) bool {
+	if n := len(l.checkpoints); n > 0 && l.checkpoints[n-1].line >= l.line {
+		return false
+	}
+	l.checkpoints = append(l.checkpoints, checkpoint{
+		token:  token,
+		offset: l.pos,
+		line:   l.line,
+		col:    l.col,
+		state:  l.saveState(),
+	})
+	return true
+}
+
+// Retokenize replaces the edit range of the input with newText and returns
+// the tokens for the whole updated input, as Tokenize would. Only the lines
+// from the edit up to the point where lexing falls back in step with the
+// previous result are lexed again; later tokens are reused with their line
+// numbers shifted. Tokenize must have been called first.
+//
+// Edits within the first line or the parse mode detection sample (the first
+// 500 bytes), edits to session transcripts and edits with name learning on
+// (see SetLearnNames) re-tokenize the whole input.
+func (l *Lexer) Retokenize(edit Range, newText string) []Token {
+	edit.Start = max(0, min(edit.Start, len(l.input)))
+	edit.End = max(edit.Start, min(edit.End, len(l.input)))
+	input := l.input[:edit.Start] + newText + l.input[edit.End:]
+	delta := len(newText) - (edit.End - edit.Start)
+
+	// Resume from the last line that starts strictly before the edit, so the
+	// whitespace leading into the edited text is lexed again too
+	i := sort.Search(len(l.checkpoints), func(i int) bool {
+		return l.checkpoints[i].offset >= edit.Start
+	}) - 1
+	// A hex dump above the edited line may grow into it, and whether a line
+	// above it is a column header depends on the rows below
+	for i > 0 && (hasTokenType(l.tokens[l.checkpoints[i-1].token:l.checkpoints[i].token], TokenHexDump) ||
+		l.parseMode == ParseModeShow && l.mayBeHeader(l.checkpoints[i-1].offset)) {
+		i--
+	}
+	if i < 0 || l.checkpoints[i].line == 1 || edit.Start < parseModeDetectionSampleSize || l.learnNames {
+		l.reset(input)
+		return l.Tokenize()
+	}
+
+	oldTokens, oldCheckpoints := l.tokens, l.checkpoints
+	endLine, endCol := l.line, l.col
+	cp := oldCheckpoints[i]
+
+	l.input = input
+	l.pos, l.line, l.col = cp.offset, cp.line, cp.col
+	l.lineStart = strings.LastIndexByte(input[:l.pos], '\n') + 1
+	l.restoreState(cp.state)
+
+	tokens := make([]Token, cp.token, len(oldTokens)+1)
+	copy(tokens, oldTokens[:cp.token])
+	l.checkpoints = append([]checkpoint(nil), oldCheckpoints[:i]...)
+
+	editEnd := edit.Start + len(newText)
+	for l.pos < len(l.input) {
+		if l.markLine(len(tokens)) && l.pos >= editEnd {
+			if j, ok := findCheckpoint(oldCheckpoints, l.pos-delta); ok && l.resumes(oldCheckpoints[j]) {
+				tokens = l.splice(tokens, oldTokens, oldCheckpoints, j, delta)
+				l.pos = len(l.input)
+				l.line, l.col = endLine+l.line-oldCheckpoints[j].line, endCol
+				break
+			}
+		}
+		token := l.nextToken()
+		if token.Type != TokenText || token.Value != "" {
+			tokens = append(tokens, token)
+		}
+	}
+
+	l.tokens = tokens
+	return tokens
+}
+
+// resumes reports whether the lexer, at a line checkpoint it just recorded,
+// is in the same position within the line and the same state as old.
+func (l *Lexer) resumes(old checkpoint) bool {
+	return l.col == old.col && l.saveState() == old.state
+}
+
+// splice appends the old tokens from checkpoint j onwards to tokens, with
+// line numbers and offsets moved to match the edited input.
+func (l *Lexer) splice(tokens, oldTokens []Token, oldCheckpoints []checkpoint, j, delta int) []Token {
+	old := oldCheckpoints[j]
+	lineShift := l.line - old.line
+	tokenShift := len(tokens) - old.token
+
+	for _, t := range oldTokens[old.token:] {
+		t.Line += lineShift
+		tokens = append(tokens, t)
+	}
+	for _, c := range oldCheckpoints[j+1:] {
+		c.token += tokenShift
+		c.offset += delta
+		c.line += lineShift
+		l.checkpoints = append(l.checkpoints, c)
+	}
+	return tokens
+}
+
+// findCheckpoint returns the index of the checkpoint at offset.
+func findCheckpoint(checkpoints []checkpoint, offset int) (int, bool) {
+	j := sort.Search(len(checkpoints), func(i int) bool {
+		return checkpoints[i].offset >= offset
+	})
+	return j, j < len(checkpoints) && checkpoints[j].offset == offset
+}
+
+// reset prepares the lexer to tokenize input from the start, keeping the
+// dialect and parse mode unless they were being detected, the state word
+// overrides and command hook, the host given to SetHost and its
+// highlighting, and the explain and name learning settings.
+func (l *Lexer) reset(input string) {
+	fresh := New(input)
+	if !l.autoDialect {
+		fresh.SetDialect(l.dialect)
+	}
+	if l.explicitMode {
+		fresh.SetParseMode(l.parseMode)
+	}
+	fresh.stateWords = l.stateWords
+	fresh.commandHook = l.commandHook
+	fresh.SetHost(l.seedHost)
+	fresh.markHost = l.markHost
+	fresh.explain = l.explain
+	if l.learnNames {
+		fresh.SetLearnedNames(l.seedNames)
+	}
+	*l = *fresh
+}
+
+// hasTokenType reports whether any of tokens is of type t.
+func hasTokenType(tokens []Token, t TokenType) bool {
+	for _, tok := range tokens {
+		if tok.Type == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package lexer
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/internal/benchdata"
+)
+
+// incrementalConfig is long enough that edits past the first 500 bytes are
+// lexed incrementally, and includes tokens that span lines (a banner and a
+// hex dump).
+var incrementalConfig = strings.Repeat(`!
+interface GigabitEthernet0/1
+ description Uplink to core
+ ip address 10.0.0.1 255.255.255.0
+ no shutdown
+!
+`, 6) + `banner motd ^C
+Authorized access only
+interface fake
+^C
+!
+crypto pki certificate chain TP-self-signed-1
+ certificate self-signed 01
+  3082032E 30820216 A0030201 02020101 300D0609 2A864886 F70D0101 05050030
+  D2E2
+  	quit
+!
+ip access-list extended EDGE
+ permit tcp any host 192.0.2.10 eq 443
+ deny ip any any log
+!
+router bgp 65000
+ neighbor 192.0.2.1 remote-as 65001
+ neighbor 192.0.2.1 route-map IMPORT in
+!
+`
+
+func TestRetokenizeMatchesTokenize(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    Range
+		newText string
+	}{
+		{"insert word", Range{600, 600}, "foo "},
+		{"replace address", rangeOf("10.0.0.1 255", 5), "172.16.0.1 255"},
+		{"delete line", rangeOf(" deny ip any any log\n", 0), ""},
+		{"add line", rangeOf("router bgp", 0), "ip route 0.0.0.0 0.0.0.0 192.0.2.254\n"},
+		{"add prompt line", rangeOf("router bgp", 0), "edge-rtr(config)#router bgp 65000\n"},
+		{"open banner", rangeOf("banner motd ^C", 0), "banner login ^C\nlocked\n^C\n"},
+		{"close banner early", rangeOf("interface fake", 0), "^C\n"},
+		{"break banner delimiter", rangeOf("^C\n!\ncrypto pki", 0), "^"},
+		{"extend hex dump", rangeOf("\tquit", 0), "ABCD\n"},
+		{"break hex dump", rangeOf("D2E2", 0), "xx"},
+		{"start hex dump", rangeOf("  3082032E", 0), "  30820216 A0030201 02020101 300D0609\n"},
+		{"start description", rangeOf(" neighbor 192.0.2.1 remote-as", 0), " description"},
+		{"edit last line", Range{len(incrementalConfig) - 2, len(incrementalConfig)}, "\n"},
+		{"append", Range{len(incrementalConfig), len(incrementalConfig)}, "end\n"},
+		{"edit in detection sample", Range{10, 12}, "TenGig"},
+		{"out of range", Range{len(incrementalConfig) - 1, len(incrementalConfig) + 50}, "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(incrementalConfig)
+			l.Tokenize()
+			got := l.Retokenize(tt.edit, tt.newText)
+
+			edited := applyEdit(incrementalConfig, tt.edit, tt.newText)
+			want := New(edited).Tokenize()
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Retokenize differs from Tokenize of %q", edited)
+			}
+		})
+	}
+}
+
+func TestRetokenizeColumnHeader(t *testing.T) {
+	input := benchdata.ShowTech(3000)
+	row := strings.Index(input, "Vlan1 ")
+	tests := []struct {
+		name    string
+		edit    Range
+		newText string
+	}{
+		{"break row", Range{row + 20, row + 30}, "\n"},
+		{"delete row", Range{row, strings.Index(input[row:], "\n") + row + 1}, ""},
+		{"add row", Range{row, row}, "Vlan2                  10.0.0.2      YES NVRAM  up                    up\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(input)
+			l.SetParseMode(ParseModeShow)
+			l.Tokenize()
+			got := l.Retokenize(tt.edit, tt.newText)
+
+			want := New(applyEdit(input, tt.edit, tt.newText))
+			want.SetParseMode(ParseModeShow)
+			if !reflect.DeepEqual(got, want.Tokenize()) {
+				t.Errorf("Retokenize differs from Tokenize")
+			}
+		})
+	}
+}
+
+func TestRetokenizeSequence(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	fragments := []string{"", " ", "\n", "no ", "^C", "description x\n", "10.1.1.1", " permit ip any any\n", "!\n"}
+
+	input := incrementalConfig
+	l := New(input)
+	l.Tokenize()
+	for i := 0; i < 200; i++ {
+		start := rng.Intn(len(input) + 1)
+		end := min(len(input), start+rng.Intn(20))
+		newText := fragments[rng.Intn(len(fragments))]
+
+		got := l.Retokenize(Range{start, end}, newText)
+		input = applyEdit(input, Range{start, end}, newText)
+		want := New(input).Tokenize()
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("edit %d (%d-%d %q): Retokenize differs from Tokenize", i, start, end, newText)
+		}
+	}
+}
+
+func TestRetokenizeKeepsParseMode(t *testing.T) {
+	l := New(incrementalConfig)
+	l.SetParseMode(ParseModeShow)
+	l.Tokenize()
+	l.Retokenize(Range{0, 1}, "#")
+	if l.GetParseMode() != ParseModeShow {
+		t.Errorf("expected explicit parse mode to be kept, got %v", l.GetParseMode())
+	}
+}
+
+func TestRetokenizeKeepsStateWords(t *testing.T) {
+	l := New("Gi1/0/1  Standby  up\n")
+	l.SetParseMode(ParseModeShow)
+	l.SetStateWords(StateWords{"standby": TokenStateGood})
+	l.Tokenize()
+	for _, tok := range l.Retokenize(Range{0, 0}, "Gi1/0/2  Standby  up\n") {
+		if tok.Value == "Standby" && tok.Type != TokenStateGood {
+			t.Errorf("expected state word overrides to be kept, got %v", tok.Type)
+		}
+	}
+}
+
+func TestRetokenizeKeepsCommandHook(t *testing.T) {
+	var got []string
+	l := New("r1#show clock\n*10:00:00 UTC Mon Jan 1 2024\n")
+	l.SetCommandHook(func(cmd Command) { got = append(got, cmd.Text) })
+	l.Tokenize()
+	l.Retokenize(Range{8, 13}, "version")
+	if want := []string{"show clock", "show version"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+}
+
+func TestRetokenizeHost(t *testing.T) {
+	input := incrementalConfig + "r1(config)#ip host r1 192.0.2.1\nip host r2 192.0.2.2\n"
+	tokenize := func(input string) []Token {
+		l := New(input)
+		l.SetHost("r2")
+		l.SetHighlightHost(true)
+		return l.Tokenize()
+	}
+
+	l := New(input)
+	l.SetHost("r2")
+	l.SetHighlightHost(true)
+	l.Tokenize()
+
+	// The prompt's host is restored from the checkpoint, and lines naming
+	// the old host are lexed again
+	edit := Range{strings.Index(input, "r1(config)"), strings.Index(input, "(config)")}
+	edited := applyEdit(input, edit, "r2")
+	if got, want := l.Retokenize(edit, "r2"), tokenize(edited); !reflect.DeepEqual(got, want) {
+		t.Errorf("Retokenize differs from Tokenize of %q", edited)
+	}
+
+	// The host given to SetHost is kept when starting over
+	if got, want := l.Retokenize(Range{0, 1}, "#"), tokenize("#"+edited[1:]); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the host given to SetHost to be kept")
+	}
+}
+
+// rangeOf returns the range of the first occurrence of s in incrementalConfig,
+// shortened to its first n bytes if n > 0.
+func rangeOf(s string, n int) Range {
+	start := strings.Index(incrementalConfig, s)
+	if n == 0 {
+		n = len(s)
+	}
+	return Range{start, start + n}
+}
+
+func applyEdit(input string, edit Range, newText string) string {
+	edit.End = min(edit.End, len(input))
+	return input[:edit.Start] + newText + input[edit.End:]
+}
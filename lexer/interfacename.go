@@ -0,0 +1,124 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The number after an interface type: "0/0/1", "10", "10.100"
+var interfaceSuffixPattern = regexp.MustCompile(`^\d+(/\d+)*(\.\d+)?$`)
+
+// interfaceType is an interface type by its full name and the abbreviation
+// show output prints for it.
+type interfaceType struct {
+	name, short string
+	aliases     []string // other spellings: NX-OS "Eth", IOS-XR "TenGigE"
+}
+
+// Interface types in the order an abbreviation is matched against them. The
+// abbreviations of show output are matched first, then any prefix of a
+// full name that only one type has, as the CLI accepts ("gig0/1", "port1").
+var interfaceTypes = []interfaceType{
+	{name: "GigabitEthernet", short: "Gi", aliases: []string{"Gig"}},
+	{name: "FastEthernet", short: "Fa"},
+	{name: "TenGigabitEthernet", short: "Te", aliases: []string{"TenGigE"}},
+	{name: "TwoGigabitEthernet", short: "Tw"},
+	{name: "FiveGigabitEthernet", short: "Fi"},
+	{name: "TwentyFiveGigE", short: "Twe", aliases: []string{"TwentyFiveGigabitEthernet"}},
+	{name: "FortyGigabitEthernet", short: "Fo", aliases: []string{"FortyGigE"}},
+	{name: "HundredGigE", short: "Hu", aliases: []string{"HundredGigabitEthernet"}},
+	{name: "AppGigabitEthernet", short: "Ap"},
+	{name: "Ethernet", short: "Et", aliases: []string{"Eth"}},
+	{name: "Loopback", short: "Lo"},
+	{name: "Vlan", short: "Vl"},
+	{name: "Port-channel", short: "Po", aliases: []string{"Port-Channel"}},
+	{name: "Tunnel", short: "Tu"},
+	{name: "Serial", short: "Se"},
+	{name: "Null", short: "Nu"},
+	{name: "BDI", short: "BDI"},
+	{name: "Dialer", short: "Di"},
+	{name: "Virtual-Template", short: "Vt"},
+	{name: "Virtual-Access", short: "Vi", aliases: []string{"Va"}},
+	{name: "Multilink", short: "Mu"},
+	{name: "Cellular", short: "Ce"},
+	{name: "Async", short: "As"},
+	{name: "ATM", short: "ATM"},
+	{name: "mgmt", short: "mgmt"},
+	{name: "nve", short: "nve"},
+}
+
+// NormalizeInterface returns the full name of an interface written in any
+// of its abbreviations, so names from show output can be matched against
+// those in the configuration:
+//
+//	NormalizeInterface("Gi0/0/1")    // GigabitEthernet0/0/1
+//	NormalizeInterface("po10")       // Port-channel10
+//	NormalizeInterface("Gig 1/0/24") // GigabitEthernet1/0/24, as CDP prints it
+//
+// Subinterface numbers are kept. Names it does not recognize, including
+// ambiguous prefixes such as "T0/1", are returned unchanged.
+func NormalizeInterface(name string) string {
+	t, number, ok := splitInterface(name)
+	if !ok {
+		return name
+	}
+	return t.name + number
+}
+
+// AbbreviateInterface returns the short form show output uses for an
+// interface written in full or in any abbreviation, the reverse of
+// NormalizeInterface:
+//
+//	AbbreviateInterface("GigabitEthernet0/0/1") // Gi0/0/1
+//	AbbreviateInterface("Port-channel10")       // Po10
+//
+// Names it does not recognize are returned unchanged.
+func AbbreviateInterface(name string) string {
+	t, number, ok := splitInterface(name)
+	if !ok {
+		return name
+	}
+	return t.short + number
+}
+
+// splitInterface splits an interface name into its type and the number
+// after it, "0/0/1" or "10.100".
+func splitInterface(name string) (interfaceType, string, bool) {
+	name = strings.TrimSpace(name)
+	i := strings.IndexFunc(name, func(r rune) bool { return r >= '0' && r <= '9' })
+	if i <= 0 {
+		return interfaceType{}, "", false
+	}
+	prefix, number := strings.TrimSpace(name[:i]), name[i:]
+	if !interfaceSuffixPattern.MatchString(number) {
+		return interfaceType{}, "", false
+	}
+	t, ok := lookupInterfaceType(prefix)
+	return t, number, ok
+}
+
+// lookupInterfaceType returns the type whose full name, abbreviation or
+// alias prefix is, ignoring case, or else the only type whose full name
+// starts with it.
+func lookupInterfaceType(prefix string) (interfaceType, bool) {
+	for _, t := range interfaceTypes {
+		if strings.EqualFold(prefix, t.name) || strings.EqualFold(prefix, t.short) {
+			return t, true
+		}
+		for _, alias := range t.aliases {
+			if strings.EqualFold(prefix, alias) {
+				return t, true
+			}
+		}
+	}
+
+	var match interfaceType
+	found := 0
+	for _, t := range interfaceTypes {
+		if len(prefix) < len(t.name) && strings.EqualFold(prefix, t.name[:len(prefix)]) {
+			match = t
+			found++
+		}
+	}
+	return match, found == 1
+}
@@ -0,0 +1,37 @@
+package lexer
+
+import "testing"
+
+func TestNormalizeInterface(t *testing.T) {
+	tests := []struct {
+		input, full, short string
+	}{
+		{"Gi0/0/1", "GigabitEthernet0/0/1", "Gi0/0/1"},
+		{"GigabitEthernet0/0/1", "GigabitEthernet0/0/1", "Gi0/0/1"},
+		{"gig 1/0/24", "GigabitEthernet1/0/24", "Gi1/0/24"}, // CDP
+		{"Te1/1/1", "TenGigabitEthernet1/1/1", "Te1/1/1"},
+		{"Twe1/0/1", "TwentyFiveGigE1/0/1", "Twe1/0/1"},
+		{"Tw1/0/1", "TwoGigabitEthernet1/0/1", "Tw1/0/1"},
+		{"po10", "Port-channel10", "Po10"},
+		{"Port-channel10", "Port-channel10", "Po10"},
+		{"Eth1/49", "Ethernet1/49", "Et1/49"},
+		{"Vl10", "Vlan10", "Vl10"},
+		{"Lo0", "Loopback0", "Lo0"},
+		{"Gi0/0/1.100", "GigabitEthernet0/0/1.100", "Gi0/0/1.100"},
+		{"loop0", "Loopback0", "Lo0"}, // unambiguous prefix
+		{"T0/1", "T0/1", "T0/1"},      // Tunnel or TenGigabitEthernet
+		{"Foo1", "Foo1", "Foo1"},
+		{"CPU", "CPU", "CPU"},
+		{"10.0.0.1", "10.0.0.1", "10.0.0.1"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeInterface(tt.input); got != tt.full {
+			t.Errorf("NormalizeInterface(%q) = %q, want %q", tt.input, got, tt.full)
+		}
+		if got := AbbreviateInterface(tt.input); got != tt.short {
+			t.Errorf("AbbreviateInterface(%q) = %q, want %q", tt.input, got, tt.short)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package lexer
+
+import "regexp"
+
+// Interface numbers given apart from the type: "interface range Gi 1/0/1 - 4"
+var interfaceNumberPattern = regexp.MustCompile(`^\d+(/\d+)+(\.\d+)?$`)
+
+// isRangeBoundary reports whether an "interface range" member ends between
+// prev and ch: at a comma, or at a dash after a digit ("Gi1/0/1-4"). Dashes
+// inside names such as Port-channel1 do not end the member.
+func isRangeBoundary(prev, ch byte) bool {
+	return ch == ',' || (ch == '-' && prev >= '0' && prev <= '9')
+}
+
+// scanRangeOperator scans the "-" or "," between "interface range" members.
+func (l *Lexer) scanRangeOperator() Token {
+	startLine, startCol := l.line, l.col
+	value := l.input[l.pos : l.pos+1]
+	l.advance()
+	l.prevWord = value
+	return Token{
+		Type:   TokenOperator,
+		Value:  value,
+		Line:   startLine,
+		Column: startCol,
+	}
+}
+
+// classifyRangeMember classifies a word in the member list of "interface
+// range": complete interface names, a type given apart from its number
+// ("vlan 10 - 20") and the number that ends a range.
+func classifyRangeMember(word string) (TokenType, bool) {
+	switch {
+	case interfacePattern.MatchString(word), interfaceNumberPattern.MatchString(word):
+		return TokenInterface, true
+	case isAllDigits(word):
+		return TokenNumber, true
+	case interfacePattern.MatchString(word + "0"):
+		return TokenInterface, true
+	}
+	return TokenText, false
+}
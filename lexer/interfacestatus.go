@@ -0,0 +1,123 @@
+package lexer
+
+import "strings"
+
+// show interfaces status
+var (
+	// Port states in the Status column besides those in the state lists
+	// (connected, notconnect, err-disabled, disabled, ...), including the
+	// NX-OS ones cut to the column's width
+	interfaceStatusStates = map[string]TokenType{
+		"notconnec": TokenStateBad, "err-disabl": TokenStateBad, "err-disab": TokenStateBad,
+		"errdisable": TokenStateBad, "errdisabl": TokenStateBad,
+		"noopermem": TokenStateBad, "nooperm": TokenStateBad, "faulty": TokenStateBad,
+		"linkflape": TokenStateBad, "channeldo": TokenStateBad,
+		"monitoring": TokenStateNeutral, "sfpabsent": TokenStateNeutral, "xcvrabsent": TokenStateNeutral,
+		"xcvrabsen": TokenStateNeutral,
+	}
+
+	// The Duplex column, right after Status and Vlan: auto-negotiated values
+	// are prefixed with "a-"
+	interfaceDuplexes = map[string]TokenType{
+		"full": TokenKeyword, "a-full": TokenKeyword, "auto": TokenKeyword,
+		"half": TokenStateWarning, "a-half": TokenStateWarning,
+	}
+
+	// Vlan column values other than a VLAN ID
+	interfaceVLANWords = map[string]bool{
+		"trunk": true, "routed": true, "unassigned": true, "f-path": true,
+	}
+
+	// Type column values for empty transceiver slots
+	interfaceAbsentTypes = map[string]bool{
+		"not present": true, "no transceiver": true, "no xcvr": true, "no gbic": true,
+	}
+
+	interfaceStatusProfile = &ShowProfile{
+		Name: "interface-status",
+		Indicators: []string{
+			"duplex  speed", "status       vlan", "status    vlan",
+			"notconnect", "a-full", "a-half", "sfpabsent", "xcvrabsent",
+		},
+		Classify: classifyInterfaceStatus,
+	}
+)
+
+// classifyInterfaceStatus handles the rows of the switchport status table:
+//
+//	Port      Name               Status       Vlan       Duplex  Speed Type
+//	Gi1/0/1   uplink to core-sw1 connected    trunk        full   1000 10/100/1000BaseTX
+//	Gi1/0/2   Printer 2nd floor  notconnect   10           auto   auto 10/100/1000BaseTX
+//	Gi1/0/3   AP-lobby           err-disabled 20         a-full a-1000 10/100/1000BaseTX
+//
+// The Name column is the port description, cut to the column's width, so its
+// words are not states or keywords however they read ("down to access",
+// "Phone connected"). The row is split by finding the Status column: a port
+// state followed by the Vlan and Duplex columns.
+func classifyInterfaceStatus(l *Lexer, word, lower string) (TokenType, bool) {
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, _ := l.lineAt(lineStart)
+	cols := lineColumns(line)
+	if len(cols) < 4 || !interfacePattern.MatchString(line[cols[0].start:cols[0].end]) {
+		return TokenText, false
+	}
+	status := l.interfaceStatusColumn(line, cols)
+	if status < 0 {
+		return TokenText, false
+	}
+
+	switch field := wordColumn(cols, l.pos-len(word)-lineStart); {
+	case field <= 0:
+		return TokenText, false
+	case field < status:
+		return TokenValue, true
+	case field == status:
+		return l.portStatus(lower), true
+	case field == status+1:
+		if interfaceVLANWords[lower] {
+			return TokenKeyword, true
+		}
+		if isAllDigits(word) {
+			return TokenNumber, true
+		}
+	case field == status+2:
+		return interfaceDuplexes[lower], true
+	case field == status+3:
+		if lower == "auto" {
+			return TokenKeyword, true
+		}
+		return TokenRate, true
+	default:
+		// The Type column: "10/100/1000BaseTX", "SFP-10GBase-SR", "Not Present"
+		typ := strings.ToLower(strings.TrimSpace(line[cols[status+4].start:]))
+		if interfaceAbsentTypes[typ] {
+			return TokenStateNeutral, true
+		}
+		return TokenIdentifier, true
+	}
+	return TokenText, false
+}
+
+// interfaceStatusColumn returns the index of the Status column among the
+// words of a table row, or -1 if the row has none. Since a description may
+// hold state words too, it is the last port state followed by a duplex two
+// columns later.
+func (l *Lexer) interfaceStatusColumn(line string, cols []column) int {
+	for i := len(cols) - 3; i >= 1; i-- {
+		word := strings.ToLower(line[cols[i].start:cols[i].end])
+		duplex := strings.ToLower(line[cols[i+2].start:cols[i+2].end])
+		if l.portStatus(word) != TokenText && interfaceDuplexes[duplex] != TokenText {
+			return i
+		}
+	}
+	return -1
+}
+
+// portStatus returns the state type of a Status column value, or TokenText
+// if lower is not a port state.
+func (l *Lexer) portStatus(lower string) TokenType {
+	if t, ok := l.stateType(lower); ok {
+		return t
+	}
+	return interfaceStatusStates[lower]
+}
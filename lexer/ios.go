@@ -0,0 +1,70 @@
+package lexer
+
+import "strings"
+
+// iosDialect is Cisco IOS/IOS-XE. Its vocabulary is the built-in rule set
+// every dialect refines, so it adds no keywords or patterns of its own.
+type iosDialect struct{}
+
+func (iosDialect) Name() string        { return "ios" }
+func (iosDialect) String() string      { return "IOS" }
+func (iosDialect) Keywords() Keywords  { return Keywords{} }
+func (iosDialect) Patterns() []Pattern { return nil }
+
+// iosIndicators is evidence for IOS. NX-OS, IOS-XR and EOS share the IOS
+// syntax, so their markers count towards IOS too.
+var iosIndicators = []dialectIndicator{
+	{"building configuration", 2},
+	{"current configuration :", 2},
+	{"cisco ios", 2},
+	{"nx-os", 2},
+	{"ios xr", 2},
+	{"arista", 2},
+	{"service timestamps", 1},
+	{"enable secret", 1},
+	{"interface gigabitethernet", 1},
+	{"interface tengigabitethernet", 1},
+	{"interface fastethernet", 1},
+	{"interface ethernet", 1},
+	{"interface port-channel", 1},
+	{"switchport", 1},
+	{"spanning-tree", 1},
+	{"feature ", 1},
+}
+
+func (iosDialect) DetectScore(sample string) int {
+	return scoreIndicators(strings.ToLower(sample), iosIndicators)
+}
+
+func (iosDialect) ShowProfiles() []*ShowProfile {
+	return iosShowProfiles
+}
+
+// iosShowProfiles lists the IOS show output profiles, in detection priority order.
+var iosShowProfiles = []*ShowProfile{
+	spanningTreeProfile,
+	showVersionProfile,
+	showARPProfile,
+	mplsProfile,
+	hsrpProfile,
+	glbpProfile,
+	ospfDatabaseProfile,
+	ospfNeighborProfile,
+	dhcpProfile,
+	environmentProfile,
+	interfaceStatusProfile,
+	routeSummaryProfile,
+	lacpProfile,
+	pagpProfile,
+	bgpSummaryProfile,
+	bgpDampeningProfile,
+	bgpTableProfile,
+	routeTableProfile,
+	cryptoProfile,
+	redundancyProfile,
+	stackProfile,
+	portSecurityProfile,
+	dhcpSnoopingProfile,
+	errdisableProfile,
+	transceiverProfile,
+}
@@ -0,0 +1,21 @@
+//go:build go1.23
+
+package lexer
+
+import "iter"
+
+// All returns an iterator over the tokens of the input, for use with range:
+//
+//	for token := range lexer.New(config).All() {
+//		...
+//	}
+//
+// Like Scan it produces tokens as the input is lexed.
+func (l *Lexer) All() iter.Seq[Token] {
+	return l.Scan
+}
+
+// Tokens returns an iterator over the tokens of input, lexed by a new Lexer.
+func Tokens(input string) iter.Seq[Token] {
+	return New(input).All()
+}
@@ -0,0 +1,28 @@
+//go:build go1.23
+
+package lexer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTokens(t *testing.T) {
+	input := scanInputs["config"]
+	if got, want := slices.Collect(Tokens(input)), New(input).Tokenize(); !slices.Equal(got, want) {
+		t.Errorf("Tokens gave %v, Tokenize %v", got, want)
+	}
+
+	n := 0
+	for token := range New(input).All() {
+		if token.Value == "" {
+			t.Errorf("unexpected empty token %v", token)
+		}
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("range should stop at break, got %d tokens", n)
+	}
+}
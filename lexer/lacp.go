@@ -0,0 +1,174 @@
+package lexer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// show lacp internal, show lacp neighbor, show pagp neighbor
+var (
+	// Port states in the State column of show lacp internal: "bndl" is in
+	// the bundle, "hot-sby" waits as a standby link, "indep" forwards on its
+	// own for lack of LACP partners, "susp" is suspended as incompatible
+	lacpBundleStates = map[string]TokenType{
+		"bndl":    TokenStateGood,
+		"hot-sby": TokenStateWarning, "indep": TokenStateWarning, "indiv": TokenStateWarning,
+		"susp": TokenStateBad, "down": TokenStateBad, "inact": TokenStateBad,
+	}
+
+	// Single-letter flags joined in the Flags column: "SA", "FP", "SC"
+	channelFlagsPattern = regexp.MustCompile(`^[SFAPCHdUu]{1,4}$`)
+
+	// Partner system IDs, the system priority and MAC: "32768,0011.2233.4455",
+	// and on NX-OS "32768,0-23-4-ee-be-1"
+	lacpSystemIDPattern = regexp.MustCompile(`^(?i)\d{1,5},([0-9a-f]{4}\.[0-9a-f]{4}\.[0-9a-f]{4}|[0-9a-f]{1,2}(-[0-9a-f]{1,2}){5})$`)
+
+	lacpProfile = &ShowProfile{
+		Name: "lacp",
+		Indicators: []string{
+			"lacp port", "lacpdus", "partner's information", "bndl", "hot-sby",
+			"device is in active mode", "device is in passive mode",
+		},
+		Classify: classifyLACP,
+	}
+
+	pagpProfile = &ShowProfile{
+		Name: "pagp",
+		Indicators: []string{
+			"partner group", "device is sending slow hello", "device is in consistent state",
+			"device learns on physical port", "pagp is down",
+		},
+		Classify: classifyPAgP,
+	}
+)
+
+// LACP actor and partner state bits of the Port State column (IEEE 802.1AX)
+const (
+	lacpStateSync         = 0x08 // the link is in the right aggregation
+	lacpStateCollecting   = 0x10
+	lacpStateDistributing = 0x20
+	lacpStateDefaulted    = 0x40 // no LACPDUs from the partner, defaults in use
+	lacpStateExpired      = 0x80 // the partner's information timed out
+)
+
+// classifyLACP handles the port tables of show lacp internal and neighbor:
+//
+//	Channel group 1
+//	                            LACP port     Admin     Oper    Port        Port
+//	Port      Flags   State     Priority      Key       Key     Number      State
+//	Gi1/0/1   SA      bndl      32768         0x1       0x1     0x102       0x3D
+//	Gi1/0/2   SA      susp      32768         0x1       0x1     0x103       0x7
+//
+//	Partner's information:
+//	Port      Flags   Priority  Dev ID          Age    key    Key    Number  State
+//	Gi1/0/1   SA      32768     0011.2233.4455  12s    0x0    0x1    0x102   0x3D
+//
+// The port State, in hex, is colored by its bits: in sync, collecting and
+// distributing is good, defaulted or expired partner information bad, and
+// anything between a warning.
+func classifyLACP(l *Lexer, word, lower string) (TokenType, bool) {
+	if t, ok := channelGroupHeader(l, lower); ok {
+		return t, true
+	}
+	if lacpSystemIDPattern.MatchString(word) {
+		return TokenMAC, true
+	}
+
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, _ := l.lineAt(lineStart)
+	cols := lineColumns(line)
+	if len(cols) < 4 || !interfacePattern.MatchString(line[cols[0].start:cols[0].end]) {
+		return TokenText, false
+	}
+	field := wordColumn(cols, l.pos-len(word)-lineStart)
+
+	switch {
+	case (field == 1 || field == len(cols)-1) && channelFlagsPattern.MatchString(word):
+		// Second on IOS, last on NX-OS
+		return TokenKeyword, true
+	case lacpBundleStates[lower] != TokenText:
+		return lacpBundleStates[lower], true
+	case hexNumberPattern.MatchString(word):
+		// Keys, port numbers and states: "0x1", "0x102", "0x3D"
+		if field == len(cols)-1 {
+			return lacpPortState(word), true
+		}
+		return TokenNumber, true
+	}
+	return TokenText, false
+}
+
+// classifyPAgP handles the neighbor table of show pagp neighbor:
+//
+//	Channel group 1 neighbors
+//	          Partner              Partner          Partner         Partner Group
+//	Port      Name                 Device ID        Port       Age  Flags   Cap.
+//	Gi1/0/1   switch2              0011.2233.4455   Gi1/0/1     10s SC      10001
+//
+// The partner's name is a hostname, whatever it reads like.
+func classifyPAgP(l *Lexer, word, lower string) (TokenType, bool) {
+	if t, ok := channelGroupHeader(l, lower); ok {
+		return t, true
+	}
+
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, _ := l.lineAt(lineStart)
+	cols := lineColumns(line)
+	if len(cols) < 6 || !interfacePattern.MatchString(line[cols[0].start:cols[0].end]) {
+		return TokenText, false
+	}
+	switch field := wordColumn(cols, l.pos-len(word)-lineStart); {
+	case field == 1:
+		return TokenValue, true
+	case field == len(cols)-2 && channelFlagsPattern.MatchString(word):
+		return TokenKeyword, true
+	case field == len(cols)-1:
+		// The group capability, in hex without a prefix on some releases
+		return TokenNumber, true
+	}
+	return TokenText, false
+}
+
+// channelGroupHeader classifies the words of the "Channel group 1" line that
+// starts the ports of each port-channel.
+func channelGroupHeader(l *Lexer, lower string) (TokenType, bool) {
+	line := strings.TrimSpace(l.lineText())
+	if !strings.HasPrefix(line, "channel group ") {
+		return TokenText, false
+	}
+	switch {
+	case lower == "channel" && l.prevWord == "", lower == "group" && l.prevWord == "channel":
+		return TokenSection, true
+	case l.prevWord == "group" && isAllDigits(lower):
+		return TokenNumber, true
+	}
+	return TokenText, false
+}
+
+// wordColumn returns the index of the column covering the byte offset start
+// of a line, or -1.
+func wordColumn(cols []column, start int) int {
+	for i, c := range cols {
+		if start >= c.start && start < c.end {
+			return i
+		}
+	}
+	return -1
+}
+
+// lacpPortState returns the state type of an LACP port state such as "0x3D".
+func lacpPortState(word string) TokenType {
+	bits, err := strconv.ParseUint(word[2:], 16, 8)
+	if err != nil {
+		return TokenNumber
+	}
+	const aggregating = lacpStateSync | lacpStateCollecting | lacpStateDistributing
+	switch {
+	case bits&(lacpStateDefaulted|lacpStateExpired) != 0:
+		return TokenStateBad
+	case bits&aggregating == aggregating:
+		return TokenStateGood
+	}
+	return TokenStateWarning
+}
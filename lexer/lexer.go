@@ -3,6 +3,7 @@ package lexer
 import (
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 // Constants for lexer configuration
@@ -17,10 +18,49 @@ type Lexer struct {
 	pos            int
 	line           int
 	col            int
+	lineStart      int // byte offset where the current line starts
 	parseMode      ParseMode
 	detectedMode   bool
-	expectingValue bool   // true after keywords like "description" that consume rest of line
-	lastToken      string // tracks the last non-whitespace token value for context
+	dialect        Dialect
+	autoDialect    bool        // dialect was DialectAuto and is detected from the input
+	words          *vocabulary // word lists as of New (see AddWords)
+	stateWords     StateWords  // per-lexer state list overrides (see SetStateWords)
+	expectingValue bool        // true after keywords like "description" that consume rest of line
+	lastToken      string      // tracks the last non-whitespace token value for context
+	prevWord       string      // previous word on the current line (lowercase), for show profiles
+	pendingArg     string      // keyword awaiting its argument on this line: "vrf", "rd", "route-target"
+	bannerStage    int         // progress through a "banner <type> <delim>" header
+	bannerDelim    string      // closing delimiter while inside a banner body
+	lineCommand    string      // first word of the current line (lowercase, ignoring "no")
+	lineAction     bool        // permit/deny seen on the current line (ACL entry)
+	prevAddr       bool        // previous word was an IPv4 address that may be followed by a mask
+	inRange        bool        // inside the member list of "interface range"
+	headerLine     bool        // the current line is a table column header (show mode)
+	type7Checked   bool        // type7Line is known for the current line
+	type7Line      bool        // the current line has a keyword a type 7 password follows
+	asnList        bool        // after "confederation peers" or "as-path prepend" on this line
+
+	profile         *ShowProfile // detected show output profile (nil if none)
+	detectedProfile bool
+
+	host     string  // hostname from the last prompt seen (or set with SetHost)
+	markHost bool    // classify later occurrences of host as TokenPromptHost
+	seedHost string  // host given to SetHost, kept by Retokenize
+	queued   []Token // rest of a prompt line scanned ahead (see scanPromptLine)
+
+	commandHook CommandHook // called for each command typed at a prompt, nil when off
+
+	learnNames bool            // classify defined names (see SetLearnNames)
+	names      map[string]bool // names defined so far, and those given to SetLearnedNames
+	seedNames  []string        // names given to SetLearnedNames, kept by Retokenize
+
+	explain bool   // attach an Explanation to each token (see SetExplain)
+	rule    string // rule that classified the token being scanned, while explaining
+
+	// Incremental re-tokenization state (see Retokenize)
+	tokens       []Token      // result of the last Tokenize or Retokenize
+	checkpoints  []checkpoint // one per line, in input order
+	explicitMode bool         // parse mode was set with SetParseMode
 }
 
 // ParseMode determines which classification rules to use for tokenization.
@@ -36,6 +76,10 @@ const (
 
 	// ParseModeShow uses show command output classification rules.
 	ParseModeShow
+
+	// ParseModeTranscript handles an interactive session capture: prompt
+	// lines with echoed commands, each followed by that command's output.
+	ParseModeTranscript
 )
 
 // String returns a human-readable name for the parse mode.
@@ -47,131 +91,50 @@ func (m ParseMode) String() string {
 		return "Config"
 	case ParseModeShow:
 		return "Show"
+	case ParseModeTranscript:
+		return "Transcript"
 	default:
 		return "Unknown"
 	}
 }
 
-// Keyword sets for Cisco IOS/IOS-XE classification
+// Banner header scanning stages
+const (
+	bannerNone        = iota
+	bannerExpectType  // after "banner": optional type (motd, login, exec, ...)
+	bannerExpectDelim // next non-space character(s) open the banner body
+)
+
+// ParseModeByName returns a parse mode by its name. Returns ParseModeAuto for unknown names.
+func ParseModeByName(name string) ParseMode {
+	switch name {
+	case "config", "configuration":
+		return ParseModeConfig
+	case "show", "output":
+		return ParseModeShow
+	case "transcript", "session":
+		return ParseModeTranscript
+	default:
+		return ParseModeAuto
+	}
+}
+
+// Keyword sets for Cisco IOS/IOS-XE classification. The main vocabulary is
+// in words/*.txt (see vocabulary.go).
 var (
-	commands = map[string]bool{
-		"interface": true, "router": true, "ip": true, "ipv6": true,
-		"show": true, "configure": true, "hostname": true, "username": true,
-		"enable": true, "service": true, "line": true, "logging": true,
-		"ntp": true, "snmp-server": true, "crypto": true, "aaa": true,
-		"spanning-tree": true, "vlan": true, "banner": true,
-		"shutdown": true, "write": true, "copy": true, "reload": true,
-		"ping": true, "traceroute": true, "clock": true, "boot": true,
-		"archive": true, "errdisable": true, "default-gateway": true,
-		"do": true, "exit": true, "end": true,
-	}
-
-	sections = map[string]bool{
-		"interface": true, "router": true, "line": true,
-		"access-list": true, "route-map": true, "prefix-list": true,
-		"class-map": true, "policy-map": true, "crypto": true,
-		"vlan": true, "redundancy": true, "controller": true,
-		"ip access-list": true, "key": true, "track": true,
-		"monitor": true, "event": true, "applet": true,
-	}
-
-	protocols = map[string]bool{
-		"ospf": true, "bgp": true, "eigrp": true, "rip": true,
-		"isis": true, "mpls": true, "hsrp": true, "vrrp": true,
-		"stp": true, "rstp": true, "lacp": true, "dot1q": true,
-		"ipsec": true, "gre": true, "tcp": true, "udp": true,
-		"icmp": true, "ssh": true, "dhcp": true, "bfd": true,
-		"cdp": true, "lldp": true, "evpn": true, "vxlan": true,
-		"isakmp": true, "nhrp": true, "pim": true, "igmp": true,
-		"msdp": true, "lisp": true, "omp": true, "snmp": true,
-		"radius": true, "tacacs": true, "tacacs+": true,
-		"telnet": true, "ftp": true, "tftp": true, "http": true,
-		"https": true, "ntp": true, "dns": true, "syslog": true,
-		"netflow": true, "sflow": true, "ipfix": true,
-	}
-
-	actions = map[string]bool{
-		"permit": true, "deny": true, "log": true, "log-input": true,
-		"established": true, "match": true, "set": true,
-		"remark": true, "evaluate": true, "reflect": true,
-	}
-
-	operators = map[string]bool{
-		"eq": true, "gt": true, "lt": true, "neq": true,
-		"range": true, "ge": true, "le": true, "any": true,
-		"host": true,
-	}
-
-	keywords = map[string]bool{
-		// Interface keywords
-		"description": true, "address": true, "switchport": true,
-		"speed": true, "duplex": true, "mtu": true, "bandwidth": true,
-		"encapsulation": true, "channel-group": true, "channel-protocol": true,
-		"standby": true, "ip address": true,
-		"no-autostate": true, "autostate": true,
-
-		// Routing keywords
-		"network": true, "neighbor": true, "redistribute": true,
-		"area": true, "remote-as": true, "update-source": true,
-		"route-map": true, "access-group": true, "nat": true,
-		"inside": true, "outside": true, "overload": true,
-		"default-information": true, "originate": true,
-		"summary-address": true, "passive-interface": true,
-		"distance": true, "metric": true, "weight": true,
-		"local-preference": true, "next-hop-self": true,
-		"soft-reconfiguration": true, "inbound": true,
-		"prefix-list": true, "distribute-list": true,
-		"maximum-paths": true, "auto-summary": true,
-		"synchronization": true, "log-neighbor-changes": true,
-		"address-family": true, "unicast": true, "multicast": true,
-		"vpnv4": true, "vpnv6": true,
-
-		// Security keywords
-		"access-class": true, "transport": true, "input": true,
-		"output": true, "login": true, "password": true,
-		"secret": true, "privilege": true, "authentication": true,
-		"authorization": true, "accounting": true, "group": true,
-		"method": true, "local": true,
-
-		// System keywords
-		"version": true, "source": true, "trap": true,
-		"community": true, "location": true, "contact": true,
-		"default": true, "timeout": true, "exec-timeout": true,
-		"mask": true, "wildcard": true, "inverse-mask": true,
-
-		// Spanning-tree keywords
-		"mode": true, "priority": true, "vlan": true,
-		"portfast": true, "bpduguard": true, "bpdufilter": true,
-		"guard": true, "root": true,
-
-		// VLAN keywords
-		"name": true, "state": true, "active": true, "suspend": true,
-
-		// QoS keywords
-		"class": true, "police": true, "shape": true,
-		"queue": true, "dscp": true, "cos": true,
-		"service-policy": true, "policy-map": true,
-
-		// AAA keywords
-		"new-model": true, "server": true, "key": true,
-
-		// Other
-		"trunk": true,
-		"native": true, "allowed": true, "tagging": true,
-		"nonegotiate": true, "negotiation": true, "auto": true,
-		"half": true, "flow-control": true,
-		"send": true, "both": true,
-		"storm-control": true, "level": true,
-	}
-
-	// Keywords that consume the rest of the line as a value
-	valueKeywords = map[string]bool{
-		"description": true,
-		"hostname":    true,
-		"banner":      true,
-		"remark":      true,
+	// Banner types accepted between "banner" and the delimiter
+	bannerTypes = map[string]bool{
+		"motd": true, "login": true, "exec": true, "incoming": true,
+		"slip-ppp": true, "prompt-timeout": true, "config-save": true,
 	}
 
+	// Type 7 encoded password: two-digit salt, then hex byte pairs
+	type7Pattern = regexp.MustCompile(`^\d{2}([0-9A-Fa-f]{2})+$`)
+
+	// Lines on which "7" introduces a type 7 password (also matches key-string,
+	// authentication-key and message-digest-key)
+	type7Context = regexp.MustCompile(`\b(password|secret|key)\b`)
+
 	// Cisco interface naming patterns
 	// Matches: GigabitEthernet0/0/0, Gi0/0/0, FastEthernet0/0, Fa0/0,
 	//          TenGigabitEthernet1/0/0, Te1/0/0, Loopback0, Lo0,
@@ -181,61 +144,23 @@ var (
 
 	ipv4Pattern       = regexp.MustCompile(`^(\d{1,3}\.){3}\d{1,3}$`)
 	ipv4PrefixPattern = regexp.MustCompile(`^(\d{1,3}\.){3}\d{1,3}/\d{1,2}$`)
-	// IPv6: require either "::" (compressed) or at least 3 colon-separated groups
-	ipv6Pattern       = regexp.MustCompile(`^([0-9a-fA-F]{0,4}:){2,7}[0-9a-fA-F]{0,4}$|^::([0-9a-fA-F]{1,4}:)*[0-9a-fA-F]{0,4}$|^[0-9a-fA-F]{1,4}::([0-9a-fA-F]{1,4}:)*[0-9a-fA-F]{0,4}$`)
-	ipv6PrefixPattern = regexp.MustCompile(`^(([0-9a-fA-F]{0,4}:){2,7}[0-9a-fA-F]{0,4}|::([0-9a-fA-F]{1,4}:)*[0-9a-fA-F]{0,4}|[0-9a-fA-F]{1,4}::([0-9a-fA-F]{1,4}:)*[0-9a-fA-F]{0,4})/\d{1,3}$`)
 
 	// Cisco MAC format: 0011.2233.4455 (dotted) and also colon format
 	macPatternCisco = regexp.MustCompile(`^[0-9a-fA-F]{4}\.[0-9a-fA-F]{4}\.[0-9a-fA-F]{4}$`)
 	macPatternColon = regexp.MustCompile(`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`)
 
-	communityPattern = regexp.MustCompile(`^\d+:\d+$`)
-	asnPattern       = regexp.MustCompile(`^[Aa][Ss]\d+$`)
-
-	// Show output state keywords
-	statesGood = map[string]bool{
-		"up": true, "connected": true, "established": true,
-		"full": true, "enabled": true, "active": true,
-		"forwarding": true, "ok": true, "online": true,
-		"running": true, "ready": true, "complete": true,
-	}
-
-	// Compound state patterns matched as whole words
-	statesGoodCompound = []string{"up/up"}
+	// Hex literals: config registers, memory addresses, LSA sequence numbers
+	// and checksums: 0x2102, 0x7F3A2B10, 0x80000005
+	hexNumberPattern = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
 
-	statesBad = map[string]bool{
-		"down": true, "notconnect": true, "err-disabled": true,
-		"disabled": true, "failed": true, "idle": true,
-		"connect": true, "opensent": true, "openconfirm": true,
-		"error": true, "offline": true, "unreachable": true,
-	}
+	asnPattern = regexp.MustCompile(`^[Aa][Ss]\d+(\.\d+)?$`)
 
-	statesBadCompound = []string{"down/down", "administratively"}
+	// Route distinguishers and route targets: ASN:nn, IPv4:nn, or asdot ASN:nn
+	extCommunityPattern = regexp.MustCompile(`^(\d+|(\d{1,3}\.){3}\d{1,3}|\d+\.\d+):\d+$`)
 
-	statesWarning = map[string]bool{
-		"init": true, "2way": true, "exstart": true,
-		"exchange": true, "loading": true, "attempt": true,
-		"flapping": true, "pending": true, "waiting": true,
-		"starting": true, "stopping": true,
-	}
-
-	statesNeutral = map[string]bool{
-		"inactive": true, "standby": true, "backup": true,
-		"suspended": true, "n/a": true, "none": true,
-	}
-
-	columnHeaders = map[string]bool{
-		"interface": true, "status": true, "protocol": true,
-		"address": true, "admin": true, "link": true,
-		"speed": true, "type": true, "duplex": true,
-		"neighbor": true, "peer": true, "state": true,
-		"as": true, "inpkt": true, "outpkt": true,
-		"uptime": true, "dead": true, "pri": true,
-		"mtu": true, "metric": true, "local": true,
-		"remote": true, "outq": true, "up/dn": true,
-		"flaps": true, "prefixes": true, "paths": true,
-		"vlan": true, "description": true,
-	}
+	// Words that may sit between a keyword and the argument it introduces
+	vrfSubcommands        = map[string]bool{"definition": true, "forwarding": true, "context": true, "member": true, "select": true}
+	routeTargetDirections = map[string]bool{"import": true, "export": true, "both": true}
 
 	statusSymbols = map[string]bool{
 		"*": true, "+": true, "-": true, ">": true,
@@ -244,11 +169,11 @@ var (
 	}
 
 	// Show output regex patterns
-	timeDurationPattern  = regexp.MustCompile(`^(\d+[wdhms])+$|^\d+:\d{2}(:\d{2})?$`)
+	timeDurationPattern  = regexp.MustCompile(`^(\d+[ywdhms])+$|^\d+:\d{2}(:\d{2})?$`)
 	percentagePattern    = regexp.MustCompile(`^\d+(\.\d+)?%$`)
 	byteSizePattern      = regexp.MustCompile(`^\d+(\.\d+)?[KMGTP][Bb]?$`)
 	routeProtocolPattern = regexp.MustCompile(`^\[(BGP|OSPF|EIGRP|RIP|ISIS|Static|Direct|Local|Connected|Aggregate)/\d+\]$`)
-	tabularPattern       = regexp.MustCompile(`\w+\s{2,}\w+\s{2,}\w+`)
+	tabularPattern       = regexp.MustCompile(`\S+[ \t]{2,}\S+[ \t]{2,}\S+`)
 
 	// Cisco prompt pattern
 	// Matches: Router>, Router#, Router(config)#, Router(config-if)#
@@ -258,40 +183,92 @@ var (
 	// Group 3 = mode string e.g. (config-if) - optional
 	// Group 4 = prompt char (> or #)
 	// Group 5 = command after prompt (optional)
-	promptPattern = regexp.MustCompile(`^([\s\x00-\x1f]*)([\w.-]+)(\([\w-]+\))?([>#])\s*(.*?)\n?$`)
+	promptPattern = regexp.MustCompile(`^([\s\x00-\x1f]*)([\w.-]+)(\([\w-]+\))?([>#])[ \t]*(.*?)\n?$`)
 )
 
 // New creates a new Lexer for the given input.
 func New(input string) *Lexer {
 	return &Lexer{
-		input: input,
-		pos:   0,
-		line:  1,
-		col:   1,
+		input:       input,
+		pos:         0,
+		line:        1,
+		col:         1,
+		dialect:     DialectAuto,
+		autoDialect: true,
+		words:       words.Load(),
 	}
 }
 
 // Tokenize processes the input and returns all tokens.
 func (l *Lexer) Tokenize() []Token {
 	var tokens []Token
+	l.checkpoints = nil
+	l.tokens = nil
+	l.scan(func(token Token) bool {
+		tokens = append(tokens, token)
+		return true
+	}, true)
+	l.tokens = tokens
+	return tokens
+}
+
+// Scan passes the tokens of the input to yield one at a time, stopping early
+// if yield returns false. It tokenizes like Tokenize without building the
+// whole slice, for very large input and streaming pipelines; it records no
+// checkpoints, so Retokenize starts over after it.
+func (l *Lexer) Scan(yield func(Token) bool) {
+	l.checkpoints = nil
+	l.tokens = nil
+	l.scan(yield, false)
+}
 
+// Scan tokenizes input with a new Lexer, passing each token to yield until
+// it returns false.
+func Scan(input string, yield func(Token) bool) {
+	New(input).Scan(yield)
+}
+
+// scan lexes the input, passing each token to yield until it returns false.
+// With checkpoints it records the line checkpoints Retokenize resumes from.
+func (l *Lexer) scan(yield func(Token) bool, checkpoints bool) {
 	// Check if the entire input is a prompt line
-	if promptTokens := l.tryTokenizePrompt(l.input); promptTokens != nil {
-		return promptTokens
+	if promptTokens := l.tryTokenizePrompt(l.input, 1); promptTokens != nil {
+		yieldAll(promptTokens, yield)
+		return
+	}
+
+	if l.parseMode == ParseModeTranscript || (l.parseMode == ParseModeAuto && !l.detectedMode && looksLikeTranscript(l.input)) {
+		yieldAll(l.tokenizeTranscript(), yield)
+		return
 	}
 
+	n := 0
 	for l.pos < len(l.input) {
+		if checkpoints {
+			l.markLine(n)
+		}
 		token := l.nextToken()
 		if token.Type != TokenText || token.Value != "" {
-			tokens = append(tokens, token)
+			if !yield(token) {
+				return
+			}
+			n++
 		}
 	}
+}
 
-	return tokens
+// yieldAll passes tokens to yield until it returns false.
+func yieldAll(tokens []Token, yield func(Token) bool) {
+	for _, token := range tokens {
+		if !yield(token) {
+			return
+		}
+	}
 }
 
-// tryTokenizePrompt checks if input matches a Cisco prompt and returns tokens if so
-func (l *Lexer) tryTokenizePrompt(input string) []Token {
+// tryTokenizePrompt checks if input matches a Cisco prompt and returns tokens if so.
+// line is the line number the prompt is on.
+func (l *Lexer) tryTokenizePrompt(input string, line int) []Token {
 	matches := promptPattern.FindStringSubmatch(input)
 	if matches == nil {
 		return nil
@@ -311,31 +288,33 @@ func (l *Lexer) tryTokenizePrompt(input string) []Token {
 		tokens = append(tokens, Token{
 			Type:   TokenText,
 			Value:  matches[1],
-			Line:   1,
+			Line:   line,
 			Column: col,
 		})
-		col += len(matches[1])
+		col = AdvanceColumn(col, matches[1])
+		line += strings.Count(matches[1], "\n")
 	}
 
 	// Add hostname
+	l.host = matches[2]
 	isConfig := matches[4] == "#"
 	tokens = append(tokens, Token{
 		Type:   TokenPromptHost,
 		Value:  matches[2],
-		Line:   1,
+		Line:   line,
 		Column: col,
 	})
-	col += len(matches[2])
+	col = AdvanceColumn(col, matches[2])
 
 	// Add mode string if present (e.g., "(config-if)")
 	if matches[3] != "" {
 		tokens = append(tokens, Token{
 			Type:   TokenPromptMode,
 			Value:  matches[3],
-			Line:   1,
+			Line:   line,
 			Column: col,
 		})
-		col += len(matches[3])
+		col = AdvanceColumn(col, matches[3])
 	}
 
 	// Add prompt character
@@ -346,27 +325,57 @@ func (l *Lexer) tryTokenizePrompt(input string) []Token {
 	tokens = append(tokens, Token{
 		Type:   promptTokenType,
 		Value:  matches[4],
-		Line:   1,
+		Line:   line,
 		Column: col,
 	})
 	col++
 
-	// Add command after prompt if present
-	if matches[5] != "" {
-		tokens = append(tokens, Token{
-			Type:   TokenText,
-			Value:  " ",
-			Line:   1,
-			Column: col,
-		})
-		col++
+	// Add command after prompt if present, keeping the original spacing
+	afterPrompt := len(matches[1]) + len(matches[2]) + len(matches[3]) + len(matches[4])
+	if rest := strings.TrimSuffix(input[afterPrompt:], "\n"); rest != "" {
+		command := strings.TrimRight(matches[5], " \t\r")
+		spacing := rest[:len(rest)-len(matches[5])]
+		trailing := matches[5][len(command):]
+
+		if spacing != "" {
+			tokens = append(tokens, Token{
+				Type:   TokenText,
+				Value:  spacing,
+				Line:   line,
+				Column: col,
+			})
+			col = AdvanceColumn(col, spacing)
+		}
+
+		if command != "" {
+			l.reportCommand(Command{
+				Host:       matches[2],
+				Mode:       strings.Trim(matches[3], "()"),
+				Privileged: isConfig,
+				Text:       command,
+				Line:       line,
+				Column:     col,
+			})
+
+			cmdLexer := New(command)
+			cmdLexer.explain = l.explain
+			cmdTokens := cmdLexer.Tokenize()
+			for _, tok := range cmdTokens {
+				tok.Line = line
+				tok.Column = col
+				tokens = append(tokens, tok)
+				col = AdvanceColumn(col, tok.Value)
+			}
+		}
 
-		cmdLexer := New(strings.TrimSpace(matches[5]))
-		cmdTokens := cmdLexer.Tokenize()
-		for _, tok := range cmdTokens {
-			tok.Column = col
-			tokens = append(tokens, tok)
-			col += len(tok.Value)
+		if trailing != "" {
+			tokens = append(tokens, Token{
+				Type:   TokenText,
+				Value:  trailing,
+				Line:   line,
+				Column: col,
+			})
+			col = AdvanceColumn(col, trailing)
 		}
 	}
 
@@ -375,50 +384,86 @@ func (l *Lexer) tryTokenizePrompt(input string) []Token {
 		tokens = append(tokens, Token{
 			Type:   TokenText,
 			Value:  "\n",
-			Line:   1,
+			Line:   line,
 			Column: col,
 		})
 	}
 
+	if l.explain {
+		for i := range tokens {
+			if tokens[i].Explain == nil {
+				tokens[i].Explain = &Explanation{Type: tokens[i].Type, Rule: "prompt line"}
+			}
+		}
+	}
 	return tokens
 }
 
 // nextToken extracts the next token from the input
 func (l *Lexer) nextToken() Token {
+	token := l.scanToken()
+	if l.explain && token.Explain == nil && token.Value != "" {
+		l.explainToken(&token)
+	}
+	return token
+}
+
+// scanToken scans the next token from the input.
+func (l *Lexer) scanToken() Token {
 	startLine, startCol := l.line, l.col
 
+	if len(l.queued) > 0 {
+		return l.nextPromptToken()
+	}
 	if l.pos >= len(l.input) {
 		return Token{Type: TokenText, Value: "", Line: startLine, Column: startCol}
 	}
 
 	ch := l.input[l.pos]
 
-	switch {
-	case ch == '!' && l.col == 1:
-		return l.scanComment()
-	case ch == '"':
-		isValue := l.expectingValue
-		l.expectingValue = false
-		token := l.scanString('"')
-		if isValue {
-			token.Type = TokenValue
-		}
+	if l.bannerDelim != "" {
+		l.because("banner text")
+		return l.scanBannerBody()
+	}
+	if l.bannerStage != bannerNone && !isWhitespace(ch) {
+		l.because("banner header")
+		return l.scanBannerHeader()
+	}
+	if token, ok := l.scanPromptLine(); ok {
 		return token
-	case ch == '\'':
-		isValue := l.expectingValue
-		l.expectingValue = false
-		token := l.scanString('\'')
-		if isValue {
-			token.Type = TokenValue
-		}
+	}
+	if token, ok := l.scanPager(); ok {
+		l.because("pager prompt")
 		return token
+	}
+	if token, ok := l.scanHexDump(); ok {
+		l.because("hex dump")
+		return token
+	}
+
+	switch {
+	case ch == '!' && (l.col == 1 || l.parseMode != ParseModeShow && l.prevWord == "" && l.lineCommand == ""):
+		// Sections may hold indented comments: " ! uplinks"
+		l.because("comment line")
+		return l.scanComment()
+	case (ch == '%' || ch == '^') && l.isErrorLine():
+		l.because("CLI error line")
+		return l.scanErrorLine()
 	case isWhitespace(ch):
+		l.because("whitespace")
 		return l.scanWhitespace()
+	case l.expectingValue:
+		// Quotes included: snmp-server location "Main DC" rack 4
+		l.expectingValue = false
+		l.because("rest of line after a value keyword")
+		return l.scanValueToEndOfLine()
+	case ch == '"', ch == '\'':
+		l.because("quoted string")
+		return l.scanString(ch)
+	case l.inRange && (ch == ',' || ch == '-'):
+		l.because("interface range separator")
+		return l.scanRangeOperator()
 	default:
-		if l.expectingValue {
-			l.expectingValue = false
-			return l.scanValueToEndOfLine()
-		}
 		return l.scanWord()
 	}
 }
@@ -440,6 +485,43 @@ func (l *Lexer) scanComment() Token {
 	}
 }
 
+// isErrorLine reports whether the rest of the line, from the current
+// position, is a CLI error message ("% Incomplete command.") or the "^" marker
+// the CLI prints under the offending word. Either must be the first thing on
+// the line; syslog messages such as "%LINK-3-UPDOWN:" have no space after
+// the percent sign and are not errors.
+func (l *Lexer) isErrorLine() bool {
+	if strings.TrimSpace(l.input[l.lineStart:l.pos]) != "" {
+		return false
+	}
+	rest := l.input[l.pos:]
+	if end := strings.IndexByte(rest, '\n'); end >= 0 {
+		rest = rest[:end]
+	}
+	rest = strings.TrimRight(rest, " \t\r")
+	if rest[0] == '^' {
+		return rest == "^"
+	}
+	return len(rest) > 2 && rest[1] == ' '
+}
+
+// scanErrorLine scans a CLI error message or marker to the end of the line.
+func (l *Lexer) scanErrorLine() Token {
+	startLine, startCol := l.line, l.col
+	start := l.pos
+
+	for l.pos < len(l.input) && l.input[l.pos] != '\n' && l.input[l.pos] != '\r' {
+		l.advance()
+	}
+
+	return Token{
+		Type:   TokenError,
+		Value:  l.input[start:l.pos],
+		Line:   startLine,
+		Column: startCol,
+	}
+}
+
 // scanString scans a quoted string
 func (l *Lexer) scanString(quote byte) Token {
 	startLine, startCol := l.line, l.col
@@ -453,7 +535,11 @@ func (l *Lexer) scanString(quote byte) Token {
 			l.advance() // closing quote
 			break
 		}
-		if ch == '\\' && l.pos+1 < len(l.input) {
+		if ch == '\n' {
+			// An unterminated quote ends with its line
+			break
+		}
+		if ch == '\\' && l.pos+1 < len(l.input) && l.input[l.pos+1] != '\n' {
 			l.advance() // escape char
 		}
 		l.advance()
@@ -488,12 +574,93 @@ func (l *Lexer) scanValueToEndOfLine() Token {
 	}
 }
 
+// scanBannerHeader scans the optional banner type and the opening delimiter
+// of a "banner motd ^C" header. The delimiter is "^C" when written that way
+// in the config, otherwise the first character after the type.
+func (l *Lexer) scanBannerHeader() Token {
+	if l.bannerStage == bannerExpectType {
+		l.bannerStage = bannerExpectDelim
+		if bannerTypes[strings.ToLower(l.peekWord())] {
+			return l.scanWord()
+		}
+	}
+
+	startLine, startCol := l.line, l.col
+	start := l.pos
+	// The delimiter is a whole character, which may take several bytes
+	for end := start + runeLen(l.input[start:]); l.pos < end; {
+		l.advance()
+	}
+	if l.input[start] == '^' && l.pos < len(l.input) && l.input[l.pos] == 'C' {
+		l.advance()
+	}
+
+	l.bannerStage = bannerNone
+	l.bannerDelim = l.input[start:l.pos]
+
+	return Token{
+		Type:   TokenOperator,
+		Value:  l.bannerDelim,
+		Line:   startLine,
+		Column: startCol,
+	}
+}
+
+// scanBannerBody scans banner text up to the closing delimiter as a single
+// TokenValue (which may span lines), then the delimiter itself.
+func (l *Lexer) scanBannerBody() Token {
+	startLine, startCol := l.line, l.col
+	start := l.pos
+	delim := l.bannerDelim
+
+	if strings.HasPrefix(l.input[l.pos:], delim) {
+		for i := 0; i < len(delim); i++ {
+			l.advance()
+		}
+		l.bannerDelim = ""
+		return Token{
+			Type:   TokenOperator,
+			Value:  delim,
+			Line:   startLine,
+			Column: startCol,
+		}
+	}
+
+	end := len(l.input)
+	if idx := strings.Index(l.input[l.pos:], delim); idx >= 0 {
+		end = l.pos + idx
+	}
+	for l.pos < end {
+		l.advance()
+	}
+
+	return Token{
+		Type:   TokenValue,
+		Value:  l.input[start:l.pos],
+		Line:   startLine,
+		Column: startCol,
+	}
+}
+
 // scanWhitespace scans whitespace characters
 func (l *Lexer) scanWhitespace() Token {
 	startLine, startCol := l.line, l.col
 	start := l.pos
 
 	for l.pos < len(l.input) && isWhitespace(l.input[l.pos]) {
+		if l.input[l.pos] == '\n' {
+			l.prevWord = ""
+			l.pendingArg = ""
+			l.expectingValue = false
+			l.bannerStage = bannerNone
+			l.lineCommand = ""
+			l.lineAction = false
+			l.prevAddr = false
+			l.inRange = false
+			l.headerLine = false
+			l.type7Checked = false
+			l.asnList = false
+		}
 		l.advance()
 	}
 
@@ -510,16 +677,87 @@ func (l *Lexer) scanWord() Token {
 	startLine, startCol := l.line, l.col
 	start := l.pos
 
+	// The slash between the two CPU loads of "95%/2%" stands alone
+	if l.input[start] == '/' && start > 0 && l.input[start-1] == '%' {
+		l.advance()
+		l.because("punctuation")
+		return Token{Type: TokenText, Value: "/", Line: startLine, Column: startCol}
+	}
+
 	for l.pos < len(l.input) {
 		ch := l.input[l.pos]
-		if isWhitespace(ch) || ch == '"' || ch == '\'' {
+		// An apostrophe within a word, as in "Partner's information", does
+		// not start a string
+		if ch == '\'' && l.pos > start && l.pos+1 < len(l.input) &&
+			isAlphaNum(rune(l.input[l.pos-1])) && isAlphaNum(rune(l.input[l.pos+1])) {
+			l.advance()
+			continue
+		}
+		if isWhitespace(ch) || ch == '"' || ch == '\'' || ch == '\b' {
+			break
+		}
+		if l.inRange && l.pos > start && isRangeBoundary(l.input[l.pos-1], ch) {
+			break
+		}
+		if ch == '/' && l.pos > start && l.input[l.pos-1] == '%' {
 			break
 		}
 		l.advance()
 	}
 
 	word := l.input[start:l.pos]
-	tokenType := l.classifyWord(word)
+
+	// Split punctuation off addresses and rates, as in "via 10.0.0.1," or
+	// "(2001:db8::1)"; it is left uncolored and does not count as a word
+	if lead, trail, ok := valuePunctuation(word); ok {
+		if lead > 0 {
+			word = word[:lead]
+		} else {
+			word = word[:len(word)-trail]
+		}
+		l.pos, l.col = start+len(word), AdvanceColumn(startCol, word)
+	}
+	// Older releases print a BGP route's status codes against its network:
+	// "*>i10.2.2.0/24"
+	if l.profile == bgpTableProfile && l.prevWord == "" {
+		if n := bgpStatusLength(word); n > 0 {
+			word = word[:n]
+			l.pos, l.col = start+n, AdvanceColumn(startCol, word)
+		}
+	}
+	if isPunctuation(word) {
+		l.because("punctuation")
+		return Token{Type: TokenText, Value: word, Line: startLine, Column: startCol}
+	}
+
+	// A number and its unit make one token: "1000 bits/sec"
+	tokenType := TokenRate
+	if l.scanRateUnit(word) {
+		word = l.input[start:l.pos]
+		l.because("number followed by a rate unit")
+	} else {
+		tokenType = l.classifyWord(word)
+		if l.learnNames {
+			tokenType = l.classifyName(word, start, tokenType)
+		}
+	}
+	lower := strings.ToLower(word)
+
+	// Line context for mask classification
+	if l.lineCommand == "" && lower != "no" {
+		l.lineCommand = lower
+	}
+	if lower == "permit" || lower == "deny" {
+		l.lineAction = true
+	}
+	if lower == "peers" && l.prevWord == "confederation" || lower == "prepend" && l.prevWord == "as-path" {
+		l.asnList = true
+	}
+	l.prevAddr = tokenType == TokenIPv4 && l.prevWord != "host"
+	l.prevWord = lower
+	if lower == "range" && l.lineCommand == "interface" {
+		l.inRange = true
+	}
 
 	return Token{
 		Type:   tokenType,
@@ -535,14 +773,77 @@ func (l *Lexer) classifyWord(word string) TokenType {
 		l.parseMode = l.detectParseMode()
 		l.detectedMode = true
 	}
+	l.resolveDialect()
 
 	lower := strings.ToLower(word)
 
+	if l.markHost && l.host != "" && strings.EqualFold(word, l.host) {
+		l.because("hostname of the last prompt")
+		return TokenPromptHost
+	}
+
+	// Every word on a column header line, whatever it is
+	if l.parseMode == ParseModeShow {
+		if l.prevWord == "" {
+			l.headerLine = l.isHeaderLine()
+		}
+		if l.headerLine {
+			l.because("column header line")
+			return TokenColumnHeader
+		}
+	}
+
+	if t, ok := l.classifyDialectWord(word, lower); ok {
+		return t
+	}
+
 	if l.parseMode == ParseModeShow {
 		return l.classifyShowWord(word, lower)
 	}
 
-	return l.classifyConfigWord(word, lower)
+	t := l.classifyConfigWord(word, lower)
+	if l.bannerStage == bannerNone && l.endsValueKeyword(lower) {
+		l.expectingValue = true
+	}
+	return t
+}
+
+// endsValueKeyword reports whether the word being classified ends a value
+// keyword (see WordsValueKeywords), with the words before it on the line for
+// keywords of several words such as "snmp-server location".
+func (l *Lexer) endsValueKeyword(lower string) bool {
+	if l.words.valueKeywords[lower] {
+		return true
+	}
+	n := l.words.valueKeywordEnds[lower]
+	if l.prevWord == "" || n == 0 {
+		return false
+	}
+	fields := lastFields(l.input[l.lineStart:l.pos], n)
+	for i := len(fields) - 2; i >= 0; i-- {
+		if l.words.valueKeywords[strings.ToLower(strings.Join(fields[i:], " "))] {
+			return true
+		}
+	}
+	return false
+}
+
+// lastFields returns the last n words of s, or all of them if s has fewer,
+// without splitting the rest of s.
+func lastFields(s string, n int) []string {
+	fields := make([]string, n)
+	i := n
+	for i > 0 {
+		s = strings.TrimRight(s, " \t\r")
+		if s == "" {
+			break
+		}
+		start := strings.LastIndexAny(s, " \t") + 1
+		i--
+		fields[i] = s[start:]
+		s = s[:start]
+	}
+	return fields[i:]
 }
 
 // classifyConfigWord handles Cisco configuration syntax classification
@@ -550,99 +851,153 @@ func (l *Lexer) classifyConfigWord(word, lower string) TokenType {
 	// Check for "no" prefix (negation)
 	if lower == "no" {
 		l.lastToken = lower
+		l.because("negation")
 		return TokenNegation
 	}
 
-	// Check for AS number format (AS65000, as65001)
-	if asnPattern.MatchString(word) {
-		return TokenASN
+	if l.inRange {
+		if t, ok := classifyRangeMember(word); ok {
+			l.because("interface range member")
+			return t
+		}
 	}
 
-	// Check keyword maps
-	if commands[lower] {
-		l.lastToken = lower
-		return TokenCommand
+	// Type 7 passwords: "password 7 0822455D0A16", "key-string 7 104D000A0618"
+	if l.prevWord == "7" && type7Pattern.MatchString(word) && l.isType7Line() {
+		l.because("type 7 password")
+		return TokenSecret
 	}
-	if sections[lower] {
-		l.lastToken = lower
-		return TokenSection
+
+	if t, ok := l.classifyMPLSConfig(word); ok {
+		l.because("mpls label range")
+		return t
 	}
-	if protocols[lower] {
-		l.lastToken = lower
-		return TokenProtocol
+	if t, ok := l.classifyRateConfig(word); ok {
+		l.because("argument of a rate keyword")
+		return t
 	}
-	if actions[lower] {
-		// Set flag for remark (consumes rest of line)
-		if valueKeywords[lower] {
-			l.expectingValue = true
-		}
-		l.lastToken = lower
-		return TokenAction
+
+	// AS numbers: AS65000, "router bgp 65000.100", "remote-as 4200000001"
+	if t, ok := l.classifyASN(word, lower); ok {
+		l.because("AS number")
+		return t
 	}
-	if operators[lower] {
-		l.lastToken = lower
-		return TokenOperator
+
+	// Arguments of vrf, rd and route-target
+	if t, ok := l.classifyPendingArg(word, lower); ok {
+		l.because("argument of vrf, rd or route-target")
+		return t
 	}
-	if keywords[lower] {
-		if valueKeywords[lower] {
-			l.expectingValue = true
+
+	// Keyword lists, in one lookup (see vocabulary.configWords)
+	if t, ok := l.words.configWords[lower]; ok {
+		switch {
+		case t == TokenCommand && lower == "banner":
+			l.bannerStage = bannerExpectType
+		case t == TokenKeyword && (lower == "vrf" || lower == "rd" || lower == "route-target"):
+			l.pendingArg = lower
 		}
 		l.lastToken = lower
-		return TokenKeyword
+		l.because(configWordRule(t))
+		return t
 	}
 
 	return l.classifySharedPatterns(word)
 }
 
-// classifyShowWord handles show command output classification
-func (l *Lexer) classifyShowWord(word, lower string) TokenType {
-	// Compound states
-	for _, s := range statesGoodCompound {
-		if lower == s {
-			return TokenStateGood
+// classifyPendingArg classifies the argument of a preceding vrf, rd or
+// route-target keyword on the same line.
+func (l *Lexer) classifyPendingArg(word, lower string) (TokenType, bool) {
+	switch l.pendingArg {
+	case "vrf":
+		if vrfSubcommands[lower] {
+			return TokenText, false
+		}
+		l.pendingArg = ""
+		return TokenVRF, true
+	case "rd":
+		l.pendingArg = ""
+		if extCommunityPattern.MatchString(word) {
+			return TokenRouteDistinguisher, true
+		}
+	case "route-target":
+		if routeTargetDirections[lower] {
+			return TokenText, false
+		}
+		l.pendingArg = ""
+		if extCommunityPattern.MatchString(word) {
+			return TokenRouteTarget, true
 		}
 	}
-	for _, s := range statesBadCompound {
-		if lower == s {
-			return TokenStateBad
+	return TokenText, false
+}
+
+// classifyShowWord handles show command output classification
+func (l *Lexer) classifyShowWord(word, lower string) TokenType {
+	if !l.detectedProfile {
+		l.profile = detectShowProfile(l.sample(), l.dialect.ShowProfiles())
+		l.detectedProfile = true
+	}
+	// Words added with SetStateWords take precedence over profiles
+	if t := l.stateWords[lower]; t != TokenText {
+		l.because("state words set on the lexer")
+		return t
+	}
+	if l.profile != nil {
+		if t, ok := l.profile.Classify(l, word, lower); ok {
+			if l.explain {
+				l.rule = "show profile " + l.profile.Name
+			}
+			return t
 		}
 	}
 
 	// State classification
-	if statesGood[lower] {
-		return TokenStateGood
-	}
-	if statesBad[lower] {
-		return TokenStateBad
-	}
-	if statesWarning[lower] {
-		return TokenStateWarning
-	}
-	if statesNeutral[lower] {
-		return TokenStateNeutral
+	if t, ok := l.stateType(lower); ok {
+		l.because("state word lists")
+		return t
 	}
 
 	// Status symbols
 	if len(word) <= 2 && statusSymbols[word] {
+		l.because("status symbol")
 		return TokenStatusSymbol
 	}
 
-	// Show-specific patterns
-	if timeDurationPattern.MatchString(word) {
-		return TokenTimeDuration
+	if t, ok := l.classifyASN(word, lower); ok {
+		l.because("AS number")
+		return t
 	}
-	if percentagePattern.MatchString(word) {
-		return TokenPercentage
+
+	// Communities before durations, which share the NN:NN form
+	if t, ok := l.classifyCommunity(word, lower); ok {
+		l.because("community")
+		return t
 	}
-	if byteSizePattern.MatchString(word) {
-		return TokenByteSize
+
+	// Show-specific patterns, all of which start with a digit but the last
+	if startsWithDigit(word) {
+		if timeDurationPattern.MatchString(word) {
+			l.because("duration pattern")
+			return TokenTimeDuration
+		}
+		if percentagePattern.MatchString(word) {
+			l.because("percentage pattern")
+			return TokenPercentage
+		}
+		if byteSizePattern.MatchString(word) {
+			l.because("byte size pattern")
+			return TokenByteSize
+		}
 	}
-	if routeProtocolPattern.MatchString(word) {
+	if strings.HasPrefix(word, "[") && routeProtocolPattern.MatchString(word) {
+		l.because("route protocol pattern")
 		return TokenRouteProtocol
 	}
 
 	// Column headers
-	if columnHeaders[lower] {
+	if l.words.columnHeaders[lower] {
+		l.because("column headers word list")
 		return TokenColumnHeader
 	}
 
@@ -652,61 +1007,198 @@ func (l *Lexer) classifyShowWord(word, lower string) TokenType {
 // classifySharedPatterns handles patterns common to both config and show modes
 func (l *Lexer) classifySharedPatterns(word string) TokenType {
 	// Cisco interface names
-	if interfacePattern.MatchString(word) {
+	if startsWithLetter(word) && interfacePattern.MatchString(word) {
+		l.because("interface pattern")
 		return TokenInterface
 	}
 
 	// IP patterns - more specific first
-	if ipv4PrefixPattern.MatchString(word) {
+	digit := startsWithDigit(word)
+	if digit && ipv4PrefixPattern.MatchString(word) {
+		l.because("IPv4 prefix pattern")
 		return TokenIPv4Prefix
 	}
-	if ipv4Pattern.MatchString(word) {
-		return TokenIPv4
+	if digit && ipv4Pattern.MatchString(word) {
+		l.because("IPv4 pattern and the words before it")
+		return l.classifyIPv4(word)
 	}
 
 	// MAC addresses (Cisco dotted and colon format)
-	if macPatternCisco.MatchString(word) {
+	if len(word) == len("0011.2233.4455") && macPatternCisco.MatchString(word) {
+		l.because("MAC address pattern")
 		return TokenMAC
 	}
-	if macPatternColon.MatchString(word) {
+	if len(word) == len("00:11:22:33:44:55") && macPatternColon.MatchString(word) {
+		l.because("MAC address pattern")
 		return TokenMAC
 	}
 
-	// BGP community - only after "community" keyword to avoid false positives (e.g., "12:00")
-	if l.lastToken == "community" && communityPattern.MatchString(word) {
-		return TokenCommunity
+	// BGP communities
+	if t, ok := l.classifyCommunity(word, strings.ToLower(word)); ok {
+		l.because("community")
+		return t
 	}
 
 	// IPv6 patterns
-	if ipv6PrefixPattern.MatchString(word) {
+	if isIPv6Prefix(word) {
+		l.because("IPv6 prefix pattern")
 		return TokenIPv6Prefix
 	}
-	if ipv6Pattern.MatchString(word) {
+	if isIPv6(word) {
+		l.because("IPv6 pattern")
 		return TokenIPv6
 	}
 
+	if digit && ratePattern.MatchString(word) {
+		l.because("rate pattern")
+		return TokenRate
+	}
+
+	if strings.HasPrefix(word, "0x") && hexNumberPattern.MatchString(word) {
+		l.because("hex pattern")
+		return TokenHex
+	}
+
 	// Numbers
 	if isAllDigits(word) {
+		l.because("number")
 		return TokenNumber
 	}
 
+	l.because("no word list or pattern matched")
 	return TokenIdentifier
 }
 
+// classifyIPv4 tells masks apart from addresses using the line context: a
+// dotted quad following an address is a wildcard mask in ACL entries and
+// OSPF/EIGRP network statements, and a subnet mask elsewhere.
+func (l *Lexer) classifyIPv4(word string) TokenType {
+	switch l.prevWord {
+	case "mask", "netmask", "subnet-mask":
+		return TokenSubnetMask
+	case "wildcard", "bits":
+		return TokenWildcardMask
+	}
+	if !l.prevAddr {
+		return TokenIPv4
+	}
+
+	switch {
+	case l.lineAction:
+		return TokenWildcardMask
+	case l.lineCommand == "network":
+		// OSPF/EIGRP use wildcards, DHCP pools use subnet masks
+		if IsSubnetMask(word) && !strings.HasPrefix(word, "0.") {
+			return TokenSubnetMask
+		}
+		return TokenWildcardMask
+	case IsSubnetMask(word):
+		return TokenSubnetMask
+	}
+	return TokenIPv4
+}
+
+// IsSubnetMask reports whether s is a dotted-quad subnet mask with contiguous
+// one bits, such as 255.255.255.0 or 0.0.0.0.
+func IsSubnetMask(s string) bool {
+	v, ok := parseDottedQuad(s)
+	inv := ^v
+	return ok && inv&(inv+1) == 0
+}
+
+// IsWildcardMask reports whether s is a dotted-quad wildcard mask with
+// contiguous low one bits, such as 0.0.0.255. ACLs also accept discontiguous
+// wildcards, which this reports as false.
+func IsWildcardMask(s string) bool {
+	v, ok := parseDottedQuad(s)
+	return ok && v&(v+1) == 0
+}
+
+// parseDottedQuad parses an IPv4 dotted quad into a 32-bit value.
+func parseDottedQuad(s string) (uint32, bool) {
+	var v uint32
+	parts := strings.Split(s, ".")
+	if len(parts) != 4 {
+		return 0, false
+	}
+	for _, p := range parts {
+		if !isAllDigits(p) || len(p) > 3 {
+			return 0, false
+		}
+		n := 0
+		for i := 0; i < len(p); i++ {
+			n = n*10 + int(p[i]-'0')
+		}
+		if n > 255 {
+			return 0, false
+		}
+		v = v<<8 | uint32(n)
+	}
+	return v, true
+}
+
 // Helper methods
 
 func (l *Lexer) advance() {
 	if l.pos < len(l.input) {
-		if l.input[l.pos] == '\n' {
+		ch := l.input[l.pos]
+		switch {
+		case ch == '\n':
 			l.line++
 			l.col = 1
-		} else {
-			l.col++
+			l.lineStart = l.pos + 1
+		case ch < utf8.RuneSelf:
+			l.col = nextColumn(l.col, ch)
+		default:
+			l.col += byteWidth(l.input, l.pos)
 		}
 		l.pos++
 	}
 }
 
+// peekWord returns the next word on the current line without consuming input.
+func (l *Lexer) peekWord() string {
+	i := l.pos
+	for i < len(l.input) && (l.input[i] == ' ' || l.input[i] == '\t') {
+		i++
+	}
+	start := i
+	for i < len(l.input) && !isWhitespace(l.input[i]) {
+		i++
+	}
+	return l.input[start:i]
+}
+
+// lineText returns the current input line in lowercase, for context checks.
+func (l *Lexer) lineText() string {
+	start := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	end := strings.IndexByte(l.input[l.pos:], '\n')
+	if end < 0 {
+		end = len(l.input)
+	} else {
+		end += l.pos
+	}
+	return strings.ToLower(l.input[start:end])
+}
+
+// isType7Line reports whether the current line has a keyword after which
+// "7" introduces a type 7 password. The line is only matched once.
+func (l *Lexer) isType7Line() bool {
+	if !l.type7Checked {
+		l.type7Line = type7Context.MatchString(l.lineText())
+		l.type7Checked = true
+	}
+	return l.type7Line
+}
+
+// sample returns the leading portion of the input used for detection heuristics.
+func (l *Lexer) sample() string {
+	if len(l.input) > parseModeDetectionSampleSize {
+		return l.input[:parseModeDetectionSampleSize]
+	}
+	return l.input
+}
+
 func isWhitespace(ch byte) bool {
 	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
 }
@@ -724,6 +1216,19 @@ func isAllDigits(s string) bool {
 	return true
 }
 
+// startsWithDigit reports whether s starts with an ASCII digit, as addresses,
+// rates, durations and percentages do. It guards the patterns for them, so
+// words that cannot match are not run through the regexp engine.
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// startsWithLetter reports whether s starts with an ASCII letter, as
+// interface names do.
+func startsWithLetter(s string) bool {
+	return s != "" && (s[0]|0x20) >= 'a' && (s[0]|0x20) <= 'z'
+}
+
 // ConfigIndicators contains keywords/patterns that suggest Cisco configuration input.
 var ConfigIndicators = []string{
 	"hostname ", "interface ", "router ", "ip address ",
@@ -732,6 +1237,7 @@ var ConfigIndicators = []string{
 	"ip route ", "snmp-server ", "logging ", "ntp ",
 	"crypto ", "aaa ", "spanning-tree ", "vlan ",
 	"banner ", "ip access-list ",
+	"frr version ", "frr defaults ",
 }
 
 // ShowIndicators contains keywords/patterns that suggest show command output.
@@ -742,14 +1248,27 @@ var ShowIndicators = []string{
 	"show ", "last input", "last output",
 	"5 minute", "input rate", "output rate",
 	"show version", "cisco ios",
+	"spanning tree enabled protocol",
+	"uptime is", "configuration register is", "processor board id",
+	"hardware addr", "age (min)",
+	"% invalid input", "% incomplete command", "% ambiguous command",
+	"p indicates configured to preempt", "virtual ip address is",
+	"active router", "standby router", "fwd pri", "master addr",
+	"gateway of last resort", "routing table", "is directly connected",
+	"bgp router identifier", "state/pfxrcd", "dead time",
+	"flaps duration", "dampinfo", "bgp table version", "origin codes:", "locprf",
+	"conn-id status", "#pkts encaps", "current_peer", "inbound esp sas",
+	"crypto session current status", "session status:",
+	"redundant system information", "redundancy mode", "current software state",
+	"my state =", "switch/stack mac address", "mac persistency wait time",
+	"securityviolation", "violation mode", "security violation count",
+	"secure mac address table", "dhcp-snooping", "errdisable reason",
+	"interfaces that will be enabled",
 }
 
 // detectParseMode analyzes input to determine if it's config or show output.
 func (l *Lexer) detectParseMode() ParseMode {
-	sample := l.input
-	if len(sample) > parseModeDetectionSampleSize {
-		sample = sample[:parseModeDetectionSampleSize]
-	}
+	sample := l.sample()
 	lower := strings.ToLower(sample)
 
 	// Config indicators
@@ -783,6 +1302,11 @@ func (l *Lexer) detectParseMode() ParseMode {
 	return ParseModeConfig
 }
 
+// DetectParseMode reports whether input looks like configuration or show output.
+func DetectParseMode(input string) ParseMode {
+	return New(input).detectParseMode()
+}
+
 // IsPrompt checks if the input matches a Cisco CLI prompt pattern.
 func IsPrompt(input string) bool {
 	return promptPattern.MatchString(strings.TrimSpace(input))
@@ -792,6 +1316,7 @@ func IsPrompt(input string) bool {
 func (l *Lexer) SetParseMode(mode ParseMode) {
 	l.parseMode = mode
 	l.detectedMode = true
+	l.explicitMode = true
 }
 
 // GetParseMode returns the current parse mode
@@ -1,6 +1,8 @@
 package lexer
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -281,6 +283,12 @@ func TestTokenizeIPv6(t *testing.T) {
 		{"2001:db8::1", TokenIPv6},
 		{"::1", TokenIPv6},
 		{"fe80::1", TokenIPv6},
+		{"fe80::1%Vlan100", TokenIPv6},
+		{"::ffff:10.0.0.1", TokenIPv6},
+		{"FE80::A8BB:CCFF:FE00:200", TokenIPv6},
+		{"2001:DB8:0:0:8:800:200C:417A", TokenIPv6},
+		{"12:34:56", TokenIdentifier},
+		{"2001:db8::1::2", TokenIdentifier},
 	}
 
 	for _, tt := range tests {
@@ -305,6 +313,9 @@ func TestTokenizeIPv6Prefix(t *testing.T) {
 		{"2001:db8::/32", TokenIPv6Prefix},
 		{"::/0", TokenIPv6Prefix},
 		{"fe80::/10", TokenIPv6Prefix},
+		{"::ffff:10.0.0.0/104", TokenIPv6Prefix},
+		{"2001:DB8::/48", TokenIPv6Prefix},
+		{"2001:db8::/129", TokenIdentifier},
 	}
 
 	for _, tt := range tests {
@@ -321,6 +332,50 @@ func TestTokenizeIPv6Prefix(t *testing.T) {
 	}
 }
 
+func TestAddressPunctuation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []Token
+	}{
+		{"via 10.0.0.1, 00:00:12", []Token{
+			{Type: TokenIPv4, Value: "10.0.0.1"},
+			{Type: TokenText, Value: ","},
+		}},
+		{"via FE80::A8BB:CCFF:FE00:200, Gi0/0", []Token{
+			{Type: TokenIPv6, Value: "FE80::A8BB:CCFF:FE00:200"},
+			{Type: TokenText, Value: ","},
+		}},
+		{"Neighbor (2001:DB8::1), up", []Token{
+			{Type: TokenText, Value: "("},
+			{Type: TokenIPv6, Value: "2001:DB8::1"},
+			{Type: TokenText, Value: "),"},
+		}},
+		{"next hop fe80::1%Vlan100.", []Token{
+			{Type: TokenIPv6, Value: "fe80::1%Vlan100"},
+			{Type: TokenText, Value: "."},
+		}},
+		{"prefix [2001:db8::/32]", []Token{
+			{Type: TokenText, Value: "["},
+			{Type: TokenIPv6Prefix, Value: "2001:db8::/32"},
+			{Type: TokenText, Value: "]"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var got []Token
+			for _, tok := range New(tt.input).Tokenize() {
+				if tok.Type == TokenIPv4 || tok.Type == TokenIPv6 || tok.Type == TokenIPv6Prefix || isPunctuation(tok.Value) {
+					got = append(got, Token{Type: tok.Type, Value: tok.Value})
+				}
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestTokenizeCiscoMAC(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -376,6 +431,21 @@ func TestTokenizeCommentWithText(t *testing.T) {
 	}
 }
 
+func TestTokenizeIndentedComment(t *testing.T) {
+	input := "interface Gi0/1\n ! uplinks\n shutdown ! not a comment\n"
+	l := New(input)
+	l.SetParseMode(ParseModeConfig)
+	var comments []string
+	for _, tok := range l.Tokenize() {
+		if tok.Type == TokenComment {
+			comments = append(comments, tok.Value)
+		}
+	}
+	if len(comments) != 1 || comments[0] != "! uplinks" {
+		t.Errorf("expected one comment \"! uplinks\", got %q", comments)
+	}
+}
+
 func TestTokenizeStrings(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -403,6 +473,18 @@ func TestTokenizeStrings(t *testing.T) {
 	}
 }
 
+func TestTokenizeUnterminatedString(t *testing.T) {
+	tokens := New("snmp-server community \"pub lic\n shutdown\n").Tokenize()
+	for _, tok := range tokens {
+		if tok.Type == TokenString && tok.Value != `"pub lic` {
+			t.Errorf("unterminated string should end with its line, got %q", tok.Value)
+		}
+		if tok.Value == "shutdown" && tok.Line != 2 {
+			t.Errorf("shutdown on line %d, want 2", tok.Line)
+		}
+	}
+}
+
 func TestTokenizeNumbers(t *testing.T) {
 	tests := []struct {
 		input string
@@ -429,8 +511,8 @@ func TestTokenizeNumbers(t *testing.T) {
 
 func TestTokenizeCommunity(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    string
+		name      string
+		input     string
 		community string
 	}{
 		{"basic", "community 65000:100", "65000:100"},
@@ -489,9 +571,12 @@ func TestTokenizeASN(t *testing.T) {
 		input    string
 		expected TokenType
 	}{
-		{"AS65000", TokenASN},
+		{"AS3356", TokenASN},
 		{"AS1", TokenASN},
-		{"as65001", TokenASN},
+		{"as174", TokenASN},
+		{"AS65000", TokenPrivateASN},
+		{"as65001", TokenPrivateASN},
+		{"AS1.10", TokenPrivateASN},
 	}
 
 	for _, tt := range tests {
@@ -524,7 +609,7 @@ func TestTokenizeFullLine(t *testing.T) {
 		{TokenText, " "},
 		{TokenIPv4, "192.168.1.1"},
 		{TokenText, " "},
-		{TokenIPv4, "255.255.255.0"},
+		{TokenSubnetMask, "255.255.255.0"},
 	}
 
 	if len(tokens) != len(expected) {
@@ -586,6 +671,121 @@ func TestTokenizeAccessList(t *testing.T) {
 	}
 }
 
+func TestMaskClassification(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		masks map[string]TokenType
+	}{
+		{
+			"interface address",
+			"ip address 10.0.0.1 255.255.255.0 secondary",
+			map[string]TokenType{"10.0.0.1": TokenIPv4, "255.255.255.0": TokenSubnetMask},
+		},
+		{
+			"static route next hop is not a mask",
+			"ip route 10.0.0.0 255.0.0.0 192.0.2.1",
+			map[string]TokenType{"255.0.0.0": TokenSubnetMask, "192.0.2.1": TokenIPv4},
+		},
+		{
+			"default route",
+			"ip route 0.0.0.0 0.0.0.0 192.0.2.1",
+			map[string]TokenType{"0.0.0.0": TokenIPv4, "192.0.2.1": TokenIPv4},
+		},
+		{
+			"extended acl",
+			"permit ip 10.0.0.0 0.0.0.255 192.168.0.0 0.0.255.255",
+			map[string]TokenType{"10.0.0.0": TokenIPv4, "0.0.0.255": TokenWildcardMask, "192.168.0.0": TokenIPv4, "0.0.255.255": TokenWildcardMask},
+		},
+		{
+			"acl host is not followed by a mask",
+			"access-list 101 permit ip host 10.0.0.1 172.16.0.0 0.15.255.255",
+			map[string]TokenType{"10.0.0.1": TokenIPv4, "172.16.0.0": TokenIPv4, "0.15.255.255": TokenWildcardMask},
+		},
+		{
+			"discontiguous wildcard",
+			"deny 10.0.0.0 0.255.0.255",
+			map[string]TokenType{"0.255.0.255": TokenWildcardMask},
+		},
+		{
+			"ospf network",
+			"network 10.1.0.0 0.0.255.255 area 0",
+			map[string]TokenType{"10.1.0.0": TokenIPv4, "0.0.255.255": TokenWildcardMask},
+		},
+		{
+			"bgp network mask",
+			"network 10.1.0.0 mask 255.255.0.0",
+			map[string]TokenType{"255.255.0.0": TokenSubnetMask},
+		},
+		{
+			"dhcp pool network",
+			"network 10.1.0.0 255.255.0.0",
+			map[string]TokenType{"255.255.0.0": TokenSubnetMask},
+		},
+		{
+			"nat pool netmask",
+			"ip nat pool P 192.0.2.1 192.0.2.10 netmask 255.255.255.0",
+			map[string]TokenType{"192.0.2.10": TokenIPv4, "255.255.255.0": TokenSubnetMask},
+		},
+		{
+			"static nat pair",
+			"ip nat inside source static 10.0.0.1 192.0.2.1",
+			map[string]TokenType{"192.0.2.1": TokenIPv4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			l.SetParseMode(ParseModeConfig)
+			seen := map[string]bool{}
+			for _, tok := range l.Tokenize() {
+				want, ok := tt.masks[tok.Value]
+				if !ok || seen[tok.Value] {
+					continue
+				}
+				seen[tok.Value] = true
+				if tok.Type != want {
+					t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestShowAccessListWildcardBits(t *testing.T) {
+	l := New("    10 permit 10.0.0.0, wildcard bits 0.255.255.255 (5 matches)\n")
+	l.SetParseMode(ParseModeShow)
+	for _, tok := range l.Tokenize() {
+		if tok.Value == "0.255.255.255" && tok.Type != TokenWildcardMask {
+			t.Errorf("expected TokenWildcardMask after 'wildcard bits', got %v", tok.Type)
+		}
+	}
+}
+
+func TestMaskValidation(t *testing.T) {
+	tests := []struct {
+		mask             string
+		subnet, wildcard bool
+	}{
+		{"255.255.255.0", true, false},
+		{"255.255.255.255", true, true},
+		{"0.0.0.0", true, true},
+		{"0.0.0.255", false, true},
+		{"255.0.255.0", false, false},
+		{"255.255.255.256", false, false},
+		{"10.0.0", false, false},
+	}
+	for _, tt := range tests {
+		if got := IsSubnetMask(tt.mask); got != tt.subnet {
+			t.Errorf("IsSubnetMask(%q) = %v, want %v", tt.mask, got, tt.subnet)
+		}
+		if got := IsWildcardMask(tt.mask); got != tt.wildcard {
+			t.Errorf("IsWildcardMask(%q) = %v, want %v", tt.mask, got, tt.wildcard)
+		}
+	}
+}
+
 func TestTokenizeKeywords(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -835,6 +1035,24 @@ func TestParseModeDetection(t *testing.T) {
 			input:    "GigabitEthernet0/0/0 is up, line protocol is up\n  Internet address is 203.0.113.1/24\n  5 minute input rate 1000 bits/sec",
 			expected: ParseModeShow,
 		},
+		{
+			name:     "ip interface brief",
+			input:    "Interface              IP-Address      OK? Method Status                Protocol\nGigabitEthernet0/0     10.0.0.1        YES manual up                    up\nGigabitEthernet0/1     10.0.1.1        YES manual down                  down\n",
+			expected: ParseModeShow,
+		},
+		{
+			// Columns are told apart by the spaces and tabs between them,
+			// whatever their cells hold
+			name:     "table of punctuated cells",
+			input:    "Port       (Celsius)    (Volts)  (mA)\n---------  -----------  -------  --------\n",
+			expected: ParseModeShow,
+		},
+		{
+			// Blank lines between config lines are not columns
+			name:     "config with blank lines",
+			input:    "interface Loopback0\n\n shutdown\n\n exit\n",
+			expected: ParseModeConfig,
+		},
 	}
 
 	for _, tt := range tests {
@@ -866,6 +1084,20 @@ func TestSetParseMode(t *testing.T) {
 	}
 }
 
+func TestParseModeByName(t *testing.T) {
+	tests := map[string]ParseMode{
+		"config": ParseModeConfig,
+		"show":   ParseModeShow,
+		"auto":   ParseModeAuto,
+		"bogus":  ParseModeAuto,
+	}
+	for name, want := range tests {
+		if got := ParseModeByName(name); got != want {
+			t.Errorf("ParseModeByName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
 func TestShowModePreservesSharedPatterns(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -941,6 +1173,21 @@ func TestTokenizePrompt(t *testing.T) {
 	}
 }
 
+func TestTokenizePromptPosition(t *testing.T) {
+	input := "\n\nR1#show version   \n"
+	tokens := New(input).Tokenize()
+	var got strings.Builder
+	for _, tok := range tokens {
+		got.WriteString(tok.Value)
+		if tok.Type == TokenPromptHost && (tok.Line != 3 || tok.Column != 1) {
+			t.Errorf("hostname at %d:%d, want 3:1", tok.Line, tok.Column)
+		}
+	}
+	if got.String() != input {
+		t.Errorf("tokens give %q, want %q", got.String(), input)
+	}
+}
+
 func TestTokenizePromptWithMode(t *testing.T) {
 	input := "Router(config-if)#"
 	l := New(input)
@@ -982,3 +1229,1871 @@ func TestTokenizePromptWithCommand(t *testing.T) {
 		t.Error("expected to find TokenPromptConf")
 	}
 }
+
+func TestSpanningTreeProfile(t *testing.T) {
+	input := `VLAN0100
+  Spanning tree enabled protocol rstp
+  Root ID    Priority    24676
+             Address     0011.2233.4455
+
+Interface           Role Sts Cost      Prio.Nbr Type
+------------------- ---- --- --------- -------- ----
+Gi1/0/1             Root FWD 4         128.1    P2p
+Gi1/0/3             Altn BLK 4         128.3    P2p
+Gi1/0/4             Desg LRN 4         128.4    P2p
+`
+	l := New(input)
+	l.SetParseMode(ParseModeShow)
+	tokens := l.Tokenize()
+
+	expected := []struct {
+		value     string
+		tokenType TokenType
+	}{
+		{"VLAN0100", TokenSection},
+		{"24676", TokenBridgeID},
+		{"0011.2233.4455", TokenMAC},
+		{"Role", TokenColumnHeader},
+		{"Prio.Nbr", TokenColumnHeader},
+		{"FWD", TokenStateGood},
+		{"BLK", TokenStateBad},
+		{"LRN", TokenStateWarning},
+		{"Altn", TokenStateWarning},
+		{"Desg", TokenStateGood},
+		{"128.1", TokenNumber},
+	}
+
+	for _, exp := range expected {
+		found := false
+		for _, tok := range tokens {
+			if tok.Value == exp.value {
+				found = true
+				if tok.Type != exp.tokenType {
+					t.Errorf("%q: expected %v, got %v", exp.value, exp.tokenType, tok.Type)
+				}
+				break
+			}
+		}
+		if !found {
+			t.Errorf("token %q not found", exp.value)
+		}
+	}
+
+	// "Root ID" is a label, while "Root" in the port table is a role
+	var roots []TokenType
+	for _, tok := range tokens {
+		if tok.Value == "Root" {
+			roots = append(roots, tok.Type)
+		}
+	}
+	if len(roots) != 2 || roots[0] != TokenColumnHeader || roots[1] != TokenStateGood {
+		t.Errorf("expected Root label then Root role, got %v", roots)
+	}
+}
+
+func TestSpanningTreeBridgeID(t *testing.T) {
+	l := New("Designated bridge 32769.0011.2233.4455 port id 128.1")
+	l.SetParseMode(ParseModeShow)
+	l.profile, l.detectedProfile = spanningTreeProfile, true
+	tokens := l.Tokenize()
+
+	for _, tok := range tokens {
+		if tok.Value == "32769.0011.2233.4455" && tok.Type != TokenBridgeID {
+			t.Errorf("expected TokenBridgeID, got %v", tok.Type)
+		}
+	}
+}
+
+func TestTokenizeVRF(t *testing.T) {
+	tests := []struct {
+		input string
+		vrf   string
+	}{
+		{"vrf definition CUST-A", "CUST-A"},
+		{"ip vrf CUST-A", "CUST-A"},
+		{" vrf forwarding CUST-A", "CUST-A"},
+		{" ip vrf forwarding MGMT", "MGMT"},
+		{"vrf context management", "management"},
+		{" address-family ipv4 vrf CUST-B", "CUST-B"},
+		{"ip route vrf CUST-A 0.0.0.0 0.0.0.0 10.0.0.1", "CUST-A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			tokens := New(tt.input).Tokenize()
+			found := false
+			for _, tok := range tokens {
+				if tok.Type == TokenVRF {
+					found = true
+					if tok.Value != tt.vrf {
+						t.Errorf("expected VRF %q, got %q", tt.vrf, tok.Value)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("expected TokenVRF in %q, token types: %v", tt.input, tokenTypes(tokens))
+			}
+		})
+	}
+}
+
+func TestTokenizeRouteDistinguisherAndTarget(t *testing.T) {
+	tests := []struct {
+		input    string
+		value    string
+		expected TokenType
+	}{
+		{" rd 65000:100", "65000:100", TokenRouteDistinguisher},
+		{" rd 10.0.0.1:100", "10.0.0.1:100", TokenRouteDistinguisher},
+		{" route-target export 65000:100", "65000:100", TokenRouteTarget},
+		{" route-target import 65000:200", "65000:200", TokenRouteTarget},
+		{" route-target both 65000.10:1", "65000.10:1", TokenRouteTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			tokens := New(tt.input).Tokenize()
+			last := tokens[len(tokens)-1]
+			if last.Value != tt.value || last.Type != tt.expected {
+				t.Errorf("expected %v %q, got %v %q", tt.expected, tt.value, last.Type, last.Value)
+			}
+		})
+	}
+}
+
+func TestPendingArgResetsAtNewline(t *testing.T) {
+	tokens := New("ip vrf\nCUST-A").Tokenize()
+	last := tokens[len(tokens)-1]
+	if last.Type == TokenVRF {
+		t.Error("VRF context should not carry over to the next line")
+	}
+}
+
+func TestTokenizeBanner(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		delim string
+		body  string
+	}{
+		{"caret", "banner motd ^\n*** permit any deny all ***\n^\n", "^", "\n*** permit any deny all ***\n"},
+		{"caret C", "banner login ^C\nAuthorized access only\ninterface Gi0/0\n^C\n", "^C", "\nAuthorized access only\ninterface Gi0/0\n"},
+		{"hash single line", "banner exec #Welcome to router 10.0.0.1#", "#", "Welcome to router 10.0.0.1"},
+		{"no type", "banner %\nhello\n%", "%", "\nhello\n"},
+		{"multibyte", "banner motd ☕\nhello\n☕\n", "☕", "\nhello\n"},
+		{"wide multibyte", "banner login 東Authorized only東", "東", "Authorized only"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			l.SetParseMode(ParseModeConfig)
+			tokens := l.Tokenize()
+
+			var delims []string
+			var values []string
+			for _, tok := range tokens {
+				switch tok.Type {
+				case TokenOperator:
+					delims = append(delims, tok.Value)
+				case TokenValue:
+					values = append(values, tok.Value)
+				}
+			}
+
+			if len(delims) != 2 || delims[0] != tt.delim || delims[1] != tt.delim {
+				t.Errorf("expected delimiters %q twice, got %q", tt.delim, delims)
+			}
+			if len(values) != 1 || values[0] != tt.body {
+				t.Errorf("expected body %q, got %q", tt.body, values)
+			}
+		})
+	}
+}
+
+func TestBannerResumesNormalTokenizing(t *testing.T) {
+	input := "banner motd ^\nhello\n^\ninterface Loopback0\n"
+	l := New(input)
+	l.SetParseMode(ParseModeConfig)
+	tokens := l.Tokenize()
+
+	var last Token
+	for _, tok := range tokens {
+		if tok.Type != TokenText {
+			last = tok
+		}
+	}
+	if last.Type != TokenInterface || last.Value != "Loopback0" {
+		t.Errorf("expected tokenizing to resume after banner, last token %v %q", last.Type, last.Value)
+	}
+	if last.Line != 4 {
+		t.Errorf("expected line numbers to account for banner body, got line %d", last.Line)
+	}
+}
+
+func TestShowVersionProfile(t *testing.T) {
+	input := `Cisco IOS Software, C2960X Software (C2960X-UNIVERSALK9-M), Version 15.2(4)E7, RELEASE SOFTWARE (fc2)
+access-01 uptime is 5 weeks, 1 day, 2 minutes
+cisco WS-C2960X-48FPD-L (APM86XXX) processor (revision B0) with 524288K bytes of memory.
+Processor board ID FOC1234X0AB
+Configuration register is 0x2102
+`
+	l := New(input)
+	tokens := l.Tokenize()
+	if l.GetParseMode() != ParseModeShow {
+		t.Fatalf("show version output should be detected as show mode, got %v", l.GetParseMode())
+	}
+
+	expected := map[string]TokenType{
+		"15.2(4)E7,":        TokenVersion,
+		"weeks,":            TokenUptime,
+		"5":                 TokenUptime,
+		"WS-C2960X-48FPD-L": TokenModel,
+		"524288K":           TokenMemorySize,
+		"FOC1234X0AB":       TokenSerial,
+		"0x2102":            TokenConfigRegister,
+	}
+	for _, tok := range tokens {
+		if want, ok := expected[tok.Value]; ok {
+			if tok.Type != want {
+				t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+			}
+			delete(expected, tok.Value)
+		}
+	}
+	for value := range expected {
+		t.Errorf("token %q not found", value)
+	}
+}
+
+func TestBGPCommunities(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		mode     ParseMode
+		expected map[string]TokenType
+	}{
+		{
+			name:  "set community",
+			input: "route-map OUT permit 10\n set community 65000:100 65000:200 no-export additive\n",
+			mode:  ParseModeConfig,
+			expected: map[string]TokenType{
+				"65000:100": TokenCommunity, "65000:200": TokenCommunity, "no-export": TokenCommunity,
+			},
+		},
+		{
+			name:  "community-list",
+			input: "ip community-list standard CUST permit 65000:100 internet local-AS\n",
+			mode:  ParseModeConfig,
+			expected: map[string]TokenType{
+				"65000:100": TokenCommunity, "internet": TokenCommunity, "local-AS": TokenCommunity,
+			},
+		},
+		{
+			name:  "extended communities",
+			input: "route-map RT permit 10\n set extcommunity rt 65000:100 192.0.2.1:5 additive\n set extcommunity soo 65000:1\n",
+			mode:  ParseModeConfig,
+			expected: map[string]TokenType{
+				"65000:100": TokenCommunity, "192.0.2.1:5": TokenCommunity, "65000:1": TokenCommunity,
+			},
+		},
+		{
+			name:  "large communities",
+			input: "ip large-community-list standard LC permit 65000:1:1\nroute-map LC permit 10\n set large-community 4200000000:100:200\n",
+			mode:  ParseModeConfig,
+			expected: map[string]TokenType{
+				"65000:1:1": TokenCommunity, "4200000000:100:200": TokenCommunity,
+			},
+		},
+		{
+			name:  "local-as outside community lines",
+			input: "router bgp 65000\n neighbor 192.0.2.1 local-as 65001\n",
+			mode:  ParseModeConfig,
+			expected: map[string]TokenType{
+				"local-as": TokenIdentifier,
+			},
+		},
+		{
+			name:  "show bgp path attributes",
+			input: "      Community: 65000:10 no-export\n      Extended Community: RT:65000:100 SoO:65000:1\n      Large Community: 65000:1:1\n",
+			mode:  ParseModeShow,
+			expected: map[string]TokenType{
+				"65000:10": TokenCommunity, "no-export": TokenCommunity, "RT:65000:100": TokenCommunity,
+				"SoO:65000:1": TokenCommunity, "65000:1:1": TokenCommunity,
+			},
+		},
+		{
+			name:  "times are not communities",
+			input: "10.0.0.2  4 65002  123  125  0  0  0 01:02:03  5\n  Last reset 12:30, due to Peer closed\n",
+			mode:  ParseModeShow,
+			expected: map[string]TokenType{
+				"01:02:03": TokenTimeDuration, "12:30,": TokenIdentifier,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			l.SetParseMode(tt.mode)
+			for _, tok := range l.Tokenize() {
+				if want, ok := tt.expected[tok.Value]; ok && tok.Type != want {
+					t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestShowARPProfile(t *testing.T) {
+	input := `Protocol  Address          Age (min)  Hardware Addr   Type   Interface
+Internet  10.0.0.1                -   0011.2233.4455  ARPA   GigabitEthernet0/0
+Internet  10.0.0.2               12   0011.2233.4466  ARPA   Vlan10
+Internet  10.0.0.3                0   Incomplete      ARPA
+`
+	l := New(input)
+	tokens := l.Tokenize()
+	if l.GetParseMode() != ParseModeShow {
+		t.Fatalf("show ip arp output should be detected as show mode, got %v", l.GetParseMode())
+	}
+
+	expected := map[string]TokenType{
+		"Hardware":       TokenColumnHeader,
+		"(min)":          TokenColumnHeader,
+		"Internet":       TokenProtocol,
+		"-":              TokenStateNeutral,
+		"12":             TokenTimeDuration,
+		"0011.2233.4466": TokenMAC,
+		"ARPA":           TokenKeyword,
+		"Vlan10":         TokenInterface,
+		"Incomplete":     TokenStateBad,
+	}
+	for _, tok := range tokens {
+		if want, ok := expected[tok.Value]; ok && tok.Type != want {
+			t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+		}
+	}
+}
+
+func TestType7Secret(t *testing.T) {
+	tests := []struct {
+		input    string
+		value    string
+		expected TokenType
+	}{
+		{"username admin password 7 0822455D0A16\n", "0822455D0A16", TokenSecret},
+		{" password 7 094F471A1A0A\n", "094F471A1A0A", TokenSecret},
+		{" key-string 7 104D000A0618\n", "104D000A0618", TokenSecret},
+		{" ip ospf message-digest-key 1 md5 7 0822455D0A16\n", "0822455D0A16", TokenSecret},
+		{"ip route 10.0.0.0 255.0.0.0 Null0 7 1234\n", "1234", TokenNumber},
+		{" password 0 0822455D0A16\n", "0822455D0A16", TokenIdentifier},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		l.SetParseMode(ParseModeConfig)
+		found := false
+		for _, tok := range l.Tokenize() {
+			if tok.Value == tt.value {
+				found = true
+				if tok.Type != tt.expected {
+					t.Errorf("%q: expected %v, got %v", tt.input, tt.expected, tok.Type)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%q: token %q not found", tt.input, tt.value)
+		}
+	}
+}
+
+func TestInterfaceRange(t *testing.T) {
+	l := New("interface range GigabitEthernet1/0/1 - 24, Gi1/0/30-32\n description x-y, z\naccess-list 101 permit tcp any any range 1000 2000\n")
+	l.SetParseMode(ParseModeConfig)
+
+	var got []Token
+	for _, tok := range l.Tokenize() {
+		if tok.Type != TokenText {
+			got = append(got, tok)
+		}
+	}
+
+	expected := []struct {
+		value string
+		typ   TokenType
+	}{
+		{"interface", TokenCommand},
+		{"range", TokenOperator},
+		{"GigabitEthernet1/0/1", TokenInterface},
+		{"-", TokenOperator},
+		{"24", TokenNumber},
+		{",", TokenOperator},
+		{"Gi1/0/30", TokenInterface},
+		{"-", TokenOperator},
+		{"32", TokenNumber},
+		{"description", TokenKeyword},
+		{"x-y, z", TokenValue},
+		{"access-list", TokenSection},
+		{"101", TokenNumber},
+		{"permit", TokenAction},
+		{"tcp", TokenProtocol},
+		{"any", TokenOperator},
+		{"any", TokenOperator},
+		{"range", TokenOperator},
+		{"1000", TokenNumber},
+		{"2000", TokenNumber},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d: %v", len(expected), len(got), got)
+	}
+	for i, e := range expected {
+		if got[i].Value != e.value || got[i].Type != e.typ {
+			t.Errorf("token %d: expected %q (%v), got %q (%v)", i, e.value, e.typ, got[i].Value, got[i].Type)
+		}
+	}
+}
+
+func TestColumnHeaderLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		headers []string // words expected as TokenColumnHeader, in order
+	}{
+		{
+			name: "ip interface brief",
+			input: "Interface              IP-Address      OK? Method Status                Protocol\n" +
+				"GigabitEthernet0/0     10.0.0.1        YES NVRAM  up                    up\n",
+			headers: []string{"Interface", "IP-Address", "OK?", "Method", "Status", "Protocol"},
+		},
+		{
+			name: "bgp summary right aligned",
+			input: "BGP router identifier 10.0.0.1, local AS number 65001\n\n" +
+				"Neighbor        V           AS MsgRcvd MsgSent   TblVer  InQ OutQ Up/Down  State/PfxRcd\n" +
+				"10.0.0.2        4        65002     100     101       10    0    0 01:00:00        5\n",
+			headers: []string{"local", "AS", "Neighbor", "V", "AS", "MsgRcvd", "MsgSent", "TblVer", "InQ", "OutQ", "Up/Down", "State/PfxRcd"},
+		},
+		{
+			name: "dash underline",
+			input: "Port      Name               Status       Vlan\n" +
+				"--------- ------------------ ------------ ----------\n" +
+				"Gi1/0/1   uplink             connected    trunk\n",
+			headers: []string{"Port", "Name", "Status", "Vlan"},
+		},
+		{
+			name: "no data row",
+			input: "Foo  Bar  Baz\n" +
+				"Qux  Quux  Corge\n",
+		},
+		{
+			name: "row of labels below a row",
+			input: "Slot      Type                State                 Insert time (ago)\n" +
+				"--------- ------------------- --------------------- -----------------\n" +
+				"P0        PWR-4330-AC         ok                    2w3d\n" +
+				"P1        Unknown             empty                 never\n" +
+				"P2        ACS-4330-FANASSY    ok                    2w3d\n",
+			headers: []string{"Slot", "Type", "State", "Insert", "time", "(ago)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			l.SetParseMode(ParseModeShow)
+
+			var got []string
+			for _, tok := range l.Tokenize() {
+				if tok.Type == TokenColumnHeader {
+					got = append(got, tok.Value)
+				}
+			}
+			if strings.Join(got, " ") != strings.Join(tt.headers, " ") {
+				t.Errorf("column headers = %q, want %q", got, tt.headers)
+			}
+		})
+	}
+}
+
+func TestMPLSProfile(t *testing.T) {
+	input := `Local      Outgoing   Prefix           Bytes Label   Outgoing   Next Hop
+Label      Label      or Tunnel Id     Switched      interface
+16         Pop Label  10.0.0.2/32      0             Gi0/0      10.1.1.2
+17         24         10.0.0.3/32      1234          Gi0/0      10.1.1.2
+18         No Label   10.0.0.4/32      0             Gi0/1      10.1.2.2
+19         implicit-null 10.0.0.5/32   0             Gi0/1      10.1.2.2
+`
+	l := New(input)
+	tokens := l.Tokenize()
+	if l.GetParseMode() != ParseModeShow {
+		t.Fatalf("show mpls forwarding-table output should be detected as show mode, got %v", l.GetParseMode())
+	}
+
+	expected := map[string]TokenType{
+		"Local":         TokenColumnHeader,
+		"Switched":      TokenColumnHeader,
+		"16":            TokenMPLSLabel,
+		"Pop":           TokenMPLSLabel,
+		"24":            TokenMPLSLabel,
+		"No":            TokenMPLSLabel,
+		"implicit-null": TokenMPLSLabel,
+		"10.0.0.3/32":   TokenIPv4Prefix,
+		"1234":          TokenNumber,
+		"Gi0/1":         TokenInterface,
+	}
+	for _, tok := range tokens {
+		if want, ok := expected[tok.Value]; ok && tok.Type != want {
+			t.Errorf("%q: expected %v, got %v", tok.Value, want, tok.Type)
+		}
+	}
+}
+
+func TestFHRPProfile(t *testing.T) {
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	tests := []struct {
+		name     string
+		input    string
+		expected []want
+	}{
+		{
+			name: "show standby brief",
+			input: `                     P indicates configured to preempt.
+                     |
+Interface   Grp  Pri P State   Active          Standby         Virtual IP
+Vl10        10   110 P Active  local           10.0.10.3       10.0.10.1
+Gi0/1       1    100   Init    unknown         unknown         192.168.1.1
+`,
+			expected: []want{
+				{3, "Virtual", TokenColumnHeader},
+				{4, "P", TokenStatusSymbol},
+				{4, "Active", TokenStateGood},
+				{5, "Init", TokenStateBad},
+				{4, "10.0.10.3", TokenIPv4},
+				{4, "10.0.10.1", TokenVirtualIP},
+				{5, "192.168.1.1", TokenVirtualIP},
+			},
+		},
+		{
+			name: "show vrrp brief",
+			input: `Interface          Grp Pri Time  Own Pre State   Master addr     Group addr
+Gi0/0              1   110 3570       Y  Master  10.0.0.2        10.0.0.1
+Gi0/1              2   100 3609       Y  Backup  10.0.1.3        10.0.1.1
+`,
+			expected: []want{
+				{2, "Y", TokenStatusSymbol},
+				{2, "Master", TokenStateGood},
+				{3, "Backup", TokenStateNeutral},
+				{2, "10.0.0.2", TokenIPv4},
+				{2, "10.0.0.1", TokenVirtualIP},
+			},
+		},
+		{
+			name: "show glbp brief",
+			input: `Interface   Grp  Fwd Pri State    Address         Active router   Standby router
+Gi0/0       1    -   100 Active   10.0.0.1        local           10.0.0.3
+Gi0/0       1    1   -   Listen   0007.b400.0101  10.0.0.3        -
+`,
+			expected: []want{
+				{3, "Listen", TokenStateNeutral},
+				{2, "10.0.0.1", TokenVirtualIP},
+				{2, "10.0.0.3", TokenIPv4},
+				{3, "0007.b400.0101", TokenMAC},
+			},
+		},
+		{
+			name: "show standby",
+			input: `Vlan10 - Group 10
+  State is Speak
+  Virtual IP address is 10.0.10.1
+  Active virtual MAC address is 0000.0c07.ac0a (MAC In Use)
+  Preemption enabled
+  Active router is 10.0.10.2, priority 120 (expires in 8.128 sec)
+  Standby router is unknown
+`,
+			expected: []want{
+				{2, "Speak", TokenStateWarning},
+				{3, "10.0.10.1", TokenVirtualIP},
+				{4, "Active", TokenColumnHeader},
+				{7, "Standby", TokenColumnHeader},
+				{6, "10.0.10.2", TokenIPv4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			tokens := l.Tokenize()
+			if l.GetParseMode() != ParseModeShow {
+				t.Fatalf("expected show mode, got %v", l.GetParseMode())
+			}
+			got := make(map[want]bool)
+			for _, tok := range tokens {
+				got[want{tok.Line, tok.Value, tok.Type}] = true
+			}
+			for _, e := range tt.expected {
+				if !got[e] {
+					t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+				}
+			}
+		})
+	}
+}
+
+func TestOSPFDatabaseProfile(t *testing.T) {
+	input := `
+            OSPF Router with ID (10.0.0.1) (Process ID 1)
+
+                Router Link States (Area 0)
+
+Link ID         ADV Router      Age         Seq#       Checksum Link count
+10.0.0.1        10.0.0.1        1234        0x80000005 0x00A1B2 3
+10.0.0.2        10.0.0.2        45          0x80000003 0x004C21 2
+
+                Type-5 AS External Link States
+
+Link ID         ADV Router      Age         Seq#       Checksum Tag
+0.0.0.0         10.0.0.1        300         0x80000001 0x00DEAD 1
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{4, "Router", TokenSection},
+		{4, "States", TokenSection},
+		{6, "Age", TokenColumnHeader},
+		{7, "10.0.0.1", TokenIPv4},
+		{7, "1234", TokenTimeDuration},
+		{7, "0x80000005", TokenNumber},
+		{7, "0x00A1B2", TokenNumber},
+		{7, "3", TokenNumber},
+		{8, "45", TokenTimeDuration},
+		{10, "External", TokenSection},
+		{13, "300", TokenTimeDuration},
+		{13, "1", TokenNumber},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	if l.GetParseMode() != ParseModeShow {
+		t.Fatalf("expected show mode, got %v", l.GetParseMode())
+	}
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestMPLSLabels(t *testing.T) {
+	tests := []struct {
+		input    string
+		mode     ParseMode
+		value    string
+		expected TokenType
+	}{
+		{"  lib entry: 10.0.0.2/32, rev 4\n\tlocal binding:  label: 16\n", ParseModeShow, "16", TokenMPLSLabel},
+		{"  lib entry: 10.0.0.2/32, rev 4\n\tremote binding: lsr: 10.0.0.2:0, label: imp-null\n", ParseModeShow, "imp-null", TokenMPLSLabel},
+		{"  lib entry: 10.0.0.2/32, rev 4\n", ParseModeShow, "10.0.0.2/32", TokenIPv4Prefix},
+		{"mpls label range 16 100000\n", ParseModeConfig, "100000", TokenMPLSLabel},
+		{"mpls label protocol ldp\n", ParseModeConfig, "ldp", TokenProtocol},
+		{"mpls mtu 1508\n", ParseModeConfig, "1508", TokenNumber},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		l.SetParseMode(tt.mode)
+		found := false
+		for _, tok := range l.Tokenize() {
+			if tok.Value == tt.value {
+				found = true
+				if tok.Type != tt.expected {
+					t.Errorf("%q: expected %v, got %v", tt.input, tt.expected, tok.Type)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%q: token %q not found", tt.input, tt.value)
+		}
+	}
+}
+
+func TestErrorLines(t *testing.T) {
+	tests := []struct {
+		input    string
+		mode     ParseMode
+		value    string
+		expected TokenType
+	}{
+		{"% Invalid input detected at '^' marker.\n", ParseModeShow, "% Invalid input detected at '^' marker.", TokenError},
+		{"% Incomplete command.\r\n", ParseModeShow, "% Incomplete command.", TokenError},
+		{"R1(config)#interface Gi0/9\n% Invalid input detected at '^' marker.\n", ParseModeConfig, "% Invalid input detected at '^' marker.", TokenError},
+		{"R1#show ip intrface\n            ^\n", ParseModeShow, "^", TokenError},
+		{"*Mar  1 00:00:01: %LINK-3-UPDOWN: Interface Gi0/1, changed state to down\n", ParseModeShow, "%LINK-3-UPDOWN:", TokenIdentifier},
+		{"%SYS-5-CONFIG_I: Configured from console\n", ParseModeShow, "%SYS-5-CONFIG_I:", TokenIdentifier},
+		{"  CPU utilization 5%\n", ParseModeShow, "5%", TokenPercentage},
+		{"CPU utilization for five seconds: 95%/2%; one minute: 7%\n", ParseModeShow, "95%", TokenPercentage},
+		{"CPU utilization for five seconds: 95%/2%; one minute: 7%\n", ParseModeShow, "2%", TokenPercentage},
+		{"CPU utilization for five seconds: 95%/2%; one minute: 7%\n", ParseModeShow, "7%", TokenPercentage},
+		{"Memory (kB): Used: 3700000 (94%) Free: 250000 (6%)\n", ParseModeShow, "94%", TokenPercentage},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		l.SetParseMode(tt.mode)
+		found := false
+		for _, tok := range l.Tokenize() {
+			if tok.Value == tt.value {
+				found = true
+				if tok.Type != tt.expected {
+					t.Errorf("%q: expected %v, got %v", tt.input, tt.expected, tok.Type)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%q: token %q not found", tt.input, tt.value)
+		}
+	}
+}
+
+func TestTokenColumns(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		value  string
+		column int
+	}{
+		{"ascii", "interface Gi0/1\n", "Gi0/1", 11},
+		{"tab", "Gi0/1\tup\n", "up", 9},
+		{"tab after tab stop", "GigabitEthernet0/1\tup\n", "up", 25},
+		{"utf-8", "Gi1/0/1   Büro   connected\n", "connected", 18},
+		{"wide runes", "Gi1/0/2   会議室   notconnect\n", "notconnect", 20},
+		{"wide neighbor name", "東京-sw1   Gi1/0/1   120\n", "Gi1/0/1", 12},
+		{"wide runes after prompt", "core01#ping 東京 repeat 5\n", "repeat", 18},
+		{"invalid utf-8", "Gi1/0/3 \xa5\xff up\n", "up", 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := false
+			for _, tok := range New(tt.input).Tokenize() {
+				if tok.Value == tt.value {
+					found = true
+					if tok.Column != tt.column {
+						t.Errorf("%q: column %d, want %d", tt.value, tok.Column, tt.column)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("token %q not found", tt.value)
+			}
+		})
+	}
+}
+
+func TestAdvanceColumn(t *testing.T) {
+	tests := []struct {
+		col  int
+		s    string
+		want int
+	}{
+		{1, "abc", 4},
+		{1, "\t", 9},
+		{5, "\t", 9},
+		{9, "\t", 17},
+		{1, "Büro", 5},
+		{1, "会議室", 7},
+		{1, "é", 2}, // combining acute accent
+		{7, "ab\ncd", 3},
+	}
+	for _, tt := range tests {
+		if got := AdvanceColumn(tt.col, tt.s); got != tt.want {
+			t.Errorf("AdvanceColumn(%d, %q) = %d, want %d", tt.col, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestColumnHeaderAlignment(t *testing.T) {
+	// The rows only line up with the header by display column: the wide
+	// runes take more bytes than columns, and the tabs more columns than bytes
+	tests := []struct {
+		name   string
+		input  string
+		header string
+	}{
+		{"wide runes", "Port      Name                          Ckt       Own       Rem\nGi1/0/2   会議室会議室                  c17       noc       n/a\n", "Ckt"},
+		{"tabs", "Port            Name            Circuit         Owner\nGi1/0/1\t\tuplink\t\tckt-17\t\tnoc\n", "Circuit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			l.SetParseMode(ParseModeShow)
+			found := false
+			for _, tok := range l.Tokenize() {
+				if tok.Value == tt.header {
+					found = true
+					if tok.Type != TokenColumnHeader {
+						t.Errorf("%q: expected %v, got %v", tok.Value, TokenColumnHeader, tok.Type)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("token %q not found", tt.header)
+			}
+		})
+	}
+}
+
+func TestDHCPProfile(t *testing.T) {
+	input := `Bindings from all pools not associated with VRF:
+IP address          Client-ID/              Lease expiration        Type       State      Interface
+                    Hardware address/
+                    User name
+10.1.1.11           0100.5079.6668.00       Mar 01 2024 12:04 AM    Automatic  Active     GigabitEthernet0/0
+10.1.1.12           0063.6973.636f.2d30.    Mar 01 2024 12:05 PM    Automatic  Selecting  GigabitEthernet0/0
+                    3030.632e.3239.6530.
+192.168.1.5         0100.0c29.1a2b.3c       Infinite                Manual     Active     Unknown
+
+Pool LAN :
+ Total addresses                : 254
+ Leased addresses               : 250
+ Pending event                  : none
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{2, "Lease", TokenColumnHeader},
+		{3, "Hardware", TokenColumnHeader},
+		{4, "name", TokenColumnHeader},
+		{5, "10.1.1.11", TokenIPv4},
+		{5, "0100.5079.6668.00", TokenMAC},
+		{5, "Mar", TokenTimestamp},
+		{5, "01", TokenTimestamp},
+		{5, "2024", TokenTimestamp},
+		{5, "12:04", TokenTimestamp},
+		{5, "AM", TokenTimestamp},
+		{5, "Automatic", TokenKeyword},
+		{5, "Active", TokenStateGood},
+		{6, "Selecting", TokenStateWarning},
+		{7, "3030.632e.3239.6530.", TokenMAC},
+		{8, "Infinite", TokenTimestamp},
+		{8, "Manual", TokenKeyword},
+		{10, "Pool", TokenSection},
+		{11, "254", TokenNumber},
+		{13, "Pending", TokenIdentifier},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	if l.GetParseMode() != ParseModeShow {
+		t.Fatalf("expected show mode, got %v", l.GetParseMode())
+	}
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestInterfaceStatusProfile(t *testing.T) {
+	input := `Port      Name               Status       Vlan       Duplex  Speed Type
+Gi1/0/1   uplink to core-sw1 connected    trunk        full   1000 10/100/1000BaseTX
+Gi1/0/2   Printer 2nd floor  notconnect   10           auto   auto 10/100/1000BaseTX
+Gi1/0/3   AP-lobby           err-disabled 20         a-half  a-100 10/100/1000BaseTX
+Gi1/0/4   down to access     connected    30         a-full a-1000 10/100/1000BaseTX
+Gi1/0/5   Link to dist       monitoring   routed     a-full  a-10G SFP-10GBase-SR
+Gi1/0/6   Phone connected    sfpAbsent    1            full    100 Not Present
+Eth1/7    --                 xcvrAbsen    routed       auto   auto --
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{1, "Status", TokenColumnHeader},
+		{2, "uplink", TokenValue},
+		{2, "connected", TokenStateGood},
+		{2, "trunk", TokenKeyword},
+		{2, "1000", TokenRate},
+		{2, "10/100/1000BaseTX", TokenIdentifier},
+		{3, "notconnect", TokenStateBad},
+		{3, "10", TokenNumber},
+		{3, "auto", TokenKeyword},
+		{4, "err-disabled", TokenStateBad},
+		{4, "a-half", TokenStateWarning},
+		{5, "down", TokenValue}, // the description, not the port state
+		{5, "a-full", TokenKeyword},
+		{5, "a-1000", TokenRate},
+		{6, "Link", TokenValue},
+		{6, "monitoring", TokenStateNeutral},
+		{6, "routed", TokenKeyword},
+		{7, "connected", TokenValue},
+		{7, "sfpAbsent", TokenStateNeutral},
+		{7, "Present", TokenStateNeutral},
+		{8, "xcvrAbsen", TokenStateNeutral},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	if l.GetParseMode() != ParseModeShow {
+		t.Fatalf("expected show mode, got %v", l.GetParseMode())
+	}
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestRouteSummaryProfile(t *testing.T) {
+	input := `IP routing table name is default (0x0)
+IP routing table maximum-paths is 32
+Route Source    Networks    Subnets     Replicates  Overhead    Memory (bytes)
+application     0           0           0           0           0
+connected       0           4           0           384         1216
+ospf 1          0           12          0           1152        3648
+  Intra-area: 6 Inter-area: 6 External-1: 0 External-2: 0
+bgp 65000       120         450         0           54720       173280
+Total           126         466         0           56352       202428
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{1, "default", TokenValue},
+		{3, "Source", TokenColumnHeader},
+		{3, "Subnets", TokenColumnHeader},
+		{4, "application", TokenProtocol},
+		{5, "connected", TokenProtocol}, // a route source, not a port state
+		{5, "4", TokenNumber},
+		{6, "ospf", TokenProtocol},
+		{6, "1", TokenNumber},
+		{6, "12", TokenNumber},
+		{8, "bgp", TokenProtocol},
+		{8, "65000", TokenASN},
+		{8, "450", TokenNumber},
+		{9, "Total", TokenKeyword},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	if l.GetParseMode() != ParseModeShow {
+		t.Fatalf("expected show mode, got %v", l.GetParseMode())
+	}
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestLACPProfile(t *testing.T) {
+	input := `Flags:  S - Device is requesting Slow LACPDUs
+        A - Device is in Active mode       P - Device is in Passive mode
+
+Channel group 1
+                            LACP port     Admin     Oper    Port        Port
+Port      Flags   State     Priority      Key       Key     Number      State
+Gi1/0/1   SA      bndl      32768         0x1       0x1     0x102       0x3D
+Gi1/0/2   SA      susp      32768         0x1       0x1     0x103       0x7
+Gi1/0/3   FA      hot-sby   32768         0x1       0x1     0x104       0x45
+
+Partner's information:
+
+Port      Flags   Priority  Dev ID          Age    key    Key    Number  State
+Gi1/0/1   SA      32768     0011.2233.4455  12s    0x0    0x1    0x102   0x3D
+Eth1/1    32768,0-23-4-ee-be-1    0x101     1296    SA
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{4, "Channel", TokenSection},
+		{4, "1", TokenNumber},
+		{7, "SA", TokenKeyword},
+		{7, "bndl", TokenStateGood},
+		{7, "0x102", TokenNumber},
+		{7, "0x3D", TokenStateGood}, // in sync, collecting and distributing
+		{8, "susp", TokenStateBad},
+		{8, "0x7", TokenStateWarning}, // not in sync
+		{9, "hot-sby", TokenStateWarning},
+		{9, "0x45", TokenStateBad}, // defaulted
+		{11, "Partner's", TokenIdentifier},
+		{14, "0011.2233.4455", TokenMAC},
+		{14, "0x0", TokenNumber},
+		{15, "32768,0-23-4-ee-be-1", TokenMAC},
+		{15, "SA", TokenKeyword},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestPAgPProfile(t *testing.T) {
+	input := `Flags:  S - Device is sending Slow hello.  C - Device is in Consistent state.
+        A - Device is in Auto mode.        P - Device learns on physical port.
+
+Channel group 1 neighbors
+          Partner              Partner          Partner         Partner Group
+Port      Name                 Device ID        Port       Age  Flags   Cap.
+Gi1/0/1   DOWN-SW2             0011.2233.4455   Gi1/0/1     10s SC      10001
+`
+	type want struct {
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{"Channel", TokenSection},
+		{"DOWN-SW2", TokenValue}, // a hostname, not a state
+		{"0011.2233.4455", TokenMAC},
+		{"SC", TokenKeyword},
+		{"10001", TokenNumber},
+	}
+
+	got := make(map[want]bool)
+	for _, tok := range New(input).Tokenize() {
+		got[want{tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q: expected %v", e.value, e.typ)
+		}
+	}
+}
+
+func TestRouteTableProfile(t *testing.T) {
+	input := `IPv6 Routing Table - default - 4 entries
+Codes: C - Connected, L - Local, S - Static, U - Per-user Static route
+C   2001:DB8:1::/64 [0/0]
+     via GigabitEthernet0/0/0, directly connected
+O   2001:DB8:2::/64 [110/2]
+     via FE80::2, GigabitEthernet0/0/0
+OE2 ::/0 [110/1], tag 1
+     via FE80::1%GigabitEthernet0/0/0
+S*    0.0.0.0/0 [1/0] via 10.0.0.1
+O E2     10.2.0.0/24 [110/20] via 10.0.0.3, 00:10:12, GigabitEthernet0/1
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{1, "default", TokenValue},
+		{3, "C", TokenStatusSymbol},
+		{3, "[0/0]", TokenNumber},
+		{4, "via", TokenKeyword},
+		{6, "FE80::2", TokenIPv6},
+		{6, "GigabitEthernet0/0/0", TokenInterface},
+		{7, "OE2", TokenStatusSymbol},
+		{7, "[110/1],", TokenNumber},
+		{8, "FE80::1%GigabitEthernet0/0/0", TokenIPv6},
+		{9, "S*", TokenStatusSymbol},
+		{10, "O", TokenStatusSymbol},
+		{10, "E2", TokenStatusSymbol},
+		{10, "00:10:12", TokenTimeDuration},
+		{10, "GigabitEthernet0/1", TokenInterface},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestBGPSummaryProfile(t *testing.T) {
+	input := `BGP router identifier 10.0.0.1, local AS number 65000
+BGP table version is 12, main routing table version 12
+
+Neighbor        V           AS MsgRcvd MsgSent   TblVer  InQ OutQ Up/Down  State/PfxRcd
+2001:DB8:0:1::2 4        65001    1234    1230       12    0    0 1d02h           3
+2001:DB8:0:1::3 4        65002       0       0        1    0    0 never    Idle (Admin)
+FE80::A8BB:CCFF:FE00:200%GigabitEthernet0/0/1
+                4        65003     120     118       12    0    0 00:58:10        2
+2001:DB8:0:FFFF:FFFF:FFFF:FFFF:1
+                4        65004       0       0        1    0    0 00:00:12 Active
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{5, "65001", TokenASN},
+		{5, "3", TokenNumber},
+		{6, "Idle", TokenStateWarning}, // shut down on purpose
+		{6, "(Admin)", TokenStateWarning},
+		{7, "FE80::A8BB:CCFF:FE00:200%GigabitEthernet0/0/1", TokenIPv6},
+		{8, "65003", TokenASN},
+		{10, "65004", TokenASN},
+		{10, "Active", TokenStateBad},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestOSPFNeighborProfile(t *testing.T) {
+	input := `            OSPFv3 Router with ID (10.0.0.1) (Process ID 1)
+
+Neighbor ID     Pri   State           Dead Time   Interface ID    Interface
+10.0.0.2          1   FULL/DR         00:00:35    4               GigabitEthernet0/0/0
+10.0.0.3          1   INIT/DROTHER    00:00:31    5               GigabitEthernet0/0/1
+10.0.0.4          0   FULL/  -        00:00:38    3               Tunnel0
+10.0.0.5          1   2WAY/DROTHER    00:00:33    6               GigabitEthernet0/0/2
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{4, "FULL/DR", TokenStateGood},
+		{4, "00:00:35", TokenTimeDuration},
+		{5, "INIT/DROTHER", TokenStateWarning},
+		{6, "FULL/", TokenStateGood},
+		{6, "-", TokenText}, // no role on a point-to-point link
+		{7, "2WAY/DROTHER", TokenStateGood},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestBGPDampeningProfile(t *testing.T) {
+	input := `Status codes: s suppressed, d damped, h history, * valid, > best, i - internal
+   Network          From             Flaps Duration Reuse    Path
+*d 10.1.0.0/16      192.0.2.1        5     00:12:40 00:21:30 65001 i
+h  10.2.0.0/16      192.0.2.1        3     00:05:10          65001 i
+*> 10.3.0.0/16      192.0.2.2        1     00:01:20          65002 i
+*d 2001:DB8:10::/48 2001:DB8::1      7     01:02:03 00:35:10 65003 i
+  65001, (suppressed due to dampening)
+      Dampinfo: penalty 2875, flapped 5 times in 00:12:40, reuse in 00:21:30
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{1, "suppressed,", TokenStateWarning},
+		{1, "history,", TokenStateNeutral},
+		{3, "*d", TokenStateWarning},
+		{3, "10.1.0.0/16", TokenStateWarning},
+		{3, "192.0.2.1", TokenIPv4},
+		{3, "5", TokenNumber},
+		{3, "00:21:30", TokenTimeDuration},
+		{4, "h", TokenStateNeutral},
+		{4, "10.2.0.0/16", TokenIPv4Prefix},
+		{5, "*>", TokenStatusSymbol},
+		{5, "10.3.0.0/16", TokenIPv4Prefix},
+		{6, "2001:DB8:10::/48", TokenStateWarning},
+		{7, "(suppressed", TokenStateWarning},
+		{8, "Dampinfo:", TokenKeyword},
+		{8, "2875,", TokenNumber},
+		{8, "00:21:30", TokenTimeDuration},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestCryptoProfile(t *testing.T) {
+	input := `dst             src             state          conn-id status
+192.0.2.2       192.0.2.1       QM_IDLE           1001 ACTIVE
+198.51.100.2    192.0.2.1       MM_NO_STATE       1002 ACTIVE (deleted)
+203.0.113.5     192.0.2.1       MM_KEY_EXCH       1003 ACTIVE
+    #pkts encaps: 1523, #pkts encrypt: 1523, #pkts digest: 1523
+     current outbound spi: 0x1A2B3C4D(439041101)
+      spi: 0x5E6F7A8B(1584364171)
+        sa timing: remaining key lifetime (k/sec): (4607999/3540)
+        Status: ACTIVE(ACTIVE)
+Session status: UP-IDLE
+  IKEv2 SA: local 192.0.2.1/500 remote 192.0.2.2/500 Active
+        Active SAs: 2, origin: crypto map
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{2, "QM_IDLE", TokenStateGood},
+		{2, "1001", TokenNumber},
+		{3, "MM_NO_STATE", TokenStateBad},
+		{3, "(deleted)", TokenStateWarning},
+		{4, "MM_KEY_EXCH", TokenStateWarning},
+		{5, "1523,", TokenNumber},
+		{6, "0x1A2B3C4D(439041101)", TokenHex},
+		{7, "0x5E6F7A8B(1584364171)", TokenHex},
+		{8, "(4607999/3540)", TokenNumber},
+		{9, "ACTIVE(ACTIVE)", TokenStateGood},
+		{10, "UP-IDLE", TokenStateWarning},
+		{11, "192.0.2.1/500", TokenIPv4},
+		{11, "Active", TokenStateGood},
+		{12, "Active", TokenText}, // a count of SAs, not a state
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestRedundancyProfile(t *testing.T) {
+	input := `Redundant System Information :
+------------------------------
+       Available system uptime = 1 week, 2 days, 3 hours, 4 minutes
+Switchovers system experienced = 0
+              Standby failures = 0
+        Last switchover reason = none
+
+                 Hardware Mode = Duplex
+    Configured Redundancy Mode = sso
+     Operating Redundancy Mode = rpr
+              Maintenance Mode = Disabled
+                Communications = Up
+
+Current Processor Information :
+-------------------------------
+               Active Location = slot 1
+        Current Software state = ACTIVE
+       Uptime in current state = 1 week, 2 days
+Peer Processor Information :
+----------------------------
+              Standby Location = slot 2
+        Current Software state = STANDBY HOT
+       my state = 13 -ACTIVE
+     peer state = 4  -STANDBY COLD-BULK
+`
+
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{5, "Standby", TokenText}, // labels stay text
+		{8, "Duplex", TokenStateGood},
+		{9, "sso", TokenStateGood},
+		{10, "rpr", TokenStateWarning},
+		{11, "Disabled", TokenText}, // maintenance mode off is normal
+		{12, "Up", TokenStateGood},
+		{16, "Active", TokenText},
+		{17, "ACTIVE", TokenStateGood},
+		{22, "STANDBY", TokenStateGood},
+		{22, "HOT", TokenStateGood},
+		{23, "-ACTIVE", TokenStateGood},
+		{24, "-STANDBY", TokenStateWarning},
+		{24, "COLD-BULK", TokenStateWarning},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestStackProfile(t *testing.T) {
+	input := `Switch/Stack Mac Address : 0011.2233.4455 - Local Mac Address
+Mac persistency wait time: Indefinite
+                                             H/W   Current
+Switch#   Role    Mac Address     Priority Version  State
+-------------------------------------------------------------
+*1       Active   0011.2233.4455     15     V01     Ready
+ 2       Standby  0011.2233.4466     14     V01     Ready
+ 3       Member   0011.2233.4477     1      V01     Provisioned
+ 4       Member   0000.0000.0000     0      0       Removed
+ 5       Member   0011.2233.4488     1      V02     Version Mismatch
+ 6       Member   0011.2233.4499     1      V01     HA Sync in progress
+`
+
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{1, "0011.2233.4455", TokenMAC},
+		{1, "Local", TokenText},
+		{4, "Switch#", TokenColumnHeader}, // not a prompt
+		{4, "Role", TokenColumnHeader},
+		{6, "*1", TokenStatusSymbol},
+		{6, "Active", TokenStateGood},
+		{6, "15", TokenNumber},
+		{6, "Ready", TokenStateGood},
+		{7, "Standby", TokenStateNeutral},
+		{8, "Member", TokenIdentifier},
+		{8, "Provisioned", TokenStateWarning},
+		{9, "Removed", TokenStateBad},
+		{10, "Version", TokenStateBad},
+		{10, "Mismatch", TokenStateBad},
+		{11, "HA", TokenStateWarning},
+		{11, "Sync", TokenStateWarning},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestPortSecurityProfile(t *testing.T) {
+	input := `Secure Port  MaxSecureAddr  CurrentAddr  SecurityViolation  Security Action
+                (Count)       (Count)          (Count)
+---------------------------------------------------------------------------
+      Gi1/0/1              1            1                  0         Shutdown
+      Gi1/0/2              2            1                  3         Restrict
+      Gi1/0/3              1            0                  0          Protect
+---------------------------------------------------------------------------
+Port Status                : Secure-shutdown
+Violation Mode             : Shutdown
+Last Source Address:Vlan   : 0011.2233.4455:10
+Security Violation Count   : 1
+  10    0011.2233.4455    SecureSticky                  Gi1/0/1    -
+`
+
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{4, "0", TokenNumber},
+		{4, "Shutdown", TokenAction},
+		{5, "2", TokenNumber}, // MaxSecureAddr
+		{5, "3", TokenStateBad},
+		{5, "Restrict", TokenAction},
+		{6, "Protect", TokenAction},
+		{8, "Status", TokenText}, // labels stay text
+		{8, "Secure-shutdown", TokenStateBad},
+		{9, "Shutdown", TokenAction},
+		{10, "0011.2233.4455:10", TokenMAC},
+		{11, "1", TokenStateBad},
+		{12, "SecureSticky", TokenKeyword},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestDHCPSnoopingProfile(t *testing.T) {
+	input := `MacAddress          IpAddress        Lease(sec)  Type           VLAN  Interface
+------------------  ---------------  ----------  -------------  ----  --------------------
+00:11:22:33:44:55   10.1.10.21       86234       dhcp-snooping   10    GigabitEthernet1/0/5
+00:11:22:33:44:66   10.1.10.22       infinite    dhcp-snooping   10    GigabitEthernet1/0/6
+Total number of bindings: 2
+`
+
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{1, "Lease(sec)", TokenColumnHeader},
+		{3, "00:11:22:33:44:55", TokenMAC},
+		{3, "10.1.10.21", TokenIPv4},
+		{3, "dhcp-snooping", TokenKeyword},
+		{3, "GigabitEthernet1/0/5", TokenInterface},
+		{4, "infinite", TokenKeyword},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestErrdisableProfile(t *testing.T) {
+	input := `ErrDisable Reason            Timer Status
+-----------------            --------------
+arp-inspection               Disabled
+psecure-violation            Enabled
+
+Timer interval: 300 seconds
+
+Interfaces that will be enabled at the next timeout:
+
+Interface       Errdisable reason       Time left(sec)
+---------       -----------------       --------------
+Gi1/0/2         psecure-violation          273
+Gi1/0/5                      err-disabled bpduguard
+`
+
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{3, "arp-inspection", TokenKeyword},
+		{3, "Disabled", TokenStateNeutral},
+		{4, "psecure-violation", TokenKeyword},
+		{4, "Enabled", TokenStateGood},
+		{6, "Timer", TokenIdentifier},
+		{12, "Gi1/0/2", TokenInterface},
+		{12, "psecure-violation", TokenStateBad},
+		{12, "273", TokenNumber},
+		{13, "bpduguard", TokenStateBad}, // show interfaces status err-disabled
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestBGPTableProfile(t *testing.T) {
+	input := `BGP table version is 8, local router ID is 10.0.0.1
+Status codes: s suppressed, d damped, h history, * valid, > best, i - internal,
+              r RIB-failure, S Stale, m multipath, b backup-path, f RT-Filter,
+              x best-external, a additional-path, c RIB-compressed,
+              t secondary path, L long-lived-stale,
+Origin codes: i - IGP, e - EGP, ? - incomplete
+RPKI validation codes: V valid, I invalid, N Not found
+
+     Network          Next Hop            Metric LocPrf Weight Path
+ *>   10.0.0.0/24      0.0.0.0                  0         32768 i
+ *>   10.1.0.0/16      192.0.2.1                0             0 65001 i
+ *    10.2.0.0/16      192.0.2.5                              0 3356 1299 i
+ *>                    192.0.2.1                0             0 3356 174 ?
+ * i  10.3.0.0/16      10.0.0.2                 0    100      0 65004 {65005,65006} e
+ r>i  10.4.0.0/24      10.0.0.2                 0    100      0 i
+ s>   10.5.0.0/16      192.0.2.1                             0 65001 i
+ *>   2001:DB8::/32    ::                       0         32768 i
+ *>   2001:DB8:1::/48  2001:DB8::2
+                                                0             0 65010 i
+`
+
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{9, "Next", TokenColumnHeader},
+		{9, "LocPrf", TokenColumnHeader},
+		{10, "*>", TokenStatusSymbol},
+		{10, "32768", TokenNumber}, // weight
+		{10, "i", TokenKeyword},    // origin
+		{11, "0", TokenNumber},
+		{11, "65001", TokenPrivateASN},
+		{12, "3356", TokenASN},
+		{12, "1299", TokenASN},
+		{13, "174", TokenASN},
+		{13, "?", TokenKeyword},
+		{14, "i", TokenStatusSymbol}, // internal, before the network
+		{14, "100", TokenNumber},
+		{14, "{65005,65006}", TokenPrivateASN},
+		{14, "e", TokenKeyword},
+		{15, "r>i", TokenStateWarning}, // RIB-failure
+		{16, "s>", TokenStateWarning},  // suppressed
+		{17, "::", TokenIPv6},
+		{18, "2001:DB8::2", TokenIPv6},
+		{19, "65010", TokenPrivateASN}, // wrapped below its next hop
+		{19, "i", TokenKeyword},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+	for _, tok := range tokens {
+		if tok.Line == 11 && tok.Value == "0" && tok.Type != TokenNumber {
+			t.Errorf("metric and weight on line 11 should be numbers, got %v", tok.Type)
+		}
+	}
+}
+
+func TestBGPTableGluedStatus(t *testing.T) {
+	// Older releases leave no space between the status codes and the network
+	input := `BGP table version is 5, local router ID is 10.0.0.1
+Status codes: s suppressed, d damped, h history, * valid, > best, i - internal,
+              r RIB-failure, S Stale
+Origin codes: i - IGP, e - EGP, ? - incomplete
+
+   Network          Next Hop            Metric LocPrf Weight Path
+*> 10.1.1.0/24      0.0.0.0                  0         32768 i
+*>i10.2.2.0/24      172.16.1.2               0    100      0 i
+*  10.3.0.0         192.0.2.9              100             0 7018 3356 i
+*>                  192.0.2.1               20             0 174 3356 i
+`
+
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{8, "*>i", TokenStatusSymbol},
+		{8, "10.2.2.0/24", TokenIPv4Prefix},
+		{8, "172.16.1.2", TokenIPv4},
+		{9, "10.3.0.0", TokenIPv4},
+		{9, "7018", TokenASN},
+		{10, "174", TokenASN},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+
+	tests := []struct {
+		code string
+		want TokenType
+	}{
+		{"*>", TokenStatusSymbol},
+		{"*m", TokenStatusSymbol},
+		{"r>i", TokenStateWarning},
+		{"*d", TokenStateWarning},
+		{"S>", TokenStateWarning},
+		{"h", TokenStateNeutral},
+		{"I*>", TokenStateBad}, // RPKI invalid
+		{"V*>", TokenStatusSymbol},
+	}
+	for _, tt := range tests {
+		if got := bgpStatusType(tt.code); got != tt.want {
+			t.Errorf("bgpStatusType(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestTransceiverProfile(t *testing.T) {
+	input := `If device is externally calibrated, only calibrated values are printed.
+++ : high alarm, +  : high warning, -  : low warning, -- : low alarm.
+NA or N/A: not applicable, Tx: transmit, Rx: receive.
+mA: milliamperes, dBm: decibels (milli-watts).
+
+                                             Optical   Optical
+           Temperature  Voltage  Current     Tx Power  Rx Power
+Port       (Celsius)    (Volts)  (mA)        (dBm)     (dBm)
+---------  -----------  -------  --------    --------  --------
+Te1/1/1      35.1       3.29       6.4        -2.3      -3.1
+Te1/1/2      34.8       3.30       6.5        -2.2     -40.0 --
+Te1/1/3      71.2 +     3.31       6.6        -2.4      -9.8 -
+`
+
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{2, "++", TokenStateBad},
+		{2, "+", TokenStateWarning},
+		{10, "Te1/1/1", TokenInterface},
+		{10, "35.1", TokenNumber},
+		{10, "-3.1", TokenNumber},
+		{11, "-2.2", TokenNumber},
+		{11, "-40.0", TokenStateBad}, // no light
+		{11, "--", TokenStateBad},
+		{12, "71.2", TokenStateWarning},
+		{12, "+", TokenStateWarning},
+		{12, "3.31", TokenNumber},
+		{12, "-9.8", TokenStateWarning},
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
+
+func TestTransceiverThresholds(t *testing.T) {
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	tests := []struct {
+		name     string
+		input    string
+		expected []want
+	}{
+		{
+			name: "ios detail",
+			input: `mA: milliamperes, dBm: decibels (milli-watts), NA or N/A: not applicable.
+++ : high alarm, +  : high warning, -  : low warning, -- : low alarm.
+A2D readouts (if they differ), are reported in parentheses.
+The threshold values are calibrated.
+
+                              High Alarm  High Warn  Low Warn   Low Alarm
+           Temperature        Threshold   Threshold  Threshold  Threshold
+Port       (Celsius)          (Celsius)   (Celsius)  (Celsius)  (Celsius)
+---------  -----------------  ----------  ---------  ---------  ---------
+Te1/1/1      35.1                75.0        70.0        0.0       -5.0
+
+                              High Alarm  High Warn  Low Warn   Low Alarm
+           Optical            Threshold   Threshold  Threshold  Threshold
+           Receive Power      Threshold   Threshold  Threshold  Threshold
+Port       (dBm)              (dBm)       (dBm)      (dBm)      (dBm)
+---------  -----------------  ----------  ---------  ---------  ---------
+Te1/1/1      -3.1                 2.0        -1.0      -13.9      -17.9
+Te1/1/2     -15.0                 2.0        -1.0      -13.9      -17.9
+Te1/1/3     -40.0 --              2.0        -1.0      -13.9      -17.9
+`,
+			expected: []want{
+				{10, "35.1", TokenNumber},
+				{17, "-3.1", TokenNumber},
+				{18, "-15.0", TokenStateWarning}, // below Low Warn, not flagged
+				{19, "-40.0", TokenStateBad},
+				{19, "--", TokenStateBad},
+			},
+		},
+		{
+			name: "nx-os",
+			input: `Ethernet1/49
+    transceiver is present
+    type is 10Gbase-LR
+    name is CISCO-FINISAR
+    part number is FTLX1474D3BCL-CS
+    serial number is FNS17221AAA
+    nominal bitrate is 10300 MBit/sec
+    cisco id is 3
+    cisco extended id number is 4
+
+           SFP Detail Diagnostics Information (internal calibration)
+  ----------------------------------------------------------------------------
+                Current              Alarms                  Warnings
+                Measurement     High        Low         High          Low
+  ----------------------------------------------------------------------------
+  Temperature   34.05 C        75.00 C     -5.00 C     70.00 C        0.00 C
+  Voltage        3.29 V         3.63 V      2.97 V      3.46 V        3.13 V
+  Current        6.40 mA       12.00 mA     2.00 mA    11.50 mA       3.00 mA
+  Tx Power      -2.30 dBm       1.69 dBm  -11.30 dBm    -1.30 dBm      -7.30 dBm
+  Rx Power     -12.50 dBm -     1.99 dBm  -13.97 dBm    -1.00 dBm      -9.91 dBm
+  Transmit Fault Count = 0
+  ----------------------------------------------------------------------------
+  Note: ++  high-alarm; +  high-warning; --  low-alarm; -  low-warning
+`,
+			expected: []want{
+				{2, "present", TokenStateGood},
+				{16, "34.05", TokenNumber},
+				{19, "-2.30", TokenNumber},
+				{20, "-12.50", TokenStateWarning},
+				{20, "-", TokenStateWarning},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[want]bool)
+			plain := make(map[string]bool)
+			for _, tok := range New(tt.input).Tokenize() {
+				got[want{tok.Line, tok.Value, tok.Type}] = true
+				if tok.Type == TokenText {
+					plain[strings.TrimSpace(tok.Value)] = true
+				}
+			}
+			for _, e := range tt.expected {
+				if !got[e] {
+					t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+				}
+			}
+			// Thresholds and units are left plain
+			for _, value := range []string{"75.0", "-17.9", "1.99", "dBm"} {
+				if strings.Contains(tt.input, " "+value+" ") && !plain[value] {
+					t.Errorf("%q should be plain", value)
+				}
+			}
+		})
+	}
+}
+
+func TestEnvironmentProfile(t *testing.T) {
+	input := `Sensor List:  Environmental Monitoring
+ Sensor           Location          State             Reading
+ Temp: Inlet      R0                Normal            29 Celsius
+ Temp: CPU Die    R0                Warning           95 Celsius
+ Fan: Fan2        P2                Fan Failed        0 RPM
+
+Switch 1 FAN 1 is OK
+FAN PS-2 is NOT PRESENT
+Inlet Temperature Value: 31 Degree Celsius
+Temperature State: GREEN
+Yellow Threshold : 46 Degree Celsius
+Hotspot Temperature Value: 110 Degree Celsius
+Temperature State: YELLOW
+
+Sensor          State       Reading       Range(min-max)
+SYSTEM INLET    GREEN       31 Celsius    -5 - 56
+SYSTEM OUTLET   GREEN       130 Celsius   -5 - 125
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{3, "Normal", TokenStateGood},
+		{3, "29", TokenNumber},
+		{4, "Warning", TokenStateWarning},
+		{4, "95", TokenStateWarning},
+		{5, "Failed", TokenStateBad},
+		{5, "0", TokenStateBad},
+		{7, "OK", TokenStateGood},
+		{8, "NOT", TokenStateNeutral},
+		{8, "PRESENT", TokenStateNeutral},
+		{9, "31", TokenNumber},
+		{10, "GREEN", TokenStateGood},
+		{11, "Yellow", TokenIdentifier},
+		{11, "46", TokenNumber},
+		{12, "110", TokenStateWarning},
+		{13, "YELLOW", TokenStateWarning},
+		{16, "31", TokenNumber},
+		{17, "130", TokenStateBad}, // above the range, whatever the state says
+	}
+
+	l := New(input)
+	tokens := l.Tokenize()
+	if l.GetParseMode() != ParseModeShow {
+		t.Fatalf("expected show mode, got %v", l.GetParseMode())
+	}
+	got := make(map[want]bool)
+	for _, tok := range tokens {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, e := range expected {
+		if !got[e] {
+			t.Errorf("%q on line %d: expected %v", e.value, e.line, e.typ)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Package lexertest checks the invariants every token stream from the lexer
+// holds, so dialect and show profile authors can property-test their
+// additions, by hand or with fuzzing:
+//
+//	func FuzzMyDialect(f *testing.F) {
+//		lexertest.Fuzz(f, func(input string) []lexer.Token {
+//			l := lexer.New(input)
+//			l.SetDialect(myDialect)
+//			return l.Tokenize()
+//		})
+//	}
+//
+// The invariants are:
+//
+//   - the token values, concatenated, reproduce the input byte for byte
+//   - no token is empty
+//   - every token's Line and Column are where its value starts in the input,
+//     counting columns as lexer.AdvanceColumn does
+//   - only whitespace, plain text, banner text and hex dumps span lines
+package lexertest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Violation is a broken invariant.
+type Violation struct {
+	Invariant string      // "reproduce", "empty", "position" or "newline"
+	Index     int         // index of the offending token, -1 for the stream
+	Token     lexer.Token // the offending token, zero for the stream
+	Message   string
+}
+
+func (v Violation) Error() string {
+	if v.Index < 0 {
+		return fmt.Sprintf("%s: %s", v.Invariant, v.Message)
+	}
+	return fmt.Sprintf("%s: token %d %s %q at %d:%d: %s",
+		v.Invariant, v.Index, v.Token.Type, v.Token.Value, v.Token.Line, v.Token.Column, v.Message)
+}
+
+// Types whose values may hold a line break besides whitespace: banner text
+// and the hex of certificates and memory dumps
+var multiline = map[lexer.TokenType]bool{
+	lexer.TokenText:    true,
+	lexer.TokenValue:   true,
+	lexer.TokenHexDump: true,
+}
+
+// Verify returns the invariants tokens, the result of tokenizing input,
+// break, or nil if it holds them all.
+func Verify(input string, tokens []lexer.Token) []Violation {
+	var violations []Violation
+	report := func(invariant string, i int, format string, args ...any) {
+		v := Violation{Invariant: invariant, Index: i, Message: fmt.Sprintf(format, args...)}
+		if i >= 0 {
+			v.Token = tokens[i]
+		}
+		violations = append(violations, v)
+	}
+
+	line, col := 1, 1
+	var b strings.Builder
+	for i, tok := range tokens {
+		if tok.Value == "" {
+			report("empty", i, "empty value")
+			continue
+		}
+		if tok.Line != line || tok.Column != col {
+			report("position", i, "want %d:%d", line, col)
+		}
+		if strings.Contains(tok.Value, "\n") && strings.TrimSpace(tok.Value) != "" && !multiline[tok.Type] {
+			report("newline", i, "spans lines")
+		}
+		line += strings.Count(tok.Value, "\n")
+		col = lexer.AdvanceColumn(col, tok.Value)
+		b.WriteString(tok.Value)
+	}
+
+	if got := b.String(); got != input {
+		at := 0
+		for at < len(got) && at < len(input) && got[at] == input[at] {
+			at++
+		}
+		report("reproduce", -1, "tokens differ from the input at byte %d: %q, want %q",
+			at, excerpt(got, at), excerpt(input, at))
+	}
+	return violations
+}
+
+// excerpt returns a few bytes of s from at.
+func excerpt(s string, at int) string {
+	end := at + 20
+	if end > len(s) {
+		end = len(s)
+	}
+	return s[at:end]
+}
+
+// Check reports each invariant tokens, the result of tokenizing input,
+// breaks as a test error.
+func Check(t testing.TB, input string, tokens []lexer.Token) {
+	t.Helper()
+	for _, v := range Verify(input, tokens) {
+		t.Errorf("%q: %v", input, v)
+	}
+}
+
+// Fuzz fuzzes tokenize, checking the invariants of its output for every
+// input. The corpus is seeded with Seeds.
+func Fuzz(f *testing.F, tokenize func(input string) []lexer.Token) {
+	for _, s := range Seeds() {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		Check(t, input, tokenize(input))
+	})
+}
+
+// Seeds returns inputs covering config, show output, prompts and the
+// corners of the lexer, such as banners, quotes, tabs and wide runes, for
+// seeding a fuzz corpus or looping over in a test.
+func Seeds() []string {
+	return []string{
+		"",
+		"\n",
+		"interface GigabitEthernet0/1\n description uplink to core\n ip address 10.0.0.1 255.255.255.0\n no shutdown\n!\n",
+		"router bgp 65000\n neighbor 10.0.0.2 remote-as 65001\n address-family ipv4 unicast\n  network 192.0.2.0/24\n",
+		"ip access-list extended WEB\n 10 permit tcp any host 10.0.0.10 eq 443 log\n 20 deny ip any any\r\n",
+		"banner motd ^C\nAuthorized access only\n^C\n",
+		"username admin privilege 15 secret 9 $9$abc$def\nsnmp-server community \"public ro\" RO\n",
+		"R1#show ip interface brief\nInterface    IP-Address   OK? Method Status   Protocol\nGi0/1        10.0.0.1     YES manual up       up\nR1#",
+		"Neighbor        V    AS MsgRcvd MsgSent   TblVer  InQ OutQ Up/Down  State/PfxRcd\n10.0.0.2        4 65001     120     118       10    0    0 1d02h           5\n",
+		"CPU utilization for five seconds: 95%/2%; one minute: 75%; five minutes: 10%\n",
+		"% Invalid input detected at '^' marker.\n",
+		"interface range Gi1/0/1-24 , Te1/1/1\n\tdescription\ttabs\there\n",
+		"hostname 東京-core\n description café ☕ link\n",
+		"Gi0/1 is up, line protocol is up\n --More-- \b\b\b\b\b\b\b\b\b        \b\b\b\b\b\b\b\b\b  5 minute input rate 1000 bits/sec\n",
+		"'unterminated \"quote\nnext line\n",
+		"!\n! comment\n !indented\nend",
+		"crypto pki certificate chain TP-self-signed-1\n certificate self-signed 01\n" +
+			"  3082032E 30820216 A0030201 02020101 300D0609 2A864886 F70D0101 05050030\n" +
+			"  31312F30 2D060355 04031326 494F532D 53656C66 2D536967 6E65642D 43657274\n" +
+			"  D2E2\n  \tquit\n",
+	}
+}
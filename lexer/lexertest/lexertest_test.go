@@ -0,0 +1,99 @@
+package lexertest
+
+import (
+	"testing"
+
+	"github.com/lasseh/cink/internal/benchdata"
+	"github.com/lasseh/cink/lexer"
+)
+
+func TestVerify(t *testing.T) {
+	input := "interface Gi0/1\n"
+	tests := []struct {
+		name   string
+		tokens []lexer.Token
+		want   string // invariant broken, "" for none
+	}{
+		{
+			name: "valid",
+			tokens: []lexer.Token{
+				{Type: lexer.TokenSection, Value: "interface", Line: 1, Column: 1},
+				{Type: lexer.TokenText, Value: " ", Line: 1, Column: 10},
+				{Type: lexer.TokenInterface, Value: "Gi0/1", Line: 1, Column: 11},
+				{Type: lexer.TokenText, Value: "\n", Line: 1, Column: 16},
+			},
+		},
+		{
+			name: "missing bytes",
+			tokens: []lexer.Token{
+				{Type: lexer.TokenSection, Value: "interface", Line: 1, Column: 1},
+				{Type: lexer.TokenText, Value: " ", Line: 1, Column: 10},
+				{Type: lexer.TokenInterface, Value: "Gi0/1", Line: 1, Column: 11},
+			},
+			want: "reproduce",
+		},
+		{
+			name: "wrong column",
+			tokens: []lexer.Token{
+				{Type: lexer.TokenSection, Value: "interface", Line: 1, Column: 1},
+				{Type: lexer.TokenText, Value: " ", Line: 1, Column: 10},
+				{Type: lexer.TokenInterface, Value: "Gi0/1", Line: 1, Column: 12},
+				{Type: lexer.TokenText, Value: "\n", Line: 1, Column: 16},
+			},
+			want: "position",
+		},
+		{
+			name: "empty token",
+			tokens: []lexer.Token{
+				{Type: lexer.TokenSection, Value: "interface", Line: 1, Column: 1},
+				{Type: lexer.TokenComment, Value: "", Line: 1, Column: 10},
+				{Type: lexer.TokenText, Value: " ", Line: 1, Column: 10},
+				{Type: lexer.TokenInterface, Value: "Gi0/1", Line: 1, Column: 11},
+				{Type: lexer.TokenText, Value: "\n", Line: 1, Column: 16},
+			},
+			want: "empty",
+		},
+		{
+			name: "token spanning lines",
+			tokens: []lexer.Token{
+				{Type: lexer.TokenSection, Value: "interface", Line: 1, Column: 1},
+				{Type: lexer.TokenText, Value: " ", Line: 1, Column: 10},
+				{Type: lexer.TokenInterface, Value: "Gi0/1\n", Line: 1, Column: 11},
+			},
+			want: "newline",
+		},
+	}
+
+	for _, tt := range tests {
+		violations := Verify(input, tt.tokens)
+		switch {
+		case tt.want == "" && len(violations) > 0:
+			t.Errorf("%s: unexpected violations %v", tt.name, violations)
+		case tt.want != "" && (len(violations) != 1 || violations[0].Invariant != tt.want):
+			t.Errorf("%s: violations %v, want one of %q", tt.name, violations, tt.want)
+		}
+	}
+}
+
+// The lexer holds the invariants in every parse mode and dialect
+func TestLexer(t *testing.T) {
+	inputs := append(Seeds(), benchdata.Config(200), benchdata.ShowTech(10000), benchdata.Transcript(200))
+	modes := []lexer.ParseMode{lexer.ParseModeAuto, lexer.ParseModeConfig, lexer.ParseModeShow, lexer.ParseModeTranscript}
+	dialects := []lexer.Dialect{lexer.DialectAuto, lexer.DialectIOS, lexer.DialectFRR, lexer.DialectAruba}
+	for _, input := range inputs {
+		for _, mode := range modes {
+			for _, dialect := range dialects {
+				l := lexer.New(input)
+				l.SetParseMode(mode)
+				l.SetDialect(dialect)
+				Check(t, input, l.Tokenize())
+			}
+		}
+	}
+}
+
+func FuzzTokenize(f *testing.F) {
+	Fuzz(f, func(input string) []lexer.Token {
+		return lexer.New(input).Tokenize()
+	})
+}
@@ -0,0 +1,71 @@
+package lexer
+
+import "strings"
+
+// show mpls forwarding-table, show mpls ldp bindings, show mpls traffic-eng tunnels
+var (
+	// Label values shown instead of a number
+	mplsLabelWords = map[string]bool{
+		"pop": true, "implicit-null": true, "explicit-null": true,
+		"imp-null": true, "exp-null": true, "untagged": true,
+		"unlabelled": true, "aggregate": true,
+	}
+
+	mplsProfile = &ShowProfile{
+		Name: "mpls",
+		Indicators: []string{
+			"local label", "outgoing label", "pop label", "no label",
+			"local binding", "remote binding", "lib entry", "label:",
+			"implicit-null", "imp-null", "tunnel id", "lsp",
+		},
+		Classify: classifyMPLS,
+	}
+)
+
+// classifyMPLS handles the local and outgoing label columns of forwarding
+// tables ("16  Pop Label  10.0.0.2/32 ..."), "label:" values in LDP bindings
+// and LSP names in traffic engineering tunnel output.
+func classifyMPLS(l *Lexer, word, lower string) (TokenType, bool) {
+	// "Pop Label", "No Label"
+	if (lower == "pop" || lower == "no") && strings.EqualFold(l.peekWord(), "label") {
+		return TokenMPLSLabel, true
+	}
+	if lower == "label" && (l.prevWord == "pop" || l.prevWord == "no") {
+		return TokenMPLSLabel, true
+	}
+
+	// "local binding:  label: 16", "remote binding: lsr: 10.0.0.2:0, label: imp-null"
+	if l.prevWord == "label:" && isMPLSLabel(lower) {
+		return TokenMPLSLabel, true
+	}
+
+	// The local label starts a forwarding table row; the outgoing label follows it
+	if l.prevWord == "" && isAllDigits(word) {
+		return TokenMPLSLabel, true
+	}
+	if isAllDigits(l.lineCommand) && l.prevWord == l.lineCommand && isMPLSLabel(lower) {
+		return TokenMPLSLabel, true
+	}
+
+	// "Name: R1_t100  (Tunnel100) Destination: 10.0.0.9"
+	if l.prevWord == "name:" {
+		return TokenValue, true
+	}
+
+	return TokenText, false
+}
+
+// isMPLSLabel reports whether lower is a label number or a label word such
+// as implicit-null.
+func isMPLSLabel(lower string) bool {
+	return isAllDigits(lower) || mplsLabelWords[lower]
+}
+
+// classifyMPLSConfig classifies the label numbers of "mpls label range 16
+// 100000 [static 16 999]".
+func (l *Lexer) classifyMPLSConfig(word string) (TokenType, bool) {
+	if l.lineCommand == "mpls" && isAllDigits(word) && strings.Contains(l.lineText(), " label ") {
+		return TokenMPLSLabel, true
+	}
+	return TokenText, false
+}
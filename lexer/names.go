@@ -0,0 +1,139 @@
+package lexer
+
+import (
+	"sort"
+	"strings"
+)
+
+// Defining commands and the words between them and the name they define
+var (
+	// Words that may follow class-map and policy-map before the name, as in
+	// "class-map type inspect match-any WEB"
+	classMapModifiers = map[string]bool{"match-any": true, "match-all": true, "type": true}
+
+	// Kinds of ACL, community list and object-group before the name
+	listKinds = map[string]bool{
+		"standard": true, "extended": true, "expanded": true, "role-based": true,
+		"network": true, "service": true, "security": true, "user": true,
+	}
+)
+
+// SetLearnNames enables learning the names a config defines: route-maps,
+// ACLs, prefix lists, community lists, VRFs, class-maps, policy-maps and
+// object-groups. The name in the definition and every later reference to it
+// (where it would otherwise be a plain identifier or VRF) become
+// TokenUserDefinedName, so "match ip address MGMT" reads like the
+// "ip access-list standard MGMT" it refers to. Names are case-sensitive, and
+// numbered ACLs are not learned.
+func (l *Lexer) SetLearnNames(enabled bool) {
+	l.learnNames = enabled
+	if enabled && l.names == nil {
+		l.names = make(map[string]bool)
+	}
+}
+
+// SetLearnedNames adds names learned from earlier input, for input that
+// continues a stream whose definitions were tokenized before. It enables
+// learning.
+func (l *Lexer) SetLearnedNames(names []string) {
+	l.SetLearnNames(true)
+	for _, name := range names {
+		l.names[name] = true
+	}
+	l.seedNames = append(l.seedNames, names...)
+}
+
+// LearnedNames returns the names defined in the input so far and those given
+// to SetLearnedNames, sorted.
+func (l *Lexer) LearnedNames() []string {
+	names := make([]string, 0, len(l.names))
+	for name := range l.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// classifyName learns word if it is the name a defining command introduces,
+// and classifies definitions and references to learned names as
+// TokenUserDefinedName. t is the type word was classified as otherwise.
+func (l *Lexer) classifyName(word string, start int, t TokenType) TokenType {
+	if l.isDefinedName(word, start) {
+		l.names[word] = true
+		l.because("name defined by the line")
+		return TokenUserDefinedName
+	}
+	if (t == TokenIdentifier || t == TokenVRF) && l.names[word] {
+		l.because("name defined earlier in the input")
+		return TokenUserDefinedName
+	}
+	return t
+}
+
+// isDefinedName reports whether the word at start is the name defined by a
+// global config line such as "route-map RM-IN permit 10".
+func (l *Lexer) isDefinedName(word string, start int) bool {
+	switch l.lineCommand {
+	case "route-map", "ip", "ipv6", "vrf", "class-map", "policy-map", "object-group":
+	default:
+		return false
+	}
+	if isAllDigits(word) {
+		return false
+	}
+	lineStart := strings.LastIndexByte(l.input[:start], '\n') + 1
+	if lineStart == start || isWhitespace(l.input[lineStart]) {
+		return false
+	}
+	fields := strings.Fields(strings.ToLower(l.input[lineStart:start]))
+	if len(fields) > 0 && fields[0] == "no" {
+		fields = fields[1:]
+	}
+	return definesName(fields, strings.ToLower(word))
+}
+
+// definesName reports whether the word after fields, the lowercase words
+// before it on a line, is the name the line defines.
+func definesName(fields []string, word string) bool {
+	switch len(fields) {
+	case 0:
+		return false
+	case 1:
+		switch fields[0] {
+		case "route-map":
+			return true
+		case "class-map", "policy-map":
+			return !classMapModifiers[word]
+		}
+	case 2:
+		switch fields[0] + " " + fields[1] {
+		case "ip vrf":
+			return !vrfSubcommands[word]
+		case "ip prefix-list", "ipv6 prefix-list":
+			return word != "sequence-number"
+		case "vrf definition", "ipv6 access-list":
+			return true
+		}
+		if fields[0] == "object-group" {
+			return listKinds[fields[1]]
+		}
+	case 3:
+		switch fields[0] + " " + fields[1] {
+		case "ip access-list", "ip community-list":
+			return listKinds[fields[2]]
+		}
+	}
+	// class-map type inspect match-any NAME
+	if fields[0] != "class-map" && fields[0] != "policy-map" {
+		return false
+	}
+	for i := 1; i < len(fields); i++ {
+		switch {
+		case fields[i] == "type":
+			i++
+		case !classMapModifiers[fields[i]]:
+			return false
+		}
+	}
+	return !classMapModifiers[word] && fields[len(fields)-1] != "type"
+}
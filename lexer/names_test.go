@@ -0,0 +1,103 @@
+package lexer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLearnNames(t *testing.T) {
+	input := `vrf definition CUST-A
+ip access-list standard MGMT
+ permit 10.0.0.0 0.0.0.255
+ip access-list extended 101
+ permit ip any any
+ip prefix-list PL-DEFAULT seq 5 permit 0.0.0.0/0
+class-map type inspect match-any WEB
+policy-map WAN-OUT
+object-group network SERVERS
+route-map RM-IN permit 10
+ match ip address prefix-list PL-DEFAULT
+interface Gi0/1
+ vrf forwarding CUST-A
+ ip access-group MGMT in
+ ip access-group 101 out
+ service-policy output WAN-OUT
+router bgp 65000
+ neighbor 10.0.0.1 route-map RM-IN in
+ neighbor 10.0.0.1 route-map RM-OUT out
+ neighbor 10.0.0.2 route-map rm-in in
+`
+	type want struct {
+		line  int
+		value string
+		typ   TokenType
+	}
+	expected := []want{
+		{1, "CUST-A", TokenUserDefinedName},
+		{2, "MGMT", TokenUserDefinedName},
+		{4, "101", TokenNumber},
+		{6, "PL-DEFAULT", TokenUserDefinedName},
+		{7, "inspect", TokenIdentifier},
+		{7, "WEB", TokenUserDefinedName},
+		{8, "WAN-OUT", TokenUserDefinedName},
+		{9, "SERVERS", TokenUserDefinedName},
+		{10, "RM-IN", TokenUserDefinedName},
+		{11, "PL-DEFAULT", TokenUserDefinedName},
+		{13, "CUST-A", TokenUserDefinedName},
+		{14, "MGMT", TokenUserDefinedName},
+		{16, "WAN-OUT", TokenUserDefinedName},
+		{18, "RM-IN", TokenUserDefinedName},
+		{19, "RM-OUT", TokenIdentifier}, // never defined
+		{20, "rm-in", TokenIdentifier},  // names are case-sensitive
+	}
+
+	l := New(input)
+	l.SetParseMode(ParseModeConfig)
+	l.SetLearnNames(true)
+	got := make(map[want]bool)
+	for _, tok := range l.Tokenize() {
+		got[want{tok.Line, tok.Value, tok.Type}] = true
+	}
+	for _, w := range expected {
+		if !got[w] {
+			t.Errorf("line %d: %q should be %v", w.line, w.value, w.typ)
+		}
+	}
+
+	names := []string{"CUST-A", "MGMT", "PL-DEFAULT", "RM-IN", "SERVERS", "WAN-OUT", "WEB"}
+	if got := l.LearnedNames(); !reflect.DeepEqual(got, names) {
+		t.Errorf("LearnedNames = %v, want %v", got, names)
+	}
+}
+
+func TestLearnNamesOff(t *testing.T) {
+	l := New("route-map RM-IN permit 10\nrouter bgp 65000\n neighbor 10.0.0.1 route-map RM-IN in\n")
+	l.SetParseMode(ParseModeConfig)
+	for _, tok := range l.Tokenize() {
+		if tok.Type == TokenUserDefinedName {
+			t.Errorf("%q learned with learning off", tok.Value)
+		}
+	}
+}
+
+func TestSetLearnedNames(t *testing.T) {
+	// References before their definition count once the name is known
+	l := New(" neighbor 10.0.0.1 route-map RM-IN in\n")
+	l.SetParseMode(ParseModeConfig)
+	l.SetLearnedNames([]string{"RM-IN"})
+	for _, tok := range l.Tokenize() {
+		if tok.Value == "RM-IN" && tok.Type != TokenUserDefinedName {
+			t.Errorf("RM-IN = %v, want UserDefinedName", tok.Type)
+		}
+	}
+
+	// Retokenize starts over from the names given, not those learned since
+	l.Retokenize(Range{0, 0}, "route-map RM-OUT permit 10\n")
+	if got, want := l.LearnedNames(), []string{"RM-IN", "RM-OUT"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LearnedNames = %v, want %v", got, want)
+	}
+	l.Retokenize(Range{0, len("route-map RM-OUT permit 10\n")}, "")
+	if got, want := l.LearnedNames(), []string{"RM-IN"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LearnedNames after removing the definition = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// show ip ospf database
+var (
+	ospfDatabaseProfile = &ShowProfile{
+		Name: "ospf-database",
+		Indicators: []string{
+			"link states", "adv router", "seq#", "checksum", "ospf router with id",
+		},
+		Classify: classifyOSPFDatabase,
+	}
+
+	// Neighbor states and the neighbor's role: "FULL/DR", "2WAY/DROTHER",
+	// and "FULL/" on point-to-point links, followed by a lone "-"
+	ospfNeighborStatePattern = regexp.MustCompile(`^(?i)(full|2way|init|down|attempt|exstart|exchange|loading)/(dr|bdr|drother)?$`)
+
+	ospfNeighborProfile = &ShowProfile{
+		Name: "ospf-neighbor",
+		Indicators: []string{
+			"dead time", "neighbor id", "ospfv3 router with id", "full/dr", "full/bdr", "full/drother",
+		},
+		Classify: classifyOSPFNeighbor,
+	}
+)
+
+// classifyOSPFDatabase handles the per-LSA-type section headers and the rows
+// below them:
+//
+//	                Router Link States (Area 0)
+//
+//	Link ID         ADV Router      Age         Seq#       Checksum Link count
+//	10.0.0.1        10.0.0.1        1234        0x80000005 0x00A1B2 3
+//
+// The age (in seconds) becomes a TokenTimeDuration, so the highlighter can
+// tell fresh LSAs from old ones; sequence numbers and checksums are numbers.
+func classifyOSPFDatabase(l *Lexer, word, lower string) (TokenType, bool) {
+	line := l.lineText()
+	if strings.Contains(line, "link states") {
+		return TokenSection, true
+	}
+	// The column header, which may arrive without the rows that would
+	// otherwise identify it
+	if strings.HasPrefix(strings.TrimSpace(line), "link id ") {
+		return TokenColumnHeader, true
+	}
+	if !ipv4Pattern.MatchString(l.lineCommand) {
+		return TokenText, false
+	}
+	// Link ID and ADV Router come first, then the age
+	if isAllDigits(word) && ipv4Pattern.MatchString(l.prevWord) {
+		lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+		if len(strings.Fields(l.input[lineStart:l.pos-len(word)])) == 2 {
+			return TokenTimeDuration, true
+		}
+	}
+	if hexNumberPattern.MatchString(word) {
+		return TokenNumber, true
+	}
+	return TokenText, false
+}
+
+// classifyOSPFNeighbor handles the states of show ip ospf neighbor and show
+// ipv6 ospf neighbor:
+//
+//	Neighbor ID     Pri   State           Dead Time   Interface ID    Interface
+//	10.0.0.2          1   FULL/DR         00:00:35    4               GigabitEthernet0/0/0
+//	10.0.0.3          1   INIT/DROTHER    00:00:31    5               GigabitEthernet0/0/1
+//	10.0.0.4          0   FULL/  -        00:00:38    3               Tunnel0
+//
+// The state takes the type of its adjacency state alone. 2WAY is where two
+// DROTHERs stop, so it is only a warning towards a DR or BDR.
+func classifyOSPFNeighbor(l *Lexer, word, lower string) (TokenType, bool) {
+	if word == "-" && strings.HasSuffix(l.prevWord, "/") {
+		// No role on point-to-point links
+		return TokenText, true
+	}
+	m := ospfNeighborStatePattern.FindStringSubmatch(lower)
+	if m == nil {
+		return TokenText, false
+	}
+	switch state, role := m[1], m[2]; {
+	case state == "full":
+		return TokenStateGood, true
+	case state == "2way":
+		if role == "drother" {
+			return TokenStateGood, true
+		}
+		return TokenStateWarning, true
+	case state == "down":
+		return TokenStateBad, true
+	}
+	return TokenStateWarning, true
+}
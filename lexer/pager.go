@@ -0,0 +1,71 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PagerPrompts lists the pagination prompts that devices print between pages
+// of output when terminal length is not set to 0.
+var PagerPrompts = []string{"--More--", "<--- More --->", "-- More --"}
+
+// PagerPattern matches a pagination prompt with an optional percentage, as in
+// "--More-- (42%)".
+var PagerPattern = regexp.MustCompile(`(` + quoteAll(PagerPrompts) + `)( \(\d+%\))?`)
+
+var pagerPercentPattern = regexp.MustCompile(`^ \(\d+%\)`)
+
+func quoteAll(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = regexp.QuoteMeta(w)
+	}
+	return strings.Join(quoted, "|")
+}
+
+// scanPager scans a pagination prompt and/or the backspace-and-space run that
+// erases it. Devices redraw the line after the prompt, so line context is reset.
+func (l *Lexer) scanPager() (Token, bool) {
+	ch := l.input[l.pos]
+	if ch != '\b' && ch != '-' && ch != '<' {
+		return Token{}, false
+	}
+
+	startLine, startCol := l.line, l.col
+	start := l.pos
+	end := l.pos
+
+	rest := l.input[l.pos:]
+	for _, prompt := range PagerPrompts {
+		if strings.HasPrefix(rest, prompt) {
+			end += len(prompt)
+			end += len(pagerPercentPattern.FindString(rest[len(prompt):]))
+			break
+		}
+	}
+
+	// Extend over the erase run: spaces and backspaces, up to the last backspace
+	for i := end; i < len(l.input) && (l.input[i] == '\b' || l.input[i] == ' '); i++ {
+		if l.input[i] == '\b' {
+			end = i + 1
+		}
+	}
+
+	if end == start {
+		return Token{}, false
+	}
+	for l.pos < end {
+		l.advance()
+	}
+
+	l.prevWord = ""
+	l.pendingArg = ""
+	l.expectingValue = false
+
+	return Token{
+		Type:   TokenPager,
+		Value:  l.input[start:end],
+		Line:   startLine,
+		Column: startCol,
+	}, true
+}
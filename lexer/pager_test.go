@@ -0,0 +1,67 @@
+package lexer
+
+import "testing"
+
+func TestPagerTokens(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		pager string
+	}{
+		{"ios more with erase", "Gi0/1  up\n --More-- \b\b\b\b\b\b\b\b\b\b          \b\b\b\b\b\b\b\b\b\bGi0/2  down\n", "--More-- \b\b\b\b\b\b\b\b\b\b          \b\b\b\b\b\b\b\b\b\b"},
+		{"xr more", "Gi0/1 up\n<--- More --->\nGi0/2 down\n", "<--- More --->"},
+		{"more with percentage", "line\n--More-- (42%)\n", "--More-- (42%)"},
+		{"bare backspaces", "sh\b\bshow version\n", "\b\b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			l.SetParseMode(ParseModeShow)
+			tokens := l.Tokenize()
+
+			var found bool
+			var joined string
+			for _, tok := range tokens {
+				joined += tok.Value
+				if tok.Type == TokenPager {
+					if tok.Value != tt.pager {
+						t.Errorf("pager token = %q, want %q", tok.Value, tt.pager)
+					}
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("no pager token in %v", tokens)
+			}
+			if joined != tt.input {
+				t.Errorf("content not preserved: %q", joined)
+			}
+		})
+	}
+}
+
+func TestPagerDoesNotLeakIntoWords(t *testing.T) {
+	input := " --More-- \b\b\b\b\b\b\b\b\b\b          \b\b\b\b\b\b\b\b\b\bGigabitEthernet0/2 is down\n"
+	l := New(input)
+	l.SetParseMode(ParseModeShow)
+
+	for _, tok := range l.Tokenize() {
+		if tok.Value == "GigabitEthernet0/2" && tok.Type != TokenInterface {
+			t.Errorf("interface after pager should be TokenInterface, got %v", tok.Type)
+		}
+		if tok.Value == "down" && tok.Type != TokenStateBad {
+			t.Errorf("state after pager should be TokenStateBad, got %v", tok.Type)
+		}
+	}
+}
+
+func TestDashesAreNotPager(t *testing.T) {
+	for _, input := range []string{"---- ----\n", "<none>\n", "-1\n"} {
+		for _, tok := range New(input).Tokenize() {
+			if tok.Type == TokenPager {
+				t.Errorf("%q should not produce a pager token", input)
+			}
+		}
+	}
+}
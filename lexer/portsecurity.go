@@ -0,0 +1,178 @@
+package lexer
+
+import "strings"
+
+// show port-security, show ip dhcp snooping binding, show errdisable
+var (
+	// Violation modes, in the Security Action column and after "Violation
+	// Mode": what the switch does to a port that sees an unknown MAC
+	portSecurityModes = map[string]bool{"shutdown": true, "restrict": true, "protect": true}
+
+	// Port Status of show port-security interface. Secure-down is a port
+	// that is down for other reasons; secure-shutdown has had a violation.
+	portSecurityStatuses = map[string]TokenType{
+		"secure-up": TokenStateGood, "secure-down": TokenStateNeutral,
+		"secure-shutdown": TokenStateBad,
+	}
+
+	// Types of secure MAC address
+	secureAddressTypes = map[string]bool{
+		"securesticky": true, "securedynamic": true, "secureconfigured": true,
+		"sticky": true, "dynamic": true, "static": true,
+	}
+
+	portSecurityProfile = &ShowProfile{
+		Name: "port-security",
+		Indicators: []string{
+			"maxsecureaddr", "securityviolation", "security action",
+			"secure mac address table", "violation mode", "security violation count",
+			"last source address", "sticky mac addresses",
+		},
+		Classify: classifyPortSecurity,
+	}
+
+	dhcpSnoopingProfile = &ShowProfile{
+		Name: "dhcp-snooping",
+		Indicators: []string{
+			"dhcp-snooping", "lease(sec)", "total number of bindings",
+			"dhcp snooping is", "option 82",
+		},
+		Classify: classifyDHCPSnooping,
+	}
+
+	errdisableProfile = &ShowProfile{
+		Name: "errdisable",
+		Indicators: []string{
+			"errdisable reason", "timer status", "timer interval",
+			"interfaces that will be enabled", "time left(sec)", "err-disabled vlans",
+		},
+		Classify: classifyErrdisable,
+	}
+)
+
+// classifyPortSecurity handles the summary table, the interface detail and
+// the secure address table of show port-security:
+//
+//	Secure Port  MaxSecureAddr  CurrentAddr  SecurityViolation  Security Action
+//	      Gi1/0/2              2            1                  3         Restrict
+//
+//	Port Status                : Secure-shutdown
+//	Violation Mode             : Shutdown
+//	Security Violation Count   : 1
+//
+// Violation counts above zero are bad, and violation modes are actions.
+func classifyPortSecurity(l *Lexer, word, lower string) (TokenType, bool) {
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, _ := l.lineAt(lineStart)
+	start := l.pos - len(word) - lineStart
+
+	// "Label   : value"
+	if sep := strings.Index(line, " :"); sep >= 0 {
+		if start < sep {
+			return TokenText, true
+		}
+		label := strings.ToLower(strings.Join(strings.Fields(line[:sep]), " "))
+		switch {
+		case label == "violation mode" && portSecurityModes[lower]:
+			return TokenAction, true
+		case label == "port status":
+			if t, ok := portSecurityStatuses[lower]; ok {
+				return t, true
+			}
+		case label == "security violation count" && isAllDigits(word):
+			return violationCount(word), true
+		case strings.HasPrefix(label, "last source address") && macPatternCisco.MatchString(strings.Split(word, ":")[0]):
+			// "0011.2233.4455:10", the MAC and its VLAN
+			return TokenMAC, true
+		}
+		return TokenText, false
+	}
+
+	cols := lineColumns(line)
+	if len(cols) < 5 || !interfacePattern.MatchString(line[cols[0].start:cols[0].end]) {
+		if secureAddressTypes[lower] && len(cols) >= 4 {
+			return TokenKeyword, true
+		}
+		return TokenText, false
+	}
+	switch wordColumn(cols, start) {
+	case 3:
+		if isAllDigits(word) {
+			return violationCount(word), true
+		}
+	case 4:
+		if portSecurityModes[lower] {
+			return TokenAction, true
+		}
+	}
+	return TokenText, false
+}
+
+// violationCount returns the type of a count of port security violations:
+// bad if there were any.
+func violationCount(word string) TokenType {
+	if strings.TrimLeft(word, "0") != "" {
+		return TokenStateBad
+	}
+	return TokenNumber
+}
+
+// classifyDHCPSnooping handles the binding table of show ip dhcp snooping
+// binding:
+//
+//	MacAddress          IpAddress        Lease(sec)  Type           VLAN  Interface
+//	00:11:22:33:44:55   10.1.10.21       86234       dhcp-snooping   10    GigabitEthernet1/0/5
+//
+// Binding types are keywords, and so is a lease that never expires.
+func classifyDHCPSnooping(l *Lexer, word, lower string) (TokenType, bool) {
+	switch lower {
+	case "dhcp-snooping", "static", "infinite":
+		if macPatternColon.MatchString(l.lineCommand) || macPatternCisco.MatchString(l.lineCommand) {
+			return TokenKeyword, true
+		}
+	}
+	return TokenText, false
+}
+
+// classifyErrdisable handles show errdisable recovery and detect, and show
+// interfaces status err-disabled:
+//
+//	ErrDisable Reason            Timer Status
+//	psecure-violation            Enabled
+//
+//	Interface       Errdisable reason       Time left(sec)
+//	Gi1/0/2         psecure-violation          273
+//
+// The reasons listed are keywords, and an enabled detector or recovery timer
+// is good; the reason a port is disabled is bad.
+func classifyErrdisable(l *Lexer, word, lower string) (TokenType, bool) {
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, _ := l.lineAt(lineStart)
+	cols := lineColumns(line)
+	if len(cols) < 2 || l.isHeaderLine() {
+		return TokenText, false
+	}
+	field := wordColumn(cols, l.pos-len(word)-lineStart)
+	first := line[cols[0].start:cols[0].end]
+
+	if interfacePattern.MatchString(first) {
+		// The reason follows the port state, or the port in the recovery table
+		if l.prevWord == "err-disabled" || (field == 1 && !isAllDigits(word) && !strings.Contains(strings.ToLower(line), "err-disabled")) {
+			return TokenStateBad, true
+		}
+		return TokenText, false
+	}
+	// "Timer interval: 300 seconds", and the rules under the headers
+	if strings.Contains(line, ":") || strings.HasPrefix(word, "-") {
+		return TokenText, false
+	}
+	switch {
+	case field == 0:
+		return TokenKeyword, true
+	case lower == "enabled":
+		return TokenStateGood, true
+	case lower == "disabled":
+		return TokenStateNeutral, true
+	}
+	return TokenText, false
+}
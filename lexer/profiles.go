@@ -0,0 +1,253 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ShowProfile refines show mode classification for the output of a specific
+// show command. Words that are ambiguous in general (e.g. "Root", "BLK") get a
+// meaning once the profile knows which command produced the output.
+//
+// Classify is called for every word of show output once the profile is
+// detected, before the general show rules. It can use the lexer's PrevWord,
+// PeekWord and LineText for context.
+type ShowProfile struct {
+	Name       string
+	Indicators []string // lowercase substrings that identify the output
+	Classify   func(l *Lexer, word, lower string) (TokenType, bool)
+}
+
+// detectShowProfile returns the profile whose indicators best match the
+// sample, or nil if no profile has at least one indicator hit.
+func detectShowProfile(sample string, profiles []*ShowProfile) *ShowProfile {
+	lower := strings.ToLower(sample)
+
+	var best *ShowProfile
+	bestScore := 0
+	for _, p := range profiles {
+		score := 0
+		for _, ind := range p.Indicators {
+			if strings.Contains(lower, ind) {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = p, score
+		}
+	}
+	return best
+}
+
+// PrevWord returns the previous word on the current line in lowercase, or ""
+// at the start of a line. Meant for ShowProfile classifiers.
+func (l *Lexer) PrevWord() string {
+	return l.prevWord
+}
+
+// PeekWord returns the next word on the current line without consuming input.
+// Meant for ShowProfile classifiers.
+func (l *Lexer) PeekWord() string {
+	return l.peekWord()
+}
+
+// LineText returns the current input line in lowercase. Meant for ShowProfile
+// classifiers.
+func (l *Lexer) LineText() string {
+	return l.lineText()
+}
+
+// show spanning-tree
+var (
+	stpRoles = map[string]TokenType{
+		"root": TokenStateGood, "desg": TokenStateGood, "mstr": TokenStateGood,
+		"altn": TokenStateWarning, "back": TokenStateWarning,
+		"dis": TokenStateBad,
+	}
+
+	stpStates = map[string]TokenType{
+		"fwd": TokenStateGood,
+		"lrn": TokenStateWarning, "lis": TokenStateWarning,
+		"blk": TokenStateBad, "bkn": TokenStateBad,
+	}
+
+	stpHeaders = map[string]bool{
+		"role": true, "sts": true, "cost": true, "prio.nbr": true,
+	}
+
+	stpBridgeIDPattern   = regexp.MustCompile(`^\d{1,5}\.[0-9a-fA-F]{4}\.[0-9a-fA-F]{4}\.[0-9a-fA-F]{4}$`)
+	stpVLANHeaderPattern = regexp.MustCompile(`^(?i)(VLAN\d{4}|MST\d+)$`)
+	stpPortIDPattern     = regexp.MustCompile(`^\d+\.\d+$`)
+
+	spanningTreeProfile = &ShowProfile{
+		Name: "spanning-tree",
+		Indicators: []string{
+			"spanning tree enabled protocol", "root id", "bridge id",
+			"prio.nbr", "role sts", "vlan0", "mst0",
+		},
+		Classify: classifySpanningTree,
+	}
+)
+
+// classifySpanningTree handles roles, port states, bridge IDs and per-VLAN
+// headers in show spanning-tree output.
+func classifySpanningTree(l *Lexer, word, lower string) (TokenType, bool) {
+	// "Root ID" / "Bridge ID" labels rather than the Root port role
+	if (lower == "root" || lower == "bridge") && strings.EqualFold(l.peekWord(), "id") {
+		return TokenColumnHeader, true
+	}
+	if lower == "id" && (l.prevWord == "root" || l.prevWord == "bridge") {
+		return TokenColumnHeader, true
+	}
+
+	if t, ok := stpRoles[lower]; ok {
+		return t, true
+	}
+	if t, ok := stpStates[lower]; ok {
+		return t, true
+	}
+	if stpHeaders[lower] {
+		return TokenColumnHeader, true
+	}
+
+	if stpVLANHeaderPattern.MatchString(word) {
+		return TokenSection, true
+	}
+	if stpBridgeIDPattern.MatchString(word) {
+		return TokenBridgeID, true
+	}
+	if l.prevWord == "priority" && isAllDigits(word) {
+		return TokenBridgeID, true
+	}
+	if stpPortIDPattern.MatchString(word) {
+		return TokenNumber, true
+	}
+
+	return TokenText, false
+}
+
+// show version
+var (
+	versionParenPattern  = regexp.MustCompile(`^\d+\.\d+\(\d+\)[\w.]*,?$`)
+	memorySizePattern    = regexp.MustCompile(`^\d+[KMG](/\d+[KMG])?$`)
+	configRegPattern     = regexp.MustCompile(`^0x[0-9a-fA-F]{3,4}\)?$`)
+	versionDottedPattern = regexp.MustCompile(`^\d{1,2}\.\d{1,2}\.\d{1,2}[a-z]?,?$`)
+	ciscoModelPattern    = regexp.MustCompile(`^(WS-C|C\d{4}|ISR\d|ASR\d|N\dK-|IE-\d|CSR\d|C8\d{3}|CISCO\d)[\w-/+]*$`)
+	modelPattern         = regexp.MustCompile(`^[A-Z][A-Z0-9]*-?[A-Z0-9][A-Z0-9-/+]*\d[A-Z0-9-/+]*$`)
+	versionUptimeUnits   = map[string]bool{
+		"year": true, "years": true, "week": true, "weeks": true,
+		"day": true, "days": true, "hour": true, "hours": true,
+		"minute": true, "minutes": true, "second": true, "seconds": true,
+	}
+
+	showVersionProfile = &ShowProfile{
+		Name: "version",
+		Indicators: []string{
+			"cisco ios", "uptime is", "configuration register is",
+			"processor board id", "system image file is", "bytes of memory",
+		},
+		Classify: classifyShowVersion,
+	}
+)
+
+// classifyShowVersion handles version strings, models, serial numbers, uptime,
+// memory sizes and the configuration register in show version output.
+func classifyShowVersion(l *Lexer, word, lower string) (TokenType, bool) {
+	line := l.lineText()
+
+	switch l.prevWord {
+	case "version":
+		if word[0] >= '0' && word[0] <= '9' {
+			return TokenVersion, true
+		}
+	case "id":
+		// Processor board ID FCW2233L0AB
+		if strings.Contains(line, "board id") {
+			return TokenSerial, true
+		}
+	case "cisco":
+		// cisco C9300-48P (X86) processor ...
+		if modelPattern.MatchString(word) {
+			return TokenModel, true
+		}
+	case ":":
+		// System Serial Number : FOC1234X0AB, Model Number : C9300-48P
+		switch {
+		case strings.Contains(line, "serial number"):
+			return TokenSerial, true
+		case strings.HasPrefix(strings.TrimSpace(line), "model number"):
+			return TokenModel, true
+		}
+	}
+
+	if versionParenPattern.MatchString(word) || versionDottedPattern.MatchString(word) {
+		return TokenVersion, true
+	}
+	if ciscoModelPattern.MatchString(word) {
+		return TokenModel, true
+	}
+	if configRegPattern.MatchString(word) && strings.Contains(line, "register") {
+		return TokenConfigRegister, true
+	}
+	if memorySizePattern.MatchString(word) && strings.EqualFold(l.peekWord(), "bytes") {
+		return TokenMemorySize, true
+	}
+	if strings.Contains(line, "uptime is") || strings.Contains(line, "uptime for") {
+		if isAllDigits(word) || versionUptimeUnits[strings.TrimSuffix(lower, ",")] {
+			return TokenUptime, true
+		}
+	}
+
+	return TokenText, false
+}
+
+// show ip arp
+var (
+	arpEncapsulations = map[string]bool{
+		"arpa": true, "snap": true, "sap": true, "probe": true,
+	}
+
+	arpHeaders = map[string]bool{
+		"protocol": true, "address": true, "age": true, "(min)": true,
+		"hardware": true, "addr": true, "type": true, "interface": true,
+		"mac": true, "flags": true,
+	}
+
+	showARPProfile = &ShowProfile{
+		Name: "arp",
+		Indicators: []string{
+			"hardware addr", "age (min)", "arpa", "ip arp table",
+		},
+		Classify: classifyShowARP,
+	}
+)
+
+// classifyShowARP handles the header, age column, incomplete entries and
+// encapsulation types in show ip arp output.
+func classifyShowARP(l *Lexer, word, lower string) (TokenType, bool) {
+	if arpHeaders[lower] && strings.Contains(l.lineText(), "age") {
+		return TokenColumnHeader, true
+	}
+
+	// Internet  10.0.0.2  12  0011.2233.4466  ARPA  Vlan10
+	if lower == "internet" && l.lineCommand == "" {
+		return TokenProtocol, true
+	}
+	if l.prevAddr {
+		switch {
+		case isAllDigits(word):
+			return TokenTimeDuration, true
+		case word == "-":
+			// Entries for the router's own addresses never age
+			return TokenStateNeutral, true
+		}
+	}
+
+	switch {
+	case lower == "incomplete":
+		return TokenStateBad, true
+	case arpEncapsulations[lower]:
+		return TokenKeyword, true
+	}
+	return TokenText, false
+}
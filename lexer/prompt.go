@@ -0,0 +1,36 @@
+package lexer
+
+import "strings"
+
+// scanPromptLine tokenizes a prompt line such as "R1(config-if)#no shutdown"
+// met in the middle of multi-line input, where a show command was captured
+// after other output or a configuration was pasted with its prompts. The
+// line's tokens are queued and handed out one at a time by nextPromptToken;
+// the newline is left for normal scanning.
+func (l *Lexer) scanPromptLine() (Token, bool) {
+	if l.col != 1 || (l.pos > 0 && l.input[l.pos-1] != '\n') {
+		return Token{}, false
+	}
+	text := l.input[l.pos:]
+	if end := strings.IndexByte(text, '\n'); end >= 0 {
+		text = text[:end]
+	}
+	// Most lines have no prompt character, so skip the pattern for them
+	if !strings.ContainsAny(text, "#>") || transcriptPrompt(text) == nil {
+		return Token{}, false
+	}
+
+	l.queued = l.tryTokenizePrompt(text, l.line)
+	return l.nextPromptToken(), true
+}
+
+// nextPromptToken returns the next queued prompt line token, consuming its
+// text so the position stays in step with the tokens returned.
+func (l *Lexer) nextPromptToken() Token {
+	token := l.queued[0]
+	l.queued = l.queued[1:]
+	for end := l.pos + len(token.Value); l.pos < end; {
+		l.advance()
+	}
+	return token
+}
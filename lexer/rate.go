@@ -0,0 +1,97 @@
+package lexer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Rates written as one word: "10Gbps", "100kbps", "500pps", "1000Mb/s"
+	ratePattern = regexp.MustCompile(`^\d+(\.\d+)?(?i:[kmgt]?bps|[kmgt]?pps|[kmgt]?b/s)$`)
+
+	// Rate units written after a number: "1000 bits/sec", "BW 100000
+	// Kbit/sec", "2 packets/sec", "10 Mbps"
+	rateUnitPattern = regexp.MustCompile(`^(?i:([kmgt]?)(b|bits?|bytes?)/s(ec)?|packets/s(ec)?|pkts/s(ec)?|([kmgt]?)(bps|pps))$`)
+
+	// Config arguments that are rates, keyed on the word before them. A
+	// non-empty value is the command the line must start with.
+	rateArguments = map[string]string{
+		"bandwidth": "", // kbps: "bandwidth 10000", policy-map "bandwidth 5000"
+		"speed":     "", // Mbps: "speed 1000"
+		"police":    "", // bps: "police 8000000 8000 exceed-action drop"
+		"cir":       "police",
+		"pir":       "police",
+		"average":   "shape", // bps: "shape average 10000000"
+		"peak":      "shape",
+	}
+
+	rateNumberPattern = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+	rateMultipliers = map[string]float64{"": 1, "k": 1e3, "m": 1e6, "g": 1e9, "t": 1e12}
+)
+
+// scanRateUnit extends the word just scanned over a rate unit following it,
+// so "1000 bits/sec" becomes one token. Punctuation after the unit, as in
+// "bits/sec,", is left for the next token. It reports whether it did.
+func (l *Lexer) scanRateUnit(word string) bool {
+	if !startsWithDigit(word) || !rateNumberPattern.MatchString(word) {
+		return false
+	}
+	unit := strings.TrimRight(l.peekWord(), ",;)")
+	if !rateUnitPattern.MatchString(unit) {
+		return false
+	}
+	for l.input[l.pos] == ' ' || l.input[l.pos] == '\t' {
+		l.advance()
+	}
+	for i := 0; i < len(unit); i++ {
+		l.advance()
+	}
+	return true
+}
+
+// classifyRateConfig classifies the numeric arguments of bandwidth, speed,
+// police and shape commands, which carry their unit implicitly.
+func (l *Lexer) classifyRateConfig(word string) (TokenType, bool) {
+	command, ok := rateArguments[l.prevWord]
+	if ok && (command == "" || command == l.lineCommand) && isAllDigits(word) {
+		return TokenRate, true
+	}
+	return TokenText, false
+}
+
+// ParseRate converts a rate with an explicit unit (the values classified as
+// TokenRate in show output, such as "1000000 bits/sec", "100000 Kbit/sec",
+// "10Gbps", "1000Mb/s" or "2 packets/sec") to a number per second. packets reports
+// whether the rate counts packets; byte rates are converted to bits. Bare
+// config arguments such as "bandwidth 10000" have no unit and are rejected.
+func ParseRate(s string) (perSecond float64, packets bool, err error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i <= 0 || !rateNumberPattern.MatchString(s[:i]) {
+		return 0, false, fmt.Errorf("invalid rate %q", s)
+	}
+	m := rateUnitPattern.FindStringSubmatch(strings.TrimSpace(s[i:]))
+	if m == nil {
+		return 0, false, fmt.Errorf("invalid rate %q", s)
+	}
+	n, _ := strconv.ParseFloat(s[:i], 64)
+
+	switch {
+	case m[2] != "": // b/s, bits/sec or bytes/sec, with an optional prefix
+		n *= rateMultipliers[strings.ToLower(m[1])]
+		if strings.HasPrefix(strings.ToLower(m[2]), "byte") {
+			n *= 8
+		}
+	case m[7] != "": // bps or pps, with an optional prefix
+		n *= rateMultipliers[strings.ToLower(m[6])]
+		packets = strings.EqualFold(m[7], "pps")
+	default: // packets/sec, pkts/sec
+		packets = true
+	}
+	return n, packets, nil
+}
@@ -0,0 +1,77 @@
+package lexer
+
+import "testing"
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		input     string
+		perSecond float64
+		packets   bool
+		wantErr   bool
+	}{
+		{"1000000 bits/sec", 1e6, false, false},
+		{"100000 Kbit/sec", 1e8, false, false},
+		{"10Gbps", 1e10, false, false},
+		{"1.5Mbps", 1.5e6, false, false},
+		{"1000Mb/s", 1e9, false, false},
+		{"125 bytes/sec", 1000, false, false},
+		{"2 packets/sec", 2, true, false},
+		{"500 pps", 500, true, false},
+		{"10kpps", 1e4, true, false},
+		{"10000", 0, false, true},
+		{"fast", 0, false, true},
+		{"10 furlongs/sec", 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, packets, err := ParseRate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.perSecond || packets != tt.packets {
+				t.Errorf("ParseRate(%q) = %v, %v, want %v, %v", tt.input, got, packets, tt.perSecond, tt.packets)
+			}
+		})
+	}
+}
+
+func TestTokenizeRates(t *testing.T) {
+	tests := []struct {
+		input    string
+		mode     ParseMode
+		value    string
+		expected TokenType
+	}{
+		{"  5 minute input rate 1000 bits/sec, 2 packets/sec\n", ParseModeShow, "1000 bits/sec", TokenRate},
+		{"  5 minute input rate 1000 bits/sec, 2 packets/sec\n", ParseModeShow, "2 packets/sec", TokenRate},
+		{"  5 minute input rate 1000 bits/sec, 2 packets/sec\n", ParseModeShow, ",", TokenText},
+		{"  MTU 1500 bytes, BW 1000000 Kbit/sec, DLY 10 usec,\n", ParseModeShow, "1000000 Kbit/sec", TokenRate},
+		{"  MTU 1500 bytes, BW 1000000 Kbit/sec, DLY 10 usec,\n", ParseModeShow, "1500", TokenNumber},
+		{"  Full-duplex, 1000Mb/s, media type is RJ45\n", ParseModeShow, "1000Mb/s", TokenRate},
+		{"uplink capacity 10Gbps\n", ParseModeShow, "10Gbps", TokenRate},
+		{"interface Gi0/1\n bandwidth 10000\n", ParseModeConfig, "10000", TokenRate},
+		{"interface Gi0/1\n speed 1000\n", ParseModeConfig, "1000", TokenRate},
+		{"policy-map P\n class C\n  police 8000000 8000 exceed-action drop\n", ParseModeConfig, "8000000", TokenRate},
+		{"policy-map P\n class C\n  police 8000000 8000 exceed-action drop\n", ParseModeConfig, "8000", TokenNumber},
+		{"policy-map P\n class C\n  shape average 10000000\n", ParseModeConfig, "10000000", TokenRate},
+		{"policy-map P\n class C\n  bandwidth percent 20\n", ParseModeConfig, "20", TokenNumber},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		l.SetParseMode(tt.mode)
+		found := false
+		for _, tok := range l.Tokenize() {
+			if tok.Value == tt.value {
+				found = true
+				if tok.Type != tt.expected {
+					t.Errorf("%q: %q expected %v, got %v", tt.input, tt.value, tt.expected, tok.Type)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%q: token %q not found", tt.input, tt.value)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// show redundancy [states], show switch
+var (
+	// Values of "key = value" fields in show redundancy. SSO keeps the
+	// standby in sync, RPR modes make a switchover a reload; a standby
+	// processor is ready only when HOT, and COLD-BULK is still taking the bulk
+	// sync.
+	redundancyStates = map[string]TokenType{
+		"sso": TokenStateGood, "duplex": TokenStateGood, "active": TokenStateGood,
+		"hot": TokenStateGood, "up": TokenStateGood,
+		"rpr": TokenStateWarning, "rpr+": TokenStateWarning, "rpr-plus": TokenStateWarning,
+		"simplex": TokenStateWarning, "cold": TokenStateWarning, "cold-bulk": TokenStateWarning,
+		"cold-config": TokenStateWarning, "cold-file-sys": TokenStateWarning,
+		"negotiation": TokenStateWarning, "init": TokenStateWarning,
+		"disabled": TokenStateBad, "down": TokenStateBad,
+	}
+
+	// Stack member roles and states in show switch. Provisioned is a member
+	// configured but not present; a version mismatch keeps it out of the
+	// stack.
+	stackStates = map[string]TokenType{
+		"active": TokenStateGood, "ready": TokenStateGood, "standby": TokenStateNeutral,
+		"provisioned": TokenStateWarning, "initializing": TokenStateWarning, "progressing": TokenStateWarning,
+		"waiting": TokenStateWarning, "syncing": TokenStateWarning, "hasync": TokenStateWarning,
+		"removed": TokenStateBad, "v-mismatch": TokenStateBad, "lic-mismatch": TokenStateBad,
+		"mismatch": TokenStateBad, "invalid": TokenStateBad,
+	}
+
+	// The local switch of a stack: "*1"
+	localSwitchPattern = regexp.MustCompile(`^\*\d+$`)
+
+	redundancyProfile = &ShowProfile{
+		Name: "redundancy",
+		Indicators: []string{
+			"redundant system information", "current processor information",
+			"peer processor information", "redundancy mode", "current software state",
+			"switchovers system experienced", "my state =", "peer state =",
+		},
+		Classify: classifyRedundancy,
+	}
+
+	stackProfile = &ShowProfile{
+		Name: "stack",
+		Indicators: []string{
+			"switch/stack mac address", "mac persistency wait time",
+			"priority version", "provisioned",
+		},
+		Classify: classifyStack,
+	}
+)
+
+// classifyRedundancy handles the fields of show redundancy:
+//
+//	Configured Redundancy Mode = sso
+//	    Current Software state = STANDBY HOT
+//	            peer state = 8  -STANDBY COLD-BULK
+//
+// Only values after the "=" are colored, so labels such as "Active Location"
+// stay text. STANDBY takes the state of the word after it.
+func classifyRedundancy(l *Lexer, word, lower string) (TokenType, bool) {
+	line := l.lineText()
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	eq := strings.IndexByte(line, '=')
+	if eq < 0 {
+		return TokenText, false
+	}
+	if l.pos-len(word)-lineStart < eq {
+		return TokenText, true
+	}
+	lower = strings.TrimPrefix(lower, "-")
+	if strings.Contains(line[:eq], "maintenance mode") {
+		// Maintenance mode takes the processor out of service when on
+		if lower == "enabled" {
+			return TokenStateWarning, true
+		}
+		return TokenText, lower == "disabled"
+	}
+	if lower == "standby" {
+		if t, ok := redundancyStates[strings.ToLower(l.peekWord())]; ok {
+			return t, true
+		}
+		return TokenStateNeutral, true
+	}
+	if t, ok := redundancyStates[lower]; ok {
+		return t, true
+	}
+	return TokenText, false
+}
+
+// classifyStack handles the member table of show switch:
+//
+//	Switch#   Role    Mac Address     Priority Version  State
+//	-------------------------------------------------------------
+//	*1       Active   0011.2233.4455     15     V01     Ready
+//	 3       Member   0011.2233.4477     1      0       Provisioned
+//
+// The local switch marker is a status symbol, and roles and states are
+// colored by whether the member is in the stack.
+func classifyStack(l *Lexer, word, lower string) (TokenType, bool) {
+	// "Switch/Stack Mac Address : 0011.2233.4455 - Local Mac Address"
+	if strings.HasPrefix(l.lineText(), "switch/stack mac address") && !macPatternCisco.MatchString(word) {
+		return TokenText, true
+	}
+	if localSwitchPattern.MatchString(word) && l.prevWord == "" {
+		return TokenStatusSymbol, true
+	}
+	// "Version Mismatch", "HA Sync in progress"
+	next := strings.ToLower(l.peekWord())
+	switch {
+	case lower == "version" && next == "mismatch":
+		return TokenStateBad, true
+	case lower == "ha" && next == "sync", lower == "sync" && l.prevWord == "ha":
+		return TokenStateWarning, true
+	}
+	if t, ok := stackStates[lower]; ok && l.prevWord != "" {
+		return t, true
+	}
+	return TokenText, false
+}
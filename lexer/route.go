@@ -0,0 +1,80 @@
+package lexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// show ip route, show ipv6 route
+var (
+	// Route codes before a route: "C", "S*", "O", "E2" of "O E2", "OE2",
+	// "OI", "B", "D", "EX", "i", "L2", "ND", "NDp", "l"
+	routeCodePattern = regexp.MustCompile(`^[A-Za-z]{1,3}[12]?[*+%&]?$`)
+
+	// Administrative distance and metric: "[110/20]", "[0/0],"
+	routeDistancePattern = regexp.MustCompile(`^\[\d+/\d+\],?$`)
+
+	routeTableProfile = &ShowProfile{
+		Name: "route",
+		Indicators: []string{
+			"gateway of last resort", "ipv6 routing table", "is directly connected",
+			"is variably subnetted", "directly connected", "per-user static route",
+			"codes: c - connected", "codes: l - local", "ospf inter area", "ospf inter",
+		},
+		Classify: classifyRouteTable,
+	}
+)
+
+// classifyRouteTable handles the routes of show ip route and show ipv6 route:
+//
+//	IPv6 Routing Table - default - 9 entries
+//	O   2001:DB8:2::/64 [110/2]
+//	     via FE80::2, GigabitEthernet0/0/0
+//	OE2 ::/0 [110/1], tag 1
+//	     via FE80::1%GigabitEthernet0/0/0
+//	O E2     10.2.0.0/24 [110/20] via 10.0.0.3, 00:10:12, GigabitEthernet0/1
+//
+// The codes before a route are status symbols, and its distance and metric
+// a number. Next hops, link-local ones with their interface included, are
+// left to the general rules.
+func classifyRouteTable(l *Lexer, word, lower string) (TokenType, bool) {
+	switch {
+	case routeDistancePattern.MatchString(word):
+		return TokenNumber, true
+	case lower == "via":
+		return TokenKeyword, true
+	case l.prevWord == "-" && strings.HasPrefix(strings.TrimSpace(l.lineText()), "ipv6 routing table -"):
+		// The VRF: "IPv6 Routing Table - default - 9 entries"
+		if !isAllDigits(word) {
+			return TokenValue, true
+		}
+	}
+	if routeCodePattern.MatchString(word) && isRouteCode(l, word) {
+		return TokenStatusSymbol, true
+	}
+	return TokenText, false
+}
+
+// isRouteCode reports whether word is one of the codes a route starts with:
+// at most two words, the first at the start of the line, before the prefix.
+func isRouteCode(l *Lexer, word string) bool {
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	if l.input[lineStart] == ' ' || l.input[lineStart] == '\t' {
+		return false
+	}
+	line, _ := l.lineAt(lineStart)
+	fields := strings.Fields(line)
+	for i, f := range fields {
+		if i > 2 {
+			return false
+		}
+		if isAddressWord(f) {
+			before := len(strings.Fields(l.input[lineStart : l.pos-len(word)]))
+			return before < i
+		}
+		if !routeCodePattern.MatchString(f) {
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package lexer
+
+import "strings"
+
+// show ip route summary, show ipv6 route summary
+var (
+	// Route sources the table lists besides the routing protocols: routes
+	// installed by applications, and the internal (memory) row
+	routeSummarySources = map[string]bool{
+		"application": true, "connected": true, "static": true, "local": true,
+		"internal": true, "lisp": true, "nhrp": true, "dhcp": true, "omp": true,
+		"mobile": true, "odr": true, "rip": true, "ospf": true, "ospfv3": true,
+		"eigrp": true, "isis": true, "bgp": true, "lisp-pub": true,
+	}
+
+	routeSummaryProfile = &ShowProfile{
+		Name: "route-summary",
+		Indicators: []string{
+			"route source", "routing table name is", "maximum-paths is",
+			"overhead    memory", "replicates", "networks    subnets",
+		},
+		Classify: classifyRouteSummary,
+	}
+)
+
+// classifyRouteSummary handles the per-source route counts:
+//
+//	IP routing table name is default (0x0)
+//	Route Source    Networks    Subnets     Replicates  Overhead    Memory (bytes)
+//	connected       0           4           0           384         1216
+//	ospf 1          0           12          0           1152        3648
+//	  Intra-area: 6 Inter-area: 6 External-1: 0 External-2: 0
+//	bgp 65000       120         450         0           54720       173280
+//	Total           126         466         0           56352       202428
+//
+// The first column names the source, a protocol with its process ID or AS
+// number; "connected" is a source here, not a port state. Whether the counts
+// are as expected is left to the highlighter, which can be told what to
+// expect of each source.
+func classifyRouteSummary(l *Lexer, word, lower string) (TokenType, bool) {
+	line := strings.TrimSpace(l.lineText())
+	switch {
+	case strings.HasPrefix(line, "route source"):
+		return TokenColumnHeader, true
+	case strings.HasPrefix(line, "ip routing table name is") || strings.HasPrefix(line, "ipv6 routing table name is"):
+		// The VRF: "IP routing table name is default (0x0)"
+		if l.prevWord == "is" {
+			return TokenValue, true
+		}
+		return TokenText, false
+	}
+
+	if l.prevWord == "" {
+		if routeSummarySources[lower] {
+			return TokenProtocol, true
+		}
+		if lower == "total" {
+			return TokenKeyword, true
+		}
+		return TokenText, false
+	}
+	// "ospf 1", "bgp 65000", "eigrp 100": the process ID or AS number
+	if l.lineCommand == l.prevWord && routeSummarySources[l.prevWord] && isAllDigits(word) {
+		if l.prevWord == "bgp" {
+			return TokenASN, true
+		}
+		return TokenNumber, true
+	}
+	return TokenText, false
+}
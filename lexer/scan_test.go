@@ -0,0 +1,57 @@
+package lexer
+
+import (
+	"slices"
+	"testing"
+)
+
+var scanInputs = map[string]string{
+	"config":     "hostname R1\ninterface Gi0/1\n ip address 10.0.0.1 255.255.255.0\n!\n",
+	"prompt":     "R1#show ip interface brief",
+	"transcript": "R1#show ip interface brief\nInterface  IP-Address  OK? Method Status  Protocol\nGi0/1      10.0.0.1    YES manual up      up\nR1#",
+	"empty":      "",
+}
+
+func TestScan(t *testing.T) {
+	for name, input := range scanInputs {
+		t.Run(name, func(t *testing.T) {
+			var got []Token
+			Scan(input, func(token Token) bool {
+				got = append(got, token)
+				return true
+			})
+			if want := New(input).Tokenize(); !slices.Equal(got, want) {
+				t.Errorf("Scan gave %v, Tokenize %v", got, want)
+			}
+		})
+	}
+}
+
+func TestScanStop(t *testing.T) {
+	for name, input := range scanInputs {
+		t.Run(name, func(t *testing.T) {
+			want := New(input).Tokenize()
+			if len(want) < 2 {
+				return
+			}
+			var got []Token
+			Scan(input, func(token Token) bool {
+				got = append(got, token)
+				return len(got) < 2
+			})
+			if !slices.Equal(got, want[:2]) {
+				t.Errorf("Scan should stop after yield returns false, got %v, want %v", got, want[:2])
+			}
+		})
+	}
+}
+
+func TestScanThenRetokenize(t *testing.T) {
+	input := "hostname R1\ninterface Gi0/1\n description uplink\n"
+	l := New(input)
+	l.Scan(func(Token) bool { return true })
+	edited := "hostname R2\ninterface Gi0/1\n description uplink\n"
+	if got, want := l.Retokenize(Range{10, 11}, "2"), New(edited).Tokenize(); !slices.Equal(got, want) {
+		t.Errorf("Retokenize after Scan = %v, want %v", got, want)
+	}
+}
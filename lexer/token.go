@@ -1,5 +1,7 @@
 package lexer
 
+import "fmt"
+
 // TokenType represents the type of a lexical token
 type TokenType int
 
@@ -45,14 +47,94 @@ const (
 	TokenPromptMode // (config), (config-if), etc.
 	TokenPromptOper // > (user EXEC mode prompt char)
 	TokenPromptConf // # (privileged EXEC / config mode prompt char)
+
+	// Spanning-tree tokens
+	TokenBridgeID // 32769.0011.2233.4455, bridge priority values
+
+	// L3VPN tokens
+	TokenVRF                // VRF names after vrf, vrf forwarding, vrf context
+	TokenRouteDistinguisher // 65000:100 after rd
+	TokenRouteTarget        // 65000:100 after route-target import/export/both
+
+	// Terminal capture artifacts
+	TokenPager // --More-- prompts and the backspaces that erase them
+
+	// Dotted-quad masks
+	TokenSubnetMask   // 255.255.255.0 after ip address, ip route, mask, netmask
+	TokenWildcardMask // 0.0.0.255 in ACL entries and OSPF/EIGRP network statements
+
+	// show version tokens
+	TokenVersion        // 16.9.4, 15.2(4)E7 after "Version"
+	TokenModel          // C9300-48P, WS-C3850-24T platform/model numbers
+	TokenSerial         // FCW2233L0AB serial numbers
+	TokenUptime         // 1 year, 12 weeks, 3 days
+	TokenMemorySize     // 1392780K/6147K, 2048K bytes of memory
+	TokenConfigRegister // 0x2102
+
+	// Credentials
+	TokenSecret // reversible type 7 password: 0822455D0A16 after "password 7"
+
+	// MPLS
+	TokenMPLSLabel // 16, Pop Label, No Label, implicit-null in label columns and label ranges
+
+	// Rates
+	TokenRate // 1000000 bits/sec, 100000 Kbit/sec, 10Gbps, 500 pps; bandwidth 10000
+
+	// Rendering aids (inserted by the highlighter, not the lexer)
+	TokenIndentGuide // vertical guide in the leading whitespace of nested config lines
+
+	// CLI feedback
+	TokenError // "% Invalid input detected at '^' marker." and the "^" line above it
+
+	// First-hop redundancy
+	TokenVirtualIP // HSRP/VRRP/GLBP virtual IP: "Virtual IP address is 10.0.10.1", brief table column
+
+	// BGP
+	TokenPrivateASN // private use and reserved AS numbers: 64512-65534, 4200000000+, 23456, 0
+
+	// Age emphasis (inserted by the highlighter, not the lexer)
+	TokenStale // table rows dimmed for their age, such as old OSPF LSAs
+
+	// Dates
+	TokenTimestamp // calendar dates and times: "Mar 01 2024 12:04 AM" DHCP lease expirations
+
+	// Hexadecimal
+	TokenHex     // 0x2102, memory addresses 0x7F3A2B10, IPsec SPIs 0x1A2B3C4D(439041101)
+	TokenHexDump // a block of hex lines: certificates in config, show memory dumps
+
+	// Names defined in the input (see SetLearnNames)
+	TokenUserDefinedName // RM-IN in "route-map RM-IN permit 10" and "neighbor 10.0.0.1 route-map RM-IN in"
+
+	// Line numbers (inserted by the highlighter, not the lexer)
+	TokenLineNumber // right-aligned number and separator before each line
+
+	tokenTypeCount // number of token types; keep last
 )
 
 // Token represents a single lexical token
 type Token struct {
-	Type   TokenType
-	Value  string
-	Line   int
-	Column int
+	Type    TokenType    `json:"type"`
+	Value   string       `json:"value"`
+	Line    int          `json:"line"`
+	Column  int          `json:"column"`
+	Explain *Explanation `json:"explain,omitempty"` // nil unless the lexer explains (see SetExplain)
+}
+
+// MarshalText encodes the token type as its name, so tokens marshal to JSON
+// as {"type":"Interface",...}.
+func (t TokenType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText decodes a token type name written by MarshalText.
+func (t *TokenType) UnmarshalText(text []byte) error {
+	for _, tt := range AllTokenTypes() {
+		if tt.String() == string(text) {
+			*t = tt
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown token type %q", text)
 }
 
 // String returns a string representation of the token type
@@ -128,6 +210,58 @@ func (t TokenType) String() string {
 		return "PromptOper"
 	case TokenPromptConf:
 		return "PromptConf"
+	case TokenBridgeID:
+		return "BridgeID"
+	case TokenVRF:
+		return "VRF"
+	case TokenRouteDistinguisher:
+		return "RouteDistinguisher"
+	case TokenRouteTarget:
+		return "RouteTarget"
+	case TokenPager:
+		return "Pager"
+	case TokenSubnetMask:
+		return "SubnetMask"
+	case TokenWildcardMask:
+		return "WildcardMask"
+	case TokenVersion:
+		return "Version"
+	case TokenModel:
+		return "Model"
+	case TokenSerial:
+		return "Serial"
+	case TokenUptime:
+		return "Uptime"
+	case TokenMemorySize:
+		return "MemorySize"
+	case TokenConfigRegister:
+		return "ConfigRegister"
+	case TokenSecret:
+		return "Secret"
+	case TokenMPLSLabel:
+		return "MPLSLabel"
+	case TokenRate:
+		return "Rate"
+	case TokenIndentGuide:
+		return "IndentGuide"
+	case TokenError:
+		return "Error"
+	case TokenVirtualIP:
+		return "VirtualIP"
+	case TokenPrivateASN:
+		return "PrivateASN"
+	case TokenStale:
+		return "Stale"
+	case TokenTimestamp:
+		return "Timestamp"
+	case TokenHex:
+		return "Hex"
+	case TokenHexDump:
+		return "HexDump"
+	case TokenUserDefinedName:
+		return "UserDefinedName"
+	case TokenLineNumber:
+		return "LineNumber"
 	default:
 		return "Unknown"
 	}
@@ -0,0 +1,204 @@
+package lexer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// show interfaces transceiver [detail], show interface transceiver details (NX-OS)
+var (
+	// Flags printed after a reading that crossed a threshold: high alarm,
+	// high warning, low warning, low alarm
+	transceiverFlags = map[string]TokenType{
+		"++": TokenStateBad, "+": TokenStateWarning,
+		"-": TokenStateWarning, "--": TokenStateBad,
+	}
+
+	// Readings and thresholds: "35.1", "-40.0", "3.29"
+	transceiverValuePattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+	// Units after the values of the NX-OS rows
+	transceiverUnits = map[string]bool{"c": true, "v": true, "ma": true, "dbm": true, "mw": true}
+
+	// Row labels of the NX-OS table; "Tx Power" and "Rx Power" by their first word
+	transceiverMeasurements = map[string]bool{
+		"temperature": true, "voltage": true, "current": true, "tx": true, "rx": true,
+	}
+
+	transceiverProfile = &ShowProfile{
+		Name: "transceiver",
+		Indicators: []string{
+			"tx power", "rx power", "(dbm)", "dbm:", "high alarm", "high warn",
+			"low warn", "low alarm", "transceiver is present", "diagnostics information",
+		},
+		Classify: classifyTransceiver,
+	}
+)
+
+// The thresholds of a reading, in the order the IOS columns print them.
+type transceiverThresholds struct {
+	highAlarm, highWarn, lowWarn, lowAlarm float64
+}
+
+// transceiverValue is a reading or threshold on a row of optics output.
+type transceiverValue struct {
+	field int // column of the value
+	value float64
+	flag  TokenType // state of the flag after it, TokenText if none
+}
+
+// classifyTransceiver handles the optical monitoring (DOM) readings of
+// transceivers, in the IOS tables:
+//
+//	           Temperature  Voltage  Current     Tx Power  Rx Power
+//	Port       (Celsius)    (Volts)  (mA)        (dBm)     (dBm)
+//	---------  -----------  -------  --------    --------  --------
+//	Te1/1/2      34.8       3.30       6.5        -2.2     -40.0 --
+//
+//	                              High Alarm  High Warn  Low Warn   Low Alarm
+//	           Optical            Threshold   Threshold  Threshold  Threshold
+//	           Receive Power      Threshold   Threshold  Threshold  Threshold
+//	Port       (dBm)              (dBm)       (dBm)      (dBm)      (dBm)
+//	---------  -----------------  ----------  ---------  ---------  ---------
+//	Te1/1/1     -15.0                 2.0        -1.0      -13.9      -17.9
+//
+// and the NX-OS one:
+//
+//	              Current              Alarms                  Warnings
+//	              Measurement     High        Low         High          Low
+//	Rx Power     -12.50 dBm -     1.99 dBm  -13.97 dBm    -1.00 dBm      -9.91 dBm
+//
+// A reading takes the state of the alarm (++, --) or warning (+, -) flag
+// after it. Where the thresholds are on the same row the reading is also
+// checked against them, since not every release prints the flags. The
+// thresholds themselves are left plain, so the readings stand out, and
+// "transceiver is present" above the NX-OS table is a good state.
+func classifyTransceiver(l *Lexer, word, lower string) (TokenType, bool) {
+	lineStart := strings.LastIndexByte(l.input[:l.pos], '\n') + 1
+	line, _ := l.lineAt(lineStart)
+
+	// The legend: "++ : high alarm, +  : high warning, -  : low warning, -- : low alarm."
+	// and "Note: ++  high-alarm; +  high-warning; --  low-alarm; -  low-warning"
+	if t, ok := transceiverFlags[word]; ok && strings.Contains(strings.ToLower(line), "high warning") ||
+		ok && strings.Contains(strings.ToLower(line), "high-warning") {
+		if l.prevWord == "" || strings.ContainsAny(l.prevWord[len(l.prevWord)-1:], ":,;") {
+			return t, true
+		}
+	}
+
+	// "transceiver is present", "transceiver is not present"
+	if lower == "present" && strings.Contains(strings.ToLower(line), "transceiver is") {
+		if l.prevWord == "not" {
+			return TokenStateNeutral, true
+		}
+		return TokenStateGood, true
+	}
+
+	cols := lineColumns(line)
+	if len(cols) < 2 {
+		return TokenText, false
+	}
+	field := wordColumn(cols, l.pos-len(word)-lineStart)
+	if field <= 0 {
+		return TokenText, false
+	}
+
+	first := line[cols[0].start:cols[0].end]
+	nxos := transceiverMeasurements[strings.ToLower(first)]
+	if !nxos && !interfacePattern.MatchString(first) {
+		return TokenText, false
+	}
+	values := transceiverValues(line, cols)
+	if len(values) == 0 {
+		return TokenText, false
+	}
+
+	// IOS prints the thresholds in a table of their own, NX-OS next to the
+	// reading with the alarms first: High, Low, then the warnings
+	var limits *transceiverThresholds
+	switch {
+	case len(values) != 5:
+	case nxos:
+		limits = &transceiverThresholds{values[1].value, values[3].value, values[4].value, values[2].value}
+	case l.transceiverThresholdTable(lineStart):
+		limits = &transceiverThresholds{values[1].value, values[2].value, values[3].value, values[4].value}
+	}
+
+	for i, v := range values {
+		reading := i == 0 || limits == nil
+		switch {
+		case field == v.field && reading:
+			return transceiverReading(v, limits), true
+		case field == v.field:
+			return TokenText, true
+		case field > v.field && (i+1 == len(values) || field < values[i+1].field):
+			// The unit or flag after the value
+			if t, ok := transceiverFlags[word]; ok && reading && v.flag != TokenText {
+				return t, true
+			}
+			if transceiverUnits[lower] {
+				return TokenText, true
+			}
+			return TokenText, false
+		}
+	}
+	return TokenText, false
+}
+
+// transceiverValues returns the values on a row of optics output, with the
+// state of the flag printed after each.
+func transceiverValues(line string, cols []column) []transceiverValue {
+	var values []transceiverValue
+	for i := 1; i < len(cols); i++ {
+		w := line[cols[i].start:cols[i].end]
+		if transceiverValuePattern.MatchString(w) {
+			value, _ := strconv.ParseFloat(w, 64)
+			values = append(values, transceiverValue{field: i, value: value, flag: TokenText})
+			continue
+		}
+		if t, ok := transceiverFlags[w]; ok && len(values) > 0 {
+			values[len(values)-1].flag = t
+		}
+	}
+	return values
+}
+
+// transceiverReading returns the type of a reading: the state of its flag,
+// or of the thresholds it crossed, otherwise TokenNumber.
+func transceiverReading(v transceiverValue, limits *transceiverThresholds) TokenType {
+	if v.flag != TokenText {
+		return v.flag
+	}
+	if limits == nil {
+		return TokenNumber
+	}
+	switch {
+	case v.value >= limits.highAlarm || v.value <= limits.lowAlarm:
+		return TokenStateBad
+	case v.value >= limits.highWarn || v.value <= limits.lowWarn:
+		return TokenStateWarning
+	}
+	return TokenNumber
+}
+
+// transceiverThresholdTable reports whether the IOS table the row starting at
+// lineStart belongs to is one of thresholds, by the header above its rows.
+func (l *Lexer) transceiverThresholdTable(lineStart int) bool {
+	for lineStart > 0 {
+		lineStart = strings.LastIndexByte(l.input[:lineStart-1], '\n') + 1
+		line, _ := l.lineAt(lineStart)
+		switch {
+		case strings.TrimSpace(line) == "":
+			return false
+		case isUnderline(line):
+			// The header is the two to four lines above the underline
+			headerStart := lineStart
+			for i := 0; i < 4 && headerStart > 0; i++ {
+				headerStart = strings.LastIndexByte(l.input[:headerStart-1], '\n') + 1
+			}
+			return strings.Contains(strings.ToLower(l.input[headerStart:lineStart]), "threshold")
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package lexer
+
+import "strings"
+
+// tokenizeTranscript tokenizes an interactive session capture: each prompt line
+// (hostname, mode, prompt char and echoed command) is followed by the output of
+// that command, which is tokenized in the mode the command implies.
+func (l *Lexer) tokenizeTranscript() []Token {
+	var tokens []Token
+	var command string
+	blockStart, blockLine := 0, 1
+
+	// Detect the dialect once for the whole capture rather than per block
+	l.resolveDialect()
+
+	flush := func(end int) {
+		if end > blockStart {
+			tokens = append(tokens, l.tokenizeOutputBlock(l.input[blockStart:end], command, blockLine)...)
+		}
+	}
+
+	line := 1
+	for pos := 0; pos < len(l.input); line++ {
+		end := strings.IndexByte(l.input[pos:], '\n')
+		if end < 0 {
+			end = len(l.input)
+		} else {
+			end += pos + 1
+		}
+
+		text := l.input[pos:end]
+		if matches := transcriptPrompt(text); matches != nil {
+			flush(pos)
+			tokens = append(tokens, l.tryTokenizePrompt(text, line)...)
+			command = strings.TrimSpace(matches[5])
+			blockStart, blockLine = end, line+1
+		}
+		pos = end
+	}
+	flush(len(l.input))
+
+	return tokens
+}
+
+// tokenizeOutputBlock tokenizes the output of command, offsetting token lines
+// so they refer to the whole transcript.
+func (l *Lexer) tokenizeOutputBlock(block, command string, firstLine int) []Token {
+	sub := New(block)
+	sub.SetDialect(l.dialect)
+	sub.host, sub.markHost = l.host, l.markHost
+	sub.stateWords = l.stateWords
+	sub.explain = l.explain
+	sub.learnNames, sub.names = l.learnNames, l.names
+	if mode := CommandParseMode(command); mode != ParseModeAuto {
+		sub.SetParseMode(mode)
+	}
+
+	tokens := sub.Tokenize()
+	for i := range tokens {
+		tokens[i].Line += firstLine - 1
+	}
+	return tokens
+}
+
+// transcriptPrompt returns the prompt pattern submatches for a transcript line,
+// or nil if the line is not a prompt. Single-character hostnames are rejected
+// so route codes like "B>*" in show output are not mistaken for prompts, and
+// so are table headers that look like prompts.
+func transcriptPrompt(line string) []string {
+	matches := promptPattern.FindStringSubmatch(line)
+	if matches == nil || len(matches[2]) < 2 || isHeaderPrompt(line, matches) {
+		return nil
+	}
+	return matches
+}
+
+// isHeaderPrompt reports whether a line that matches the prompt pattern is a
+// table column header instead, such as the "Switch#   Role    Mac Address"
+// of show switch: aligned columns right after the "#" rather than a command.
+func isHeaderPrompt(text string, matches []string) bool {
+	rest := text[len(matches[1])+len(matches[2])+len(matches[3])+len(matches[4]):]
+	return matches[3] == "" && strings.HasPrefix(rest, "  ") && tabularPattern.MatchString(text)
+}
+
+// PromptCommand returns the command typed at the prompt on line, such as
+// "show ip route" for "core-rtr-01#show ip route", and whether line is a
+// prompt line at all. The command is "" for a bare prompt.
+func PromptCommand(line string) (command string, ok bool) {
+	matches := transcriptPrompt(line)
+	if matches == nil {
+		return "", false
+	}
+	return strings.TrimSpace(matches[5]), true
+}
+
+// looksLikeTranscript reports whether input is a multi-line session capture
+// that starts with a prompt.
+func looksLikeTranscript(input string) bool {
+	trimmed := strings.TrimLeft(input, "\r\n")
+	first, rest, ok := strings.Cut(trimmed, "\n")
+	if !ok || strings.TrimSpace(rest) == "" {
+		return false
+	}
+	return transcriptPrompt(first) != nil
+}
+
+// CommandParseMode returns the parse mode for the output of a CLI command:
+// ParseModeConfig for "show running-config" and friends, ParseModeShow for
+// other show commands, and ParseModeAuto for anything else. Abbreviations
+// such as "sh run" are understood.
+func CommandParseMode(command string) ParseMode {
+	fields := strings.Fields(strings.ToLower(command))
+	if len(fields) == 0 || !isAbbrev(fields[0], "show", 2) {
+		return ParseModeAuto
+	}
+	if len(fields) > 1 && (isAbbrev(fields[1], "running-config", 3) || isAbbrev(fields[1], "startup-config", 3)) {
+		return ParseModeConfig
+	}
+	return ParseModeShow
+}
+
+// isAbbrev reports whether word is an abbreviation of keyword at least min characters long.
+func isAbbrev(word, keyword string, min int) bool {
+	return len(word) >= min && strings.HasPrefix(keyword, word)
+}
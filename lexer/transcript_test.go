@@ -0,0 +1,175 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleTranscript = `core-sw01#show interfaces status
+Port      Name       Status       Vlan
+Gi1/0/1   uplink     connected    trunk
+Gi1/0/2              notconnect   10
+core-sw01#sh run | section hostname
+hostname core-sw01
+core-sw01#
+`
+
+func TestTranscriptMode(t *testing.T) {
+	l := New(sampleTranscript)
+	l.SetParseMode(ParseModeTranscript)
+	tokens := l.Tokenize()
+
+	type pos struct {
+		value string
+		line  int
+	}
+	var hosts, prompts int
+	found := map[pos]Token{}
+	for _, tok := range tokens {
+		switch tok.Type {
+		case TokenPromptHost:
+			hosts++
+		case TokenPromptConf:
+			prompts++
+		}
+		found[pos{tok.Value, tok.Line}] = tok
+	}
+
+	if hosts != 3 || prompts != 3 {
+		t.Errorf("expected 3 prompts, got %d hosts and %d prompt chars", hosts, prompts)
+	}
+
+	expected := []struct {
+		value string
+		typ   TokenType
+		line  int
+	}{
+		{"connected", TokenStateGood, 3},  // show output
+		{"notconnect", TokenStateBad, 4},  // show output
+		{"Status", TokenColumnHeader, 2},  // show output header
+		{"hostname", TokenCommand, 6},     // "sh run" output is config
+		{"core-sw01", TokenPromptHost, 1}, // first prompt
+	}
+	for _, e := range expected {
+		tok, ok := found[pos{e.value, e.line}]
+		if !ok {
+			t.Errorf("token %q not found on line %d", e.value, e.line)
+			continue
+		}
+		if tok.Type != e.typ {
+			t.Errorf("%q: expected %v, got %v", e.value, e.typ, tok.Type)
+		}
+	}
+
+	if got := joinValues(tokens); got != sampleTranscript {
+		t.Errorf("transcript content not preserved:\n%q\n%q", got, sampleTranscript)
+	}
+}
+
+func TestTranscriptAutoDetect(t *testing.T) {
+	tokens := New(sampleTranscript).Tokenize()
+	if len(tokens) == 0 || tokens[0].Type != TokenPromptHost {
+		t.Fatalf("transcript starting with a prompt should be auto-detected, got %v", tokens[0])
+	}
+
+	// FRR route codes look like single-letter prompts and must not trigger transcript mode
+	l := New("B>* 10.0.0.0/8 [20/0] via 192.0.2.1, swp1\nC>* 192.0.2.0/24 is directly connected, swp1\n")
+	if tokens := l.Tokenize(); tokens[0].Type == TokenPromptHost {
+		t.Errorf("route code should not be tokenized as a prompt")
+	}
+}
+
+func TestPromptLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  ParseMode
+		input string
+		line  int    // line of the prompt
+		host  string // expected TokenPromptHost value, "" for no prompt
+	}{
+		{"after output", ParseModeAuto, "Building configuration...\nR1#show version\n", 2, "R1"},
+		{"show mode", ParseModeShow, "Interface  Status\ncore-sw01#sh ip int br\n", 2, "core-sw01"},
+		{"pasted config", ParseModeConfig, "hostname R1\nR1(config)#interface Gi0/1\nR1(config-if)#no shutdown\n", 3, "R1"},
+		{"last line", ParseModeShow, "uptime is 1 week\nedge-01>", 2, "edge-01"},
+		{"route code", ParseModeShow, "Codes: C - connected\nB>* 10.0.0.0/8 [20/0]\n", 2, ""},
+		{"mid line", ParseModeShow, "Codes: C - connected\nsee R1#show\n", 2, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(tt.input)
+			l.SetParseMode(tt.mode)
+			tokens := l.Tokenize()
+
+			host := ""
+			for _, tok := range tokens {
+				if tok.Type == TokenPromptHost && tok.Line == tt.line {
+					host = tok.Value
+				}
+			}
+			if host != tt.host {
+				t.Errorf("prompt host on line %d = %q, want %q", tt.line, host, tt.host)
+			}
+			if got := joinValues(tokens); got != tt.input {
+				t.Errorf("content not preserved:\n%q\n%q", got, tt.input)
+			}
+			line, col := 1, 1
+			for _, tok := range tokens {
+				if tok.Line != line || tok.Column != col {
+					t.Errorf("%q at %d:%d, want %d:%d", tok.Value, tok.Line, tok.Column, line, col)
+				}
+				line += strings.Count(tok.Value, "\n")
+				col = AdvanceColumn(col, tok.Value)
+			}
+		})
+	}
+}
+
+func TestCommandParseMode(t *testing.T) {
+	tests := map[string]ParseMode{
+		"show running-config":       ParseModeConfig,
+		"sh run":                    ParseModeConfig,
+		"show startup-config":       ParseModeConfig,
+		"show ip interface brief":   ParseModeShow,
+		"sh ver":                    ParseModeShow,
+		"configure terminal":        ParseModeAuto,
+		"ping 10.0.0.1":             ParseModeAuto,
+		"":                          ParseModeAuto,
+		"s run":                     ParseModeAuto,
+		"show run | include vrf":    ParseModeConfig,
+		"show running-config brief": ParseModeConfig,
+	}
+	for cmd, want := range tests {
+		if got := CommandParseMode(cmd); got != want {
+			t.Errorf("CommandParseMode(%q) = %v, want %v", cmd, got, want)
+		}
+	}
+}
+
+func TestPromptCommand(t *testing.T) {
+	tests := []struct {
+		line    string
+		command string
+		ok      bool
+	}{
+		{"core-rtr-01#show ip route\r\n", "show ip route", true},
+		{"R1(config-if)# shutdown", "shutdown", true},
+		{"\rR1#", "", true},
+		{"B>* 10.0.0.0/8 [20/0] via 192.0.2.1", "", false},
+		{" ip address 10.0.0.1 255.255.255.0", "", false},
+	}
+	for _, tt := range tests {
+		command, ok := PromptCommand(tt.line)
+		if command != tt.command || ok != tt.ok {
+			t.Errorf("PromptCommand(%q) = %q, %v, want %q, %v", tt.line, command, ok, tt.command, tt.ok)
+		}
+	}
+}
+
+func joinValues(tokens []Token) string {
+	var s string
+	for _, tok := range tokens {
+		s += tok.Value
+	}
+	return s
+}
@@ -0,0 +1,51 @@
+package lexer
+
+// CommandLexer classifies a single command line while it is being typed, for
+// terminal emulators and REPLs that color input on every keystroke. It is a
+// fast path beside Tokenize: the line is always lexed as configuration, with
+// no prompt, transcript, parse mode or dialect detection and no checkpoints
+// for Retokenize, and the lexer and token buffer are reused from call to
+// call. A CommandLexer is not safe for concurrent use.
+type CommandLexer struct {
+	l       Lexer
+	dialect Dialect
+	tokens  []Token
+}
+
+// NewCommandLexer creates a CommandLexer for IOS commands.
+func NewCommandLexer() *CommandLexer {
+	return &CommandLexer{dialect: DialectIOS}
+}
+
+// SetDialect sets the dialect of the commands. One line is too little to
+// detect a dialect from, so DialectAuto and nil mean IOS; pass the dialect
+// detected from the session instead.
+func (c *CommandLexer) SetDialect(d Dialect) {
+	if d == nil || d == DialectAuto {
+		d = DialectIOS
+	}
+	c.dialect = d
+}
+
+// Tokenize returns the tokens of line, which should not include the prompt
+// or a newline. The tokens are only valid until the next call.
+func (c *CommandLexer) Tokenize(line string) []Token {
+	c.l = Lexer{
+		input:        line,
+		line:         1,
+		col:          1,
+		parseMode:    ParseModeConfig,
+		detectedMode: true,
+		explicitMode: true,
+		dialect:      c.dialect,
+		words:        words.Load(),
+	}
+	c.tokens = c.tokens[:0]
+	for c.l.pos < len(line) {
+		token := c.l.scanToken()
+		if token.Type != TokenText || token.Value != "" {
+			c.tokens = append(c.tokens, token)
+		}
+	}
+	return c.tokens
+}
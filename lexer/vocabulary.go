@@ -0,0 +1,276 @@
+package lexer
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lasseh/cink/internal/wordlist"
+)
+
+//go:generate go run ../internal/wordlist/wordfmt words
+
+// wordFiles holds the built-in vocabulary, one file per WordList.
+//
+//go:embed words/*.txt
+var wordFiles embed.FS
+
+// WordList names one of the vocabularies the lexer classifies words with.
+// The built-in lists are the files lexer/words/<name>.txt; see AddWords to
+// extend them at runtime.
+type WordList string
+
+// Word lists and the token type their words get.
+const (
+	WordsCommands      WordList = "commands"       // TokenCommand
+	WordsSections      WordList = "sections"       // TokenSection
+	WordsProtocols     WordList = "protocols"      // TokenProtocol
+	WordsActions       WordList = "actions"        // TokenAction
+	WordsOperators     WordList = "operators"      // TokenOperator
+	WordsKeywords      WordList = "keywords"       // TokenKeyword
+	WordsStatesGood    WordList = "states-good"    // TokenStateGood (show output)
+	WordsStatesBad     WordList = "states-bad"     // TokenStateBad (show output)
+	WordsStatesWarning WordList = "states-warning" // TokenStateWarning (show output)
+	WordsStatesNeutral WordList = "states-neutral" // TokenStateNeutral (show output)
+	WordsColumnHeaders WordList = "column-headers" // TokenColumnHeader (show output)
+	WordsValueKeywords WordList = "value-keywords" // TokenValue for the rest of the line (config)
+)
+
+// AllWordLists returns every word list.
+func AllWordLists() []WordList {
+	return []WordList{
+		WordsCommands, WordsSections, WordsProtocols, WordsActions,
+		WordsOperators, WordsKeywords, WordsStatesGood, WordsStatesBad,
+		WordsStatesWarning, WordsStatesNeutral, WordsColumnHeaders,
+		WordsValueKeywords,
+	}
+}
+
+// vocabulary is a snapshot of every word list. Snapshots are never modified
+// once published, so lexers read them without locking; AddWords publishes a
+// modified copy, which lexers created afterwards pick up.
+type vocabulary struct {
+	commands, sections, protocols, actions, operators, keywords map[string]bool
+	statesGood, statesBad, statesWarning, statesNeutral         map[string]bool
+	columnHeaders, valueKeywords                                map[string]bool
+
+	// Last words of the value keywords of several words, each mapped to the
+	// most words of a keyword it ends, so the line is only searched for them
+	// after a word that can end one, and no further back than they reach
+	valueKeywordEnds map[string]int
+
+	// The config and state lists combined, each word mapped to the type of
+	// the first list the lexer checks that has it, so classifying a word
+	// takes one lookup rather than one per list
+	configWords, states map[string]TokenType
+}
+
+// Lists combined into vocabulary.configWords and vocabulary.states, in the
+// order of precedence: a word in several lists gets the first one's type.
+var (
+	configWordLists = []WordList{
+		WordsCommands, WordsSections, WordsProtocols, WordsActions, WordsOperators, WordsKeywords,
+	}
+	stateWordLists = []WordList{
+		WordsStatesGood, WordsStatesBad, WordsStatesWarning, WordsStatesNeutral,
+	}
+)
+
+// wordListTypes are the token types the words of each list get.
+var wordListTypes = map[WordList]TokenType{
+	WordsCommands: TokenCommand, WordsSections: TokenSection, WordsProtocols: TokenProtocol,
+	WordsActions: TokenAction, WordsOperators: TokenOperator, WordsKeywords: TokenKeyword,
+	WordsStatesGood: TokenStateGood, WordsStatesBad: TokenStateBad,
+	WordsStatesWarning: TokenStateWarning, WordsStatesNeutral: TokenStateNeutral,
+}
+
+var (
+	words   atomic.Pointer[vocabulary] // the current snapshot
+	wordsMu sync.Mutex                 // serializes AddWords
+)
+
+func init() {
+	v := &vocabulary{}
+	for _, list := range AllWordLists() {
+		data, err := wordFiles.ReadFile("words/" + string(list) + ".txt")
+		if err != nil {
+			panic(err)
+		}
+		entries, err := wordlist.Parse(strings.NewReader(string(data)))
+		if err != nil {
+			panic(fmt.Sprintf("words/%s.txt: %v", list, err))
+		}
+		m := make(map[string]bool, len(entries))
+		for _, w := range entries {
+			m[w] = true
+		}
+		*v.list(list) = m
+	}
+	v.index()
+	words.Store(v)
+}
+
+// list returns the field holding the named word list, or nil.
+func (v *vocabulary) list(name WordList) *map[string]bool {
+	switch name {
+	case WordsCommands:
+		return &v.commands
+	case WordsSections:
+		return &v.sections
+	case WordsProtocols:
+		return &v.protocols
+	case WordsActions:
+		return &v.actions
+	case WordsOperators:
+		return &v.operators
+	case WordsKeywords:
+		return &v.keywords
+	case WordsStatesGood:
+		return &v.statesGood
+	case WordsStatesBad:
+		return &v.statesBad
+	case WordsStatesWarning:
+		return &v.statesWarning
+	case WordsStatesNeutral:
+		return &v.statesNeutral
+	case WordsColumnHeaders:
+		return &v.columnHeaders
+	case WordsValueKeywords:
+		return &v.valueKeywords
+	default:
+		return nil
+	}
+}
+
+// AddWords adds words to the named list, for lexers created afterwards, so
+// vocabulary for another platform can be supplied without changing cink.
+// Words are matched case-insensitively. Adding a word to a list does not
+// remove it from the others; a word in several lists is classified by the one
+// the lexer checks first (commands before keywords, for example). Value
+// keywords may be several words, such as "snmp-server location".
+func AddWords(list WordList, newWords ...string) error {
+	wordsMu.Lock()
+	defer wordsMu.Unlock()
+
+	old := words.Load()
+	if old.list(list) == nil {
+		return fmt.Errorf("unknown word list %q", list)
+	}
+	v := *old
+	m := make(map[string]bool, len(*old.list(list))+len(newWords))
+	for w := range *old.list(list) {
+		m[w] = true
+	}
+	for _, w := range newWords {
+		if w = strings.ToLower(strings.Join(strings.Fields(w), " ")); w != "" {
+			m[w] = true
+		}
+	}
+	*v.list(list) = m
+	v.index()
+	words.Store(&v)
+	return nil
+}
+
+// index builds the lookups derived from the word lists: the last word of
+// each value keyword of several words, and the combined config and state
+// lists.
+func (v *vocabulary) index() {
+	v.valueKeywordEnds = make(map[string]int)
+	for w := range v.valueKeywords {
+		if i := strings.LastIndexByte(w, ' '); i >= 0 {
+			end := w[i+1:]
+			v.valueKeywordEnds[end] = max(v.valueKeywordEnds[end], len(strings.Fields(w)))
+		}
+	}
+	v.configWords = v.combine(configWordLists)
+	v.states = v.combine(stateWordLists)
+}
+
+// configWordRule names the list a word of vocabulary.configWords came from,
+// for SetExplain.
+func configWordRule(t TokenType) string {
+	switch t {
+	case TokenCommand:
+		return "commands word list"
+	case TokenSection:
+		return "sections word list"
+	case TokenProtocol:
+		return "protocols word list"
+	case TokenAction:
+		return "actions word list"
+	case TokenOperator:
+		return "operators word list"
+	}
+	return "keywords word list"
+}
+
+// combine maps the words of lists to the type of the first list that has
+// them.
+func (v *vocabulary) combine(lists []WordList) map[string]TokenType {
+	n := 0
+	for _, list := range lists {
+		n += len(*v.list(list))
+	}
+	combined := make(map[string]TokenType, n)
+	for i := len(lists) - 1; i >= 0; i-- {
+		for w := range *v.list(lists[i]) {
+			combined[w] = wordListTypes[lists[i]]
+		}
+	}
+	return combined
+}
+
+// LoadWords adds the words read from r to the named list, as AddWords does.
+// The format is that of the built-in lists: one word per line, with blank
+// lines and lines starting with "#" ignored.
+func LoadWords(list WordList, r io.Reader) error {
+	entries, err := wordlist.Parse(r)
+	if err != nil {
+		return fmt.Errorf("word list %q: %w", list, err)
+	}
+	return AddWords(list, entries...)
+}
+
+// Words returns the words in the named list, sorted, or nil for an unknown
+// list.
+func Words(list WordList) []string {
+	m := words.Load().list(list)
+	if m == nil {
+		return nil
+	}
+	out := make([]string, 0, len(*m))
+	for w := range *m {
+		out = append(out, w)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// StateWords overrides the state word lists for one lexer. Each lowercase
+// word maps to the state type it gets in show output (TokenStateGood,
+// TokenStateBad, TokenStateWarning or TokenStateNeutral), or to TokenText
+// to take it out of the state lists.
+type StateWords map[string]TokenType
+
+// SetStateWords overrides the state lists for this lexer only, for platforms
+// whose state words differ from the built-in ones (see AddWords to change
+// them for every lexer). The map must not be modified while the lexer is in
+// use.
+func (l *Lexer) SetStateWords(overrides StateWords) {
+	l.stateWords = overrides
+}
+
+// stateType returns the type a lowercase word gets from the built-in state
+// lists, unless SetStateWords took it out of them.
+func (l *Lexer) stateType(lower string) (TokenType, bool) {
+	if _, ok := l.stateWords[lower]; ok {
+		return TokenText, false
+	}
+	t, ok := l.words.states[lower]
+	return t, ok
+}
@@ -0,0 +1,213 @@
+package lexer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/internal/wordlist"
+)
+
+func TestWordFilesFormatted(t *testing.T) {
+	for _, list := range AllWordLists() {
+		data, err := wordFiles.ReadFile("words/" + string(list) + ".txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		formatted, err := wordlist.Format(data)
+		if err != nil {
+			t.Fatalf("%s: %v", list, err)
+		}
+		if !bytes.Equal(data, formatted) {
+			t.Errorf("words/%s.txt is not formatted; run go generate ./lexer", list)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	protocols := Words(WordsProtocols)
+	if len(protocols) == 0 || !contains(protocols, "bgp") {
+		t.Errorf("protocols word list missing bgp: %v", protocols)
+	}
+	if Words("bogus") != nil {
+		t.Error("expected nil for an unknown word list")
+	}
+}
+
+func TestAddWords(t *testing.T) {
+	defer words.Store(words.Load())
+
+	typeOf := func(l *Lexer, value string) TokenType {
+		l.SetParseMode(ParseModeConfig)
+		for _, tok := range l.Tokenize() {
+			if tok.Value == value {
+				return tok.Type
+			}
+		}
+		return TokenText
+	}
+
+	input := "router babel 1\n"
+	before := New(input)
+	if err := AddWords(WordsProtocols, "Babel"); err != nil {
+		t.Fatal(err)
+	}
+	if got := typeOf(New(input), "babel"); got != TokenProtocol {
+		t.Errorf("after AddWords: babel is %v, want %v", got, TokenProtocol)
+	}
+	if got := typeOf(before, "babel"); got != TokenIdentifier {
+		t.Errorf("lexer created before AddWords: babel is %v, want %v", got, TokenIdentifier)
+	}
+
+	if err := AddWords("bogus", "x"); err == nil {
+		t.Error("expected an error for an unknown word list")
+	}
+}
+
+func TestCombinedWordLists(t *testing.T) {
+	defer words.Store(words.Load())
+
+	// A word in several lists takes the type of the first one checked
+	tests := []struct {
+		word string
+		want TokenType
+	}{
+		{"interface", TokenCommand}, // also a section
+		{"permit", TokenAction},
+		{"bgp", TokenProtocol},
+	}
+	for _, tt := range tests {
+		if got := words.Load().configWords[tt.word]; got != tt.want {
+			t.Errorf("configWords[%q] = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+
+	if err := AddWords(WordsKeywords, "permit", "babel-metric"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddWords(WordsStatesBad, "degraded-ish"); err != nil {
+		t.Fatal(err)
+	}
+	v := words.Load()
+	if got := v.configWords["permit"]; got != TokenAction {
+		t.Errorf("after AddWords: permit = %v, want %v", got, TokenAction)
+	}
+	if got := v.configWords["babel-metric"]; got != TokenKeyword {
+		t.Errorf("after AddWords: babel-metric = %v, want %v", got, TokenKeyword)
+	}
+	if got := v.states["degraded-ish"]; got != TokenStateBad {
+		t.Errorf("after AddWords: degraded-ish = %v, want %v", got, TokenStateBad)
+	}
+}
+
+func TestLoadWords(t *testing.T) {
+	defer words.Store(words.Load())
+
+	list := "# Junos states\n\nEstab\n  active-ish  \n"
+	if err := LoadWords(WordsStatesGood, strings.NewReader(list)); err != nil {
+		t.Fatal(err)
+	}
+	good := Words(WordsStatesGood)
+	if !contains(good, "estab") || !contains(good, "active-ish") || !contains(good, "up") {
+		t.Errorf("states-good after LoadWords: %v", good)
+	}
+
+	if err := LoadWords(WordsStatesGood, strings.NewReader("estab # Junos\n")); err == nil {
+		t.Error("expected an error for a comment after a word")
+	}
+}
+
+func TestSetStateWords(t *testing.T) {
+	input := "Gi1/0/1  secViolEr  Standby  up  down\n"
+	overrides := StateWords{
+		"secvioler": TokenStateBad,
+		"standby":   TokenStateGood,
+		"down":      TokenText,
+	}
+	tests := []struct {
+		word      string
+		want      TokenType // with overrides
+		wantPlain TokenType // without
+	}{
+		{"secViolEr", TokenStateBad, TokenIdentifier},
+		{"Standby", TokenStateGood, TokenStateNeutral},
+		{"up", TokenStateGood, TokenStateGood},
+		{"down", TokenIdentifier, TokenStateBad},
+	}
+
+	types := func(overrides StateWords) map[string]TokenType {
+		l := New(input)
+		l.SetParseMode(ParseModeShow)
+		l.SetStateWords(overrides)
+		m := make(map[string]TokenType)
+		for _, tok := range l.Tokenize() {
+			m[tok.Value] = tok.Type
+		}
+		return m
+	}
+	with, without := types(overrides), types(nil)
+	for _, tt := range tests {
+		if got := with[tt.word]; got != tt.want {
+			t.Errorf("%s with overrides: got %v, want %v", tt.word, got, tt.want)
+		}
+		if got := without[tt.word]; got != tt.wantPlain {
+			t.Errorf("%s without overrides: got %v, want %v", tt.word, got, tt.wantPlain)
+		}
+	}
+}
+
+func TestValueKeywords(t *testing.T) {
+	tests := []struct {
+		input string
+		value string // the TokenValue expected, "" for none
+	}{
+		{"snmp-server location Main DC, rack 4\n", "Main DC, rack 4"},
+		{"snmp-server location \"Main DC\" rack 4\n", "\"Main DC\" rack 4"},
+		{"snmp-server contact NOC <noc@example.com>\n", "NOC <noc@example.com>"},
+		{"alias exec sib show ip interface brief\n", "sib show ip interface brief"},
+		{"interface Gi0/1\n description uplink to core-01\n", "uplink to core-01"},
+		{"access-list 10 remark permit mgmt hosts\n", "permit mgmt hosts"},
+		{"R1(config)#snmp-server location Main DC\n", "Main DC"},
+		// The keyword must end the words so far, and a value never runs on
+		// to the next line
+		{"snmp-server location\nsnmp-server community public RO\n", ""},
+		{"location Main DC\n", ""},
+	}
+	for _, tt := range tests {
+		l := New(tt.input)
+		l.SetParseMode(ParseModeConfig)
+		var value string
+		for _, tok := range l.Tokenize() {
+			if tok.Type == TokenValue {
+				value = tok.Value
+			}
+		}
+		if value != tt.value {
+			t.Errorf("%q: value %q, want %q", tt.input, value, tt.value)
+		}
+	}
+}
+
+func TestAddValueKeywords(t *testing.T) {
+	defer words.Store(words.Load())
+
+	input := "set system  Location Main DC\n"
+	if err := AddWords(WordsValueKeywords, "system   location"); err != nil {
+		t.Fatal(err)
+	}
+	l := New(input)
+	l.SetParseMode(ParseModeConfig)
+	tokens := l.Tokenize()
+	if last := tokens[len(tokens)-2]; last.Type != TokenValue || last.Value != "Main DC" {
+		t.Errorf("after AddWords: got %v %q, want Value \"Main DC\"", last.Type, last.Value)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// ARPEntry is a row of "show ip arp" output.
+type ARPEntry struct {
+	IP        string
+	Age       string // minutes, or "-" for the router's own addresses
+	MAC       string // empty for incomplete entries
+	Type      string // ARPA, SNAP, ...
+	Interface string // layer 3 interface, e.g. "Vlan10"
+}
+
+// MACEntry is a row of "show mac address-table" output.
+type MACEntry struct {
+	VLAN string
+	MAC  string
+	Type string // DYNAMIC, STATIC, ...
+	Port string // last column: interface, "CPU", "Router", ...
+}
+
+// HostLocation is an ARP entry joined with the MAC address-table entry for
+// the same MAC address.
+type HostLocation struct {
+	IP        string
+	MAC       string
+	VLAN      string // empty if the MAC is not in the MAC address table
+	Interface string // layer 3 interface from the ARP table
+	Port      string // switch port from the MAC address table
+}
+
+// ParseARP extracts the entries from "show ip arp" output.
+func ParseARP(output string) []ARPEntry {
+	var entries []ARPEntry
+	for _, row := range showRows(output) {
+		ip := row.index(lexer.TokenIPv4)
+		if ip < 0 {
+			continue
+		}
+		e := ARPEntry{IP: row[ip].Value}
+		if ip+1 < len(row) {
+			e.Age = row[ip+1].Value
+		}
+		if mac := row.index(lexer.TokenMAC); mac >= 0 {
+			e.MAC = row[mac].Value
+			if mac+1 < len(row) {
+				e.Type = row[mac+1].Value
+			}
+		}
+		if last := row[len(row)-1]; last.Type == lexer.TokenInterface {
+			e.Interface = last.Value
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// ParseMACTable extracts the entries from "show mac address-table" output.
+func ParseMACTable(output string) []MACEntry {
+	var entries []MACEntry
+	for _, row := range showRows(output) {
+		mac := row.index(lexer.TokenMAC)
+		if mac < 1 {
+			continue
+		}
+		e := MACEntry{
+			VLAN: row[mac-1].Value,
+			MAC:  row[mac].Value,
+			Port: row[len(row)-1].Value,
+		}
+		if mac+1 < len(row)-1 {
+			e.Type = row[mac+1].Value
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// CorrelateARP joins "show ip arp" and "show mac address-table" output by
+// MAC address to find the switch port each IP address lives on. When a MAC
+// is learned in several VLANs, the entry in the VLAN of the ARP interface
+// (Vlan10 -> 10) is preferred.
+func CorrelateARP(arpOutput, macOutput string) []HostLocation {
+	byMAC := make(map[string][]MACEntry)
+	for _, e := range ParseMACTable(macOutput) {
+		key := normalizeMAC(e.MAC)
+		byMAC[key] = append(byMAC[key], e)
+	}
+
+	var hosts []HostLocation
+	for _, a := range ParseARP(arpOutput) {
+		h := HostLocation{IP: a.IP, MAC: a.MAC, Interface: a.Interface}
+		if a.MAC != "" {
+			if e, ok := pickMACEntry(byMAC[normalizeMAC(a.MAC)], a.Interface); ok {
+				h.VLAN, h.Port = e.VLAN, e.Port
+			}
+		}
+		hosts = append(hosts, h)
+	}
+	return hosts
+}
+
+func pickMACEntry(entries []MACEntry, arpInterface string) (MACEntry, bool) {
+	if len(entries) == 0 {
+		return MACEntry{}, false
+	}
+	name := lexer.NormalizeInterface(arpInterface)
+	for _, e := range entries {
+		if name == "Vlan"+e.VLAN {
+			return e, true
+		}
+	}
+	return entries[0], true
+}
+
+// normalizeMAC reduces a MAC address in dotted or colon format to lowercase hex digits.
+func normalizeMAC(mac string) string {
+	return strings.ToLower(strings.NewReplacer(".", "", ":", "", "-", "").Replace(mac))
+}
+
+// showRow is the non-whitespace tokens of one line of show output.
+type showRow []lexer.Token
+
+// index returns the position of the first token of type t, or -1.
+func (r showRow) index(t lexer.TokenType) int {
+	for i, tok := range r {
+		if tok.Type == t {
+			return i
+		}
+	}
+	return -1
+}
+
+// showRows tokenizes show output and returns the tokens of each non-empty line.
+func showRows(output string) []showRow {
+	lex := lexer.New(output)
+	lex.SetParseMode(lexer.ParseModeShow)
+
+	var rows []showRow
+	line := 0
+	for _, tok := range lex.Tokenize() {
+		if strings.TrimSpace(tok.Value) == "" {
+			continue
+		}
+		if tok.Line != line {
+			rows = append(rows, nil)
+			line = tok.Line
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], tok)
+	}
+	return rows
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+const sampleARP = `Protocol  Address          Age (min)  Hardware Addr   Type   Interface
+Internet  10.0.10.1               -   0011.2233.4455  ARPA   Vlan10
+Internet  10.0.10.20             12   0011.2233.4466  ARPA   Vlan10
+Internet  10.0.20.30              3   00aa.bbcc.ddee  ARPA   Vlan20
+Internet  10.0.20.40              0   Incomplete      ARPA
+`
+
+const sampleMACTable = `          Mac Address Table
+-------------------------------------------
+
+Vlan    Mac Address       Type        Ports
+----    -----------       --------    -----
+ All    0100.0ccc.cccc    STATIC      CPU
+  10    0011.2233.4466    DYNAMIC     Gi1/0/5
+  20    0011.2233.4466    DYNAMIC     Gi1/0/48
+  20    00aa.bbcc.ddee    DYNAMIC     Gi1/0/7
+Total Mac Addresses for this criterion: 4
+`
+
+func TestParseARP(t *testing.T) {
+	entries := ParseARP(sampleARP)
+	expected := []ARPEntry{
+		{IP: "10.0.10.1", Age: "-", MAC: "0011.2233.4455", Type: "ARPA", Interface: "Vlan10"},
+		{IP: "10.0.10.20", Age: "12", MAC: "0011.2233.4466", Type: "ARPA", Interface: "Vlan10"},
+		{IP: "10.0.20.30", Age: "3", MAC: "00aa.bbcc.ddee", Type: "ARPA", Interface: "Vlan20"},
+		{IP: "10.0.20.40", Age: "0"},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("ParseARP:\n got %+v\nwant %+v", entries, expected)
+	}
+}
+
+func TestParseMACTable(t *testing.T) {
+	entries := ParseMACTable(sampleMACTable)
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %+v", entries)
+	}
+	want := MACEntry{VLAN: "10", MAC: "0011.2233.4466", Type: "DYNAMIC", Port: "Gi1/0/5"}
+	if entries[1] != want {
+		t.Errorf("entry 1 = %+v, want %+v", entries[1], want)
+	}
+	if entries[0].Port != "CPU" || entries[0].VLAN != "All" {
+		t.Errorf("entry 0 = %+v", entries[0])
+	}
+}
+
+func TestCorrelateARP(t *testing.T) {
+	hosts := CorrelateARP(sampleARP, sampleMACTable)
+	expected := []HostLocation{
+		{IP: "10.0.10.1", MAC: "0011.2233.4455", Interface: "Vlan10"},
+		{IP: "10.0.10.20", MAC: "0011.2233.4466", VLAN: "10", Interface: "Vlan10", Port: "Gi1/0/5"},
+		{IP: "10.0.20.30", MAC: "00aa.bbcc.ddee", VLAN: "20", Interface: "Vlan20", Port: "Gi1/0/7"},
+		{IP: "10.0.20.40"},
+	}
+	if !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("CorrelateARP:\n got %+v\nwant %+v", hosts, expected)
+	}
+}
+
+func TestNormalizeMAC(t *testing.T) {
+	if normalizeMAC("00:11:22:AA:BB:CC") != normalizeMAC("0011.22aa.bbcc") {
+		t.Error("dotted and colon MAC formats should normalize to the same key")
+	}
+}
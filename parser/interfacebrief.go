@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// InterfaceBrief is a row of "show ip interface brief" output.
+type InterfaceBrief struct {
+	Name     string
+	IP       string // empty when unassigned
+	Method   string // how the address was set: "NVRAM", "manual", "DHCP", "unset"
+	Status   string // line status: "up", "down", "administratively down"
+	Protocol string // line protocol: "up", "down"
+}
+
+// Up reports whether both the interface and its line protocol are up.
+func (i InterfaceBrief) Up() bool {
+	return i.Status == "up" && i.Protocol == "up"
+}
+
+// ParseInterfaceBrief extracts the rows of "show ip interface brief" output.
+func ParseInterfaceBrief(output string) []InterfaceBrief {
+	var interfaces []InterfaceBrief
+	for _, row := range showRows(output) {
+		// Interface IP-Address OK? Method Status Protocol
+		if len(row) < 6 || row[0].Type != lexer.TokenInterface {
+			continue
+		}
+		if ok := strings.ToUpper(row[2].Value); ok != "YES" && ok != "NO" {
+			continue
+		}
+		i := InterfaceBrief{
+			Name:     row[0].Value,
+			Method:   row[3].Value,
+			Protocol: row[len(row)-1].Value,
+		}
+		if row[1].Value != "unassigned" {
+			i.IP = row[1].Value
+		}
+
+		var status []string
+		for _, tok := range row[4 : len(row)-1] {
+			status = append(status, tok.Value)
+		}
+		i.Status = strings.Join(status, " ")
+		interfaces = append(interfaces, i)
+	}
+	return interfaces
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+const sampleInterfaceBrief = `R1#show ip interface brief
+Interface              IP-Address      OK? Method Status                Protocol
+GigabitEthernet0/0     10.0.0.1        YES NVRAM  up                    up
+GigabitEthernet0/1     unassigned      YES unset  administratively down down
+Vlan1                  unassigned      NO  unset  down                  down
+Tunnel0                172.16.0.1      YES manual up                    down
+R1#
+`
+
+func TestParseInterfaceBrief(t *testing.T) {
+	interfaces := ParseInterfaceBrief(sampleInterfaceBrief)
+	expected := []InterfaceBrief{
+		{Name: "GigabitEthernet0/0", IP: "10.0.0.1", Method: "NVRAM", Status: "up", Protocol: "up"},
+		{Name: "GigabitEthernet0/1", Method: "unset", Status: "administratively down", Protocol: "down"},
+		{Name: "Vlan1", Method: "unset", Status: "down", Protocol: "down"},
+		{Name: "Tunnel0", IP: "172.16.0.1", Method: "manual", Status: "up", Protocol: "down"},
+	}
+	if !reflect.DeepEqual(interfaces, expected) {
+		t.Fatalf("ParseInterfaceBrief:\n got %+v\nwant %+v", interfaces, expected)
+	}
+
+	var up []string
+	for _, i := range interfaces {
+		if i.Up() {
+			up = append(up, i.Name)
+		}
+	}
+	if want := []string{"GigabitEthernet0/0"}; !reflect.DeepEqual(up, want) {
+		t.Errorf("up interfaces = %v, want %v", up, want)
+	}
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// InterfaceRange is an "interface range" statement with its members expanded.
+type InterfaceRange struct {
+	Location
+	Members []string // "GigabitEthernet1/0/1", "GigabitEthernet1/0/2", ...
+}
+
+// trailingNumberPattern splits an interface name before its last number.
+var trailingNumberPattern = regexp.MustCompile(`^(.*?)(\d+)$`)
+
+// maxRangeMembers bounds how many names one range expands to; a range
+// reaching further is not a switch's ports and is left unexpanded.
+const maxRangeMembers = 4096
+
+// ExpandInterfaceRange expands the member list of an "interface range"
+// statement, with or without the leading "interface range":
+//
+//	ExpandInterfaceRange("GigabitEthernet1/0/1 - 3, Gi1/0/30")
+//	// GigabitEthernet1/0/1 GigabitEthernet1/0/2 GigabitEthernet1/0/3 Gi1/0/30
+//
+// Names are kept as written; a type given apart from its number ("vlan 10 - 12")
+// is joined to it ("vlan10", "vlan11", "vlan12").
+func ExpandInterfaceRange(spec string) []string {
+	spec = strings.TrimSpace(spec)
+	if fields := strings.Fields(spec); len(fields) < 2 || !strings.EqualFold(fields[0], "interface") || !strings.EqualFold(fields[1], "range") {
+		spec = "interface range " + spec
+	}
+
+	lex := lexer.New(spec)
+	lex.SetParseMode(lexer.ParseModeConfig)
+
+	var members []string
+	var current, prefix string
+	rangeEnd := false
+	for _, tok := range lex.Tokenize() {
+		switch {
+		case tok.Type == lexer.TokenInterface && trailingNumberPattern.MatchString(tok.Value):
+			members = appendMember(members, current)
+			current, prefix, rangeEnd = prefix+tok.Value, "", false
+		case tok.Type == lexer.TokenInterface:
+			// Type without a number: "vlan" in "vlan 10 - 20"
+			members = appendMember(members, current)
+			current, prefix = "", tok.Value
+		case tok.Type == lexer.TokenNumber && prefix != "":
+			members = appendMember(members, current)
+			current, prefix = prefix+tok.Value, ""
+		case tok.Type == lexer.TokenNumber && rangeEnd:
+			members = append(members, expandMember(current, tok.Value)...)
+			current, rangeEnd = "", false
+		case tok.Type == lexer.TokenOperator && tok.Value == "-":
+			rangeEnd = current != ""
+		}
+	}
+	return appendMember(members, current)
+}
+
+func appendMember(members []string, name string) []string {
+	if name == "" {
+		return members
+	}
+	return append(members, name)
+}
+
+// expandMember returns first and the names that follow it up to the number end:
+// "Gi1/0/1", "3" gives Gi1/0/1, Gi1/0/2, Gi1/0/3. Backwards ranges and
+// ranges of more than maxRangeMembers give first alone.
+func expandMember(first, end string) []string {
+	m := trailingNumberPattern.FindStringSubmatch(first)
+	from, _ := strconv.Atoi(m[2])
+	to, err := strconv.Atoi(end)
+	if err != nil || to < from || to-from >= maxRangeMembers {
+		return []string{first}
+	}
+
+	names := make([]string, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		names = append(names, m[1]+strconv.Itoa(i))
+	}
+	return names
+}
+
+// InterfaceRanges returns every "interface range" statement in config with
+// its members expanded, in order.
+func InterfaceRanges(config string) []InterfaceRange {
+	var out []InterfaceRange
+	for i, line := range strings.Split(config, "\n") {
+		text := strings.TrimSpace(line)
+		fields := strings.Fields(text)
+		if len(fields) < 3 || !strings.EqualFold(fields[0], "interface") || !strings.EqualFold(fields[1], "range") {
+			continue
+		}
+		out = append(out, InterfaceRange{
+			Location: Location{Line: i + 1, Text: text},
+			Members:  ExpandInterfaceRange(text),
+		})
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandInterfaceRange(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{"GigabitEthernet1/0/1 - 3, Gi1/0/30", []string{"GigabitEthernet1/0/1", "GigabitEthernet1/0/2", "GigabitEthernet1/0/3", "Gi1/0/30"}},
+		{"interface range Gi1/0/1-2 , Te1/1/1 - 2", []string{"Gi1/0/1", "Gi1/0/2", "Te1/1/1", "Te1/1/2"}},
+		{"interface range vlan 10 - 12", []string{"vlan10", "vlan11", "vlan12"}},
+		{"interface range Gi 1/0/5 - 6", []string{"Gi1/0/5", "Gi1/0/6"}},
+		{"Port-channel1-2", []string{"Port-channel1", "Port-channel2"}},
+		{"Gi1/0/1", []string{"Gi1/0/1"}},
+		{"Gi1/0/4 - 2", []string{"Gi1/0/4"}}, // backwards range
+		{"Gi1/0/1 - 9999999999999999", []string{"Gi1/0/1"}},
+		{"Gi1/0/1 - 99999999999999999999", []string{"Gi1/0/1"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			if got := ExpandInterfaceRange(tt.spec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpandInterfaceRange(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterfaceRanges(t *testing.T) {
+	config := `interface range GigabitEthernet1/0/1 - 2, Gi1/0/30
+ switchport mode access
+!
+interface GigabitEthernet1/0/48
+ shutdown
+`
+	ranges := InterfaceRanges(config)
+	if len(ranges) != 1 {
+		t.Fatalf("expected 1 interface range, got %d", len(ranges))
+	}
+	r := ranges[0]
+	if r.Line != 1 || len(r.Members) != 3 || r.Members[2] != "Gi1/0/30" {
+		t.Errorf("unexpected range: %+v", r)
+	}
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// BGPNeighbor is a neighbor row of "show ip bgp summary" output.
+type BGPNeighbor struct {
+	Neighbor       string
+	AS             string
+	Uptime         string        // Up/Down column: "1d02h", "00:12:31", "never"
+	UptimeDuration time.Duration // Uptime as a duration, 0 for "never"
+	State          string        // "Established", or the state shown: "Idle", "Active", "Idle (Admin)"
+	Prefixes       int           // prefixes received, when established
+}
+
+// OSPFNeighbor is a row of "show ip ospf neighbor" output.
+type OSPFNeighbor struct {
+	NeighborID       string
+	Priority         int
+	State            string // adjacency state: "FULL", "2WAY", "INIT", "EXSTART"
+	Role             string // the neighbor's role: "DR", "BDR", "DROTHER", "" on point-to-point links
+	DeadTime         string // "00:00:38"
+	DeadTimeDuration time.Duration
+	Address          string
+	Interface        string
+}
+
+// Full reports whether the adjacency with the neighbor is complete.
+func (n OSPFNeighbor) Full() bool {
+	return n.State == "FULL"
+}
+
+// ParseBGPSummary extracts the neighbor rows from "show ip bgp summary" (or
+// "show bgp ... summary") output.
+func ParseBGPSummary(output string) []BGPNeighbor {
+	var neighbors []BGPNeighbor
+	for _, row := range showRows(output) {
+		// Neighbor V AS MsgRcvd MsgSent TblVer InQ OutQ Up/Down State/PfxRcd
+		if len(row) < 10 || !isAddress(row[0]) {
+			continue
+		}
+		n := BGPNeighbor{
+			Neighbor: row[0].Value,
+			AS:       row[2].Value,
+			Uptime:   row[8].Value,
+		}
+		n.UptimeDuration, _ = lexer.ParseCiscoDuration(n.Uptime)
+
+		var state []string
+		for _, tok := range row[9:] {
+			state = append(state, tok.Value)
+		}
+		n.State = strings.Join(state, " ")
+		if prefixes, err := strconv.Atoi(n.State); err == nil {
+			n.State, n.Prefixes = "Established", prefixes
+		}
+		neighbors = append(neighbors, n)
+	}
+	return neighbors
+}
+
+// ParseOSPFNeighbors extracts the rows of "show ip ospf neighbor" output.
+func ParseOSPFNeighbors(output string) []OSPFNeighbor {
+	var neighbors []OSPFNeighbor
+	for _, row := range showRows(output) {
+		// Neighbor ID Pri State Dead Time Address Interface
+		if len(row) < 6 || row[0].Type != lexer.TokenIPv4 {
+			continue
+		}
+		priority, err := strconv.Atoi(row[1].Value)
+		if err != nil {
+			continue
+		}
+		n := OSPFNeighbor{NeighborID: row[0].Value, Priority: priority}
+
+		// Point-to-point adjacencies print the role as "FULL/  -"
+		rest := row[2:]
+		state := rest[0].Value
+		if strings.HasSuffix(state, "/") && len(rest) > 1 {
+			state += rest[1].Value
+			rest = rest[1:]
+		}
+		if len(rest) < 4 {
+			continue
+		}
+		n.State, n.Role, _ = strings.Cut(strings.ToUpper(state), "/")
+		if n.Role == "-" {
+			n.Role = ""
+		}
+		n.DeadTime = rest[1].Value
+		n.DeadTimeDuration, _ = lexer.ParseCiscoDuration(n.DeadTime)
+		n.Address = rest[2].Value
+		n.Interface = rest[3].Value
+		neighbors = append(neighbors, n)
+	}
+	return neighbors
+}
+
+// isAddress reports whether tok is an IPv4 or IPv6 address.
+func isAddress(tok lexer.Token) bool {
+	return tok.Type == lexer.TokenIPv4 || tok.Type == lexer.TokenIPv6
+}
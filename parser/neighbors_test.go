@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const sampleBGPSummary = `BGP router identifier 10.0.0.1, local AS number 65001
+BGP table version is 10, main routing table version 10
+
+Neighbor        V           AS MsgRcvd MsgSent   TblVer  InQ OutQ Up/Down  State/PfxRcd
+10.0.0.2        4        65002     100     101       10    0    0 1d02h           5
+10.0.0.3        4        65003       0       0        1    0    0 never    Idle (Admin)
+10.0.0.4        4        65004      12      14       10    0    0 00:12:31 Active
+2001:DB8::2     4        65005     200     210       10    0    0 00:45:10        12
+`
+
+const sampleOSPFNeighbors = `Neighbor ID     Pri   State           Dead Time   Address         Interface
+10.0.0.2          1   FULL/DR         00:00:38    10.1.1.2        GigabitEthernet0/0
+10.0.0.3          0   FULL/  -        00:00:31    10.1.2.2        GigabitEthernet0/1
+10.0.0.4          1   2WAY/DROTHER    00:00:35    10.1.1.4        GigabitEthernet0/0
+10.0.0.5          1   EXSTART/BDR     00:00:39    10.1.1.5        GigabitEthernet0/0
+`
+
+func TestParseBGPSummary(t *testing.T) {
+	neighbors := ParseBGPSummary(sampleBGPSummary)
+	expected := []BGPNeighbor{
+		{Neighbor: "10.0.0.2", AS: "65002", Uptime: "1d02h", UptimeDuration: 26 * time.Hour, State: "Established", Prefixes: 5},
+		{Neighbor: "10.0.0.3", AS: "65003", Uptime: "never", State: "Idle (Admin)"},
+		{Neighbor: "10.0.0.4", AS: "65004", Uptime: "00:12:31", UptimeDuration: 12*time.Minute + 31*time.Second, State: "Active"},
+		{Neighbor: "2001:DB8::2", AS: "65005", Uptime: "00:45:10", UptimeDuration: 45*time.Minute + 10*time.Second, State: "Established", Prefixes: 12},
+	}
+	if !reflect.DeepEqual(neighbors, expected) {
+		t.Errorf("ParseBGPSummary:\n got %+v\nwant %+v", neighbors, expected)
+	}
+}
+
+func TestParseOSPFNeighbors(t *testing.T) {
+	neighbors := ParseOSPFNeighbors(sampleOSPFNeighbors)
+	expected := []OSPFNeighbor{
+		{NeighborID: "10.0.0.2", Priority: 1, State: "FULL", Role: "DR", DeadTime: "00:00:38", DeadTimeDuration: 38 * time.Second, Address: "10.1.1.2", Interface: "GigabitEthernet0/0"},
+		{NeighborID: "10.0.0.3", State: "FULL", DeadTime: "00:00:31", DeadTimeDuration: 31 * time.Second, Address: "10.1.2.2", Interface: "GigabitEthernet0/1"},
+		{NeighborID: "10.0.0.4", Priority: 1, State: "2WAY", Role: "DROTHER", DeadTime: "00:00:35", DeadTimeDuration: 35 * time.Second, Address: "10.1.1.4", Interface: "GigabitEthernet0/0"},
+		{NeighborID: "10.0.0.5", Priority: 1, State: "EXSTART", Role: "BDR", DeadTime: "00:00:39", DeadTimeDuration: 39 * time.Second, Address: "10.1.1.5", Interface: "GigabitEthernet0/0"},
+	}
+	if !reflect.DeepEqual(neighbors, expected) {
+		t.Errorf("ParseOSPFNeighbors:\n got %+v\nwant %+v", neighbors, expected)
+	}
+
+	var full []string
+	for _, n := range neighbors {
+		if n.Full() {
+			full = append(full, n.NeighborID)
+		}
+	}
+	if !reflect.DeepEqual(full, []string{"10.0.0.2", "10.0.0.3"}) {
+		t.Errorf("Full neighbors: %v", full)
+	}
+}
@@ -0,0 +1,233 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GroupExpansion is a line referring to object-groups or named objects,
+// such as an ACL entry, with the members of each.
+type GroupExpansion struct {
+	Location
+	Groups []ExpandedGroup
+}
+
+// ExpandedGroup is an object-group or named object with its members.
+type ExpandedGroup struct {
+	Kind    ObjectKind // ObjectGroup or ObjectNamed
+	Name    string
+	Members []string // "host 10.0.0.10", "10.1.0.0 255.255.255.0", "tcp eq 443", ...
+	Defined bool     // false if the configuration does not define the group
+}
+
+// Lines of object-group and object definitions that are not members
+var groupSettings = map[string]bool{
+	"description": true, "nat": true,
+}
+
+// ObjectGroupIndex holds the object-groups and named objects a
+// configuration defines, to expand references to them.
+type ObjectGroupIndex struct {
+	report *UsageReport
+	nodes  map[int]*Node // definition lines and their members
+}
+
+// NewObjectGroupIndex indexes the object-groups and named objects of config.
+func NewObjectGroupIndex(config string) *ObjectGroupIndex {
+	x := &ObjectGroupIndex{report: AnalyzeUsage(config), nodes: make(map[int]*Node)}
+	ParseConfigTree(config).Walk(func(n *Node) bool {
+		x.nodes[n.Line] = n
+		return false
+	})
+	return x
+}
+
+// Members returns the members of the object-group or named object (kind
+// ObjectGroup or ObjectNamed) called name, with nested groups expanded. ok is
+// false if the group is not defined.
+func (x *ObjectGroupIndex) Members(kind ObjectKind, name string) (members []string, ok bool) {
+	o := x.report.Lookup(kind, name)
+	if o == nil || !o.Defined() {
+		return nil, false
+	}
+	return x.members(o, map[*Object]bool{}), true
+}
+
+// ExpandObjectGroups returns the lines of config that refer to object-groups
+// or named objects, outside their definitions, with the members of each
+// group. Members are the lines of the group's definition without their
+// "network-object" style prefix; nested groups and objects are replaced by
+// their own members, so every address and service a line allows is listed.
+func ExpandObjectGroups(config string) []GroupExpansion {
+	return NewObjectGroupIndex(config).Expand(config)
+}
+
+// Expand is ExpandObjectGroups for config, part of the configuration x
+// indexes, such as its ACLs cut out with ConfigTree.Extract.
+func (x *ObjectGroupIndex) Expand(config string) []GroupExpansion {
+	report := AnalyzeUsage(config)
+	tree := ParseConfigTree(config)
+
+	// Lines inside definitions, whose references are members, not uses
+	inside := make(map[int]bool)
+	tree.Walk(func(n *Node) bool {
+		if fields := strings.Fields(strings.ToLower(n.Text)); fields[0] == "object-group" || fields[0] == "object" {
+			for line := n.Line; line <= n.End(); line++ {
+				inside[line] = true
+			}
+		}
+		return false
+	})
+
+	byLine := make(map[int]*GroupExpansion)
+	var lines []int
+	for _, o := range report.Objects {
+		if o.Kind != ObjectGroup && o.Kind != ObjectNamed {
+			continue
+		}
+		members, defined := x.Members(o.Kind, o.Name)
+		for _, ref := range o.References {
+			if inside[ref.Line] {
+				continue
+			}
+			exp, ok := byLine[ref.Line]
+			if !ok {
+				exp = &GroupExpansion{Location: ref}
+				byLine[ref.Line] = exp
+				lines = append(lines, ref.Line)
+			}
+			exp.Groups = append(exp.Groups, ExpandedGroup{
+				Kind:    o.Kind,
+				Name:    o.Name,
+				Members: members,
+				Defined: defined,
+			})
+		}
+	}
+
+	sort.Ints(lines)
+	out := make([]GroupExpansion, 0, len(lines))
+	for _, line := range lines {
+		exp := byLine[line]
+		// In the order the line names them
+		sort.SliceStable(exp.Groups, func(i, j int) bool {
+			return exp.position(exp.Groups[i]) < exp.position(exp.Groups[j])
+		})
+		out = append(out, *exp)
+	}
+	return out
+}
+
+// position returns the index of the word naming g in the line.
+func (exp *GroupExpansion) position(g ExpandedGroup) int {
+	words := strings.Fields(exp.Text)
+	for i := 1; i < len(words); i++ {
+		if words[i] == g.Name && strings.EqualFold(words[i-1], g.Kind.String()) {
+			return i
+		}
+	}
+	return len(words)
+}
+
+// members returns the members of o, expanding nested groups. seen holds the
+// groups being expanded, so a group that includes itself ends the recursion.
+func (x *ObjectGroupIndex) members(o *Object, seen map[*Object]bool) []string {
+	if seen[o] {
+		return nil
+	}
+	seen[o] = true
+	defer delete(seen, o)
+
+	var members []string
+	for _, def := range o.Definitions {
+		n := x.nodes[def.Line]
+		if n == nil {
+			continue
+		}
+		for _, child := range n.Children {
+			members = append(members, x.member(child.Text, seen)...)
+		}
+	}
+	return members
+}
+
+// member returns the members a line of a group definition stands for.
+func (x *ObjectGroupIndex) member(text string, seen map[*Object]bool) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 || groupSettings[strings.ToLower(words[0])] {
+		return nil
+	}
+	// "network-object host 10.0.0.1", "port-object eq 443"
+	if first := strings.ToLower(words[0]); first != "group-object" && strings.HasSuffix(first, "-object") && len(words) > 1 {
+		words = words[1:]
+	}
+
+	var kind ObjectKind
+	switch strings.ToLower(words[0]) {
+	case "group-object", "object-group":
+		kind = ObjectGroup
+	case "object":
+		kind = ObjectNamed
+	default:
+		return []string{strings.Join(words, " ")}
+	}
+	if len(words) < 2 {
+		return nil
+	}
+	nested := x.report.Lookup(kind, words[1])
+	if nested == nil || !nested.Defined() {
+		return []string{fmt.Sprintf("%s %s (undefined)", kind, words[1])}
+	}
+	return x.members(nested, seen)
+}
+
+// InlineObjectGroups returns config with the members of the object-groups and
+// named objects each line refers to (see ExpandObjectGroups) inserted below
+// it, one "!" comment line per group, so an ACL can be audited without
+// looking the groups up:
+//
+//	10 permit tcp any object-group WEB-SERVERS eq 443
+//	! object-group WEB-SERVERS: host 10.0.0.10, 10.1.0.0 255.255.255.0
+func InlineObjectGroups(config string) string {
+	return NewObjectGroupIndex(config).Inline(config)
+}
+
+// Inline is InlineObjectGroups for config, part of the configuration x
+// indexes.
+func (x *ObjectGroupIndex) Inline(config string) string {
+	expansions := x.Expand(config)
+	if len(expansions) == 0 {
+		return config
+	}
+	byLine := make(map[int]GroupExpansion, len(expansions))
+	for _, exp := range expansions {
+		byLine[exp.Line] = exp
+	}
+
+	lines := strings.SplitAfter(config, "\n")
+	var b strings.Builder
+	for i, line := range lines {
+		b.WriteString(line)
+		exp, ok := byLine[i+1]
+		if !ok {
+			continue
+		}
+		if !strings.HasSuffix(line, "\n") {
+			b.WriteByte('\n')
+		}
+		eol := "\n"
+		if strings.HasSuffix(line, "\r\n") {
+			eol = "\r\n"
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		for _, g := range exp.Groups {
+			members := strings.Join(g.Members, ", ")
+			if !g.Defined {
+				members = "undefined"
+			}
+			fmt.Fprintf(&b, "%s! %s %s: %s%s", indent, g.Kind, g.Name, members, eol)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+const objectGroupConfig = `object-group network DMZ
+ description dmz hosts
+ host 10.9.0.1
+!
+object-group network WEB-SERVERS
+ host 10.0.0.10
+ 10.1.0.0 255.255.255.0
+ group-object DMZ
+ group-object LOOP
+!
+object-group network LOOP
+ group-object WEB-SERVERS
+!
+object network WEB1
+ host 10.0.0.11
+ nat (inside,outside) static 192.0.2.11
+object-group service WEB-PORTS
+ service-object tcp destination eq 443
+ network-object object WEB1
+!
+ip access-list extended OUTSIDE-IN
+ 10 permit object-group WEB-PORTS any object-group WEB-SERVERS
+ 20 permit tcp any object WEB1 eq 22
+ 30 permit tcp any object-group GHOST eq 80
+`
+
+func TestExpandObjectGroups(t *testing.T) {
+	got := ExpandObjectGroups(objectGroupConfig)
+
+	want := []GroupExpansion{
+		{
+			Location: Location{Line: 22, Text: "10 permit object-group WEB-PORTS any object-group WEB-SERVERS"},
+			Groups: []ExpandedGroup{
+				{ObjectGroup, "WEB-PORTS", []string{"tcp destination eq 443", "host 10.0.0.11"}, true},
+				// LOOP includes WEB-SERVERS again, which ends there
+				{ObjectGroup, "WEB-SERVERS", []string{"host 10.0.0.10", "10.1.0.0 255.255.255.0", "host 10.9.0.1"}, true},
+			},
+		},
+		{
+			Location: Location{Line: 23, Text: "20 permit tcp any object WEB1 eq 22"},
+			Groups:   []ExpandedGroup{{ObjectNamed, "WEB1", []string{"host 10.0.0.11"}, true}},
+		},
+		{
+			Location: Location{Line: 24, Text: "30 permit tcp any object-group GHOST eq 80"},
+			Groups:   []ExpandedGroup{{ObjectGroup, "GHOST", nil, false}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandObjectGroups:\n got %+v\nwant %+v", got, want)
+	}
+
+	r := AnalyzeUsage(objectGroupConfig)
+	if o := r.Lookup(ObjectGroup, "DMZ"); o == nil || !equalInts(lineNumbers(o.References), []int{8}) {
+		t.Errorf("group-object should reference DMZ, got %+v", o)
+	}
+}
+
+func TestInlineObjectGroups(t *testing.T) {
+	config := "object-group network WEB\n host 10.0.0.10\n 10.1.0.0 255.255.255.0\n!\n" +
+		"ip access-list extended IN\n 10 permit tcp any object-group WEB eq 443\r\n 20 deny ip any any\n"
+	want := "object-group network WEB\n host 10.0.0.10\n 10.1.0.0 255.255.255.0\n!\n" +
+		"ip access-list extended IN\n 10 permit tcp any object-group WEB eq 443\r\n" +
+		" ! object-group WEB: host 10.0.0.10, 10.1.0.0 255.255.255.0\r\n" +
+		" 20 deny ip any any\n"
+	if got := InlineObjectGroups(config); got != want {
+		t.Errorf("InlineObjectGroups:\n got %q\nwant %q", got, want)
+	}
+
+	plain := "interface Gi0/1\n shutdown\n"
+	if got := InlineObjectGroups(plain); got != plain {
+		t.Errorf("config without groups should be unchanged, got %q", got)
+	}
+}
+
+func TestObjectGroupIndex(t *testing.T) {
+	idx := NewObjectGroupIndex(objectGroupConfig)
+
+	if members, ok := idx.Members(ObjectNamed, "WEB1"); !ok || !reflect.DeepEqual(members, []string{"host 10.0.0.11"}) {
+		t.Errorf("Members(WEB1) = %v, %v", members, ok)
+	}
+	if _, ok := idx.Members(ObjectGroup, "GHOST"); ok {
+		t.Error("GHOST is not defined")
+	}
+
+	// The ACL alone, with the groups defined in the rest of the configuration
+	acl := ParseConfigTree(objectGroupConfig).Extract("ip access-list extended OUTSIDE-IN")
+	got := idx.Inline(acl)
+	want := "ip access-list extended OUTSIDE-IN\n" +
+		" 10 permit object-group WEB-PORTS any object-group WEB-SERVERS\n" +
+		" ! object-group WEB-PORTS: tcp destination eq 443, host 10.0.0.11\n" +
+		" ! object-group WEB-SERVERS: host 10.0.0.10, 10.1.0.0 255.255.255.0, host 10.9.0.1\n" +
+		" 20 permit tcp any object WEB1 eq 22\n" +
+		" ! object WEB1: host 10.0.0.11\n" +
+		" 30 permit tcp any object-group GHOST eq 80\n" +
+		" ! object-group GHOST: undefined\n"
+	if got != want {
+		t.Errorf("Inline:\n got %q\nwant %q", got, want)
+	}
+}
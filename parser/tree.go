@@ -0,0 +1,248 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// Node is one configuration line with the lines indented under it.
+type Node struct {
+	Location         // line number and trimmed text
+	Indent   int     // width of the leading whitespace
+	Parent   *Node   // enclosing section line, nil at the top level
+	Children []*Node // lines indented under this one, in order
+}
+
+// End returns the line number of the last line in the node's section.
+func (n *Node) End() int {
+	if len(n.Children) == 0 {
+		return n.Line
+	}
+	return n.Children[len(n.Children)-1].End()
+}
+
+// ConfigTree is a configuration parsed into its section hierarchy.
+type ConfigTree struct {
+	Nodes []*Node // top-level lines, in order
+
+	lines []string // the configuration, line by line
+}
+
+// ParseConfigTree builds the section hierarchy of config from indentation:
+// each line belongs to the nearest line above it that is indented less.
+// Blank lines and "!" separators are left out, and the body of a banner
+// belongs to its banner line.
+func ParseConfigTree(config string) *ConfigTree {
+	t := &ConfigTree{lines: strings.Split(config, "\n")}
+
+	var stack []*Node // the open sections, innermost last
+	bannerEnd := ""   // delimiter closing the banner being read
+	for i, line := range t.lines {
+		line = strings.TrimRight(line, " \t\r")
+		text := strings.TrimSpace(line)
+		if bannerEnd != "" {
+			banner := stack[0]
+			banner.Children = append(banner.Children, &Node{
+				Location: Location{Line: i + 1, Text: text},
+				Indent:   len(line) - len(strings.TrimLeft(line, " \t")),
+				Parent:   banner,
+			})
+			if strings.Contains(line, bannerEnd) {
+				bannerEnd = ""
+			}
+			continue
+		}
+		if text == "" || strings.HasPrefix(text, "!") {
+			continue
+		}
+
+		node := &Node{
+			Location: Location{Line: i + 1, Text: text},
+			Indent:   len(line) - len(strings.TrimLeft(line, " \t")),
+		}
+		for len(stack) > 0 && stack[len(stack)-1].Indent >= node.Indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			t.Nodes = append(t.Nodes, node)
+		} else {
+			node.Parent = stack[len(stack)-1]
+			node.Parent.Children = append(node.Parent.Children, node)
+		}
+		stack = append(stack, node)
+
+		if node.Indent == 0 {
+			bannerEnd = bannerDelimiter(text)
+		}
+	}
+	return t
+}
+
+// bannerDelimiter returns the delimiter of a banner that continues on the
+// following lines, as in "banner motd ^C", or "" for other lines and banners
+// that end on the same line.
+func bannerDelimiter(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) < 3 || !strings.EqualFold(fields[0], "banner") {
+		return ""
+	}
+	rest := strings.TrimSpace(text[strings.Index(text, fields[2]):])
+	delim := rest[:1]
+	if strings.HasPrefix(rest, "^C") {
+		delim = "^C"
+	}
+	if strings.Contains(rest[len(delim):], delim) {
+		return ""
+	}
+	return delim
+}
+
+// Walk calls fn for every node in configuration order, parents before their
+// children. Returning false from fn skips the node's children.
+func (t *ConfigTree) Walk(fn func(*Node) bool) {
+	var walk func([]*Node)
+	walk = func(nodes []*Node) {
+		for _, n := range nodes {
+			if fn(n) {
+				walk(n.Children)
+			}
+		}
+	}
+	walk(t.Nodes)
+}
+
+// Find returns the nodes whose line matches any of the patterns, in order.
+// The sections under a match are not searched. Patterns match the leading
+// words of a line, case-insensitively, so "router bgp" finds "router bgp
+// 65000". Words may be abbreviated as on the CLI ("int Gi0/0/1" finds
+// "interface GigabitEthernet0/0/1", "int Po10" finds "interface
+// Port-channel10") and may contain * and ? wildcards ("interface Gi1/0/*").
+func (t *ConfigTree) Find(patterns ...string) []*Node {
+	var matchers [][]*regexp.Regexp
+	for _, p := range patterns {
+		matchers = append(matchers, compilePattern(p))
+	}
+
+	var found []*Node
+	t.Walk(func(n *Node) bool {
+		words := strings.Fields(n.Text)
+		for _, m := range matchers {
+			if matchWords(m, words) {
+				found = append(found, n)
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// Extract returns the configuration of the sections matching any of the
+// patterns (see Find), with the lines of their enclosing sections, as text.
+// Top-level sections are separated by "!" lines as in a saved configuration.
+// It returns "" if nothing matches.
+func (t *ConfigTree) Extract(patterns ...string) string {
+	keep := make(map[int]bool)
+	for _, n := range t.Find(patterns...) {
+		for p := n.Parent; p != nil; p = p.Parent {
+			keep[p.Line] = true
+		}
+		for line := n.Line; line <= n.End(); line++ {
+			keep[line] = true
+		}
+	}
+
+	lines := make([]int, 0, len(keep))
+	for line := range keep {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+
+	top := make(map[int]bool, len(t.Nodes))
+	for _, n := range t.Nodes {
+		top[n.Line] = true
+	}
+
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 && top[line] {
+			b.WriteString("!\n")
+		}
+		b.WriteString(strings.TrimRight(t.lines[line-1], "\r"))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Interface returns the "interface" section of the named interface, written
+// in full or abbreviated as in show output ("Gi0/0/1", "Po10"), or nil if
+// the configuration has none.
+func (t *ConfigTree) Interface(name string) *Node {
+	want := lexer.NormalizeInterface(name)
+	for _, n := range t.Nodes {
+		fields := strings.Fields(n.Text)
+		if len(fields) >= 2 && strings.EqualFold(fields[0], "interface") &&
+			strings.EqualFold(lexer.NormalizeInterface(fields[1]), want) {
+			return n
+		}
+	}
+	return nil
+}
+
+// compilePattern turns each word of pattern into a regexp matching the word
+// or, when it starts with letters, any word those letters abbreviate.
+// Interface names after "interface" also match their full name, since
+// abbreviations such as Po for Port-channel are not prefixes of it.
+func compilePattern(pattern string) []*regexp.Regexp {
+	var out []*regexp.Regexp
+	prev := ""
+	for _, word := range strings.Fields(pattern) {
+		expr := wordExpr(word)
+		if len(prev) >= 3 && strings.HasPrefix("interface", strings.ToLower(prev)) {
+			// The config may use the name as written (IOS-XR TenGigE0/0/0/0)
+			if full := lexer.NormalizeInterface(word); full != word {
+				expr = "(?:" + expr + "|" + wordExpr(full) + ")"
+			}
+		}
+		prev = word
+		out = append(out, regexp.MustCompile(`(?i)^`+expr+`$`))
+	}
+	return out
+}
+
+// wordExpr returns the regular expression for one word of a pattern.
+func wordExpr(word string) string {
+	letters := strings.IndexFunc(word, func(r rune) bool { return !unicode.IsLetter(r) })
+	if letters < 0 {
+		letters = len(word)
+	}
+	expr := globExpr(word[:letters])
+	if letters > 0 && !strings.ContainsAny(word[:letters], "*?") {
+		// The letters may be cut short: Gi matches GigabitEthernet
+		expr += `\pL*`
+	}
+	return expr + globExpr(word[letters:])
+}
+
+// globExpr converts a glob with * and ? wildcards to a regular expression.
+func globExpr(glob string) string {
+	expr := regexp.QuoteMeta(glob)
+	expr = strings.ReplaceAll(expr, `\*`, `.*`)
+	return strings.ReplaceAll(expr, `\?`, `.`)
+}
+
+func matchWords(pattern []*regexp.Regexp, words []string) bool {
+	if len(pattern) == 0 || len(pattern) > len(words) {
+		return false
+	}
+	for i, re := range pattern {
+		if !re.MatchString(words[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+const treeConfig = `hostname R1
+!
+interface GigabitEthernet0/0/1
+ description uplink
+ ip address 10.0.0.1 255.255.255.0
+!
+interface GigabitEthernet0/0/2
+ shutdown
+!
+router bgp 65000
+ neighbor 10.0.0.2 remote-as 65001
+ address-family ipv4
+  neighbor 10.0.0.2 activate
+ exit-address-family
+!
+banner motd ^C
+Authorized access only
+^C
+end
+`
+
+func TestParseConfigTree(t *testing.T) {
+	tree := ParseConfigTree(treeConfig)
+
+	var top []string
+	for _, n := range tree.Nodes {
+		top = append(top, n.Text)
+	}
+	want := []string{"hostname R1", "interface GigabitEthernet0/0/1", "interface GigabitEthernet0/0/2", "router bgp 65000", "banner motd ^C", "end"}
+	if !reflect.DeepEqual(top, want) {
+		t.Fatalf("top-level lines = %q, want %q", top, want)
+	}
+
+	bgp := tree.Nodes[3]
+	if len(bgp.Children) != 3 || bgp.End() != 14 {
+		t.Errorf("router bgp: %d children ending on line %d, want 3 ending on line 14", len(bgp.Children), bgp.End())
+	}
+	af := bgp.Children[1]
+	if len(af.Children) != 1 || af.Children[0].Parent != af || af.Parent != bgp {
+		t.Errorf("address-family children/parents not linked: %+v", af)
+	}
+	if banner := tree.Nodes[4]; len(banner.Children) != 2 || banner.End() != 18 {
+		t.Errorf("banner: %d children ending on line %d, want 2 ending on line 18", len(banner.Children), banner.End())
+	}
+}
+
+func TestConfigTreeFind(t *testing.T) {
+	tree := ParseConfigTree(treeConfig)
+
+	tests := []struct {
+		patterns []string
+		want     []int
+	}{
+		{[]string{"interface GigabitEthernet0/0/1"}, []int{3}},
+		{[]string{"interface Gi0/0/1"}, []int{3}},
+		{[]string{"int gi0/0/1"}, []int{3}},
+		{[]string{"interface Gi0/0/*"}, []int{3, 7}},
+		{[]string{"interface Gi0/0/?"}, []int{3, 7}},
+		{[]string{"router bgp"}, []int{10}},
+		{[]string{"neighbor 10.0.0.2 activate"}, []int{13}},
+		{[]string{"neighbor 10.0.0.2"}, []int{11, 13}},
+		{[]string{"hostname", "interface Gi0/0/2"}, []int{1, 7}},
+		{[]string{"interface Gi0/0/10"}, nil},
+		{[]string{"interface Gi0/0"}, nil},
+		{[]string{""}, nil},
+	}
+
+	for _, tt := range tests {
+		var got []int
+		for _, n := range tree.Find(tt.patterns...) {
+			got = append(got, n.Line)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Find(%q) = lines %v, want %v", tt.patterns, got, tt.want)
+		}
+	}
+}
+
+func TestConfigTreeInterface(t *testing.T) {
+	tree := ParseConfigTree(treeConfig + `interface Port-channel10
+ description lag to sw2
+interface Serial0/0/0.1 point-to-point
+`)
+
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"GigabitEthernet0/0/1", 3},
+		{"Gi0/0/2", 7},
+		{"gi0/0/2", 7},
+		{"Po10", 20},
+		{"Se0/0/0.1", 22},
+		{"Gi0/0/3", 0},
+		{"hostname", 0},
+	}
+	for _, tt := range tests {
+		got := 0
+		if n := tree.Interface(tt.name); n != nil {
+			got = n.Line
+		}
+		if got != tt.want {
+			t.Errorf("Interface(%q) = line %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	// Find expands abbreviations that are not prefixes of the full name
+	if found := tree.Find("int Po10"); len(found) != 1 || found[0].Line != 20 {
+		t.Errorf("Find(%q) = %v, want line 20", "int Po10", found)
+	}
+}
+
+func TestConfigTreeFindInterfaceAliases(t *testing.T) {
+	// IOS-XR and NX-OS spell interface types other than IOS does
+	tree := ParseConfigTree(`hostname XR1
+interface TenGigE0/0/0/0
+ ipv4 address 10.0.0.1 255.255.255.252
+!
+interface FortyGigE0/0/0/1
+ shutdown
+!
+interface Eth1/1
+ description nxos style
+!
+interface Ethernet1/2
+ shutdown
+`)
+
+	tests := []struct {
+		pattern string
+		want    []int
+	}{
+		{"interface TenGigE0/0/0/0", []int{2}},
+		{"interface Te0/0/0/0", []int{2}},
+		{"interface FortyGigE0/0/0/1", []int{5}},
+		{"interface Fo0/0/0/1", []int{5}},
+		{"interface Eth1/1", []int{8}},
+		{"interface Ethernet1/2", []int{11}},
+		{"interface Eth1/2", []int{11}},
+		{"interface TenGigE0/0/0/1", nil},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, n := range tree.Find(tt.pattern) {
+			got = append(got, n.Line)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Find(%q) = lines %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestConfigTreeExtract(t *testing.T) {
+	tree := ParseConfigTree(treeConfig)
+
+	tests := []struct {
+		patterns []string
+		want     string
+	}{
+		{[]string{"interface Gi0/0/1"}, "interface GigabitEthernet0/0/1\n description uplink\n ip address 10.0.0.1 255.255.255.0\n"},
+		{[]string{"hostname", "interface Gi0/0/2"}, "hostname R1\n!\ninterface GigabitEthernet0/0/2\n shutdown\n"},
+		{[]string{"neighbor * activate"}, "router bgp 65000\n address-family ipv4\n  neighbor 10.0.0.2 activate\n"},
+		{[]string{"banner motd"}, "banner motd ^C\nAuthorized access only\n^C\n"},
+		{[]string{"line vty"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tree.Extract(tt.patterns...); got != tt.want {
+			t.Errorf("Extract(%q) = %q, want %q", tt.patterns, got, tt.want)
+		}
+	}
+}
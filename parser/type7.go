@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// ErrInvalidType7 is returned by DecodeType7 for strings that are not type 7
+// encoded passwords.
+var ErrInvalidType7 = errors.New("invalid type 7 password")
+
+// type7Key is the fixed key Cisco type 7 XORs the password with.
+const type7Key = "dsfd;kfoA,.iyewrkldJKDHSUBsgvca69834ncxv9873254k;fg87"
+
+// DecodeType7 decodes a Cisco type 7 password ("0822455D0A16") into its
+// plaintext. Type 7 is a reversible obfuscation, not a hash; this is meant for
+// audit tooling that needs to check what a configuration actually contains.
+func DecodeType7(encoded string) (string, error) {
+	if len(encoded) < 2 || len(encoded)%2 != 0 {
+		return "", ErrInvalidType7
+	}
+	salt, err := strconv.Atoi(encoded[:2])
+	if err != nil || salt >= len(type7Key) {
+		return "", ErrInvalidType7
+	}
+
+	var plain strings.Builder
+	for i := 2; i < len(encoded); i += 2 {
+		b, err := strconv.ParseUint(encoded[i:i+2], 16, 8)
+		if err != nil {
+			return "", ErrInvalidType7
+		}
+		plain.WriteByte(byte(b) ^ type7Key[salt%len(type7Key)])
+		salt++
+	}
+	return plain.String(), nil
+}
+
+// Type7Password is a type 7 encoded password found in a configuration.
+type Type7Password struct {
+	Location
+	Encoded string // "0822455D0A16"
+}
+
+// Decode returns the plaintext of the password.
+func (p Type7Password) Decode() (string, error) {
+	return DecodeType7(p.Encoded)
+}
+
+// FindType7Passwords returns every type 7 password in config, in order.
+// Type 7 can be reversed by anyone with the configuration, so each one is a
+// weak credential finding in a configuration audit.
+func FindType7Passwords(config string) []Type7Password {
+	lex := lexer.New(config)
+	lex.SetParseMode(lexer.ParseModeConfig)
+	lines := strings.Split(config, "\n")
+
+	var out []Type7Password
+	for _, tok := range lex.Tokenize() {
+		if tok.Type != lexer.TokenSecret {
+			continue
+		}
+		out = append(out, Type7Password{
+			Location: Location{Line: tok.Line, Text: strings.TrimSpace(lines[tok.Line-1])},
+			Encoded:  tok.Value,
+		})
+	}
+	return out
+}
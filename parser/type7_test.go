@@ -0,0 +1,59 @@
+package parser
+
+import "testing"
+
+func TestDecodeType7(t *testing.T) {
+	tests := []struct {
+		encoded string
+		want    string
+		wantErr bool
+	}{
+		{"0822455D0A16", "cisco", false},
+		{"094F471A1A0A", "cisco", false},
+		{"", "", true},
+		{"08224", "", true},        // odd length
+		{"9922455D", "", true},     // salt out of range
+		{"0822ZZ", "", true},       // not hex
+		{"XX22455D0A16", "", true}, // salt not a number
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoded, func(t *testing.T) {
+			got, err := DecodeType7(tt.encoded)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeType7(%q) error = %v, wantErr %v", tt.encoded, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DecodeType7(%q) = %q, want %q", tt.encoded, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindType7Passwords(t *testing.T) {
+	config := `hostname R1
+enable secret 9 $9$abc
+username admin privilege 15 password 7 0822455D0A16
+!
+line vty 0 4
+ password 7 094F471A1A0A
+!
+interface GigabitEthernet0/1
+ ip route 10.0.0.0 255.0.0.0 Null0 7
+`
+	found := FindType7Passwords(config)
+	if len(found) != 2 {
+		t.Fatalf("expected 2 type 7 passwords, got %d: %+v", len(found), found)
+	}
+	if found[0].Line != 3 || found[0].Encoded != "0822455D0A16" {
+		t.Errorf("unexpected first finding: %+v", found[0])
+	}
+	if found[1].Line != 6 || found[1].Text != "password 7 094F471A1A0A" {
+		t.Errorf("unexpected second finding: %+v", found[1])
+	}
+	for _, p := range found {
+		if plain, err := p.Decode(); err != nil || plain != "cisco" {
+			t.Errorf("Decode() = %q, %v", plain, err)
+		}
+	}
+}
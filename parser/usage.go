@@ -0,0 +1,316 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lasseh/cink/lexer"
+)
+
+// ObjectKind identifies a kind of named policy object
+type ObjectKind int
+
+const (
+	ObjectACL        ObjectKind = iota // ip/ipv6/mac access-list, numbered access-list
+	ObjectPrefixList                   // ip/ipv6 prefix-list
+	ObjectRouteMap                     // route-map
+	ObjectGroup                        // object-group (IOS XE, ASA, NX-OS)
+	ObjectNamed                        // ASA named object: object network, object service
+)
+
+// String returns a human-readable name for the object kind.
+func (k ObjectKind) String() string {
+	switch k {
+	case ObjectACL:
+		return "access-list"
+	case ObjectPrefixList:
+		return "prefix-list"
+	case ObjectRouteMap:
+		return "route-map"
+	case ObjectGroup:
+		return "object-group"
+	case ObjectNamed:
+		return "object"
+	default:
+		return "unknown"
+	}
+}
+
+// Location is a line in the configuration.
+type Location struct {
+	Line int    // 1-based line number
+	Text string // the line with surrounding whitespace trimmed
+}
+
+// Object is a named ACL, prefix-list, route-map, object-group or object with
+// every line that defines it and every line that refers to it.
+type Object struct {
+	Kind        ObjectKind
+	Name        string
+	Definitions []Location
+	References  []Location
+}
+
+// Defined reports whether the object has at least one definition line.
+func (o *Object) Defined() bool { return len(o.Definitions) > 0 }
+
+// Referenced reports whether the object is used anywhere.
+func (o *Object) Referenced() bool { return len(o.References) > 0 }
+
+// UsageReport lists the policy objects found in a configuration.
+type UsageReport struct {
+	// Objects is sorted by kind, then name.
+	Objects []*Object
+}
+
+// Lookup returns the object of the given kind and name, or nil.
+func (r *UsageReport) Lookup(kind ObjectKind, name string) *Object {
+	for _, o := range r.Objects {
+		if o.Kind == kind && o.Name == name {
+			return o
+		}
+	}
+	return nil
+}
+
+// Unused returns objects that are defined but never referenced.
+func (r *UsageReport) Unused() []*Object {
+	return r.filter(func(o *Object) bool { return o.Defined() && !o.Referenced() })
+}
+
+// Undefined returns objects that are referenced but never defined.
+func (r *UsageReport) Undefined() []*Object {
+	return r.filter(func(o *Object) bool { return o.Referenced() && !o.Defined() })
+}
+
+func (r *UsageReport) filter(keep func(*Object) bool) []*Object {
+	var out []*Object
+	for _, o := range r.Objects {
+		if keep(o) {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// AnalyzeUsage reports which ACLs, prefix-lists, route-maps, object-groups
+// and named objects a configuration defines and where each one is
+// referenced. Descriptions, remarks, banners and comments are ignored.
+func AnalyzeUsage(config string) *UsageReport {
+	u := &usageBuilder{objects: make(map[objectKey]*Object)}
+	lines := strings.Split(config, "\n")
+
+	for _, cl := range configLines(config) {
+		loc := Location{Line: cl.line, Text: strings.TrimSpace(lines[cl.line-1])}
+		u.scanLine(cl.words, loc)
+	}
+
+	report := &UsageReport{}
+	for _, o := range u.objects {
+		report.Objects = append(report.Objects, o)
+	}
+	sort.Slice(report.Objects, func(i, j int) bool {
+		a, b := report.Objects[i], report.Objects[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+type objectKey struct {
+	kind ObjectKind
+	name string
+}
+
+type usageBuilder struct {
+	objects map[objectKey]*Object
+}
+
+func (u *usageBuilder) object(kind ObjectKind, name string) *Object {
+	key := objectKey{kind, name}
+	o, ok := u.objects[key]
+	if !ok {
+		o = &Object{Kind: kind, Name: name}
+		u.objects[key] = o
+	}
+	return o
+}
+
+func (u *usageBuilder) define(kind ObjectKind, name string, loc Location) {
+	o := u.object(kind, name)
+	// Numbered ACLs and route-map sequences repeat the definition on each entry
+	if n := len(o.Definitions); n == 0 || o.Definitions[n-1].Line != loc.Line {
+		o.Definitions = append(o.Definitions, loc)
+	}
+}
+
+func (u *usageBuilder) reference(kind ObjectKind, name string, loc Location) {
+	if name == "" || directionWords[name] {
+		return
+	}
+	o := u.object(kind, name)
+	for _, r := range o.References {
+		if r.Line == loc.Line {
+			return
+		}
+	}
+	o.References = append(o.References, loc)
+}
+
+// Words that end a list of object names
+var directionWords = map[string]bool{
+	"in": true, "out": true, "both": true,
+}
+
+// Keywords that may sit between "access-group"/"access-class" and the ACL name
+var aclQualifiers = map[string]bool{
+	"name": true, "peer": true, "serve": true, "serve-only": true,
+	"query-only": true, "ipv6": true, "mac": true,
+}
+
+// scanLine records definitions and references found in one config line.
+func (u *usageBuilder) scanLine(words []string, loc Location) {
+	// Negated commands remove objects or references rather than adding them
+	if len(words) == 0 || strings.EqualFold(words[0], "no") {
+		return
+	}
+	lower := make([]string, len(words))
+	for i, w := range words {
+		lower[i] = strings.ToLower(w)
+	}
+	arg := func(i int) string {
+		if i < len(words) {
+			return words[i]
+		}
+		return ""
+	}
+
+	// Definitions
+	switch {
+	case lower[0] == "route-map" && len(words) > 1:
+		u.define(ObjectRouteMap, words[1], loc)
+		return
+	case lower[0] == "access-list" && len(words) > 1:
+		u.define(ObjectACL, words[1], loc)
+		return
+	case (lower[0] == "ip" || lower[0] == "ipv6" || lower[0] == "mac") && len(words) > 2 && lower[1] == "access-list":
+		name := 2
+		if lower[2] == "standard" || lower[2] == "extended" {
+			name = 3
+		}
+		if lower[0] == "ip" && (lower[2] == "resequence" || lower[2] == "logging") {
+			return
+		}
+		u.define(ObjectACL, arg(name), loc)
+		return
+	case (lower[0] == "ip" || lower[0] == "ipv6") && len(words) > 2 && lower[1] == "prefix-list":
+		if lower[2] == "sequence-number" {
+			return
+		}
+		u.define(ObjectPrefixList, words[2], loc)
+		return
+	case lower[0] == "object-group" && len(words) > 2:
+		// "object-group network NAME", NX-OS "object-group ip address NAME"
+		name := 2
+		if lower[1] == "ip" || lower[1] == "ipv6" {
+			name = 3
+		}
+		u.define(ObjectGroup, arg(name), loc)
+		return
+	case lower[0] == "object" && len(words) > 2:
+		u.define(ObjectNamed, words[2], loc)
+		return
+	}
+
+	// References
+	for i := 0; i < len(lower); i++ {
+		switch lower[i] {
+		case "route-map", "table-map":
+			u.reference(ObjectRouteMap, arg(i+1), loc)
+		case "map":
+			// VRF "import map NAME" / "export map NAME"
+			if i > 0 && (lower[i-1] == "import" || lower[i-1] == "export") {
+				u.reference(ObjectRouteMap, arg(i+1), loc)
+			}
+		case "prefix-list", "prefix":
+			// "match ip address prefix-list A B", "neighbor X prefix-list A in",
+			// "distribute-list prefix A in"
+			if lower[i] == "prefix" && (i == 0 || lower[i-1] != "distribute-list") {
+				continue
+			}
+			for _, name := range words[i+1:] {
+				if directionWords[strings.ToLower(name)] {
+					break
+				}
+				u.reference(ObjectPrefixList, name, loc)
+			}
+			return
+		case "access-group", "access-class", "traffic-filter":
+			j := i + 1
+			for j < len(lower) && aclQualifiers[lower[j]] {
+				j++
+			}
+			u.reference(ObjectACL, arg(j), loc)
+		case "distribute-list":
+			// "distribute-list prefix ..." and "distribute-list route-map ..." are handled above
+			if next := strings.ToLower(arg(i + 1)); next != "prefix" && next != "prefix-list" && next != "route-map" && next != "gateway" {
+				u.reference(ObjectACL, arg(i+1), loc)
+			}
+		case "address":
+			// "match ip address A B" (route-map) and "match address A" (crypto map)
+			if i > 0 && (lower[i-1] == "ip" || lower[i-1] == "ipv6" || lower[i-1] == "match") && lower[0] == "match" {
+				if strings.ToLower(arg(i+1)) == "prefix-list" {
+					continue
+				}
+				for _, name := range words[i+1:] {
+					u.reference(ObjectACL, name, loc)
+				}
+				return
+			}
+		case "object-group", "group-object":
+			// "permit tcp any object-group WEB eq 443" and nested groups
+			if i > 0 || lower[i] == "group-object" {
+				u.reference(ObjectGroup, arg(i+1), loc)
+			}
+		case "object":
+			// "permit tcp any object WEB1 eq 443", "network-object object WEB1"
+			if i > 0 {
+				u.reference(ObjectNamed, arg(i+1), loc)
+			}
+		case "list":
+			// "ip nat inside source list A pool P"
+			if i > 0 && lower[i-1] == "source" {
+				u.reference(ObjectACL, arg(i+1), loc)
+			}
+		}
+	}
+}
+
+// configLine is the significant words of one configuration line.
+type configLine struct {
+	line  int
+	words []string
+}
+
+// configLines tokenizes config and returns the words of each line, skipping
+// comments and free-text values (descriptions, remarks, banners).
+func configLines(config string) []configLine {
+	lex := lexer.New(config)
+	lex.SetParseMode(lexer.ParseModeConfig)
+
+	var out []configLine
+	for _, tok := range lex.Tokenize() {
+		switch tok.Type {
+		case lexer.TokenText, lexer.TokenComment, lexer.TokenValue:
+			continue
+		}
+		if n := len(out); n == 0 || out[n-1].line != tok.Line {
+			out = append(out, configLine{line: tok.Line})
+		}
+		last := &out[len(out)-1]
+		last.words = append(last.words, tok.Value)
+	}
+	return out
+}
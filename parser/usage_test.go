@@ -0,0 +1,140 @@
+package parser
+
+import "testing"
+
+const usageConfig = `hostname edge-01
+!
+interface GigabitEthernet0/0
+ description uplink, see route-map NOT-A-REF
+ ip address 192.0.2.1 255.255.255.0
+ ip access-group EDGE-IN in
+ ip policy route-map PBR
+!
+ip access-list extended EDGE-IN
+ remark uses prefix-list NOT-A-REF
+ permit tcp any any eq 22
+ip access-list extended STALE
+ deny ip any any
+access-list 10 permit 10.0.0.0 0.255.255.255
+access-list 10 deny any
+!
+ip prefix-list CUSTOMER seq 5 permit 198.51.100.0/24
+ip prefix-list BOGONS seq 5 deny 0.0.0.0/8 le 32
+!
+route-map FROM-CUSTOMER permit 10
+ match ip address prefix-list CUSTOMER MISSING-PL
+route-map FROM-CUSTOMER deny 20
+route-map PBR permit 10
+ match ip address 10
+route-map UNUSED permit 10
+!
+router bgp 65000
+ neighbor 192.0.2.2 route-map FROM-CUSTOMER in
+ neighbor 192.0.2.2 route-map GHOST out
+ no neighbor 192.0.2.3 route-map STALE-RM in
+!
+line vty 0 4
+ access-class 10 in
+`
+
+func TestAnalyzeUsage(t *testing.T) {
+	r := AnalyzeUsage(usageConfig)
+
+	tests := []struct {
+		kind       ObjectKind
+		name       string
+		defs, refs []int // line numbers
+	}{
+		{ObjectACL, "EDGE-IN", []int{9}, []int{6}},
+		{ObjectACL, "STALE", []int{12}, nil},
+		{ObjectACL, "10", []int{14, 15}, []int{24, 33}},
+		{ObjectPrefixList, "CUSTOMER", []int{17}, []int{21}},
+		{ObjectPrefixList, "BOGONS", []int{18}, nil},
+		{ObjectPrefixList, "MISSING-PL", nil, []int{21}},
+		{ObjectRouteMap, "FROM-CUSTOMER", []int{20, 22}, []int{28}},
+		{ObjectRouteMap, "PBR", []int{23}, []int{7}},
+		{ObjectRouteMap, "UNUSED", []int{25}, nil},
+		{ObjectRouteMap, "GHOST", nil, []int{29}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String()+" "+tt.name, func(t *testing.T) {
+			o := r.Lookup(tt.kind, tt.name)
+			if o == nil {
+				t.Fatalf("object not found")
+			}
+			if got := lineNumbers(o.Definitions); !equalInts(got, tt.defs) {
+				t.Errorf("definitions on lines %v, want %v", got, tt.defs)
+			}
+			if got := lineNumbers(o.References); !equalInts(got, tt.refs) {
+				t.Errorf("references on lines %v, want %v", got, tt.refs)
+			}
+		})
+	}
+
+	for _, name := range []string{"NOT-A-REF", "STALE-RM"} {
+		for _, kind := range []ObjectKind{ObjectACL, ObjectPrefixList, ObjectRouteMap} {
+			if r.Lookup(kind, name) != nil {
+				t.Errorf("%s %s should not be reported", kind, name)
+			}
+		}
+	}
+
+	if got := len(r.Unused()); got != 3 {
+		t.Errorf("expected 3 unused objects (STALE, BOGONS, UNUSED), got %d", got)
+	}
+	if got := len(r.Undefined()); got != 2 {
+		t.Errorf("expected 2 undefined objects (MISSING-PL, GHOST), got %d", got)
+	}
+	if loc := r.Lookup(ObjectRouteMap, "PBR").References[0]; loc.Text != "ip policy route-map PBR" {
+		t.Errorf("location text = %q", loc.Text)
+	}
+}
+
+func TestAnalyzeUsageReferenceForms(t *testing.T) {
+	tests := []struct {
+		line string
+		kind ObjectKind
+		name string
+	}{
+		{"ipv6 traffic-filter V6-IN in", ObjectACL, "V6-IN"},
+		{"ntp access-group peer NTP-PEERS", ObjectACL, "NTP-PEERS"},
+		{"match access-group name CLASSIFY", ObjectACL, "CLASSIFY"},
+		{"distribute-list 20 in", ObjectACL, "20"},
+		{"distribute-list prefix DL-PFX in", ObjectPrefixList, "DL-PFX"},
+		{"neighbor 10.0.0.1 prefix-list NBR-PFX in", ObjectPrefixList, "NBR-PFX"},
+		{"ip nat inside source list NAT-ACL interface Gi0/0 overload", ObjectACL, "NAT-ACL"},
+		{"redistribute connected route-map CONN", ObjectRouteMap, "CONN"},
+		{"import map VRF-IMPORT", ObjectRouteMap, "VRF-IMPORT"},
+		{"table-map RIB-FILTER", ObjectRouteMap, "RIB-FILTER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			o := AnalyzeUsage(tt.line+"\n").Lookup(tt.kind, tt.name)
+			if o == nil || len(o.References) != 1 {
+				t.Errorf("expected one %s reference to %s, got %+v", tt.kind, tt.name, o)
+			}
+		})
+	}
+}
+
+func lineNumbers(locs []Location) []int {
+	var out []int
+	for _, l := range locs {
+		out = append(out, l.Line)
+	}
+	return out
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
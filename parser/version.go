@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionInfo holds the fields of interest from "show version" output.
+// Fields that are not present in the output are left empty.
+type VersionInfo struct {
+	Software         string        // "Cisco IOS XE Software"
+	Version          string        // "16.9.4", "15.2(4)E7"
+	Hostname         string        // from the "<host> uptime is" line
+	Uptime           string        // "1 year, 12 weeks, 3 days, 4 hours, 17 minutes"
+	UptimeDuration   time.Duration // Uptime as a duration (years are 365 days)
+	Image            string        // "flash:packages.conf"
+	LastReloadReason string        // "Reload Command"
+	Model            string        // "C9300-48P"
+	Serial           string        // "FCW2233L0AB"
+	MemoryKB         int           // main memory in kilobytes (sum of "NK/MK bytes of memory")
+	ConfigRegister   string        // "0x2102"
+}
+
+var (
+	versionSoftwarePattern = regexp.MustCompile(`^(Cisco .*?Software)\b.*?,\s*Version\s+([^\s,]+)`)
+	versionUptimePattern   = regexp.MustCompile(`^(\S+) uptime is (.+)$`)
+	versionImagePattern    = regexp.MustCompile(`^System image file is "([^"]+)"`)
+	versionReloadPattern   = regexp.MustCompile(`^Last reload reason:\s*(.+)$`)
+	versionModelPattern    = regexp.MustCompile(`^[Cc]isco (\S+) (?:\(.*?\) )?processor.*? with (\d+)K(?:/(\d+)K)? bytes of memory`)
+	versionBoardIDPattern  = regexp.MustCompile(`^Processor board ID (\S+)`)
+	versionFieldPattern    = regexp.MustCompile(`^(Model [Nn]umber|System [Ss]erial [Nn]umber)\s*:\s*(\S+)`)
+	versionConfRegPattern  = regexp.MustCompile(`^Configuration register is (0x[0-9a-fA-F]+)`)
+	uptimePartPattern      = regexp.MustCompile(`(\d+)\s+(year|week|day|hour|minute|second)s?`)
+)
+
+// ParseShowVersion extracts structured fields from IOS/IOS-XE "show version" output.
+func ParseShowVersion(output string) *VersionInfo {
+	v := &VersionInfo{}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if m := versionSoftwarePattern.FindStringSubmatch(line); m != nil && v.Version == "" {
+			v.Software, v.Version = m[1], m[2]
+			continue
+		}
+		if m := versionUptimePattern.FindStringSubmatch(line); m != nil && v.Hostname == "" {
+			v.Hostname, v.Uptime = m[1], m[2]
+			v.UptimeDuration = ParseUptime(m[2])
+			continue
+		}
+		if m := versionImagePattern.FindStringSubmatch(line); m != nil {
+			v.Image = m[1]
+			continue
+		}
+		if m := versionReloadPattern.FindStringSubmatch(line); m != nil {
+			v.LastReloadReason = m[1]
+			continue
+		}
+		if m := versionModelPattern.FindStringSubmatch(line); m != nil {
+			v.Model = m[1]
+			v.MemoryKB, _ = strconv.Atoi(m[2])
+			if io, err := strconv.Atoi(m[3]); err == nil {
+				v.MemoryKB += io
+			}
+			continue
+		}
+		if m := versionBoardIDPattern.FindStringSubmatch(line); m != nil {
+			v.Serial = m[1]
+			continue
+		}
+		if m := versionFieldPattern.FindStringSubmatch(line); m != nil {
+			// The per-switch fields are more specific than the processor line
+			if strings.HasPrefix(strings.ToLower(m[1]), "model") {
+				v.Model = m[2]
+			} else {
+				v.Serial = m[2]
+			}
+			continue
+		}
+		if m := versionConfRegPattern.FindStringSubmatch(line); m != nil {
+			v.ConfigRegister = m[1]
+		}
+	}
+
+	return v
+}
+
+// ParseUptime converts an IOS uptime string such as "1 year, 12 weeks,
+// 3 days, 4 hours, 17 minutes" to a duration. Years count as 365 days.
+func ParseUptime(s string) time.Duration {
+	units := map[string]time.Duration{
+		"year":   365 * 24 * time.Hour,
+		"week":   7 * 24 * time.Hour,
+		"day":    24 * time.Hour,
+		"hour":   time.Hour,
+		"minute": time.Minute,
+		"second": time.Second,
+	}
+
+	var d time.Duration
+	for _, m := range uptimePartPattern.FindAllStringSubmatch(s, -1) {
+		n, _ := strconv.Atoi(m[1])
+		d += time.Duration(n) * units[m[2]]
+	}
+	return d
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+const sampleShowVersion = `Cisco IOS XE Software, Version 16.09.04
+Cisco IOS Software [Fuji], Catalyst L3 Switch Software (CAT9K_IOSXE), Version 16.9.4, RELEASE SOFTWARE (fc2)
+Technical Support: http://www.cisco.com/techsupport
+
+core-sw01 uptime is 1 year, 12 weeks, 3 days, 4 hours, 17 minutes
+Uptime for this control processor is 1 year, 12 weeks, 3 days, 4 hours, 19 minutes
+System image file is "flash:packages.conf"
+Last reload reason: Reload Command
+
+cisco C9300-48P (X86) processor with 1392780K/6147K bytes of memory.
+Processor board ID FCW2233L0AB
+2048K bytes of non-volatile configuration memory.
+
+Model Number                       : C9300-48P
+System Serial Number               : FCW2233L0AC
+
+Configuration register is 0x102
+`
+
+func TestParseShowVersion(t *testing.T) {
+	v := ParseShowVersion(sampleShowVersion)
+
+	expected := VersionInfo{
+		Software:         "Cisco IOS XE Software",
+		Version:          "16.09.04",
+		Hostname:         "core-sw01",
+		Uptime:           "1 year, 12 weeks, 3 days, 4 hours, 17 minutes",
+		UptimeDuration:   (365+12*7+3)*24*time.Hour + 4*time.Hour + 17*time.Minute,
+		Image:            "flash:packages.conf",
+		LastReloadReason: "Reload Command",
+		Model:            "C9300-48P",
+		Serial:           "FCW2233L0AC",
+		MemoryKB:         1392780 + 6147,
+		ConfigRegister:   "0x102",
+	}
+	if *v != expected {
+		t.Errorf("ParseShowVersion:\n got %+v\nwant %+v", *v, expected)
+	}
+}
+
+func TestParseShowVersionClassicIOS(t *testing.T) {
+	output := `Cisco IOS Software, C2960X Software (C2960X-UNIVERSALK9-M), Version 15.2(4)E7, RELEASE SOFTWARE (fc2)
+access-01 uptime is 5 weeks, 1 day, 2 minutes
+cisco WS-C2960X-48FPD-L (APM86XXX) processor (revision B0) with 524288K bytes of memory.
+Processor board ID FOC1234X0AB
+Configuration register is 0xF
+`
+	v := ParseShowVersion(output)
+	if v.Version != "15.2(4)E7" {
+		t.Errorf("Version = %q", v.Version)
+	}
+	if v.Model != "WS-C2960X-48FPD-L" {
+		t.Errorf("Model = %q", v.Model)
+	}
+	if v.Serial != "FOC1234X0AB" {
+		t.Errorf("Serial = %q", v.Serial)
+	}
+	if v.MemoryKB != 524288 {
+		t.Errorf("MemoryKB = %d", v.MemoryKB)
+	}
+	if v.UptimeDuration != 36*24*time.Hour+2*time.Minute {
+		t.Errorf("UptimeDuration = %v", v.UptimeDuration)
+	}
+	if v.ConfigRegister != "0xF" {
+		t.Errorf("ConfigRegister = %q", v.ConfigRegister)
+	}
+}
@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: cink/v1/cink.proto
+
+// Package cink.v1 exposes the cink lexer and highlighter as a service, for
+// programs that are not written in Go.
+
+package cinkv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CinkService_Tokenize_FullMethodName        = "/cink.v1.CinkService/Tokenize"
+	CinkService_Highlight_FullMethodName       = "/cink.v1.CinkService/Highlight"
+	CinkService_HighlightStream_FullMethodName = "/cink.v1.CinkService/HighlightStream"
+)
+
+// CinkServiceClient is the client API for CinkService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CinkServiceClient interface {
+	// Tokenize splits input into classified tokens.
+	Tokenize(ctx context.Context, in *TokenizeRequest, opts ...grpc.CallOption) (*TokenizeResponse, error)
+	// Highlight returns input with ANSI color codes.
+	Highlight(ctx context.Context, in *HighlightRequest, opts ...grpc.CallOption) (*HighlightResponse, error)
+	// HighlightStream highlights a live session as it is relayed: each chunk
+	// is answered with its highlighted form as soon as it arrives. Chunks may
+	// split lines anywhere. State such as the device hostname carries over
+	// from chunk to chunk, as in the cink terminal wrapper.
+	HighlightStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HighlightStreamRequest, HighlightResponse], error)
+}
+
+type cinkServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCinkServiceClient(cc grpc.ClientConnInterface) CinkServiceClient {
+	return &cinkServiceClient{cc}
+}
+
+func (c *cinkServiceClient) Tokenize(ctx context.Context, in *TokenizeRequest, opts ...grpc.CallOption) (*TokenizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenizeResponse)
+	err := c.cc.Invoke(ctx, CinkService_Tokenize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cinkServiceClient) Highlight(ctx context.Context, in *HighlightRequest, opts ...grpc.CallOption) (*HighlightResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HighlightResponse)
+	err := c.cc.Invoke(ctx, CinkService_Highlight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cinkServiceClient) HighlightStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HighlightStreamRequest, HighlightResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CinkService_ServiceDesc.Streams[0], CinkService_HighlightStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HighlightStreamRequest, HighlightResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CinkService_HighlightStreamClient = grpc.BidiStreamingClient[HighlightStreamRequest, HighlightResponse]
+
+// CinkServiceServer is the server API for CinkService service.
+// All implementations must embed UnimplementedCinkServiceServer
+// for forward compatibility.
+type CinkServiceServer interface {
+	// Tokenize splits input into classified tokens.
+	Tokenize(context.Context, *TokenizeRequest) (*TokenizeResponse, error)
+	// Highlight returns input with ANSI color codes.
+	Highlight(context.Context, *HighlightRequest) (*HighlightResponse, error)
+	// HighlightStream highlights a live session as it is relayed: each chunk
+	// is answered with its highlighted form as soon as it arrives. Chunks may
+	// split lines anywhere. State such as the device hostname carries over
+	// from chunk to chunk, as in the cink terminal wrapper.
+	HighlightStream(grpc.BidiStreamingServer[HighlightStreamRequest, HighlightResponse]) error
+	mustEmbedUnimplementedCinkServiceServer()
+}
+
+// UnimplementedCinkServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCinkServiceServer struct{}
+
+func (UnimplementedCinkServiceServer) Tokenize(context.Context, *TokenizeRequest) (*TokenizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Tokenize not implemented")
+}
+func (UnimplementedCinkServiceServer) Highlight(context.Context, *HighlightRequest) (*HighlightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Highlight not implemented")
+}
+func (UnimplementedCinkServiceServer) HighlightStream(grpc.BidiStreamingServer[HighlightStreamRequest, HighlightResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method HighlightStream not implemented")
+}
+func (UnimplementedCinkServiceServer) mustEmbedUnimplementedCinkServiceServer() {}
+func (UnimplementedCinkServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeCinkServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CinkServiceServer will
+// result in compilation errors.
+type UnsafeCinkServiceServer interface {
+	mustEmbedUnimplementedCinkServiceServer()
+}
+
+func RegisterCinkServiceServer(s grpc.ServiceRegistrar, srv CinkServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCinkServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CinkService_ServiceDesc, srv)
+}
+
+func _CinkService_Tokenize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CinkServiceServer).Tokenize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CinkService_Tokenize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CinkServiceServer).Tokenize(ctx, req.(*TokenizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CinkService_Highlight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HighlightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CinkServiceServer).Highlight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CinkService_Highlight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CinkServiceServer).Highlight(ctx, req.(*HighlightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CinkService_HighlightStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CinkServiceServer).HighlightStream(&grpc.GenericServerStream[HighlightStreamRequest, HighlightResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CinkService_HighlightStreamServer = grpc.BidiStreamingServer[HighlightStreamRequest, HighlightResponse]
+
+// CinkService_ServiceDesc is the grpc.ServiceDesc for CinkService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CinkService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cink.v1.CinkService",
+	HandlerType: (*CinkServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Tokenize",
+			Handler:    _CinkService_Tokenize_Handler,
+		},
+		{
+			MethodName: "Highlight",
+			Handler:    _CinkService_Highlight_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "HighlightStream",
+			Handler:       _CinkService_HighlightStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "cink/v1/cink.proto",
+}
@@ -0,0 +1,72 @@
+// cink-grpc serves the cink lexer and highlighter over gRPC (see
+// rpc/proto/cink/v1/cink.proto), for collectors that run cink as a sidecar.
+//
+//	cink-grpc -listen :50051
+//	cink-grpc -listen unix:/run/cink.sock
+//
+// The standard gRPC health service and server reflection are registered, so
+// grpcurl and health probes work without the proto file.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/lasseh/cink/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// version is set via ldflags at build time
+var version = "dev"
+
+func main() {
+	listen := flag.String("listen", ":50051", `Address to listen on: "host:port" or "unix:/path/to/socket"`)
+	showVersion := flag.Bool("version", false, "Show version")
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("cink-grpc version %s\n", version)
+		return
+	}
+
+	if err := serve(*listen); err != nil {
+		fmt.Fprintf(os.Stderr, "cink-grpc: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func serve(addr string) error {
+	network := "tcp"
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		network, addr = "unix", path
+		// A socket left behind by an earlier run would make Listen fail
+		_ = os.Remove(addr)
+	}
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		return err
+	}
+
+	s := grpc.NewServer()
+	rpc.Register(s)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	reflection.Register(s)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		s.GracefulStop()
+	}()
+
+	fmt.Fprintf(os.Stderr, "cink-grpc: listening on %s\n", ln.Addr())
+	return s.Serve(ln)
+}
@@ -0,0 +1,125 @@
+// Package rpc serves the cink lexer and highlighter over gRPC, so programs
+// in other languages can run cink as a sidecar. The service is defined in
+// proto/cink/v1/cink.proto; cinkv1 holds the generated Go code.
+//
+// It is a separate module so the main cink module does not depend on gRPC.
+// Regenerate cinkv1 after editing the proto with go generate (requires buf,
+// protoc-gen-go and protoc-gen-go-grpc).
+package rpc
+
+//go:generate buf generate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+	"github.com/lasseh/cink/rpc/cinkv1"
+	"google.golang.org/grpc"
+)
+
+// Server implements cinkv1.CinkServiceServer. It holds no state between
+// calls; each stream gets its own highlighter.
+type Server struct {
+	cinkv1.UnimplementedCinkServiceServer
+}
+
+// NewServer creates a Server.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Register registers a new Server with s.
+func Register(s *grpc.Server) {
+	cinkv1.RegisterCinkServiceServer(s, NewServer())
+}
+
+// Tokenize splits the input into classified tokens.
+func (s *Server) Tokenize(_ context.Context, req *cinkv1.TokenizeRequest) (*cinkv1.TokenizeResponse, error) {
+	opts := req.GetOptions()
+	lex := lexer.New(highlighter.StripANSI(req.GetInput()))
+	lex.SetDialect(dialect(opts))
+	if mode := mode(opts); mode != lexer.ParseModeAuto {
+		lex.SetParseMode(mode)
+	}
+
+	tokens := lex.Tokenize()
+	resp := &cinkv1.TokenizeResponse{Tokens: make([]*cinkv1.Token, len(tokens))}
+	for i, tok := range tokens {
+		resp.Tokens[i] = &cinkv1.Token{
+			Type:     tok.Type.String(),
+			Value:    tok.Value,
+			Line:     int32(tok.Line),
+			Column:   int32(tok.Column),
+			Category: tok.Type.Category().String(),
+		}
+	}
+	return resp, nil
+}
+
+// Highlight returns the input with ANSI color codes.
+func (s *Server) Highlight(_ context.Context, req *cinkv1.HighlightRequest) (*cinkv1.HighlightResponse, error) {
+	opts := req.GetOptions()
+	hl := newHighlighter(opts)
+	if opts.GetForce() {
+		return &cinkv1.HighlightResponse{Output: hl.HighlightForced(req.GetInput())}, nil
+	}
+	return &cinkv1.HighlightResponse{Output: hl.Highlight(req.GetInput())}, nil
+}
+
+// HighlightStream answers each chunk of a live session with its highlighted
+// form. The options of the first message apply to the whole stream.
+func (s *Server) HighlightStream(stream cinkv1.CinkService_HighlightStreamServer) error {
+	var (
+		hl    *highlighter.Highlighter
+		force bool
+	)
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hl == nil {
+			// A session is one device: once it looks like Cisco, keep
+			// highlighting, as the cink command does
+			hl = newHighlighter(req.GetOptions(), highlighter.WithPinnedDetection())
+			force = req.GetOptions().GetForce()
+		}
+
+		var out string
+		if force {
+			out = hl.HighlightForced(req.GetChunk())
+		} else {
+			out = hl.Highlight(req.GetChunk())
+		}
+		if err := stream.Send(&cinkv1.HighlightResponse{Output: out}); err != nil {
+			return err
+		}
+	}
+}
+
+// newHighlighter creates a highlighter configured from opts.
+func newHighlighter(opts *cinkv1.Options, extra ...highlighter.Option) *highlighter.Highlighter {
+	return highlighter.New(append([]highlighter.Option{
+		highlighter.WithTheme(highlighter.ThemeByName(strings.ToLower(opts.GetTheme()))),
+		highlighter.WithDialect(dialect(opts)),
+		highlighter.WithMode(mode(opts)),
+	}, extra...)...)
+}
+
+func dialect(opts *cinkv1.Options) lexer.Dialect {
+	if opts.GetDialect() == "" {
+		return lexer.DialectAuto
+	}
+	return lexer.DialectByName(strings.ToLower(opts.GetDialect()))
+}
+
+func mode(opts *cinkv1.Options) lexer.ParseMode {
+	return lexer.ParseModeByName(strings.ToLower(opts.GetMode()))
+}
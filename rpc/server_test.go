@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
+	"github.com/lasseh/cink/rpc/cinkv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial starts a server on an in-memory listener and returns a client for it.
+func dial(t *testing.T) cinkv1.CinkServiceClient {
+	t.Helper()
+	ln := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	Register(s)
+	go s.Serve(ln)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ln.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return cinkv1.NewCinkServiceClient(conn)
+}
+
+func TestTokenize(t *testing.T) {
+	client := dial(t)
+	resp, err := client.Tokenize(context.Background(), &cinkv1.TokenizeRequest{
+		Input:   "interface GigabitEthernet0/1\n ip address 10.0.0.1 255.255.255.0\n",
+		Options: &cinkv1.Options{Mode: "config"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got *cinkv1.Token
+	for _, tok := range resp.GetTokens() {
+		if tok.GetValue() == "10.0.0.1" {
+			got = tok
+		}
+	}
+	if got == nil {
+		t.Fatalf("address token missing: %v", resp.GetTokens())
+	}
+	if got.GetType() != "IPv4" || got.GetCategory() != "Address" || got.GetLine() != 2 || got.GetColumn() != 13 {
+		t.Errorf("unexpected token %v", got)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	client := dial(t)
+	theme := highlighter.NordTheme()
+	tests := []struct {
+		name    string
+		input   string
+		options *cinkv1.Options
+		colored bool
+	}{
+		{"config", "interface GigabitEthernet0/1\n shutdown\n", &cinkv1.Options{Theme: "nord"}, true},
+		{"not cisco", "hello world\n", &cinkv1.Options{Theme: "nord"}, false},
+		{"forced", "hello world\n", &cinkv1.Options{Theme: "nord", Force: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.Highlight(context.Background(), &cinkv1.HighlightRequest{Input: tt.input, Options: tt.options})
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := resp.GetOutput()
+			if colored := out != tt.input; colored != tt.colored {
+				t.Errorf("colored = %v, want %v: %q", colored, tt.colored, out)
+			}
+			if highlighter.StripANSI(out) != tt.input {
+				t.Errorf("content changed: %q", out)
+			}
+		})
+	}
+
+	resp, _ := client.Highlight(context.Background(), &cinkv1.HighlightRequest{Input: "interface Gi0/1\n", Options: &cinkv1.Options{Theme: "nord", Force: true}})
+	if !strings.Contains(resp.GetOutput(), theme.GetColor(lexer.TokenInterface)+"Gi0/1") {
+		t.Errorf("theme option not applied: %q", resp.GetOutput())
+	}
+}
+
+func TestHighlightStream(t *testing.T) {
+	client := dial(t)
+	stream, err := client.HighlightStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A prompt, then output split mid-line, as a PTY relay delivers it
+	chunks := []string{"core-rtr-01#", "show ip int br\nGi0/1  10.0.0.1  YES manual u", "p  up\n"}
+	var out strings.Builder
+	for i, chunk := range chunks {
+		req := &cinkv1.HighlightStreamRequest{Chunk: chunk}
+		if i == 0 {
+			req.Options = &cinkv1.Options{Mode: "show", Force: true}
+		}
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out.WriteString(resp.GetOutput())
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := highlighter.StripANSI(out.String()); got != strings.Join(chunks, "") {
+		t.Errorf("stream content changed: %q", got)
+	}
+	theme := highlighter.DefaultTheme()
+	if !strings.Contains(out.String(), theme.GetColor(lexer.TokenPromptHost)+"core-rtr-01") {
+		t.Errorf("prompt not highlighted: %q", out.String())
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/creack/pty"
 	"github.com/lasseh/cink/highlighter"
+	"github.com/lasseh/cink/lexer"
 	"golang.org/x/term"
 )
 
@@ -44,16 +45,20 @@ func IsDebug() bool {
 type Terminal struct {
 	cmd         *exec.Cmd
 	pty         *os.File
-	highlighter *highlighter.Highlighter
+	session     *highlighter.SessionHighlighter
+	highlighter *highlighter.Highlighter // the session's highlighter
+	redactor    *highlighter.Anonymizer  // scrubs output, nil when off
 	enabled     bool
 }
 
 // New creates a new Terminal for the given command
 func New(name string, args ...string) *Terminal {
 	cmd := exec.Command(name, args...)
+	session := highlighter.NewSession()
 	return &Terminal{
 		cmd:         cmd,
-		highlighter: highlighter.New(),
+		session:     session,
+		highlighter: session.Highlighter(),
 		enabled:     true,
 	}
 }
@@ -63,6 +68,35 @@ func (t *Terminal) SetTheme(theme *highlighter.Theme) {
 	t.highlighter.SetTheme(theme)
 }
 
+// SetDialect changes the tokenization dialect
+func (t *Terminal) SetDialect(dialect lexer.Dialect) {
+	t.highlighter.SetDialect(dialect)
+}
+
+// SetHeatmap enables counter heatmap coloring (nil disables it)
+func (t *Terminal) SetHeatmap(heatmap *highlighter.Heatmap) {
+	t.highlighter.SetHeatmap(heatmap)
+}
+
+// SetHighlightHost enables coloring the device hostname wherever it appears
+func (t *Terminal) SetHighlightHost(enabled bool) {
+	t.highlighter.SetHighlightHost(enabled)
+}
+
+// CurrentHost returns the hostname from the last prompt seen in the session
+func (t *Terminal) CurrentHost() string {
+	return t.highlighter.CurrentHost()
+}
+
+// SetRedaction scrubs identifying values from the output with the Anonymizer
+// a, whether it is highlighted or not, keeping table columns aligned. nil
+// turns it off.
+func (t *Terminal) SetRedaction(a *highlighter.Anonymizer) {
+	t.redactor = a
+	t.highlighter.SetRedaction(a)
+	t.highlighter.SetColumnAlignment(a != nil)
+}
+
 // SetEnabled enables or disables highlighting
 func (t *Terminal) SetEnabled(enabled bool) {
 	t.enabled = enabled
@@ -200,10 +234,12 @@ func (t *Terminal) processOutput(r io.Reader, w io.Writer) {
 func (t *Terminal) writeOutput(w io.Writer, data []byte) {
 	var output string
 	if t.enabled {
-		output = t.highlighter.HighlightForced(string(data))
+		output = t.session.Highlight(string(data))
 		if IsDebug() {
 			fmt.Fprintf(os.Stderr, "[DEBUG] Highlight: %q -> %q\n", data, output)
 		}
+	} else if t.redactor != nil {
+		output = t.redactor.AnonymizeAligned(string(data))
 	} else {
 		output = string(data)
 	}
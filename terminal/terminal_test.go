@@ -124,6 +124,20 @@ func TestWriteOutput(t *testing.T) {
 	}
 }
 
+func TestWriteOutputRedaction(t *testing.T) {
+	term := New("echo", "test")
+	term.SetRedaction(highlighter.NewAnonymizer())
+
+	for _, enabled := range []bool{true, false} {
+		term.SetEnabled(enabled)
+		var buf bytes.Buffer
+		term.writeOutput(&buf, []byte(" ip address 192.0.2.1 255.255.255.0\n"))
+		if output := buf.String(); strings.Contains(output, "192.0.2.1") || !strings.Contains(output, "10.0.0.1") {
+			t.Errorf("enabled=%v: address not scrubbed: %q", enabled, output)
+		}
+	}
+}
+
 func TestProcessOutputBasic(t *testing.T) {
 	term := New("echo", "test")
 	term.SetEnabled(false)